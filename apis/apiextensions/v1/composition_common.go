@@ -41,6 +41,136 @@ const (
 	CompositionModePipeline CompositionMode = "Pipeline"
 )
 
+// AnnotationKeyGarbageCollectionPolicy overrides the garbage collection
+// policy of a Composition. It may be set on a composite resource, or on a
+// composed resource - for example by the function that returns it. The policy
+// of a composed resource takes precedence over that of its composite resource,
+// which takes precedence over that of its Composition.
+const AnnotationKeyGarbageCollectionPolicy = "crossplane.io/garbage-collection-policy"
+
+// AnnotationKeyGarbageCollectionConfirmation confirms garbage collection that
+// exceeds a Composition's garbage collection limits. It's set on a composite
+// resource. Its value must match the confirmation token Crossplane reports when
+// it refuses to garbage collect, so it only confirms the deletion of a
+// specific set of composed resources.
+const AnnotationKeyGarbageCollectionConfirmation = "crossplane.io/confirm-garbage-collection"
+
+// AnnotationKeyCompositionPlan puts a composite resource in plan mode when set
+// to "true". Crossplane runs the composite resource's function pipeline, but
+// doesn't apply, delete, or orphan any composed resources, or change the
+// composite resource's spec. Instead it summarizes the changes it would make
+// using the composite resource's Planned condition, and records them as JSON
+// in this annotation of a PlanComposedResources event. A composite resource
+// must have selected a Composition before it can be planned.
+const AnnotationKeyCompositionPlan = "crossplane.io/composition-plan"
+
+// AnnotationKeyLastReadyCompositionRevision is set on a composite resource when
+// it automatically updates to a new CompositionRevision while it's ready. Its
+// value is the name of the revision the composite resource updated from.
+// Crossplane rolls a composite resource back to this revision if its
+// Composition's rollback policy is Automatic.
+const AnnotationKeyLastReadyCompositionRevision = "crossplane.io/last-ready-composition-revision"
+
+// AnnotationKeyCompositionRevisionUpdateTime is set on a composite resource
+// when it automatically updates to a new CompositionRevision. Its value is the
+// RFC 3339 time at which it updated.
+const AnnotationKeyCompositionRevisionUpdateTime = "crossplane.io/composition-revision-update-time"
+
+// A GarbageCollectionPolicy determines what happens to a composed resource
+// that a Composition's pipeline stops returning.
+type GarbageCollectionPolicy string
+
+const (
+	// GarbageCollectionPolicyDelete deletes composed resources that are no
+	// longer desired.
+	GarbageCollectionPolicyDelete GarbageCollectionPolicy = "Delete"
+
+	// GarbageCollectionPolicyOrphan orphans composed resources that are no
+	// longer desired. The composite resource stops referencing and
+	// controlling an orphaned resource, but the resource isn't deleted.
+	GarbageCollectionPolicyOrphan GarbageCollectionPolicy = "Orphan"
+)
+
+// GarbageCollection configures how Crossplane garbage collects composed
+// resources that a Composition's pipeline stops returning.
+type GarbageCollection struct {
+	// Policy determines what happens to composed resources that are no longer
+	// desired. Delete deletes them. Orphan stops composing them but leaves
+	// them in the API server. Composite resources and composed resources may
+	// override the policy using the crossplane.io/garbage-collection-policy
+	// annotation.
+	// +optional
+	// +kubebuilder:validation:Enum=Delete;Orphan
+	// +kubebuilder:default=Delete
+	Policy GarbageCollectionPolicy `json:"policy,omitempty"`
+
+	// MaxDeletions is the maximum number of composed resources Crossplane
+	// deletes in one reconcile. Crossplane refuses to garbage collect more,
+	// and marks the composite resource as not synced, until the deletion is
+	// confirmed using the crossplane.io/confirm-garbage-collection annotation.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	MaxDeletions *int32 `json:"maxDeletions,omitempty"`
+
+	// MaxDeletionPercent is the maximum percentage of a composite resource's
+	// composed resources Crossplane deletes in one reconcile. Crossplane
+	// refuses to garbage collect more, and marks the composite resource as not
+	// synced, until the deletion is confirmed using the
+	// crossplane.io/confirm-garbage-collection annotation.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=100
+	MaxDeletionPercent *int32 `json:"maxDeletionPercent,omitempty"`
+}
+
+// A RollbackPolicy determines whether a composite resource is rolled back to a
+// previous CompositionRevision when it becomes unhealthy.
+type RollbackPolicy string
+
+const (
+	// RollbackPolicyNever never rolls composite resources back.
+	RollbackPolicyNever RollbackPolicy = "Never"
+
+	// RollbackPolicyAutomatic rolls a composite resource back to the last
+	// CompositionRevision under which it was ready if it becomes unhealthy
+	// shortly after it automatically updated to a new revision.
+	RollbackPolicyAutomatic RollbackPolicy = "Automatic"
+)
+
+// Rollback configures whether Crossplane rolls composite resources back to a
+// previous CompositionRevision when they become unhealthy.
+type Rollback struct {
+	// Policy determines whether composite resources are rolled back. Automatic
+	// rolls a composite resource back to the last revision under which it was
+	// ready if it fails to sync, or stays unready for longer than the ready
+	// grace period, within the rollback window after it automatically updated
+	// to a new revision. Crossplane rolls a composite resource back by
+	// referencing the previous revision and setting its composition update
+	// policy to Manual.
+	// +optional
+	// +kubebuilder:validation:Enum=Never;Automatic
+	// +kubebuilder:default=Never
+	Policy RollbackPolicy `json:"policy,omitempty"`
+
+	// WindowSeconds is how long after a composite resource updates to a new
+	// revision Crossplane rolls it back if it becomes unhealthy.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:default=600
+	WindowSeconds *int64 `json:"windowSeconds,omitempty"`
+
+	// ReadyGracePeriodSeconds is how long a composite resource may stay
+	// unready after it updates to a new revision before Crossplane rolls it
+	// back. Composite resources are often unready for a while after they
+	// update, for example while a new composed resource is created. Set it
+	// lower than the rollback window, or Crossplane only rolls composite
+	// resources back when they fail to sync.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:default=300
+	ReadyGracePeriodSeconds *int64 `json:"readyGracePeriodSeconds,omitempty"`
+}
+
 // TypeReference is used to refer to a type for declaring compatibility.
 type TypeReference struct {
 	// APIVersion of the type.
@@ -141,6 +271,14 @@ type FunctionRequirements struct {
 	// +listType=map
 	// +listMapKey=requirementName
 	RequiredSchemas []RequiredSchemaSelector `json:"requiredSchemas,omitempty"`
+
+	// MaxIterations is the maximum number of times this pipeline step's
+	// function is called again to satisfy the requirements it returns.
+	// Defaults to 5.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=20
+	MaxIterations *int32 `json:"maxIterations,omitempty"`
 }
 
 // RequiredResourceSelector selects a required resource.
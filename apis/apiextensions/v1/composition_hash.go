@@ -23,7 +23,9 @@ import (
 	"sigs.k8s.io/yaml"
 )
 
-// Hash of the Composition.
+// Hash of the Composition. The hash doesn't include the Composition's rollout
+// strategy, which controls how composite resources move between revisions
+// rather than how they're composed. Changing it doesn't create a new revision.
 func (c *Composition) Hash() string {
 	h := sha256.New()
 
@@ -40,7 +42,10 @@ func (c *Composition) Hash() string {
 		return "unknown"
 	}
 
-	s, err := yaml.Marshal(c.Spec)
+	spec := c.Spec.DeepCopy()
+	spec.Rollout = nil
+
+	s, err := yaml.Marshal(spec)
 	if err != nil {
 		return "unknown"
 	}
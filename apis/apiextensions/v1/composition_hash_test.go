@@ -0,0 +1,81 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+)
+
+func TestCompositionHash(t *testing.T) {
+	comp := &Composition{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   "cool-composition",
+			Labels: map[string]string{"channel": "dev"},
+		},
+		Spec: CompositionSpec{
+			CompositeTypeRef: TypeReference{APIVersion: "example.org/v1", Kind: "XCool"},
+			Mode:             CompositionModePipeline,
+		},
+	}
+
+	cases := map[string]struct {
+		reason string
+		modify func(c *Composition)
+		same   bool
+	}{
+		"Unchanged": {
+			reason: "An unchanged Composition should have the same hash.",
+			modify: func(_ *Composition) {},
+			same:   true,
+		},
+		"RolloutChanged": {
+			reason: "Changing a Composition's rollout strategy shouldn't change its hash.",
+			modify: func(c *Composition) {
+				c.Spec.Rollout = &RolloutStrategy{CanaryPercent: ptr.To[int32](20)}
+			},
+			same: true,
+		},
+		"SpecChanged": {
+			reason: "Changing how a Composition composes resources should change its hash.",
+			modify: func(c *Composition) {
+				c.Spec.CompositeTypeRef.Kind = "XCooler"
+			},
+			same: false,
+		},
+		"LabelsChanged": {
+			reason: "Changing a Composition's labels should change its hash.",
+			modify: func(c *Composition) {
+				c.SetLabels(map[string]string{"channel": "prod"})
+			},
+			same: false,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			modified := comp.DeepCopy()
+			tc.modify(modified)
+
+			if got := comp.Hash() == modified.Hash(); got != tc.same {
+				t.Errorf("\n%s\nc.Hash() == modified.Hash(): want %t, got %t", tc.reason, tc.same, got)
+			}
+		})
+	}
+}
@@ -17,7 +17,11 @@ limitations under the License.
 package v1
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 )
 
 // LatestRevision returns the latest revision of the supplied composition.
@@ -45,3 +49,12 @@ func LatestRevision(c *Composition, revs []CompositionRevision) *CompositionRevi
 
 	return &latest
 }
+
+// RolloutOrder returns the order in which the composite resource with the
+// supplied UID moves to the supplied revision during a rollout. Composite
+// resources move in ascending order. Each revision uses a different, stable
+// order.
+func RolloutOrder(rev *CompositionRevision, uid types.UID) string {
+	h := sha256.Sum256([]byte(rev.GetName() + "/" + string(uid)))
+	return hex.EncodeToString(h[:])
+}
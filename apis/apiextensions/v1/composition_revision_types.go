@@ -68,6 +68,17 @@ type CompositionRevisionSpec struct {
 	// +optional
 	WriteConnectionSecretsToNamespace *string `json:"writeConnectionSecretsToNamespace,omitempty"`
 
+	// GarbageCollection configures how composed resources that the pipeline
+	// stops returning are garbage collected.
+	// +optional
+	GarbageCollection *GarbageCollection `json:"garbageCollection,omitempty"`
+
+	// Rollback configures whether composite resources that become unhealthy
+	// after automatically updating to this revision are rolled back to their
+	// previous revision.
+	// +optional
+	Rollback *Rollback `json:"rollback,omitempty"`
+
 	// Revision number. Newer revisions have larger numbers.
 	//
 	// This number can change. When a Composition transitions from state A
@@ -81,6 +92,40 @@ type CompositionRevisionSpec struct {
 // revision.
 type CompositionRevisionStatus struct {
 	xpv2.ConditionedStatus `json:",inline"`
+
+	// Rollout shows the progress of moving composite resources to this
+	// revision, if its Composition has a rollout strategy.
+	// +optional
+	Rollout *RolloutStatus `json:"rollout,omitempty"`
+}
+
+// RolloutStatus shows the progress of moving composite resources to a
+// CompositionRevision. Crossplane starts one wave at a time, and records when
+// it started the latest wave.
+type RolloutStatus struct {
+	// Wave is the latest rollout wave that started. The first (canary) wave
+	// is wave 0.
+	Wave int32 `json:"wave"`
+
+	// WaveStartTime is when the latest wave started. The next wave starts a
+	// pause after it.
+	WaveStartTime metav1.Time `json:"waveStartTime"`
+
+	// Through is the rollout order of the last composite resource in the
+	// waves that started. Composite resources whose rollout order sorts at or
+	// before it move to this revision.
+	// +optional
+	Through string `json:"through,omitempty"`
+
+	// Halted is true while the rollout is halted because too many of the
+	// composite resources using this revision aren't ready.
+	// +optional
+	Halted bool `json:"halted,omitempty"`
+
+	// Complete is true once the waves that started include every composite
+	// resource. Any composite resource may then move to this revision.
+	// +optional
+	Complete bool `json:"complete,omitempty"`
 }
 
 // +kubebuilder:object:root=true
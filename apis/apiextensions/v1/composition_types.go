@@ -58,6 +58,64 @@ type CompositionSpec struct {
 	// this composition will be created.
 	// +optional
 	WriteConnectionSecretsToNamespace *string `json:"writeConnectionSecretsToNamespace,omitempty"`
+
+	// GarbageCollection configures how composed resources that the pipeline
+	// stops returning are garbage collected.
+	// +optional
+	GarbageCollection *GarbageCollection `json:"garbageCollection,omitempty"`
+
+	// Rollback configures whether composite resources that become unhealthy
+	// after automatically updating to a new revision of this composition are
+	// rolled back to their previous revision.
+	// +optional
+	Rollback *Rollback `json:"rollback,omitempty"`
+
+	// Rollout configures how composite resources that automatically update
+	// to new revisions of this composition move to a new revision. By default
+	// they all move as soon as the revision is created. A rollout moves them
+	// in waves instead, and halts if too many of the composite resources that
+	// already moved aren't ready.
+	// +optional
+	Rollout *RolloutStrategy `json:"rollout,omitempty"`
+}
+
+// A RolloutStrategy progressively moves composite resources to a new
+// CompositionRevision. The first wave is a canary. Each subsequent wave starts
+// a pause after the one before it.
+type RolloutStrategy struct {
+	// CanaryPercent is the percentage of composite resources that move to a
+	// new revision in the first wave. At least one composite resource moves in
+	// the first wave.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=100
+	// +kubebuilder:default=10
+	CanaryPercent *int32 `json:"canaryPercent,omitempty"`
+
+	// BatchSize is the number of composite resources that move to a new
+	// revision in each wave after the first.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:default=50
+	BatchSize *int32 `json:"batchSize,omitempty"`
+
+	// PauseSeconds is the number of seconds between the start of each wave.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:default=300
+	PauseSeconds *int64 `json:"pauseSeconds,omitempty"`
+
+	// MaxUnhealthyPercent is the maximum percentage of composite resources
+	// using a new revision that may not be ready. Composite resources only
+	// count once they've been unready for longer than the new revision's
+	// rollback ready grace period, or five minutes if it doesn't set one.
+	// The rollout halts while more aren't ready, and resumes once enough of
+	// them are ready again.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=100
+	// +kubebuilder:default=10
+	MaxUnhealthyPercent *int32 `json:"maxUnhealthyPercent,omitempty"`
 }
 
 // +kubebuilder:object:root=true
@@ -41,6 +41,15 @@ const (
 
 	// A TypeResponsive indicates whether the resource is responsive to changes.
 	TypeResponsive xpv2.ConditionType = "Responsive"
+
+	// A TypePlanned composite resource is in plan mode. Crossplane runs its
+	// function pipeline, but only reports the changes it would make to its
+	// composed resources.
+	TypePlanned xpv2.ConditionType = "Planned"
+
+	// A TypeRolledBack composite resource was automatically rolled back to
+	// the last CompositionRevision under which it was ready.
+	TypeRolledBack xpv2.ConditionType = "RolledBack"
 )
 
 // Reasons a resource is or is not established or offered.
@@ -56,6 +65,12 @@ const (
 
 	ReasonWatchCircuitOpen   xpv2.ConditionReason = "WatchCircuitOpen"
 	ReasonWatchCircuitClosed xpv2.ConditionReason = "WatchCircuitClosed"
+
+	ReasonPlanned    xpv2.ConditionReason = "Planned"
+	ReasonNotPlanned xpv2.ConditionReason = "PlanModeDisabled"
+
+	ReasonRolledBack    xpv2.ConditionReason = "RolledBack"
+	ReasonNotRolledBack xpv2.ConditionReason = "AutomaticUpdatesResumed"
 )
 
 // WatchingComposite indicates that Crossplane has defined and is watching for a
@@ -146,6 +161,53 @@ func WatchCircuitClosed() xpv2.Condition {
 	}
 }
 
+// Planned indicates that a composite resource is in plan mode. The supplied
+// message describes the changes Crossplane would make to its composed
+// resources.
+func Planned(message string) xpv2.Condition {
+	return xpv2.Condition{
+		Type:               TypePlanned,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonPlanned,
+		Message:            message,
+	}
+}
+
+// NotPlanned indicates that a composite resource is no longer in plan mode.
+func NotPlanned() xpv2.Condition {
+	return xpv2.Condition{
+		Type:               TypePlanned,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonNotPlanned,
+	}
+}
+
+// RolledBack indicates that a composite resource was automatically rolled back
+// to the last CompositionRevision under which it was ready. The supplied
+// message describes the rollback.
+func RolledBack(message string) xpv2.Condition {
+	return xpv2.Condition{
+		Type:               TypeRolledBack,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonRolledBack,
+		Message:            message,
+	}
+}
+
+// NotRolledBack indicates that a composite resource that was rolled back
+// automatically updates to new CompositionRevisions again.
+func NotRolledBack() xpv2.Condition {
+	return xpv2.Condition{
+		Type:               TypeRolledBack,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonNotRolledBack,
+	}
+}
+
 // IsSystemConditionType returns true if the condition type is a system
 // condition. This includes both core system conditions and
 // apiextensions-specific system conditions like the circuit breaker.
@@ -156,5 +218,5 @@ func IsSystemConditionType(t xpv2.ConditionType) bool {
 	}
 
 	// Then check Crossplane-specific system conditions
-	return t == TypeResponsive
+	return t == TypeResponsive || t == TypePlanned || t == TypeRolledBack
 }
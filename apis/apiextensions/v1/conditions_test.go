@@ -43,6 +43,16 @@ func TestIsSystemConditionType(t *testing.T) {
 			conditionType: TypeResponsive,
 			want:          true,
 		},
+		"CrossplanePlannedCondition": {
+			reason:        "plan mode planned condition should be system type",
+			conditionType: TypePlanned,
+			want:          true,
+		},
+		"CrossplaneRolledBackCondition": {
+			reason:        "automatic rollback condition should be system type",
+			conditionType: TypeRolledBack,
+			want:          true,
+		},
 		"CustomCondition": {
 			reason:        "custom database condition should not be system type",
 			conditionType: "DatabaseReady",
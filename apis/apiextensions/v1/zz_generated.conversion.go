@@ -24,6 +24,8 @@ func (c *GeneratedRevisionSpecConverter) FromRevisionSpec(source CompositionRevi
 		xstring := *source.WriteConnectionSecretsToNamespace
 		v1CompositionSpec.WriteConnectionSecretsToNamespace = &xstring
 	}
+	v1CompositionSpec.GarbageCollection = c.pV1GarbageCollectionToPV1GarbageCollection(source.GarbageCollection)
+	v1CompositionSpec.Rollback = c.pV1RollbackToPV1Rollback(source.Rollback)
 	return v1CompositionSpec
 }
 func (c *GeneratedRevisionSpecConverter) ToRevisionSpec(source CompositionSpec) CompositionRevisionSpec {
@@ -40,6 +42,8 @@ func (c *GeneratedRevisionSpecConverter) ToRevisionSpec(source CompositionSpec)
 		xstring := *source.WriteConnectionSecretsToNamespace
 		v1CompositionRevisionSpec.WriteConnectionSecretsToNamespace = &xstring
 	}
+	v1CompositionRevisionSpec.GarbageCollection = c.pV1GarbageCollectionToPV1GarbageCollection(source.GarbageCollection)
+	v1CompositionRevisionSpec.Rollback = c.pV1RollbackToPV1Rollback(source.Rollback)
 	return v1CompositionRevisionSpec
 }
 func (c *GeneratedRevisionSpecConverter) pRuntimeRawExtensionToPRuntimeRawExtension(source *runtime.RawExtension) *runtime.RawExtension {
@@ -66,10 +70,48 @@ func (c *GeneratedRevisionSpecConverter) pV1FunctionRequirementsToPV1FunctionReq
 				v1FunctionRequirements.RequiredSchemas[j] = c.v1RequiredSchemaSelectorToV1RequiredSchemaSelector((*source).RequiredSchemas[j])
 			}
 		}
+		if (*source).MaxIterations != nil {
+			xint32 := *(*source).MaxIterations
+			v1FunctionRequirements.MaxIterations = &xint32
+		}
 		pV1FunctionRequirements = &v1FunctionRequirements
 	}
 	return pV1FunctionRequirements
 }
+func (c *GeneratedRevisionSpecConverter) pV1GarbageCollectionToPV1GarbageCollection(source *GarbageCollection) *GarbageCollection {
+	var pV1GarbageCollection *GarbageCollection
+	if source != nil {
+		var v1GarbageCollection GarbageCollection
+		v1GarbageCollection.Policy = c.v1GarbageCollectionPolicyToV1GarbageCollectionPolicy((*source).Policy)
+		if (*source).MaxDeletions != nil {
+			xint32 := *(*source).MaxDeletions
+			v1GarbageCollection.MaxDeletions = &xint32
+		}
+		if (*source).MaxDeletionPercent != nil {
+			xint322 := *(*source).MaxDeletionPercent
+			v1GarbageCollection.MaxDeletionPercent = &xint322
+		}
+		pV1GarbageCollection = &v1GarbageCollection
+	}
+	return pV1GarbageCollection
+}
+func (c *GeneratedRevisionSpecConverter) pV1RollbackToPV1Rollback(source *Rollback) *Rollback {
+	var pV1Rollback *Rollback
+	if source != nil {
+		var v1Rollback Rollback
+		v1Rollback.Policy = c.v1RollbackPolicyToV1RollbackPolicy((*source).Policy)
+		if (*source).WindowSeconds != nil {
+			xint64 := *(*source).WindowSeconds
+			v1Rollback.WindowSeconds = &xint64
+		}
+		if (*source).ReadyGracePeriodSeconds != nil {
+			xint642 := *(*source).ReadyGracePeriodSeconds
+			v1Rollback.ReadyGracePeriodSeconds = &xint642
+		}
+		pV1Rollback = &v1Rollback
+	}
+	return pV1Rollback
+}
 func (c *GeneratedRevisionSpecConverter) pV2SecretReferenceToPV2SecretReference(source *v2.SecretReference) *v2.SecretReference {
 	var pV2SecretReference *v2.SecretReference
 	if source != nil {
@@ -112,6 +154,17 @@ func (c *GeneratedRevisionSpecConverter) v1FunctionReferenceToV1FunctionReferenc
 	v1FunctionReference.Name = source.Name
 	return v1FunctionReference
 }
+func (c *GeneratedRevisionSpecConverter) v1GarbageCollectionPolicyToV1GarbageCollectionPolicy(source GarbageCollectionPolicy) GarbageCollectionPolicy {
+	var v1GarbageCollectionPolicy GarbageCollectionPolicy
+	switch source {
+	case GarbageCollectionPolicyDelete:
+		v1GarbageCollectionPolicy = GarbageCollectionPolicyDelete
+	case GarbageCollectionPolicyOrphan:
+		v1GarbageCollectionPolicy = GarbageCollectionPolicyOrphan
+	default: // ignored
+	}
+	return v1GarbageCollectionPolicy
+}
 func (c *GeneratedRevisionSpecConverter) v1PipelineStepToV1PipelineStep(source PipelineStep) PipelineStep {
 	var v1PipelineStep PipelineStep
 	v1PipelineStep.Step = source.Step
@@ -154,6 +207,17 @@ func (c *GeneratedRevisionSpecConverter) v1RequiredSchemaSelectorToV1RequiredSch
 	v1RequiredSchemaSelector.Kind = source.Kind
 	return v1RequiredSchemaSelector
 }
+func (c *GeneratedRevisionSpecConverter) v1RollbackPolicyToV1RollbackPolicy(source RollbackPolicy) RollbackPolicy {
+	var v1RollbackPolicy RollbackPolicy
+	switch source {
+	case RollbackPolicyAutomatic:
+		v1RollbackPolicy = RollbackPolicyAutomatic
+	case RollbackPolicyNever:
+		v1RollbackPolicy = RollbackPolicyNever
+	default: // ignored
+	}
+	return v1RollbackPolicy
+}
 func (c *GeneratedRevisionSpecConverter) v1TypeReferenceToV1TypeReference(source TypeReference) TypeReference {
 	var v1TypeReference TypeReference
 	v1TypeReference.APIVersion = source.APIVersion
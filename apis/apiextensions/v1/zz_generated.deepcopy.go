@@ -446,6 +446,16 @@ func (in *CompositionRevisionSpec) DeepCopyInto(out *CompositionRevisionSpec) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.GarbageCollection != nil {
+		in, out := &in.GarbageCollection, &out.GarbageCollection
+		*out = new(GarbageCollection)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Rollback != nil {
+		in, out := &in.Rollback, &out.Rollback
+		*out = new(Rollback)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CompositionRevisionSpec.
@@ -462,6 +472,11 @@ func (in *CompositionRevisionSpec) DeepCopy() *CompositionRevisionSpec {
 func (in *CompositionRevisionStatus) DeepCopyInto(out *CompositionRevisionStatus) {
 	*out = *in
 	in.ConditionedStatus.DeepCopyInto(&out.ConditionedStatus)
+	if in.Rollout != nil {
+		in, out := &in.Rollout, &out.Rollout
+		*out = new(RolloutStatus)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CompositionRevisionStatus.
@@ -490,6 +505,21 @@ func (in *CompositionSpec) DeepCopyInto(out *CompositionSpec) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.GarbageCollection != nil {
+		in, out := &in.GarbageCollection, &out.GarbageCollection
+		*out = new(GarbageCollection)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Rollback != nil {
+		in, out := &in.Rollback, &out.Rollback
+		*out = new(Rollback)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Rollout != nil {
+		in, out := &in.Rollout, &out.Rollout
+		*out = new(RolloutStrategy)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CompositionSpec.
@@ -552,6 +582,11 @@ func (in *FunctionRequirements) DeepCopyInto(out *FunctionRequirements) {
 		*out = make([]RequiredSchemaSelector, len(*in))
 		copy(*out, *in)
 	}
+	if in.MaxIterations != nil {
+		in, out := &in.MaxIterations, &out.MaxIterations
+		*out = new(int32)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FunctionRequirements.
@@ -564,6 +599,31 @@ func (in *FunctionRequirements) DeepCopy() *FunctionRequirements {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GarbageCollection) DeepCopyInto(out *GarbageCollection) {
+	*out = *in
+	if in.MaxDeletions != nil {
+		in, out := &in.MaxDeletions, &out.MaxDeletions
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxDeletionPercent != nil {
+		in, out := &in.MaxDeletionPercent, &out.MaxDeletionPercent
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GarbageCollection.
+func (in *GarbageCollection) DeepCopy() *GarbageCollection {
+	if in == nil {
+		return nil
+	}
+	out := new(GarbageCollection)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *GeneratedRevisionSpecConverter) DeepCopyInto(out *GeneratedRevisionSpecConverter) {
 	*out = *in
@@ -659,6 +719,82 @@ func (in *RequiredSchemaSelector) DeepCopy() *RequiredSchemaSelector {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Rollback) DeepCopyInto(out *Rollback) {
+	*out = *in
+	if in.WindowSeconds != nil {
+		in, out := &in.WindowSeconds, &out.WindowSeconds
+		*out = new(int64)
+		**out = **in
+	}
+	if in.ReadyGracePeriodSeconds != nil {
+		in, out := &in.ReadyGracePeriodSeconds, &out.ReadyGracePeriodSeconds
+		*out = new(int64)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Rollback.
+func (in *Rollback) DeepCopy() *Rollback {
+	if in == nil {
+		return nil
+	}
+	out := new(Rollback)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RolloutStatus) DeepCopyInto(out *RolloutStatus) {
+	*out = *in
+	in.WaveStartTime.DeepCopyInto(&out.WaveStartTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RolloutStatus.
+func (in *RolloutStatus) DeepCopy() *RolloutStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(RolloutStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RolloutStrategy) DeepCopyInto(out *RolloutStrategy) {
+	*out = *in
+	if in.CanaryPercent != nil {
+		in, out := &in.CanaryPercent, &out.CanaryPercent
+		*out = new(int32)
+		**out = **in
+	}
+	if in.BatchSize != nil {
+		in, out := &in.BatchSize, &out.BatchSize
+		*out = new(int32)
+		**out = **in
+	}
+	if in.PauseSeconds != nil {
+		in, out := &in.PauseSeconds, &out.PauseSeconds
+		*out = new(int64)
+		**out = **in
+	}
+	if in.MaxUnhealthyPercent != nil {
+		in, out := &in.MaxUnhealthyPercent, &out.MaxUnhealthyPercent
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RolloutStrategy.
+func (in *RolloutStrategy) DeepCopy() *RolloutStrategy {
+	if in == nil {
+		return nil
+	}
+	out := new(RolloutStrategy)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TypeReference) DeepCopyInto(out *TypeReference) {
 	*out = *in
@@ -141,6 +141,14 @@ type FunctionRequirements struct {
 	// +listType=map
 	// +listMapKey=requirementName
 	RequiredSchemas []RequiredSchemaSelector `json:"requiredSchemas,omitempty"`
+
+	// MaxIterations is the maximum number of times this pipeline step's
+	// function is called again to satisfy the requirements it returns.
+	// Defaults to 5.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=20
+	MaxIterations *int32 `json:"maxIterations,omitempty"`
 }
 
 // RequiredResourceSelector selects resources that should be fetched before
@@ -219,6 +219,11 @@ func (in *FunctionRequirements) DeepCopyInto(out *FunctionRequirements) {
 		*out = make([]RequiredSchemaSelector, len(*in))
 		copy(*out, *in)
 	}
+	if in.MaxIterations != nil {
+		in, out := &in.MaxIterations, &out.MaxIterations
+		*out = new(int32)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FunctionRequirements.
@@ -33,6 +33,14 @@ const (
 	// used in an operation.
 	FunctionCapabilityOperation = "operation"
 
+	// FunctionCapabilityStreaming is a capability key for a function that
+	// implements the RunFunctionStream RPC.
+	FunctionCapabilityStreaming = "streaming"
+
+	// FunctionCapabilityObservedDelta is a capability key for a function that
+	// accepts observed state deltas.
+	FunctionCapabilityObservedDelta = "observed-delta"
+
 	// ProviderCapabilitySafeStart is a capability key for a provider that
 	// supports "safe" starting of its controller gated on the existence of
 	// dependent kinds in the cluster.
@@ -44,6 +44,11 @@ const (
 	// zero, awaiting activation of the first ManagedResourceDefinition owned
 	// by the revision.
 	TypeRuntimeActive xpv2.ConditionType = "RuntimeActive"
+
+	// TypeCircuitClosed indicates whether Crossplane is calling a function
+	// package revision's runtime. It is false while a circuit breaker
+	// rejects calls to the runtime because too many recent calls failed.
+	TypeCircuitClosed xpv2.ConditionType = "CircuitClosed"
 )
 
 // Reasons a package is or is not installed.
@@ -58,6 +63,13 @@ const (
 	ReasonAwaitingActivation xpv2.ConditionReason = "AwaitingActivation"
 )
 
+// Reasons a function package revision's circuit is or is not closed.
+const (
+	ReasonCircuitClosed   xpv2.ConditionReason = "CircuitClosed"
+	ReasonCircuitOpen     xpv2.ConditionReason = "CircuitOpen"
+	ReasonCircuitHalfOpen xpv2.ConditionReason = "CircuitHalfOpen"
+)
+
 // Unpacking indicates that the package manager is waiting for a package
 // revision to be unpacked.
 func Unpacking() xpv2.Condition {
@@ -217,6 +229,40 @@ func RuntimeUnknownHealth() xpv2.Condition {
 	}
 }
 
+// CircuitClosed indicates that Crossplane is calling the current function
+// package revision's runtime.
+func CircuitClosed() xpv2.Condition {
+	return xpv2.Condition{
+		Type:               TypeCircuitClosed,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonCircuitClosed,
+	}
+}
+
+// CircuitOpen indicates that Crossplane is rejecting calls to the current
+// function package revision's runtime because too many recent calls failed.
+func CircuitOpen() xpv2.Condition {
+	return xpv2.Condition{
+		Type:               TypeCircuitClosed,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonCircuitOpen,
+	}
+}
+
+// CircuitHalfOpen indicates that Crossplane is probing whether the current
+// function package revision's runtime has recovered, and rejecting all other
+// calls until it knows.
+func CircuitHalfOpen() xpv2.Condition {
+	return xpv2.Condition{
+		Type:               TypeCircuitClosed,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonCircuitHalfOpen,
+	}
+}
+
 // PackageHealth returns the health condition of a Package based on the provided
 // PackageRevision. It checks both the revision health and runtime health
 // conditions, and returns a healthy condition if both are healthy, an unhealthy
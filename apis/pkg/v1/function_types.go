@@ -51,6 +51,48 @@ type FunctionSpec struct {
 	PackageSpec `json:",inline"`
 
 	PackageRuntimeSpec `json:",inline"`
+
+	// CallPolicy configures how Crossplane calls the Function. It overrides
+	// Crossplane's default call policy.
+	// +optional
+	CallPolicy *FunctionCallPolicy `json:"callPolicy,omitempty"`
+}
+
+// A FunctionCallPolicy configures how Crossplane calls a Function. Any field
+// that isn't set uses Crossplane's default.
+type FunctionCallPolicy struct {
+	// Timeout is the maximum time to wait for the Function to respond,
+	// including any retries or hedged requests.
+	// +optional
+	// +kubebuilder:validation:XValidation:rule="duration(self) >= duration('0s')",message="timeout must not be negative"
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+
+	// MaxAttempts is the maximum number of requests to send for each call,
+	// including the original request.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=5
+	MaxAttempts *int32 `json:"maxAttempts,omitempty"`
+
+	// RetryBackoff is how long to wait before the first retry. The backoff
+	// doubles after each retry, up to ten times its initial value. Requests
+	// are only retried if the Function is unavailable.
+	// +optional
+	// +kubebuilder:validation:XValidation:rule="duration(self) >= duration('0s')",message="retryBackoff must not be negative"
+	RetryBackoff *metav1.Duration `json:"retryBackoff,omitempty"`
+
+	// HedgingDelay enables hedging. Instead of waiting for a request to fail
+	// before retrying, Crossplane sends another request if no response has
+	// arrived after this delay, up to MaxAttempts. The first response wins.
+	// +optional
+	// +kubebuilder:validation:XValidation:rule="duration(self) >= duration('0s')",message="hedgingDelay must not be negative"
+	HedgingDelay *metav1.Duration `json:"hedgingDelay,omitempty"`
+
+	// MaxInFlight is the maximum number of calls that may be in flight to the
+	// Function at once. Further calls wait their turn. Zero means unlimited.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	MaxInFlight *int32 `json:"maxInFlight,omitempty"`
 }
 
 // FunctionStatus represents the observed state of a Function.
@@ -73,6 +115,11 @@ type FunctionList struct {
 type FunctionRevisionSpec struct {
 	PackageRevisionSpec        `json:",inline"`
 	PackageRevisionRuntimeSpec `json:",inline"`
+
+	// CallPolicy configures how Crossplane calls the Function. It's
+	// propagated from the parent Function.
+	// +optional
+	CallPolicy *FunctionCallPolicy `json:"callPolicy,omitempty"`
 }
 
 // +kubebuilder:object:root=true
@@ -23,6 +23,7 @@ package v1
 import (
 	"github.com/crossplane/crossplane/apis/v2/core/v2"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 )
 
@@ -219,6 +220,46 @@ func (in *Function) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FunctionCallPolicy) DeepCopyInto(out *FunctionCallPolicy) {
+	*out = *in
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.MaxAttempts != nil {
+		in, out := &in.MaxAttempts, &out.MaxAttempts
+		*out = new(int32)
+		**out = **in
+	}
+	if in.RetryBackoff != nil {
+		in, out := &in.RetryBackoff, &out.RetryBackoff
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.HedgingDelay != nil {
+		in, out := &in.HedgingDelay, &out.HedgingDelay
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.MaxInFlight != nil {
+		in, out := &in.MaxInFlight, &out.MaxInFlight
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FunctionCallPolicy.
+func (in *FunctionCallPolicy) DeepCopy() *FunctionCallPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(FunctionCallPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *FunctionList) DeepCopyInto(out *FunctionList) {
 	*out = *in
@@ -315,6 +356,11 @@ func (in *FunctionRevisionSpec) DeepCopyInto(out *FunctionRevisionSpec) {
 	*out = *in
 	in.PackageRevisionSpec.DeepCopyInto(&out.PackageRevisionSpec)
 	in.PackageRevisionRuntimeSpec.DeepCopyInto(&out.PackageRevisionRuntimeSpec)
+	if in.CallPolicy != nil {
+		in, out := &in.CallPolicy, &out.CallPolicy
+		*out = new(FunctionCallPolicy)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FunctionRevisionSpec.
@@ -349,6 +395,11 @@ func (in *FunctionSpec) DeepCopyInto(out *FunctionSpec) {
 	*out = *in
 	in.PackageSpec.DeepCopyInto(&out.PackageSpec)
 	in.PackageRuntimeSpec.DeepCopyInto(&out.PackageRuntimeSpec)
+	if in.CallPolicy != nil {
+		in, out := &in.CallPolicy, &out.CallPolicy
+		*out = new(FunctionCallPolicy)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FunctionSpec.
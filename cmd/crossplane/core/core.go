@@ -24,12 +24,14 @@ import (
 	"io"
 	"os"
 	"path/filepath"
+	"strings"
 	"time"
 
 	"github.com/alecthomas/kong"
 	"github.com/spf13/afero"
 	corev1 "k8s.io/api/core/v1"
 	kmeta "k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/discovery"
 	"k8s.io/client-go/discovery/cached/memory"
@@ -42,6 +44,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/cache"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
 	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 
@@ -73,6 +76,7 @@ import (
 	"github.com/crossplane/crossplane/v2/internal/transport"
 	usagehook "github.com/crossplane/crossplane/v2/internal/webhook/protection/usage"
 	"github.com/crossplane/crossplane/v2/internal/xfn"
+	"github.com/crossplane/crossplane/v2/internal/xfn/breaker"
 	xfncached "github.com/crossplane/crossplane/v2/internal/xfn/cached"
 	"github.com/crossplane/crossplane/v2/internal/xfn/inspected"
 )
@@ -115,6 +119,11 @@ type startCommand struct {
 	MaxConcurrentReconciles          int           `aliases:"max-reconcile-rate" default:"100"                                                                                            help:"The maximum number of concurrent reconcile operations (worker pool size)."`
 	MaxConcurrentPackageEstablishers int           `default:"10"                 help:"The maximum number of goroutines to use for establishing Providers, Configurations and Functions."`
 
+	FunctionTimeout          time.Duration `default:"0"           help:"Default maximum time to wait for a Function to respond, including retries. Override it per Function with spec.callPolicy.timeout. Set to 0 to disable."`
+	FunctionMaxAttempts      int           `default:"1"           help:"Default maximum number of requests to send each time a Function is called. Requests are retried if the Function is unavailable. Override it per Function with spec.callPolicy.maxAttempts."`
+	FunctionMaxInFlight      int           `default:"0"           help:"Default maximum number of calls that may be in flight to each Function at once. Further calls wait in a fair queue. Override it per Function with spec.callPolicy.maxInFlight. Set to 0 to disable."`
+	FunctionQueueFairnessKey string        `default:"Composition" help:"Which callers share a Function's calls fairly when calls wait in its queue. One of Composition or XRD."`
+
 	CircuitBreakerBurst      float64       `default:"100.0" help:"XR circuit breaker token bucket capacity."`
 	CircuitBreakerRefillRate float64       `default:"1.0"   help:"XR circuit breaker token refill rate (tokens/second)."`
 	CircuitBreakerCooldown   time.Duration `default:"5m"    help:"How long XR circuit breakers stay open after triggering."`
@@ -137,10 +146,29 @@ type startCommand struct {
 	EnableOperations                  bool `group:"Alpha Features:" help:"Enable support for Operations."`
 	EnablePipelineInspector           bool `group:"Alpha Features:" help:"Enable support for emitting function pipeline execution data to a sidecar."`
 	EnableProviderDeletionProtection  bool `group:"Alpha Features:" help:"Enable automatic protection of Providers from deletion when they have active managed resources. Requires --enable-usages."`
-
-	XfnCacheDir             string        `default:"/cache/xfn"                         env:"XFN_CACHE_DIR"             group:"Alpha Features:" help:"Directory used for caching function responses. Requires --enable-function-response-cache."`
-	XfnCacheMaxTTL          time.Duration `default:"24h"                                env:"XFN_CACHE_MAX_TTL"         group:"Alpha Features:" help:"Maximum TTL for cached function responses. Set to 0 to disable. Requires --enable-function-response-cache."`
-	PipelineInspectorSocket string        `default:"/var/run/pipeline-inspector/socket" env:"PIPELINE_INSPECTOR_SOCKET" group:"Alpha Features:" help:"Unix socket path for pipeline inspector sidecar. Requires --enable-pipeline-inspector."`
+	EnableFunctionCircuitBreaker      bool `group:"Alpha Features:" help:"Enable failing fast when calls to a composition function keep failing, instead of waiting for each call to time out."`
+	EnableFunctionObservedDelta       bool `group:"Alpha Features:" help:"Enable sending functions that advertise the observed-delta capability only the changes to the observed state."`
+
+	XfnCacheDir                  string        `default:"/cache/xfn"                         env:"XFN_CACHE_DIR"                     group:"Alpha Features:" help:"Directory used for caching function responses. Requires --enable-function-response-cache."`
+	XfnCacheMaxTTL               time.Duration `default:"24h"                                env:"XFN_CACHE_MAX_TTL"                 group:"Alpha Features:" help:"Maximum TTL for cached function responses. Set to 0 to disable. Requires --enable-function-response-cache."`
+	XfnCacheMaxSize              string        `default:"0"                                  env:"XFN_CACHE_MAX_SIZE"                group:"Alpha Features:" help:"Maximum total size of cached function responses, as a Kubernetes quantity (e.g. 512Mi). Set to 0 to disable. Requires --enable-function-response-cache."`
+	XfnCacheMaxEntries           int           `default:"0"                                  env:"XFN_CACHE_MAX_ENTRIES"             group:"Alpha Features:" help:"Maximum number of cached function responses. Set to 0 to disable. Requires --enable-function-response-cache."`
+	XfnCacheMemoryEntries        int           `default:"0"                                  env:"XFN_CACHE_MEMORY_ENTRIES"          group:"Alpha Features:" help:"Number of recently used function responses to also cache in memory, in front of the cache directory. Set to 0 to disable. Requires --enable-function-response-cache."`
+	XfnCacheEvictionPolicy       string        `default:"LRU"                                env:"XFN_CACHE_EVICTION_POLICY"         group:"Alpha Features:" help:"Which cached function responses to evict first when the cache exceeds its maximum size. LRU (least recently used) or LFU (least frequently used). Requires --enable-function-response-cache."`
+	XfnCacheRemoteURL            string        `default:""                                   env:"XFN_CACHE_REMOTE_URL"              group:"Alpha Features:" help:"URL of a remote key/value store used for caching function responses instead of --xfn-cache-dir, so cached responses are shared between replicas and survive restarts. Requires --enable-function-response-cache."`
+	XfnCacheRemoteTimeout        time.Duration `default:"10s"                                env:"XFN_CACHE_REMOTE_TIMEOUT"          group:"Alpha Features:" help:"How long to wait for the remote key/value store to respond. Requires --xfn-cache-remote-url."`
+	XfnCacheRemoteCAFile         string        `default:""                                   env:"XFN_CACHE_REMOTE_CA_FILE"          group:"Alpha Features:" help:"Path to a CA bundle used to verify the remote key/value store's certificate. Requires --xfn-cache-remote-url."`
+	XfnCacheRemoteCertFile       string        `default:""                                   env:"XFN_CACHE_REMOTE_CERT_FILE"        group:"Alpha Features:" help:"Path to a client certificate presented to the remote key/value store. Requires --xfn-cache-remote-url."`
+	XfnCacheRemoteKeyFile        string        `default:""                                   env:"XFN_CACHE_REMOTE_KEY_FILE"         group:"Alpha Features:" help:"Path to the private key of the client certificate presented to the remote key/value store. Requires --xfn-cache-remote-url."`
+	XfnCacheRemoteTokenFile      string        `default:""                                   env:"XFN_CACHE_REMOTE_TOKEN_FILE"       group:"Alpha Features:" help:"Path to a bearer token used to authenticate to the remote key/value store. Requires --xfn-cache-remote-url."`
+	XfnCacheRemoteSigningKeyFile string        `default:""                                   env:"XFN_CACHE_REMOTE_SIGNING_KEY_FILE" group:"Alpha Features:" help:"Path to a key used to sign cached function responses stored in, and verify responses read from, the remote key/value store. Requires --xfn-cache-remote-url."`
+	XfnCacheRemoteMaxEntrySize   string        `default:"16Mi"                               env:"XFN_CACHE_REMOTE_MAX_ENTRY_SIZE"   group:"Alpha Features:" help:"Maximum size of a cached function response read from the remote key/value store, as a Kubernetes quantity (e.g. 16Mi). Larger responses are treated as cache misses. Requires --xfn-cache-remote-url."`
+	PipelineInspectorSocket      string        `default:"/var/run/pipeline-inspector/socket" env:"PIPELINE_INSPECTOR_SOCKET"         group:"Alpha Features:" help:"Unix socket path for pipeline inspector sidecar. Requires --enable-pipeline-inspector."`
+
+	FunctionCircuitBreakerErrorRate    float64       `default:"0.5" group:"Alpha Features:" help:"Fraction of calls to a function that must fail within a window to open its circuit breaker. Requires --enable-function-circuit-breaker."`
+	FunctionCircuitBreakerMinCalls     int           `default:"10"  group:"Alpha Features:" help:"Number of calls to a function within a window before its circuit breaker may open. Requires --enable-function-circuit-breaker."`
+	FunctionCircuitBreakerWindow       time.Duration `default:"1m"  group:"Alpha Features:" help:"How long to count calls to a function before counting again. Requires --enable-function-circuit-breaker."`
+	FunctionCircuitBreakerOpenDuration time.Duration `default:"30s" group:"Alpha Features:" help:"How long a function's circuit breaker stays open before it probes whether the function has recovered. Requires --enable-function-circuit-breaker."`
 
 	EnableDeploymentRuntimeConfigs          bool `default:"true" group:"Beta Features:" help:"Enable support for Deployment Runtime Configs."`
 	EnableUsages                            bool `default:"true" group:"Beta Features:" help:"Enable support for deletion ordering and resource protection with Usages."`
@@ -286,11 +314,25 @@ func (c *startCommand) Run(s *runtime.Scheme, log logging.Logger) error { //noli
 	pfrm := xfn.NewPrometheusMetrics()
 	metrics.Registry.MustRegister(pfrm)
 
+	if c.FunctionMaxAttempts < 0 || c.FunctionMaxAttempts > xfn.MaxCallAttempts {
+		return errors.Errorf("invalid --function-max-attempts: must be between 0 and %d", xfn.MaxCallAttempts)
+	}
+	if c.FunctionMaxInFlight < 0 {
+		return errors.New("invalid --function-max-in-flight: must not be negative")
+	}
+
+	fk := xfn.FairnessKey(c.FunctionQueueFairnessKey)
+	if fk != xfn.FairnessKeyComposition && fk != xfn.FairnessKeyCompositeType {
+		return errors.Errorf("invalid --function-queue-fairness-key %q: must be %s or %s", fk, xfn.FairnessKeyComposition, xfn.FairnessKeyCompositeType)
+	}
+
 	// We want all XR controllers to share the same gRPC clients.
 	pfr := xfn.NewPackagedFunctionRunner(mgr.GetClient(),
 		xfn.WithLogger(log),
 		xfn.WithTLSConfig(clienttls),
 		xfn.WithInterceptorCreators(pfrm),
+		xfn.WithCallPolicy(xfn.CallPolicy{Timeout: c.FunctionTimeout, MaxAttempts: c.FunctionMaxAttempts, MaxInFlight: c.FunctionMaxInFlight}),
+		xfn.WithFairQueue(xfn.NewFairQueue(fk, pfrm)),
 	)
 
 	// Periodically remove clients for Functions that no longer exist.
@@ -298,6 +340,35 @@ func (c *startCommand) Run(s *runtime.Scheme, log logging.Logger) error { //noli
 
 	var runner xfn.FunctionRunner = pfr
 
+	// Send functions that acknowledge observed states only the changes to
+	// the observed state.
+	if c.EnableFunctionObservedDelta {
+		o.Features.Enable(features.EnableAlphaFunctionObservedDelta)
+		log.Info("Alpha feature enabled", "flag", features.EnableAlphaFunctionObservedDelta)
+
+		runner = xfn.NewObservedDeltaFunctionRunner(pfr, xfn.NewRevisionCapabilityChecker(mgr.GetClient()), xfn.WithObservedDeltaLogger(log))
+	}
+
+	// Wrap the gRPC runner with a circuit breaker, so that calls to a
+	// failing function fail fast. Cached responses are still served.
+	if c.EnableFunctionCircuitBreaker {
+		o.Features.Enable(features.EnableAlphaFunctionCircuitBreaker)
+		log.Info("Alpha feature enabled", "flag", features.EnableAlphaFunctionCircuitBreaker)
+
+		fcbm := breaker.NewPrometheusMetrics()
+		metrics.Registry.MustRegister(fcbm)
+
+		runner = breaker.NewRunner(runner,
+			breaker.WithLogger(log),
+			breaker.WithMetrics(fcbm),
+			breaker.WithStateRecorder(breaker.NewRevisionStateRecorder(mgr.GetClient(), breaker.WithElected(mgr.Elected()))),
+			breaker.WithErrorRate(c.FunctionCircuitBreakerErrorRate),
+			breaker.WithMinCalls(c.FunctionCircuitBreakerMinCalls),
+			breaker.WithWindow(c.FunctionCircuitBreakerWindow),
+			breaker.WithOpenDuration(c.FunctionCircuitBreakerOpenDuration),
+		)
+	}
+
 	if c.EnablePipelineInspector {
 		o.Features.Enable(features.EnableAlphaPipelineInspector)
 		log.Info("Alpha feature enabled", "flag", features.EnableAlphaPipelineInspector)
@@ -486,14 +557,50 @@ func (c *startCommand) Run(s *runtime.Scheme, log logging.Logger) error { //noli
 		cfrm := xfncached.NewPrometheusMetrics()
 		metrics.Registry.MustRegister(cfrm)
 
-		cfr := xfncached.NewFileBackedRunner(runner, c.XfnCacheDir,
+		maxSize, err := resource.ParseQuantity(c.XfnCacheMaxSize)
+		if err != nil {
+			return errors.Wrap(err, "cannot parse --xfn-cache-max-size")
+		}
+
+		policy := xfncached.EvictionPolicy(c.XfnCacheEvictionPolicy)
+		if policy != xfncached.EvictLeastRecentlyUsed && policy != xfncached.EvictLeastFrequentlyUsed {
+			return errors.Errorf("invalid --xfn-cache-eviction-policy %q: must be %s or %s", c.XfnCacheEvictionPolicy, xfncached.EvictLeastRecentlyUsed, xfncached.EvictLeastFrequentlyUsed)
+		}
+
+		co := []xfncached.FileBackedRunnerOption{
 			xfncached.WithLogger(log),
 			xfncached.WithMaxTTL(c.XfnCacheMaxTTL),
+			xfncached.WithMaxSize(maxSize.Value()),
+			xfncached.WithMaxEntries(c.XfnCacheMaxEntries),
+			xfncached.WithEvictionPolicy(policy),
+			xfncached.WithMemoryCache(c.XfnCacheMemoryEntries),
 			xfncached.WithMetrics(cfrm),
-		)
+		}
+		if c.XfnCacheRemoteURL != "" {
+			bo, err := c.httpBackendOptions()
+			if err != nil {
+				return errors.Wrap(err, "cannot configure remote function response cache")
+			}
+			co = append(co, xfncached.WithBackend(xfncached.NewHTTPBackend(c.XfnCacheRemoteURL, bo...)))
+		}
 
-		// Periodically delete expired cache entries.
-		go cfr.GarbageCollectFiles(ctx, 1*time.Minute)
+		cfr := xfncached.NewFileBackedRunner(runner, c.XfnCacheDir, co...)
+
+		// Periodically delete expired cache entries, and evict entries
+		// if the cache exceeds its maximum size. Replicas share a remote
+		// cache, so only the leader garbage collects it. Otherwise every
+		// replica would list and read every cached response.
+		if c.XfnCacheRemoteURL != "" {
+			gc := manager.RunnableFunc(func(ctx context.Context) error {
+				cfr.GarbageCollectFiles(ctx, 1*time.Minute)
+				return nil
+			})
+			if err := mgr.Add(gc); err != nil {
+				return errors.Wrap(err, "cannot add function response cache garbage collector")
+			}
+		} else {
+			go cfr.GarbageCollectFiles(ctx, 1*time.Minute)
+		}
 
 		runner = cfr
 	}
@@ -691,3 +798,42 @@ func (c *startCommand) SetupProbes(mgr ctrl.Manager) error {
 
 	return nil
 }
+
+// httpBackendOptions returns options for the remote function response cache.
+func (c *startCommand) httpBackendOptions() ([]xfncached.HTTPBackendOption, error) {
+	maxEntrySize, err := resource.ParseQuantity(c.XfnCacheRemoteMaxEntrySize)
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot parse --xfn-cache-remote-max-entry-size")
+	}
+
+	o := []xfncached.HTTPBackendOption{
+		xfncached.WithTimeout(c.XfnCacheRemoteTimeout),
+		xfncached.WithMaxEntrySize(maxEntrySize.Value()),
+	}
+
+	if c.XfnCacheRemoteCAFile != "" || c.XfnCacheRemoteCertFile != "" || c.XfnCacheRemoteKeyFile != "" {
+		cfg, err := xfncached.LoadTLSConfig(c.XfnCacheRemoteCAFile, c.XfnCacheRemoteCertFile, c.XfnCacheRemoteKeyFile)
+		if err != nil {
+			return nil, errors.Wrap(err, "cannot load TLS config")
+		}
+		o = append(o, xfncached.WithTLSConfig(cfg))
+	}
+
+	if c.XfnCacheRemoteTokenFile != "" {
+		t, err := os.ReadFile(c.XfnCacheRemoteTokenFile)
+		if err != nil {
+			return nil, errors.Wrap(err, "cannot read bearer token")
+		}
+		o = append(o, xfncached.WithBearerToken(strings.TrimSpace(string(t))))
+	}
+
+	if c.XfnCacheRemoteSigningKeyFile != "" {
+		k, err := os.ReadFile(c.XfnCacheRemoteSigningKeyFile)
+		if err != nil {
+			return nil, errors.Wrap(err, "cannot read signing key")
+		}
+		o = append(o, xfncached.WithSigningKey(k))
+	}
+
+	return o, nil
+}
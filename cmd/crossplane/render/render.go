@@ -17,7 +17,8 @@ limitations under the License.
 // Package render implements the 'crossplane internal render' subcommand. It
 // reads a protobuf RenderRequest from stdin, dispatches to the appropriate
 // render implementation based on the oneof variant, and writes a protobuf
-// RenderResponse to stdout.
+// RenderResponse to stdout. Alternatively it serves the RenderService gRPC
+// API, which renders many requests using the same implementations.
 package render
 
 import (
@@ -43,11 +44,15 @@ import (
 // branch on FATAL without parsing stderr. See issue #7446.
 const ExitCodePipelineFatal = 3
 
+const errNoInput = "render request must set exactly one of: composite, operation, cron_operation, watch_operation"
+
 // Command renders a resource using the real reconciler engine backed by a fake
 // in-memory client. It reads a protobuf RenderRequest from stdin and writes a
-// protobuf RenderResponse to stdout.
+// protobuf RenderResponse to stdout, unless it's serving the RenderService.
 type Command struct {
-	Timeout time.Duration `default:"2m" help:"Timeout for the render operation."`
+	Timeout     time.Duration `default:"2m" help:"Timeout for the render operation. When serving, the timeout for each render request."`
+	Serve       string        `help:"Serve the RenderService gRPC API at the supplied address instead of reading a request from stdin. Use unix:///path/to/socket for a Unix socket or host:port for TCP." placeholder:"ADDRESS"`
+	FixtureRoot string        `help:"When serving, the directory under which render requests may record and replay function fixtures. Each request's fixture directory must be relative to it. Requests that use fixtures are rejected if it's unset." placeholder:"PATH" type:"path"`
 
 	// stdin and stdout default to os.Stdin/os.Stdout. They are unexported
 	// so they don't expand the production API surface; in-package tests can
@@ -73,6 +78,10 @@ func (c *Command) AfterApply() error {
 
 // Run executes the render command.
 func (c *Command) Run(log logging.Logger) error {
+	if c.Serve != "" {
+		return c.serve(log)
+	}
+
 	ctx, cancel := context.WithTimeout(context.Background(), c.Timeout)
 	defer cancel()
 
@@ -86,50 +95,11 @@ func (c *Command) Run(log logging.Logger) error {
 		return errors.Wrap(err, "cannot unmarshal render request")
 	}
 
-	rsp := &renderv1alpha1.RenderResponse{Meta: &renderv1alpha1.ResponseMeta{}}
-
-	// renderErr captures the render-side failure if any. We resolve it after
-	// the switch so the success path can still return a marshalled response,
-	// and the pipeline-fatal path can return both the partial response on
-	// stdout AND a typed error with a distinct exit code.
-	var renderErr error
-
-	switch in := req.GetInput().(type) {
-	case *renderv1alpha1.RenderRequest_Composite:
-		out, err := composite.Render(ctx, log, in.Composite)
-		if out != nil {
-			rsp.Output = &renderv1alpha1.RenderResponse_Composite{Composite: out}
-		}
-		if err != nil {
-			renderErr = errors.Wrap(err, "cannot render composite resource")
-		}
-
-	case *renderv1alpha1.RenderRequest_Operation:
-		out, err := operation.Render(ctx, log, in.Operation)
-		if out != nil {
-			rsp.Output = &renderv1alpha1.RenderResponse_Operation{Operation: out}
-		}
-		if err != nil {
-			renderErr = errors.Wrap(err, "cannot render operation")
-		}
-
-	case *renderv1alpha1.RenderRequest_CronOperation:
-		out, err := operation.NewFromCronOperation(in.CronOperation)
-		if err != nil {
-			return errors.Wrap(err, "cannot render cron operation")
-		}
-		rsp.Output = &renderv1alpha1.RenderResponse_CronOperation{CronOperation: out}
-
-	case *renderv1alpha1.RenderRequest_WatchOperation:
-		out, err := operation.NewFromWatchOperation(in.WatchOperation)
-		if err != nil {
-			return errors.Wrap(err, "cannot render watch operation")
-		}
-		rsp.Output = &renderv1alpha1.RenderResponse_WatchOperation{WatchOperation: out}
-
-	default:
-		return errors.New("render request must set exactly one of: composite, operation, cron_operation, watch_operation")
-	}
+	// renderErr captures the render-side failure if any. We resolve it below
+	// so the success path can still return a marshalled response, and the
+	// pipeline-fatal path can return both the partial response on stdout AND
+	// a typed error with a distinct exit code.
+	rsp, renderErr := Render(ctx, log, req)
 
 	// On a pipeline FATAL we surface the partial output to stdout before
 	// propagating the error so callers iterating on requirements can recover
@@ -173,6 +143,48 @@ func (c *Command) Run(log logging.Logger) error {
 	return nil
 }
 
+// Render dispatches a RenderRequest to the appropriate render implementation.
+// It returns a response even when it returns an error. If the render returned
+// partial output, such as on a pipeline FATAL, the response's output is set.
+func Render(ctx context.Context, log logging.Logger, req *renderv1alpha1.RenderRequest) (*renderv1alpha1.RenderResponse, error) {
+	rsp := &renderv1alpha1.RenderResponse{Meta: &renderv1alpha1.ResponseMeta{}}
+
+	switch in := req.GetInput().(type) {
+	case *renderv1alpha1.RenderRequest_Composite:
+		out, err := composite.Render(ctx, log, in.Composite)
+		if out != nil {
+			rsp.Output = &renderv1alpha1.RenderResponse_Composite{Composite: out}
+		}
+		return rsp, errors.Wrap(err, "cannot render composite resource")
+
+	case *renderv1alpha1.RenderRequest_Operation:
+		out, err := operation.Render(ctx, log, in.Operation)
+		if out != nil {
+			rsp.Output = &renderv1alpha1.RenderResponse_Operation{Operation: out}
+		}
+		return rsp, errors.Wrap(err, "cannot render operation")
+
+	case *renderv1alpha1.RenderRequest_CronOperation:
+		out, err := operation.NewFromCronOperation(in.CronOperation)
+		if err != nil {
+			return rsp, errors.Wrap(err, "cannot render cron operation")
+		}
+		rsp.Output = &renderv1alpha1.RenderResponse_CronOperation{CronOperation: out}
+
+	case *renderv1alpha1.RenderRequest_WatchOperation:
+		out, err := operation.NewFromWatchOperation(in.WatchOperation)
+		if err != nil {
+			return rsp, errors.Wrap(err, "cannot render watch operation")
+		}
+		rsp.Output = &renderv1alpha1.RenderResponse_WatchOperation{WatchOperation: out}
+
+	default:
+		return rsp, errors.New(errNoInput)
+	}
+
+	return rsp, nil
+}
+
 // exitCodeError wraps an error to communicate a specific process exit code
 // to Kong via the kong.ExitCoder interface. Unwrap preserves the chain so
 // callers using errors.As/Is still see whatever was wrapped.
@@ -0,0 +1,174 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package render
+
+import (
+	"context"
+	"net"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/logging"
+
+	xcomposite "github.com/crossplane/crossplane/v2/internal/controller/apiextensions/composite"
+	renderv1alpha1 "github.com/crossplane/crossplane/v2/proto/render/v1alpha1"
+)
+
+// A Server serves the RenderService gRPC API. It renders each request using
+// the same code paths as the stdin/stdout command. The gRPC server handles
+// requests concurrently.
+type Server struct {
+	renderv1alpha1.UnimplementedRenderServiceServer
+
+	log         logging.Logger
+	timeout     time.Duration
+	fixtureRoot string
+}
+
+// A ServerOption configures a Server.
+type ServerOption func(s *Server)
+
+// WithFixtureRoot specifies the directory under which render requests may
+// record and replay function fixtures. Each request's fixture directory must
+// be relative to, and within, this directory. Requests that use fixtures are
+// rejected if no fixture root is specified.
+func WithFixtureRoot(dir string) ServerOption {
+	return func(s *Server) {
+		s.fixtureRoot = dir
+	}
+}
+
+// NewServer returns a new RenderService server. Each render request times out
+// after the supplied duration.
+func NewServer(log logging.Logger, timeout time.Duration, o ...ServerOption) *Server {
+	s := &Server{log: log, timeout: timeout}
+	for _, fn := range o {
+		fn(s)
+	}
+	return s
+}
+
+// Render renders a resource. On a pipeline FATAL it returns a
+// FailedPrecondition status with the partial RenderResponse attached as a
+// status detail.
+func (s *Server) Render(ctx context.Context, req *renderv1alpha1.RenderRequest) (*renderv1alpha1.RenderResponse, error) {
+	if req.GetInput() == nil {
+		return nil, status.Error(codes.InvalidArgument, errNoInput)
+	}
+
+	// Anyone who can reach the server can send it a request, so we don't let
+	// requests read or write fixtures outside the fixture root.
+	if err := s.scopeFixtures(req); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	rsp, err := Render(ctx, s.log, req)
+	if err == nil {
+		return rsp, nil
+	}
+
+	var pfe *xcomposite.PipelineFatalError
+	if rsp.GetOutput() == nil || !errors.As(err, &pfe) {
+		return nil, status.Error(codes.Unknown, err.Error())
+	}
+
+	st, derr := status.New(codes.FailedPrecondition, err.Error()).WithDetails(rsp)
+	if derr != nil {
+		return nil, status.Error(codes.Internal, errors.Wrap(derr, "cannot attach partial render response to status").Error())
+	}
+	return nil, st.Err()
+}
+
+// scopeFixtures scopes the supplied request's fixture directory, if any, to
+// the server's fixture root. It returns an error if the request uses fixtures
+// but the server has no fixture root, or if the request's fixture directory
+// isn't within the fixture root.
+func (s *Server) scopeFixtures(req *renderv1alpha1.RenderRequest) error {
+	var fx *renderv1alpha1.FunctionFixtures
+	switch in := req.GetInput().(type) {
+	case *renderv1alpha1.RenderRequest_Composite:
+		fx = in.Composite.GetFixtures()
+	case *renderv1alpha1.RenderRequest_Operation:
+		fx = in.Operation.GetFixtures()
+	}
+
+	if fx.GetMode() == renderv1alpha1.FixtureMode_FIXTURE_MODE_UNSPECIFIED {
+		return nil
+	}
+	if s.fixtureRoot == "" {
+		return errors.New("function fixtures aren't supported unless the server is started with --fixture-root")
+	}
+	if !filepath.IsLocal(fx.GetDirectory()) {
+		return errors.Errorf("fixture directory %q must be a relative path within the server's fixture root", fx.GetDirectory())
+	}
+
+	fx.Directory = filepath.Join(s.fixtureRoot, fx.GetDirectory())
+	return nil
+}
+
+// serve serves the RenderService at the command's address until it receives
+// SIGINT or SIGTERM.
+func (c *Command) serve(log logging.Logger) error {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	lis, err := listen(ctx, c.Serve)
+	if err != nil {
+		return errors.Wrapf(err, "cannot listen at %q", c.Serve)
+	}
+
+	srv := grpc.NewServer()
+	renderv1alpha1.RegisterRenderServiceServer(srv, NewServer(log, c.Timeout, WithFixtureRoot(c.FixtureRoot)))
+
+	go func() {
+		<-ctx.Done()
+		srv.GracefulStop()
+	}()
+
+	log.Info("Serving RenderService", "address", lis.Addr().String())
+	return errors.Wrap(srv.Serve(lis), "cannot serve RenderService")
+}
+
+// listen returns a listener for the supplied address. Addresses prefixed with
+// unix:// are Unix sockets. Anything else is a TCP host:port.
+func listen(ctx context.Context, addr string) (net.Listener, error) {
+	var lc net.ListenConfig
+
+	path, ok := strings.CutPrefix(addr, "unix://")
+	if !ok {
+		return lc.Listen(ctx, "tcp", addr)
+	}
+
+	// Remove any socket left behind by a previous server that didn't shut
+	// down cleanly. Listen fails if the path already exists.
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return nil, errors.Wrap(err, "cannot remove existing socket")
+	}
+	return lc.Listen(ctx, "unix", path)
+}
@@ -0,0 +1,188 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package render
+
+import (
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/logging"
+
+	"github.com/crossplane/crossplane/v2/internal/render/rendertest"
+	fnv1 "github.com/crossplane/crossplane/v2/proto/fn/v1"
+	renderv1alpha1 "github.com/crossplane/crossplane/v2/proto/render/v1alpha1"
+)
+
+func TestServerRender(t *testing.T) {
+	type want struct {
+		code codes.Code
+		// output indicates we expect a RenderResponse with output set,
+		// either returned directly (code OK) or attached to the status.
+		output bool
+	}
+
+	// A composite request that uses function fixtures in the supplied
+	// directory.
+	withFixtures := func(dir string) func(t *testing.T) *renderv1alpha1.RenderRequest {
+		return func(_ *testing.T) *renderv1alpha1.RenderRequest {
+			return &renderv1alpha1.RenderRequest{
+				Input: &renderv1alpha1.RenderRequest_Composite{
+					Composite: &renderv1alpha1.CompositeInput{
+						Fixtures: &renderv1alpha1.FunctionFixtures{
+							Directory: dir,
+							Mode:      renderv1alpha1.FixtureMode_FIXTURE_MODE_RECORD,
+						},
+					},
+				},
+			}
+		}
+	}
+
+	cases := map[string]struct {
+		reason string
+		opts   []ServerOption
+		req    func(t *testing.T) *renderv1alpha1.RenderRequest
+		want   want
+	}{
+		"NoInput": {
+			reason: "A request without an input variant should fail with InvalidArgument.",
+			req: func(t *testing.T) *renderv1alpha1.RenderRequest {
+				t.Helper()
+				return &renderv1alpha1.RenderRequest{}
+			},
+			want: want{code: codes.InvalidArgument},
+		},
+		"FixturesWithoutRoot": {
+			reason: "A request that uses fixtures should fail with InvalidArgument if the server has no fixture root.",
+			req:    withFixtures("fixtures"),
+			want:   want{code: codes.InvalidArgument},
+		},
+		"FixturesAbsolutePath": {
+			reason: "A request whose fixture directory is an absolute path should fail with InvalidArgument.",
+			opts:   []ServerOption{WithFixtureRoot("/fixtures")},
+			req:    withFixtures("/etc"),
+			want:   want{code: codes.InvalidArgument},
+		},
+		"FixturesOutsideRoot": {
+			reason: "A request whose fixture directory escapes the fixture root should fail with InvalidArgument.",
+			opts:   []ServerOption{WithFixtureRoot("/fixtures")},
+			req:    withFixtures("../etc"),
+			want:   want{code: codes.InvalidArgument},
+		},
+		"Success": {
+			reason: "A request that renders successfully should return its output.",
+			req: func(t *testing.T) *renderv1alpha1.RenderRequest {
+				t.Helper()
+				return &renderv1alpha1.RenderRequest{
+					Input: &renderv1alpha1.RenderRequest_CronOperation{
+						CronOperation: &renderv1alpha1.CronOperationInput{
+							CronOperation: mustStruct(t, map[string]any{
+								"apiVersion": "ops.crossplane.io/v1alpha1",
+								"kind":       "CronOperation",
+								"metadata":   map[string]any{"name": "my-cron"},
+								"spec": map[string]any{
+									"schedule": "* * * * *",
+									"operationTemplate": map[string]any{
+										"spec": map[string]any{
+											"pipeline": []any{},
+										},
+									},
+								},
+							}),
+							ScheduledTime: timestamppb.New(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)),
+						},
+					},
+				}
+			},
+			want: want{code: codes.OK, output: true},
+		},
+		"PipelineFatal": {
+			reason: "When a pipeline step returns SEVERITY_FATAL the request should fail with FailedPrecondition, with the partial RenderResponse attached to the status.",
+			req: func(t *testing.T) *renderv1alpha1.RenderRequest {
+				t.Helper()
+				addr := rendertest.StartFunctionServer(t, &rendertest.FatalFunctionServer{
+					RequirementName: "namedClusterRole",
+					Selector: &fnv1.ResourceSelector{
+						ApiVersion: "rbac.authorization.k8s.io/v1",
+						Kind:       "ClusterRole",
+						Match:      &fnv1.ResourceSelector_MatchName{MatchName: "some-cluster-role"},
+					},
+					FatalMessage: "Required extra resource \"namedClusterRole\" not found",
+				})
+				return &renderv1alpha1.RenderRequest{
+					Input: &renderv1alpha1.RenderRequest_Composite{
+						Composite: &renderv1alpha1.CompositeInput{
+							CompositeResource: mustStruct(t, map[string]any{
+								"apiVersion": "example.org/v1alpha1",
+								"kind":       "XExample",
+								"metadata":   map[string]any{"name": "my-example"},
+							}),
+							Composition: mustStruct(t, map[string]any{
+								"metadata": map[string]any{"name": "example-composition"},
+								"spec": map[string]any{
+									"compositeTypeRef": map[string]any{
+										"apiVersion": "example.org/v1alpha1",
+										"kind":       "XExample",
+									},
+									"mode": "Pipeline",
+									"pipeline": []any{
+										map[string]any{
+											"step":        "fetch-extras",
+											"functionRef": map[string]any{"name": "function-extra-resources"},
+										},
+									},
+								},
+							}),
+							Functions: []*renderv1alpha1.FunctionInput{
+								{Name: "function-extra-resources", Address: addr},
+							},
+						},
+					},
+				}
+			},
+			want: want{code: codes.FailedPrecondition, output: true},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			s := NewServer(logging.NewNopLogger(), 30*time.Second, tc.opts...)
+			rsp, err := s.Render(t.Context(), tc.req(t))
+
+			st, _ := status.FromError(err)
+			if st.Code() != tc.want.code {
+				t.Fatalf("\n%s\ns.Render(...): want code %s, got %s: %v", tc.reason, tc.want.code, st.Code(), err)
+			}
+
+			if err != nil {
+				for _, d := range st.Details() {
+					if r, ok := d.(*renderv1alpha1.RenderResponse); ok {
+						rsp = r
+					}
+				}
+			}
+
+			if got := rsp.GetOutput() != nil; got != tc.want.output {
+				t.Errorf("\n%s\ns.Render(...): want output %t, got %t", tc.reason, tc.want.output, got)
+			}
+		})
+	}
+}
@@ -18,12 +18,14 @@ package composite
 
 import (
 	"context"
+	"fmt"
 	"math/rand"
 	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
 	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
@@ -39,6 +41,7 @@ import (
 
 	v1 "github.com/crossplane/crossplane/apis/v2/apiextensions/v1"
 	xpv2 "github.com/crossplane/crossplane/apis/v2/core/v2"
+	"github.com/crossplane/crossplane/v2/internal/xfn/breaker"
 )
 
 // Error strings.
@@ -57,6 +60,23 @@ const (
 	errFetchCompositionRevision        = "cannot fetch composition revision"
 )
 
+const (
+	// The default pause between rollout waves. This matches the default of
+	// the RolloutStrategy API type.
+	defaultRolloutPause = 5 * time.Minute
+
+	// How long to wait before checking whether a rollout that hasn't started,
+	// or is halted, lets a composite resource move to the latest revision.
+	rolloutPendingRetryAfter = 30 * time.Second
+)
+
+// The default rollback window and ready grace period. These match the
+// defaults of the Rollback API type.
+const (
+	defaultRollbackWindow           = 10 * time.Minute
+	defaultRollbackReadyGracePeriod = 5 * time.Minute
+)
+
 // Event reasons.
 const (
 	reasonCompositionSelection    event.Reason = "CompositionSelection"
@@ -143,18 +163,57 @@ func ConnectionSecretFor(o ConnectionSecretOwner, kind schema.GroupVersionKind)
 // are in alpha.
 type APIRevisionFetcher struct {
 	client client.Client
+	now    func() time.Time
 }
 
 // NewAPIRevisionFetcher returns a RevisionFetcher that fetches the
 // Revision referenced by a composite resource.
 func NewAPIRevisionFetcher(c client.Client) *APIRevisionFetcher {
-	return &APIRevisionFetcher{client: c}
+	return &APIRevisionFetcher{client: c, now: time.Now}
 }
 
 // Fetch the appropriate CompositionRevision for the supplied XR. Panics if the
 // composite resource's composition reference is nil, but handles setting the
 // composition revision reference.
+//
+// If the Composition has a rollout strategy Fetch may hold the XR at its
+// current revision instead of moving it to the latest one. It then returns the
+// current revision along with a *RolloutHeldError.
 func (f *APIRevisionFetcher) Fetch(ctx context.Context, cr resource.Composite) (*v1.CompositionRevision, error) {
+	rev, update, err := f.resolve(ctx, cr)
+	if err != nil || !update {
+		return rev, err
+	}
+
+	// Record what we need to roll the XR back if the latest revision asks us
+	// to, and the XR becomes unhealthy after updating to it. We record this
+	// every time the XR switches revisions, so we never roll back to a
+	// revision the XR used before its previous one.
+	if current := cr.GetCompositionRevisionReference(); current != nil {
+		recordRevisionUpdate(cr, current.Name, rev, f.now())
+	}
+
+	cr.SetCompositionRevisionReference(&corev1.LocalObjectReference{Name: rev.GetName()})
+
+	if err := f.client.Update(ctx, cr); err != nil {
+		return nil, errors.Wrap(err, errUpdate)
+	}
+
+	return rev, nil
+}
+
+// Resolve the CompositionRevision Fetch would return for the supplied XR,
+// without updating the XR. Panics if the composite resource's composition
+// reference is nil.
+func (f *APIRevisionFetcher) Resolve(ctx context.Context, cr resource.Composite) (*v1.CompositionRevision, error) {
+	rev, _, err := f.resolve(ctx, cr)
+	return rev, err
+}
+
+// resolve the appropriate CompositionRevision for the supplied XR. It returns
+// true if the XR's composition revision reference should be updated to
+// reference the returned revision.
+func (f *APIRevisionFetcher) resolve(ctx context.Context, cr resource.Composite) (*v1.CompositionRevision, bool, error) {
 	current := cr.GetCompositionRevisionReference()
 	pol := cr.GetCompositionUpdatePolicy()
 
@@ -164,7 +223,7 @@ func (f *APIRevisionFetcher) Fetch(ctx context.Context, cr resource.Composite) (
 		rev := &v1.CompositionRevision{}
 		err := f.client.Get(ctx, types.NamespacedName{Name: current.Name}, rev)
 
-		return rev, errors.Wrap(err, errGetCompositionRevision)
+		return rev, false, errors.Wrap(err, errGetCompositionRevision)
 	}
 
 	// We either haven't yet selected a revision, or our update policy is
@@ -172,28 +231,31 @@ func (f *APIRevisionFetcher) Fetch(ctx context.Context, cr resource.Composite) (
 
 	comp := &v1.Composition{}
 	if err := f.client.Get(ctx, meta.NamespacedNameOf(cr.GetCompositionReference()), comp); err != nil {
-		return nil, errors.Wrap(err, errGetComposition)
+		return nil, false, errors.Wrap(err, errGetComposition)
 	}
 
 	rl, err := f.getCompositionRevisionList(ctx, cr, comp)
 	if err != nil {
-		return nil, errors.Wrap(err, errFetchCompositionRevision)
+		return nil, false, errors.Wrap(err, errFetchCompositionRevision)
 	}
 
 	latest := v1.LatestRevision(comp, rl.Items)
 	if latest == nil {
-		return nil, errors.New(errNoCompatibleCompositionRevision)
+		return nil, false, errors.New(errNoCompatibleCompositionRevision)
 	}
 
-	if current == nil || current.Name != latest.GetName() {
-		cr.SetCompositionRevisionReference(&corev1.LocalObjectReference{Name: latest.GetName()})
-
-		if err := f.client.Update(ctx, cr); err != nil {
-			return nil, errors.Wrap(err, errUpdate)
+	if current != nil && current.Name != latest.GetName() && comp.Spec.Rollout != nil {
+		// We can only hold the XR at its current revision if it still
+		// exists. If it doesn't we move the XR to the latest revision.
+		if rev := revisionNamed(rl.Items, current.Name); rev != nil {
+			if held := rolloutHeld(cr, comp.Spec.Rollout, latest, f.now()); held != nil {
+				held.Current = rev.GetName()
+				return rev, false, held
+			}
 		}
 	}
 
-	return latest, nil
+	return latest, current == nil || current.Name != latest.GetName(), nil
 }
 
 func (f *APIRevisionFetcher) getCompositionRevisionList(ctx context.Context, cr resource.Composite, comp *v1.Composition) (*v1.CompositionRevisionList, error) {
@@ -213,6 +275,199 @@ func (f *APIRevisionFetcher) getCompositionRevisionList(ctx context.Context, cr
 	return rl, nil
 }
 
+// rolloutHeld returns a *RolloutHeldError if the supplied revision's rollout
+// should hold the supplied XR at its current revision, rather than moving it
+// to the latest revision.
+//
+// The Composition controller starts each wave of a rollout, and records the
+// rollout's progress in the latest revision's status. An XR moves once its
+// rollout order is included in the waves that started, unless the rollout is
+// halted.
+func rolloutHeld(cr resource.Composite, ro *v1.RolloutStrategy, latest *v1.CompositionRevision, now time.Time) *RolloutHeldError {
+	st := latest.Status.Rollout
+
+	switch {
+	case st == nil:
+		return &RolloutHeldError{
+			Latest:     latest.GetName(),
+			Reason:     "rollout of the latest revision hasn't started",
+			RetryAfter: rolloutPendingRetryAfter,
+		}
+	case st.Complete:
+		return nil
+	case st.Halted:
+		return &RolloutHeldError{
+			Latest:     latest.GetName(),
+			Reason:     "rollout is halted because too many composite resources using the latest revision aren't ready",
+			RetryAfter: rolloutPendingRetryAfter,
+		}
+	case v1.RolloutOrder(latest, cr.GetUID()) <= st.Through:
+		return nil
+	}
+
+	pause := defaultRolloutPause
+	if ro.PauseSeconds != nil {
+		pause = time.Duration(*ro.PauseSeconds) * time.Second
+	}
+	next := st.WaveStartTime.Add(pause)
+
+	return &RolloutHeldError{
+		Latest:     latest.GetName(),
+		Reason:     fmt.Sprintf("composite resource isn't in rollout wave %d or earlier; the next wave starts at %s at the earliest", st.Wave, next.UTC().Format(time.RFC3339)),
+		RetryAfter: max(next.Sub(now), rolloutPendingRetryAfter),
+	}
+}
+
+// recordRevisionUpdate records when the supplied XR updated from the supplied
+// revision to the supplied latest revision, and whether it was ready under the
+// revision it updated from. It clears what it recorded when the XR previously
+// updated, so a rollback only ever returns the XR to the revision it just
+// updated from.
+func recordRevisionUpdate(cr resource.Composite, from string, latest *v1.CompositionRevision, now time.Time) {
+	meta.RemoveAnnotations(cr, v1.AnnotationKeyCompositionRevisionUpdateTime, v1.AnnotationKeyLastReadyCompositionRevision)
+
+	if rb := latest.Spec.Rollback; rb == nil || rb.Policy != v1.RollbackPolicyAutomatic {
+		return
+	}
+
+	meta.AddAnnotations(cr, map[string]string{v1.AnnotationKeyCompositionRevisionUpdateTime: now.UTC().Format(time.RFC3339)})
+	if resource.IsConditionTrue(cr.GetCondition(xpv2.TypeReady)) {
+		meta.AddAnnotations(cr, map[string]string{v1.AnnotationKeyLastReadyCompositionRevision: from})
+	}
+}
+
+// revisionNamed returns the revision with the supplied name, if any.
+func revisionNamed(revs []v1.CompositionRevision, name string) *v1.CompositionRevision {
+	for i := range revs {
+		if revs[i].GetName() == name {
+			return &revs[i]
+		}
+	}
+	return nil
+}
+
+// An APIRevisionRollbacker rolls a composite resource back to the last
+// CompositionRevision under which it was ready, if the composite resource's
+// current revision asks it to.
+type APIRevisionRollbacker struct {
+	client client.Client
+	now    func() time.Time
+}
+
+// NewAPIRevisionRollbacker returns a RevisionRollbacker that rolls composite
+// resources back by updating them to reference a previous revision.
+func NewAPIRevisionRollbacker(c client.Client) *APIRevisionRollbacker {
+	return &APIRevisionRollbacker{client: c, now: time.Now}
+}
+
+// Rollback the supplied XR to the last CompositionRevision under which it was
+// ready if the supplied (current) revision's rollback policy is Automatic, and
+// the XR fails within the rollback window after it updated to the current
+// revision. See failedAfterUpdate for what counts as failing. Returns the
+// revision the XR was rolled back to, or nil if it wasn't rolled back. The XR
+// is pinned to the revision it's rolled back to by setting its composition
+// update policy to Manual.
+func (r *APIRevisionRollbacker) Rollback(ctx context.Context, cr resource.Composite, rev *v1.CompositionRevision) (*v1.CompositionRevision, error) {
+	rb := rev.Spec.Rollback
+	if rb == nil || rb.Policy != v1.RollbackPolicyAutomatic {
+		return nil, nil
+	}
+
+	// Only XRs that automatically update to new revisions are rolled back.
+	// This includes XRs we already rolled back.
+	if pol := cr.GetCompositionUpdatePolicy(); pol != nil && *pol == xpv2.UpdateManual {
+		return nil, nil
+	}
+
+	a := cr.GetAnnotations()
+	last := a[v1.AnnotationKeyLastReadyCompositionRevision]
+	if last == "" || last == rev.GetName() {
+		return nil, nil
+	}
+
+	// We don't know when the XR updated to this revision, so we can't tell
+	// whether it's within the rollback window.
+	updated, err := time.Parse(time.RFC3339, a[v1.AnnotationKeyCompositionRevisionUpdateTime])
+	if err != nil {
+		return nil, nil //nolint:nilerr // An invalid update time means we don't roll back.
+	}
+
+	window := defaultRollbackWindow
+	if rb.WindowSeconds != nil {
+		window = time.Duration(*rb.WindowSeconds) * time.Second
+	}
+	if r.now().Sub(updated) > window {
+		return nil, nil
+	}
+
+	grace := defaultRollbackReadyGracePeriod
+	if rb.ReadyGracePeriodSeconds != nil {
+		grace = time.Duration(*rb.ReadyGracePeriodSeconds) * time.Second
+	}
+	if !failedAfterUpdate(cr, updated, grace, r.now()) {
+		return nil, nil
+	}
+
+	prev := &v1.CompositionRevision{}
+	if err = r.client.Get(ctx, types.NamespacedName{Name: last}, prev); err != nil {
+		// We can't roll back to a revision that no longer exists.
+		if kerrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, errors.Wrap(err, errGetCompositionRevision)
+	}
+
+	manual := xpv2.UpdateManual
+	cr.SetCompositionUpdatePolicy(&manual)
+	cr.SetCompositionRevisionReference(&corev1.LocalObjectReference{Name: prev.GetName()})
+	if err = r.client.Update(ctx, cr); err != nil {
+		return nil, errors.Wrap(err, errUpdate)
+	}
+
+	return prev, nil
+}
+
+// failedAfterUpdate returns true if the supplied XR failed after it updated
+// to its current revision at the supplied time. An XR fails if it can't be
+// synced, for example because a function returned a fatal result, its
+// requirements didn't stabilize, or a composed resource couldn't be applied.
+// It also fails if it stays unready for longer than the supplied grace period.
+// An XR that isn't ready yet hasn't failed - it's normal for an XR to be
+// unready while new composed resources are created.
+func failedAfterUpdate(cr resource.Composite, updated time.Time, grace time.Duration, now time.Time) bool {
+	if c := cr.GetCondition(xpv2.TypeSynced); c.Status == corev1.ConditionFalse {
+		switch c.Reason {
+		case xpv2.ReasonReconcilePaused:
+			// Someone paused the XR. It hasn't failed.
+		case reasonGarbageCollectionBlocked:
+			// The XR composed its resources, but someone needs to
+			// confirm deleting the ones it no longer wants. Rolling
+			// back would recreate them.
+		case breaker.ReasonCircuitOpen:
+			// A function the XR uses is failing, and we've stopped
+			// calling it for a while. The XR's previous revision
+			// most likely uses the same function. If the new
+			// revision broke the function, the XR failed to sync
+			// before the circuit opened.
+		default:
+			return true
+		}
+	}
+
+	c := cr.GetCondition(xpv2.TypeReady)
+	if c.Status != corev1.ConditionFalse {
+		return false
+	}
+
+	// The XR may have been unready since before it updated.
+	since := updated
+	if c.LastTransitionTime.After(since) {
+		since = c.LastTransitionTime.Time
+	}
+
+	return now.Sub(since) > grace
+}
+
 // NewCompositionSelectorChain returns a new CompositionSelectorChain.
 func NewCompositionSelectorChain(list ...CompositionSelector) *CompositionSelectorChain {
 	return &CompositionSelectorChain{list: list}
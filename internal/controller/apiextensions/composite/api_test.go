@@ -19,18 +19,22 @@ package composite
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
 	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/crossplane/crossplane-runtime/v2/pkg/errors"
 	"github.com/crossplane/crossplane-runtime/v2/pkg/event"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/meta"
 	"github.com/crossplane/crossplane-runtime/v2/pkg/reconciler/managed"
 	"github.com/crossplane/crossplane-runtime/v2/pkg/resource"
 	"github.com/crossplane/crossplane-runtime/v2/pkg/resource/fake"
@@ -39,6 +43,8 @@ import (
 
 	v1 "github.com/crossplane/crossplane/apis/v2/apiextensions/v1"
 	xpv2 "github.com/crossplane/crossplane/apis/v2/core/v2"
+	"github.com/crossplane/crossplane/v2/internal/xfn"
+	"github.com/crossplane/crossplane/v2/internal/xfn/breaker"
 )
 
 func TestPublishConnection(t *testing.T) {
@@ -158,6 +164,7 @@ func TestPublishConnection(t *testing.T) {
 func TestFetchRevision(t *testing.T) {
 	errBoom := errors.New("boom")
 	manual := xpv2.UpdateManual
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
 	uid := types.UID("no-you-id")
 	ctrl := true
 
@@ -191,6 +198,10 @@ func TestFetchRevision(t *testing.T) {
 		Spec: v1.CompositionRevisionSpec{Revision: 2},
 	}
 
+	// The latest revision, with an automatic rollback policy.
+	rev2rb := rev2.DeepCopy()
+	rev2rb.Spec.Rollback = &v1.Rollback{Policy: v1.RollbackPolicyAutomatic}
+
 	// An older revision
 	rev1 := &v1.CompositionRevision{
 		ObjectMeta: metav1.ObjectMeta{
@@ -448,6 +459,119 @@ func TestFetchRevision(t *testing.T) {
 				rev: rev2,
 			},
 		},
+		"OutdatedRevisionSetRecordsRollbackState": {
+			reason: "We should record what we need to roll back when we update to a latest revision with an automatic rollback policy.",
+			client: &test.MockClient{
+				MockGet: test.NewMockGetFn(nil, func(obj client.Object) error {
+					*obj.(*v1.Composition) = *comp
+					return nil
+				}),
+				MockList: test.NewMockListFn(nil, func(obj client.ObjectList) error {
+					*obj.(*v1.CompositionRevisionList) = v1.CompositionRevisionList{
+						Items: []v1.CompositionRevision{*rev2rb, *rev1},
+					}
+					return nil
+				}),
+				MockUpdate: test.NewMockUpdateFn(nil, func(obj client.Object) error {
+					want := &fake.Composite{
+						ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{
+							v1.AnnotationKeyLastReadyCompositionRevision:  rev1.GetName(),
+							v1.AnnotationKeyCompositionRevisionUpdateTime: now.Format(time.RFC3339),
+						}},
+						CompositionReferencer: fake.CompositionReferencer{
+							Ref: &corev1.ObjectReference{Name: comp.GetName()},
+						},
+						CompositionRevisionReferencer: fake.CompositionRevisionReferencer{
+							Ref: &corev1.LocalObjectReference{
+								Name: rev2rb.GetName(),
+							},
+						},
+					}
+					want.SetConditions(xpv2.Available())
+					if diff := cmp.Diff(want, obj); diff != "" {
+						t.Errorf("Apply(): -want, +got: %s", diff)
+					}
+					return nil
+				}),
+			},
+			args: args{
+				cr: func() resource.Composite {
+					cr := &fake.Composite{
+						CompositionReferencer: fake.CompositionReferencer{
+							Ref: &corev1.ObjectReference{Name: comp.GetName()},
+						},
+						CompositionRevisionReferencer: fake.CompositionRevisionReferencer{
+							Ref: &corev1.LocalObjectReference{
+								Name: rev1.GetName(),
+							},
+						},
+					}
+					cr.SetConditions(xpv2.Available())
+					return cr
+				}(),
+			},
+			want: want{
+				rev: rev2rb,
+			},
+		},
+		"OutdatedRevisionSetClearsRollbackState": {
+			reason: "We should clear what we recorded the last time we updated the revision, so an XR that wasn't ready can't roll back two revisions.",
+			client: &test.MockClient{
+				MockGet: test.NewMockGetFn(nil, func(obj client.Object) error {
+					*obj.(*v1.Composition) = *comp
+					return nil
+				}),
+				MockList: test.NewMockListFn(nil, func(obj client.ObjectList) error {
+					*obj.(*v1.CompositionRevisionList) = v1.CompositionRevisionList{
+						Items: []v1.CompositionRevision{*rev2rb, *rev1},
+					}
+					return nil
+				}),
+				MockUpdate: test.NewMockUpdateFn(nil, func(obj client.Object) error {
+					want := &fake.Composite{
+						ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{
+							v1.AnnotationKeyCompositionRevisionUpdateTime: now.Format(time.RFC3339),
+						}},
+						CompositionReferencer: fake.CompositionReferencer{
+							Ref: &corev1.ObjectReference{Name: comp.GetName()},
+						},
+						CompositionRevisionReferencer: fake.CompositionRevisionReferencer{
+							Ref: &corev1.LocalObjectReference{
+								Name: rev2rb.GetName(),
+							},
+						},
+					}
+					want.SetConditions(xpv2.Unavailable())
+					if diff := cmp.Diff(want, obj); diff != "" {
+						t.Errorf("Apply(): -want, +got: %s", diff)
+					}
+					return nil
+				}),
+			},
+			args: args{
+				cr: func() resource.Composite {
+					cr := &fake.Composite{
+						ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{
+							v1.AnnotationKeyLastReadyCompositionRevision:  "cool-composition-older",
+							v1.AnnotationKeyCompositionRevisionUpdateTime: now.Add(-time.Hour).Format(time.RFC3339),
+						}},
+						CompositionReferencer: fake.CompositionReferencer{
+							Ref: &corev1.ObjectReference{Name: comp.GetName()},
+						},
+						CompositionRevisionReferencer: fake.CompositionRevisionReferencer{
+							Ref: &corev1.LocalObjectReference{
+								Name: rev1.GetName(),
+							},
+						},
+					}
+					cr.SetConditions(xpv2.Unavailable())
+					return cr
+				}(),
+			},
+			want: want{
+				rev: rev2rb,
+			},
+		},
 		"SetRevisionError": {
 			reason: "We should return the latest revision and update our reference if none is set.",
 			client: &test.MockClient{
@@ -483,6 +607,7 @@ func TestFetchRevision(t *testing.T) {
 	for name, tc := range cases {
 		t.Run(name, func(t *testing.T) {
 			f := NewAPIRevisionFetcher(tc.client)
+			f.now = func() time.Time { return now }
 
 			got, err := f.Fetch(tc.args.ctx, tc.args.cr)
 			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
@@ -496,6 +621,587 @@ func TestFetchRevision(t *testing.T) {
 	}
 }
 
+func TestResolveRevision(t *testing.T) {
+	errBoom := errors.New("boom")
+	ctrl := true
+
+	comp := &v1.Composition{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "cool-composition",
+			UID:  types.UID("no-you-id"),
+		},
+	}
+
+	// The latest revision.
+	rev2 := &v1.CompositionRevision{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   comp.GetName() + "-dl2nd",
+			Labels: map[string]string{v1.LabelCompositionHash: comp.Hash()},
+			OwnerReferences: []metav1.OwnerReference{{
+				UID:        comp.GetUID(),
+				Controller: &ctrl,
+			}},
+		},
+		Spec: v1.CompositionRevisionSpec{Revision: 2},
+	}
+
+	// An older revision.
+	rev1 := &v1.CompositionRevision{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   comp.GetName() + "-mdk12",
+			Labels: map[string]string{v1.LabelCompositionHash: "I'm different!"},
+			OwnerReferences: []metav1.OwnerReference{{
+				UID:        comp.GetUID(),
+				Controller: &ctrl,
+			}},
+		},
+		Spec: v1.CompositionRevisionSpec{Revision: 1},
+	}
+
+	xr := func() *fake.Composite {
+		return &fake.Composite{
+			CompositionReferencer: fake.CompositionReferencer{
+				Ref: &corev1.ObjectReference{Name: comp.GetName()},
+			},
+			CompositionRevisionReferencer: fake.CompositionRevisionReferencer{
+				Ref: &corev1.LocalObjectReference{Name: rev1.GetName()},
+			},
+		}
+	}
+
+	type want struct {
+		rev *v1.CompositionRevision
+		cr  resource.Composite
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		client client.Client
+		want   want
+	}{
+		"OutdatedRevisionSet": {
+			reason: "We should return the latest revision without updating our reference if an outdated revision is referenced.",
+			client: &test.MockClient{
+				MockGet: test.NewMockGetFn(nil, func(obj client.Object) error {
+					*obj.(*v1.Composition) = *comp
+					return nil
+				}),
+				MockList: test.NewMockListFn(nil, func(obj client.ObjectList) error {
+					*obj.(*v1.CompositionRevisionList) = v1.CompositionRevisionList{Items: []v1.CompositionRevision{*rev2, *rev1}}
+					return nil
+				}),
+				// We'd return an error if we tried to update the XR.
+				MockUpdate: test.NewMockUpdateFn(errBoom),
+			},
+			want: want{
+				rev: rev2,
+				cr:  xr(),
+			},
+		},
+		"GetCompositionError": {
+			reason: "We should wrap and return errors encountered getting the Composition.",
+			client: &test.MockClient{
+				MockGet: test.NewMockGetFn(errBoom),
+			},
+			want: want{
+				cr:  xr(),
+				err: errors.Wrap(errBoom, errGetComposition),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			cr := xr()
+
+			got, err := NewAPIRevisionFetcher(tc.client).Resolve(context.Background(), cr)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("%s\nf.Resolve(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.rev, got); diff != "" {
+				t.Errorf("%s\nf.Resolve(...): -want, +got:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.cr, cr); diff != "" {
+				t.Errorf("%s\nf.Resolve(...): -want composite, +got composite:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestFetchRevisionRollout(t *testing.T) {
+	errBoom := errors.New("boom")
+	ctrl := true
+	started := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	comp := &v1.Composition{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "cool-composition",
+			UID:  types.UID("no-you-id"),
+		},
+		Spec: v1.CompositionSpec{
+			Rollout: &v1.RolloutStrategy{
+				PauseSeconds: ptr.To[int64](60),
+			},
+		},
+	}
+
+	// The latest revision, with the supplied rollout status.
+	rev2 := func(st *v1.RolloutStatus) *v1.CompositionRevision {
+		return &v1.CompositionRevision{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: comp.GetName() + "-dl2nd",
+				Labels: map[string]string{
+					v1.LabelCompositionHash: comp.Hash(),
+				},
+				OwnerReferences: []metav1.OwnerReference{{
+					UID:                comp.GetUID(),
+					Controller:         &ctrl,
+					BlockOwnerDeletion: &ctrl,
+				}},
+			},
+			Spec:   v1.CompositionRevisionSpec{Revision: 2},
+			Status: v1.CompositionRevisionStatus{Rollout: st},
+		}
+	}
+
+	// The revision XRs are moving from.
+	rev1 := &v1.CompositionRevision{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: comp.GetName() + "-mdk12",
+			Labels: map[string]string{
+				v1.LabelCompositionHash: "I'm different!",
+			},
+			OwnerReferences: []metav1.OwnerReference{{
+				UID:                comp.GetUID(),
+				Controller:         &ctrl,
+				BlockOwnerDeletion: &ctrl,
+			}},
+		},
+		Spec: v1.CompositionRevisionSpec{Revision: 1},
+	}
+
+	// An XR that uses the revision it's moving from.
+	cr := func() *fake.Composite {
+		return &fake.Composite{
+			ObjectMeta:            metav1.ObjectMeta{UID: types.UID("cool-xr")},
+			CompositionReferencer: fake.CompositionReferencer{Ref: &corev1.ObjectReference{Name: comp.GetName()}},
+			CompositionRevisionReferencer: fake.CompositionRevisionReferencer{
+				Ref: &corev1.LocalObjectReference{Name: rev1.GetName()},
+			},
+		}
+	}
+
+	// The XR's rollout order.
+	order := v1.RolloutOrder(rev2(nil), cr().GetUID())
+
+	mockClient := func(latest *v1.CompositionRevision, update test.MockUpdateFn) client.Client {
+		return &test.MockClient{
+			MockGet: test.NewMockGetFn(nil, func(obj client.Object) error {
+				*obj.(*v1.Composition) = *comp
+				return nil
+			}),
+			MockList: test.NewMockListFn(nil, func(obj client.ObjectList) error {
+				obj.(*v1.CompositionRevisionList).Items = []v1.CompositionRevision{*latest, *rev1}
+				return nil
+			}),
+			MockUpdate: update,
+		}
+	}
+
+	type args struct {
+		client client.Client
+		now    time.Time
+	}
+
+	type want struct {
+		rev  *v1.CompositionRevision
+		held *RolloutHeldError
+	}
+
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   want
+	}{
+		"NotStarted": {
+			reason: "We should hold an XR at its current revision until the rollout starts.",
+			args: args{
+				// We'd return an error if we tried to update the XR.
+				client: mockClient(rev2(nil), test.NewMockUpdateFn(errBoom)),
+				now:    started,
+			},
+			want: want{
+				rev: rev1,
+				held: &RolloutHeldError{
+					Current:    rev1.GetName(),
+					Latest:     rev2(nil).GetName(),
+					Reason:     "rollout of the latest revision hasn't started",
+					RetryAfter: rolloutPendingRetryAfter,
+				},
+			},
+		},
+		"WaitingForWave": {
+			reason: "We should hold an XR at its current revision until a wave that includes it starts.",
+			args: args{
+				client: mockClient(rev2(&v1.RolloutStatus{Wave: 1, WaveStartTime: metav1.NewTime(started)}), test.NewMockUpdateFn(errBoom)),
+				now:    started.Add(10 * time.Second),
+			},
+			want: want{
+				rev: rev1,
+				held: &RolloutHeldError{
+					Current:    rev1.GetName(),
+					Latest:     rev2(nil).GetName(),
+					Reason:     "composite resource isn't in rollout wave 1 or earlier; the next wave starts at 2026-01-01T00:01:00Z at the earliest",
+					RetryAfter: 50 * time.Second,
+				},
+			},
+		},
+		"WaveStarted": {
+			reason: "We should move an XR to the latest revision once a wave that includes it starts.",
+			args: args{
+				client: mockClient(rev2(&v1.RolloutStatus{Wave: 1, WaveStartTime: metav1.NewTime(started), Through: order}), test.NewMockUpdateFn(nil)),
+				now:    started,
+			},
+			want: want{
+				rev: rev2(&v1.RolloutStatus{Wave: 1, WaveStartTime: metav1.NewTime(started), Through: order}),
+			},
+		},
+		"Halted": {
+			reason: "We should hold an XR at its current revision while the rollout is halted, even if its wave started.",
+			args: args{
+				client: mockClient(rev2(&v1.RolloutStatus{Wave: 1, WaveStartTime: metav1.NewTime(started), Through: order, Halted: true}), test.NewMockUpdateFn(errBoom)),
+				now:    started,
+			},
+			want: want{
+				rev: rev1,
+				held: &RolloutHeldError{
+					Current:    rev1.GetName(),
+					Latest:     rev2(nil).GetName(),
+					Reason:     "rollout is halted because too many composite resources using the latest revision aren't ready",
+					RetryAfter: rolloutPendingRetryAfter,
+				},
+			},
+		},
+		"Complete": {
+			reason: "We should move any XR to the latest revision once the rollout is complete.",
+			args: args{
+				client: mockClient(rev2(&v1.RolloutStatus{Wave: 3, WaveStartTime: metav1.NewTime(started), Complete: true}), test.NewMockUpdateFn(nil)),
+				now:    started,
+			},
+			want: want{
+				rev: rev2(&v1.RolloutStatus{Wave: 3, WaveStartTime: metav1.NewTime(started), Complete: true}),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			f := NewAPIRevisionFetcher(tc.args.client)
+			f.now = func() time.Time { return tc.args.now }
+
+			got, err := f.Fetch(context.Background(), cr())
+
+			var held *RolloutHeldError
+			if errors.As(err, &held) {
+				err = nil
+			}
+			if diff := cmp.Diff(tc.want.held, held); diff != "" {
+				t.Errorf("%s\nf.Fetch(...): -want held, +got held:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(nil, err, test.EquateErrors()); diff != "" {
+				t.Errorf("%s\nf.Fetch(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.rev, got); diff != "" {
+				t.Errorf("%s\nf.Fetch(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestRevisionRollback(t *testing.T) {
+	errBoom := errors.New("boom")
+	manual := xpv2.UpdateManual
+	now := time.Date(2026, 1, 1, 0, 5, 0, 0, time.UTC)
+	updated := now.Add(-5 * time.Minute)
+
+	rev := &v1.CompositionRevision{
+		ObjectMeta: metav1.ObjectMeta{Name: "cool-composition-dl2nd"},
+		Spec: v1.CompositionRevisionSpec{
+			Rollback: &v1.Rollback{
+				Policy:        v1.RollbackPolicyAutomatic,
+				WindowSeconds: ptr.To[int64](600),
+			},
+		},
+	}
+	prev := &v1.CompositionRevision{
+		ObjectMeta: metav1.ObjectMeta{Name: "cool-composition-mdk12"},
+	}
+
+	// A Ready condition that became false at the supplied time.
+	unready := func(since time.Time) xpv2.Condition {
+		c := xpv2.Unavailable()
+		c.LastTransitionTime = metav1.NewTime(since)
+		return c
+	}
+
+	// A Synced condition that failed for the supplied reason.
+	failed := func(reason xpv2.ConditionReason) xpv2.Condition {
+		c := xpv2.ReconcileError(errBoom)
+		c.Reason = reason
+		return c
+	}
+
+	xr := func(fns ...func(cr *fake.Composite)) *fake.Composite {
+		cr := &fake.Composite{
+			ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{
+				v1.AnnotationKeyLastReadyCompositionRevision:  prev.GetName(),
+				v1.AnnotationKeyCompositionRevisionUpdateTime: updated.Format(time.RFC3339),
+			}},
+			CompositionRevisionReferencer: fake.CompositionRevisionReferencer{
+				Ref: &corev1.LocalObjectReference{Name: rev.GetName()},
+			},
+		}
+		cr.SetConditions(xpv2.ReconcileError(errBoom))
+		for _, fn := range fns {
+			fn(cr)
+		}
+		return cr
+	}
+
+	type args struct {
+		cr  resource.Composite
+		rev *v1.CompositionRevision
+	}
+
+	type want struct {
+		rev *v1.CompositionRevision
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		client client.Client
+		args   args
+		want   want
+	}{
+		"NoRollbackPolicy": {
+			reason: "We shouldn't roll back if the revision doesn't have a rollback policy.",
+			client: &test.MockClient{},
+			args: args{
+				cr:  xr(),
+				rev: &v1.CompositionRevision{},
+			},
+			want: want{},
+		},
+		"ManualUpdatePolicy": {
+			reason: "We shouldn't roll back an XR that doesn't automatically update.",
+			client: &test.MockClient{},
+			args: args{
+				cr:  xr(func(cr *fake.Composite) { cr.SetCompositionUpdatePolicy(&manual) }),
+				rev: rev,
+			},
+			want: want{},
+		},
+		"Healthy": {
+			reason: "We shouldn't roll back an XR that's ready and synced.",
+			client: &test.MockClient{},
+			args: args{
+				cr:  xr(func(cr *fake.Composite) { cr.SetConditions(xpv2.Available()) }),
+				rev: rev,
+			},
+			want: want{},
+		},
+		"NotSyncedTransiently": {
+			reason: "We shouldn't roll back an XR that isn't synced because it's paused.",
+			client: &test.MockClient{},
+			args: args{
+				cr:  xr(func(cr *fake.Composite) { cr.SetConditions(xpv2.ReconcilePaused(), xpv2.Available()) }),
+				rev: rev,
+			},
+			want: want{},
+		},
+		"GarbageCollectionBlocked": {
+			reason: "We shouldn't roll back an XR that isn't synced because garbage collection is blocked.",
+			client: &test.MockClient{},
+			args: args{
+				cr:  xr(func(cr *fake.Composite) { cr.SetConditions(failed(reasonGarbageCollectionBlocked), xpv2.Available()) }),
+				rev: rev,
+			},
+			want: want{},
+		},
+		"CircuitOpen": {
+			reason: "We shouldn't roll back an XR that isn't synced because a function's circuit is open.",
+			client: &test.MockClient{},
+			args: args{
+				cr:  xr(func(cr *fake.Composite) { cr.SetConditions(failed(breaker.ReasonCircuitOpen), xpv2.Available()) }),
+				rev: rev,
+			},
+			want: want{},
+		},
+		"RequirementsUnstable": {
+			reason: "We should roll back an XR that isn't synced because its function requirements didn't stabilize.",
+			client: &test.MockClient{
+				MockGet: test.NewMockGetFn(nil, func(obj client.Object) error {
+					*obj.(*v1.CompositionRevision) = *prev
+					return nil
+				}),
+				MockUpdate: test.NewMockUpdateFn(nil),
+			},
+			args: args{
+				cr:  xr(func(cr *fake.Composite) { cr.SetConditions(failed(xfn.ReasonRequirementsUnstable), xpv2.Available()) }),
+				rev: rev,
+			},
+			want: want{
+				rev: prev,
+			},
+		},
+		"NotReadyYet": {
+			reason: "We shouldn't roll back an XR that has been unready for less than the ready grace period.",
+			client: &test.MockClient{},
+			args: args{
+				cr:  xr(func(cr *fake.Composite) { cr.SetConditions(xpv2.ReconcileSuccess(), unready(now.Add(-time.Minute))) }),
+				rev: rev,
+			},
+			want: want{},
+		},
+		"UnreadyBeforeUpdate": {
+			reason: "We should measure the ready grace period from when the XR updated if it was unready before it updated.",
+			client: &test.MockClient{},
+			args: args{
+				cr: xr(func(cr *fake.Composite) {
+					cr.SetConditions(xpv2.ReconcileSuccess(), unready(now.Add(-time.Hour)))
+					meta.AddAnnotations(cr, map[string]string{v1.AnnotationKeyCompositionRevisionUpdateTime: now.Add(-time.Minute).Format(time.RFC3339)})
+				}),
+				rev: rev,
+			},
+			want: want{},
+		},
+		"UnreadyPastGracePeriod": {
+			reason: "We should roll back an XR that has been unready for longer than the ready grace period.",
+			client: &test.MockClient{
+				MockGet: test.NewMockGetFn(nil, func(obj client.Object) error {
+					*obj.(*v1.CompositionRevision) = *prev
+					return nil
+				}),
+				MockUpdate: test.NewMockUpdateFn(nil),
+			},
+			args: args{
+				cr: xr(func(cr *fake.Composite) { cr.SetConditions(xpv2.ReconcileSuccess(), unready(updated)) }),
+				rev: func() *v1.CompositionRevision {
+					rev := rev.DeepCopy()
+					rev.Spec.Rollback.ReadyGracePeriodSeconds = ptr.To[int64](60)
+					return rev
+				}(),
+			},
+			want: want{
+				rev: prev,
+			},
+		},
+		"NeverReady": {
+			reason: "We shouldn't roll back an XR that wasn't ready under a previous revision.",
+			client: &test.MockClient{},
+			args: args{
+				cr: xr(func(cr *fake.Composite) {
+					cr.SetAnnotations(map[string]string{v1.AnnotationKeyCompositionRevisionUpdateTime: updated.Format(time.RFC3339)})
+				}),
+				rev: rev,
+			},
+			want: want{},
+		},
+		"OutsideWindow": {
+			reason: "We shouldn't roll back an XR that updated to the current revision before the rollback window.",
+			client: &test.MockClient{},
+			args: args{
+				cr: xr(func(cr *fake.Composite) {
+					meta.AddAnnotations(cr, map[string]string{v1.AnnotationKeyCompositionRevisionUpdateTime: now.Add(-time.Hour).Format(time.RFC3339)})
+				}),
+				rev: rev,
+			},
+			want: want{},
+		},
+		"PreviousRevisionNotFound": {
+			reason: "We shouldn't roll back to a revision that no longer exists.",
+			client: &test.MockClient{
+				MockGet: test.NewMockGetFn(kerrors.NewNotFound(schema.GroupResource{}, prev.GetName())),
+			},
+			args: args{
+				cr:  xr(),
+				rev: rev,
+			},
+			want: want{},
+		},
+		"GetPreviousRevisionError": {
+			reason: "We should wrap and return errors encountered getting the previous revision.",
+			client: &test.MockClient{
+				MockGet: test.NewMockGetFn(errBoom),
+			},
+			args: args{
+				cr:  xr(),
+				rev: rev,
+			},
+			want: want{
+				err: errors.Wrap(errBoom, errGetCompositionRevision),
+			},
+		},
+		"UpdateError": {
+			reason: "We should wrap and return errors encountered updating the XR.",
+			client: &test.MockClient{
+				MockGet:    test.NewMockGetFn(nil),
+				MockUpdate: test.NewMockUpdateFn(errBoom),
+			},
+			args: args{
+				cr:  xr(),
+				rev: rev,
+			},
+			want: want{
+				err: errors.Wrap(errBoom, errUpdate),
+			},
+		},
+		"RolledBack": {
+			reason: "We should pin an XR that failed to sync within the rollback window to the last revision under which it was ready.",
+			client: &test.MockClient{
+				MockGet: test.NewMockGetFn(nil, func(obj client.Object) error {
+					*obj.(*v1.CompositionRevision) = *prev
+					return nil
+				}),
+				MockUpdate: test.NewMockUpdateFn(nil, func(obj client.Object) error {
+					want := xr(func(cr *fake.Composite) {
+						cr.SetCompositionUpdatePolicy(&manual)
+						cr.SetCompositionRevisionReference(&corev1.LocalObjectReference{Name: prev.GetName()})
+					})
+					if diff := cmp.Diff(want, obj); diff != "" {
+						t.Errorf("Update(): -want, +got: %s", diff)
+					}
+					return nil
+				}),
+			},
+			args: args{
+				cr:  xr(),
+				rev: rev,
+			},
+			want: want{
+				rev: prev,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			r := NewAPIRevisionRollbacker(tc.client)
+			r.now = func() time.Time { return now }
+
+			got, err := r.Rollback(context.Background(), tc.args.cr, tc.args.rev)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("%s\nr.Rollback(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.rev, got); diff != "" {
+				t.Errorf("%s\nr.Rollback(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
 func TestConfigure(t *testing.T) {
 	errBoom := errors.New("boom")
 
@@ -20,15 +20,21 @@ import (
 	"crypto/sha256"
 	"encoding/hex"
 	"fmt"
+	"maps"
+	"slices"
 	"sort"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
 	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/structpb"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	kerrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kunstructured "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -75,6 +81,10 @@ const (
 	errFmtControllerMismatch          = "refusing to delete composed resource %q that is controlled by %s %q"
 	errFmtCleanupLabelsCD             = "cannot cleanup composed resource labels of resource %q (a %s named %s)"
 	errFmtDeleteCD                    = "cannot delete composed resource %q (a %s named %s)"
+	errFmtOrphanCD                    = "cannot orphan composed resource %q (a %s named %s)"
+	errFmtGCPolicy                    = "cannot determine garbage collection policy of composed resource %q"
+	errFmtDryRunApplyCD               = "cannot dry-run apply composed resource %q"
+	errFmtInvalidGCPolicy             = "invalid %s annotation %q: must be Delete or Orphan"
 	errFmtUnmarshalDesiredCD          = "cannot unmarshal desired composed resource %q from RunFunctionResponse"
 	errFmtRenderMetadata              = "cannot render metadata for composed resource %q"
 	errFmtGenerateName                = "cannot generate a name for composed resource %q"
@@ -184,20 +194,20 @@ func (fn ComposedResourceObserverFn) ObserveComposedResources(ctx context.Contex
 	return fn(ctx, xr)
 }
 
-// A ComposedResourceGarbageCollector deletes observed composed resources that
-// are no longer desired.
+// A ComposedResourceGarbageCollector deletes or orphans observed composed
+// resources that are no longer desired, according to the supplied policy.
 type ComposedResourceGarbageCollector interface {
-	GarbageCollectComposedResources(ctx context.Context, owner metav1.Object, observed, desired ComposedResourceStates) error
+	GarbageCollectComposedResources(ctx context.Context, owner metav1.Object, observed, desired ComposedResourceStates, p v1.GarbageCollectionPolicy) error
 }
 
-// A ComposedResourceGarbageCollectorFn deletes observed composed resources that
-// are no longer desired.
-type ComposedResourceGarbageCollectorFn func(ctx context.Context, owner metav1.Object, observed, desired ComposedResourceStates) error
+// A ComposedResourceGarbageCollectorFn deletes or orphans observed composed
+// resources that are no longer desired, according to the supplied policy.
+type ComposedResourceGarbageCollectorFn func(ctx context.Context, owner metav1.Object, observed, desired ComposedResourceStates, p v1.GarbageCollectionPolicy) error
 
-// GarbageCollectComposedResources deletes observed composed resources that are
-// no longer desired.
-func (fn ComposedResourceGarbageCollectorFn) GarbageCollectComposedResources(ctx context.Context, owner metav1.Object, observed, desired ComposedResourceStates) error {
-	return fn(ctx, owner, observed, desired)
+// GarbageCollectComposedResources deletes or orphans observed composed
+// resources that are no longer desired, according to the supplied policy.
+func (fn ComposedResourceGarbageCollectorFn) GarbageCollectComposedResources(ctx context.Context, owner metav1.Object, observed, desired ComposedResourceStates, p v1.GarbageCollectionPolicy) error {
+	return fn(ctx, owner, observed, desired, p)
 }
 
 // A ManagedFieldsUpgrader upgrades an objects managed fields from client-side
@@ -453,6 +463,12 @@ func (c *FunctionComposer) Compose(ctx context.Context, xr *composite.Unstructur
 		// Add step metadata to context for use by downstream components like InspectedRunner.
 		stepCtx := step.ContextWithStepMetaForCompositions(ctx, traceID, fn.Step, int32(stepIndex), compositionName)
 
+		// Limit how many times the function may be called to satisfy its
+		// requirements, if the step configures a limit.
+		if fn.Requirements != nil && fn.Requirements.MaxIterations != nil {
+			stepCtx = xfn.ContextWithMaxRequirementsIterations(stepCtx, *fn.Requirements.MaxIterations)
+		}
+
 		rsp, err := c.pipeline.RunFunction(stepCtx, fn.FunctionRef.Name, fnreq)
 		if err != nil {
 			return CompositionResult{}, errors.Wrapf(err, errFmtRunPipelineStep, fn.Step)
@@ -624,12 +640,44 @@ func (c *FunctionComposer) Compose(ctx context.Context, xr *composite.Unstructur
 		}
 	}
 
+	gc := req.Revision.Spec.GarbageCollection
+	gcp := v1.GarbageCollectionPolicyDelete
+	if gc != nil && gc.Policy != "" {
+		gcp = gc.Policy
+	}
+
+	// When planning we report the changes we'd make to our composed resources
+	// instead of making them.
+	if req.Plan {
+		plan, err := c.Plan(ctx, xr, observed, desired, gc, gcp)
+		if err != nil {
+			return CompositionResult{}, err
+		}
+		return CompositionResult{Plan: plan, TTL: ttl}, nil
+	}
+
+	// Refuse to garbage collect more composed resources than the Composition
+	// allows, unless someone confirmed the deletion. This protects against a
+	// pipeline that suddenly returns far fewer composed resources - e.g.
+	// because a function couldn't parse its input. We still apply our desired
+	// state, but keep referencing the composed resources we didn't delete so
+	// we don't leak them.
+	referenced := desired
+	gcBlocked := CheckGarbageCollectionLimits(xr, observed, desired, gc, gcp)
+	if gcBlocked != nil {
+		referenced = make(ComposedResourceStates, len(observed)+len(desired))
+		maps.Copy(referenced, observed)
+		maps.Copy(referenced, desired)
+	}
+
 	// Garbage collect any observed resources that aren't part of our final
 	// desired state. We must do this before we update the XR's resource
 	// references to ensure that we don't forget and leak them if a delete
 	// fails.
-	if err := c.composite.GarbageCollectComposedResources(ctx, xr, observed, desired); err != nil {
-		return CompositionResult{}, errors.Wrap(err, errGarbageCollectCDs)
+	if gcBlocked == nil {
+		if err := c.composite.GarbageCollectComposedResources(ctx, xr, observed, desired, gcp); err != nil {
+			return CompositionResult{}, errors.Wrap(err, errGarbageCollectCDs)
+		}
 	}
 
 	// Record references to all desired composed resources. We need to do this
@@ -641,7 +689,7 @@ func (c *FunctionComposer) Compose(ctx context.Context, xr *composite.Unstructur
 	refs := composite.New(composite.WithSchema(xr.Schema), composite.WithGroupVersionKind(xr.GroupVersionKind()))
 	refs.SetNamespace(xr.GetNamespace())
 	refs.SetName(xr.GetName())
-	UpdateResourceRefs(refs, desired)
+	UpdateResourceRefs(refs, referenced)
 
 	// Persist our updated composed resource references. We want this to be an
 	// atomic replace of the entire array. Note that we're relying on the status
@@ -784,15 +832,123 @@ func (c *FunctionComposer) Compose(ctx context.Context, xr *composite.Unstructur
 	c.tracker.Track(xrKey, composed, required)
 
 	return CompositionResult{
-		Composed:          resources,
-		ConnectionDetails: d.GetComposite().GetConnectionDetails(),
-		Ready:             ready,
-		Events:            events,
-		Conditions:        conditions,
-		TTL:               ttl,
+		Composed:                 resources,
+		ConnectionDetails:        d.GetComposite().GetConnectionDetails(),
+		Ready:                    ready,
+		Events:                   events,
+		Conditions:               conditions,
+		TTL:                      ttl,
+		GarbageCollectionBlocked: gcBlocked,
 	}, nil
 }
 
+// Plan returns the changes composing the supplied desired composed resources
+// would make. It determines whether an observed composed resource would be
+// updated by applying it with server-side dry-run. It reports whether the
+// supplied garbage collection limits would block deleting composed resources.
+func (c *FunctionComposer) Plan(ctx context.Context, xr *composite.Unstructured, observed, desired ComposedResourceStates, gc *v1.GarbageCollection, p v1.GarbageCollectionPolicy) (*CompositionPlan, error) {
+	plan := &CompositionPlan{Changes: make([]PlannedChange, 0)}
+
+	var blocked GarbageCollectionBlockedError
+	if errors.As(CheckGarbageCollectionLimits(xr, observed, desired, gc, p), &blocked) {
+		plan.GarbageCollectionBlocked = &blocked
+	}
+
+	for name, cd := range desired {
+		ref := referenceTo(cd.Resource)
+
+		o, ok := observed[name]
+		if !ok {
+			plan.Changes = append(plan.Changes, PlannedChange{Action: PlannedActionCreate, ResourceName: name, Resource: ref})
+			continue
+		}
+
+		// Compose upgrades the managed fields of a composed resource it
+		// previously applied using client-side apply before it applies it
+		// again. The upgrade changes what applying it does, and a dry-run
+		// upgrade isn't visible to a dry-run apply, so we can't tell what
+		// would change beyond the upgrade itself.
+		if c.composite.NeedsUpgrade(o.Resource) {
+			plan.Changes = append(plan.Changes, PlannedChange{Action: PlannedActionUpdate, ResourceName: name, Resource: ref, Note: "managed fields would be upgraded from client-side to server-side apply; other changes can't be planned until they are"})
+			continue
+		}
+
+		//nolint:staticcheck // TODO(adamwg) Stop using client.Apply after the v2.2 release.
+		if err := c.client.Patch(ctx, cd.Resource, client.Apply, client.ForceOwnership, client.FieldOwner(ComposedFieldOwnerName(xr)), client.DryRunAll); err != nil {
+			return nil, errors.Wrapf(err, errFmtDryRunApplyCD, name)
+		}
+
+		changed, err := composedResourceChanged(o.Resource, cd.Resource)
+		if err != nil {
+			return nil, errors.Wrapf(err, errFmtDryRunApplyCD, name)
+		}
+		if changed {
+			plan.Changes = append(plan.Changes, PlannedChange{Action: PlannedActionUpdate, ResourceName: name, Resource: ref})
+		}
+	}
+
+	for name, cd := range observed {
+		if _, ok := desired[name]; ok {
+			continue
+		}
+		// We only garbage collect composed resources we control.
+		if ctrl := metav1.GetControllerOf(cd.Resource); ctrl == nil || ctrl.UID != xr.GetUID() {
+			continue
+		}
+		policy, err := GarbageCollectionPolicyOf(xr, cd.Resource, p)
+		if err != nil {
+			return nil, errors.Wrapf(err, errFmtGCPolicy, name)
+		}
+		change := PlannedChange{Action: PlannedActionDelete, ResourceName: name, Resource: referenceTo(cd.Resource)}
+		switch {
+		case policy == v1.GarbageCollectionPolicyOrphan:
+			change.Action = PlannedActionOrphan
+		case plan.GarbageCollectionBlocked != nil:
+			change.Note = "blocked by the Composition's garbage collection limits"
+		}
+		plan.Changes = append(plan.Changes, change)
+	}
+
+	sort.Slice(plan.Changes, func(i, j int) bool {
+		return plan.Changes[i].ResourceName < plan.Changes[j].ResourceName
+	})
+
+	return plan, nil
+}
+
+func referenceTo(cd resource.Composed) corev1.ObjectReference {
+	gvk := cd.GetObjectKind().GroupVersionKind()
+	return corev1.ObjectReference{
+		APIVersion: gvk.GroupVersion().String(),
+		Kind:       gvk.Kind,
+		Namespace:  cd.GetNamespace(),
+		Name:       cd.GetName(),
+	}
+}
+
+// composedResourceChanged returns true if the supplied applied composed
+// resource differs from the supplied observed composed resource, ignoring
+// metadata that changes on every write and status.
+func composedResourceChanged(observed, applied runtime.Object) (bool, error) {
+	o, err := runtime.DefaultUnstructuredConverter.ToUnstructured(observed)
+	if err != nil {
+		return false, err
+	}
+	a, err := runtime.DefaultUnstructuredConverter.ToUnstructured(applied)
+	if err != nil {
+		return false, err
+	}
+	// ToUnstructured doesn't copy the content of unstructured objects.
+	o, a = runtime.DeepCopyJSON(o), runtime.DeepCopyJSON(a)
+	for _, u := range []map[string]any{o, a} {
+		kunstructured.RemoveNestedField(u, "metadata", "managedFields")
+		kunstructured.RemoveNestedField(u, "metadata", "resourceVersion")
+		kunstructured.RemoveNestedField(u, "metadata", "generation")
+		kunstructured.RemoveNestedField(u, "status")
+	}
+	return !equality.Semantic.DeepEqual(o, a), nil
+}
+
 // Tag uniquely identifies a request. Two identical requests created by the
 // same Crossplane binary will produce identical tags. Different builds of
 // Crossplane may produce different tags for the same inputs. See the docs for
@@ -942,7 +1098,7 @@ func AsState(xr resource.Composite, xc managed.ConnectionDetails, rs ComposedRes
 }
 
 // An DeletingComposedResourceGarbageCollector deletes undesired composed resources from
-// the API server.
+// the API server, or orphans them if their garbage collection policy is Orphan.
 type DeletingComposedResourceGarbageCollector struct {
 	client client.Writer
 }
@@ -956,7 +1112,12 @@ func NewDeletingComposedResourceGarbageCollector(c client.Writer) *DeletingCompo
 // GarbageCollectComposedResources deletes any composed resource that didn't
 // come out the other end of the Composition Function pipeline (i.e. that wasn't
 // in the final desired state after running the pipeline) from the API server.
-func (d *DeletingComposedResourceGarbageCollector) GarbageCollectComposedResources(ctx context.Context, owner metav1.Object, observed, desired ComposedResourceStates) error {
+//
+// The supplied policy may be overridden by the garbage collection policy
+// annotation of the owner, or of the composed resource. Composed resources
+// whose policy is Orphan aren't deleted. Instead we remove the owner's
+// reference to them, so they're no longer composed.
+func (d *DeletingComposedResourceGarbageCollector) GarbageCollectComposedResources(ctx context.Context, owner metav1.Object, observed, desired ComposedResourceStates, p v1.GarbageCollectionPolicy) error {
 	del := ComposedResourceStates{}
 
 	for name, cd := range observed {
@@ -991,11 +1152,29 @@ func (d *DeletingComposedResourceGarbageCollector) GarbageCollectComposedResourc
 			return errors.Errorf(errFmtControllerMismatch, name, c.Kind, c.Name)
 		}
 
+		policy, err := GarbageCollectionPolicyOf(owner, cd.Resource, p)
+		if err != nil {
+			return errors.Wrapf(err, errFmtGCPolicy, name)
+		}
+
 		// Remove the labels that indicate this resource was owned by a
 		// Composition. This helps differentiate whether a resource was deleted
 		// due to garbage collection or because its owning composite was deleted.
 		meta.RemoveLabels(cd.Resource, xcrd.LabelKeyNamePrefixForComposed, xcrd.LabelKeyClaimName, xcrd.LabelKeyClaimNamespace)
 
+		// Orphan the composed resource by removing our owner reference. We
+		// won't reference it once we update the XR's resource references, so
+		// it won't be composed anymore.
+		if policy == v1.GarbageCollectionPolicyOrphan {
+			cd.Resource.SetOwnerReferences(slices.DeleteFunc(cd.Resource.GetOwnerReferences(), func(r metav1.OwnerReference) bool {
+				return r.UID == owner.GetUID()
+			}))
+			if err := d.client.Update(ctx, cd.Resource); resource.IgnoreNotFound(err) != nil {
+				return errors.Wrapf(err, errFmtOrphanCD, name, cd.Resource.GetObjectKind().GroupVersionKind().Kind, cd.Resource.GetName())
+			}
+			continue
+		}
+
 		if err := d.client.Update(ctx, cd.Resource); resource.IgnoreNotFound(err) != nil {
 			return errors.Wrapf(err, errFmtCleanupLabelsCD, name, cd.Resource.GetObjectKind().GroupVersionKind().Kind, cd.Resource.GetName())
 		}
@@ -1011,6 +1190,76 @@ func (d *DeletingComposedResourceGarbageCollector) GarbageCollectComposedResourc
 	return nil
 }
 
+// CheckGarbageCollectionLimits returns a GarbageCollectionBlockedError if
+// garbage collecting the observed composed resources that aren't desired would
+// delete more of them than the supplied GarbageCollection allows, unless the
+// owner's garbage collection confirmation annotation confirms the deletion.
+// Composed resources that would be orphaned rather than deleted don't count
+// toward the limits.
+func CheckGarbageCollectionLimits(owner metav1.Object, observed, desired ComposedResourceStates, gc *v1.GarbageCollection, p v1.GarbageCollectionPolicy) error {
+	if gc == nil || (gc.MaxDeletions == nil && gc.MaxDeletionPercent == nil) {
+		return nil
+	}
+
+	del := make([]ResourceName, 0)
+	uids := make([]string, 0)
+	for name, cd := range observed {
+		if _, ok := desired[name]; ok {
+			continue
+		}
+		// We only garbage collect composed resources we control.
+		if c := metav1.GetControllerOf(cd.Resource); c == nil || c.UID != owner.GetUID() {
+			continue
+		}
+		if policy, err := GarbageCollectionPolicyOf(owner, cd.Resource, p); err != nil || policy == v1.GarbageCollectionPolicyOrphan {
+			continue
+		}
+		del = append(del, name)
+		uids = append(uids, string(cd.Resource.GetUID()))
+	}
+
+	exceeded := gc.MaxDeletions != nil && len(del) > int(*gc.MaxDeletions)
+	if gc.MaxDeletionPercent != nil && len(del)*100 > int(*gc.MaxDeletionPercent)*len(observed) {
+		exceeded = true
+	}
+	if !exceeded {
+		return nil
+	}
+
+	// The confirmation token identifies the composed resources we'd delete,
+	// so confirming one deletion doesn't confirm future deletions.
+	slices.Sort(uids)
+	h := sha256.Sum256([]byte(strings.Join(uids, ",")))
+	token := hex.EncodeToString(h[:])[:16]
+
+	if owner.GetAnnotations()[v1.AnnotationKeyGarbageCollectionConfirmation] == token {
+		return nil
+	}
+
+	slices.Sort(del)
+	return GarbageCollectionBlockedError{Resources: del, Composed: len(observed), Token: token}
+}
+
+// GarbageCollectionPolicyOf returns the garbage collection policy of the
+// supplied composed resource. The composed resource's garbage collection policy
+// annotation takes precedence over its owner's, which takes precedence over the
+// supplied policy.
+func GarbageCollectionPolicyOf(owner, cd metav1.Object, p v1.GarbageCollectionPolicy) (v1.GarbageCollectionPolicy, error) {
+	for _, o := range []metav1.Object{cd, owner} {
+		v, ok := o.GetAnnotations()[v1.AnnotationKeyGarbageCollectionPolicy]
+		if !ok {
+			continue
+		}
+		switch gcp := v1.GarbageCollectionPolicy(v); gcp {
+		case v1.GarbageCollectionPolicyDelete, v1.GarbageCollectionPolicyOrphan:
+			return gcp, nil
+		default:
+			return "", errors.Errorf(errFmtInvalidGCPolicy, v1.AnnotationKeyGarbageCollectionPolicy, v)
+		}
+	}
+	return p, nil
+}
+
 // UpdateResourceRefs updates the supplied state to ensure the XR references all
 // composed resources that exist or are pending creation.
 func UpdateResourceRefs(xr resource.Composite, desired ComposedResourceStates) {
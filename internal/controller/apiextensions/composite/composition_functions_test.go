@@ -18,6 +18,8 @@ package composite
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 	"testing"
 	"time"
@@ -552,7 +554,7 @@ func TestFunctionCompose(t *testing.T) {
 					WithComposedResourceObserver(ComposedResourceObserverFn(func(_ context.Context, _ resource.Composite) (ComposedResourceStates, error) {
 						return nil, nil
 					})),
-					WithComposedResourceGarbageCollector(ComposedResourceGarbageCollectorFn(func(_ context.Context, _ metav1.Object, _, _ ComposedResourceStates) error {
+					WithComposedResourceGarbageCollector(ComposedResourceGarbageCollectorFn(func(_ context.Context, _ metav1.Object, _, _ ComposedResourceStates, _ v1.GarbageCollectionPolicy) error {
 						return errBoom
 					})),
 				},
@@ -610,7 +612,7 @@ func TestFunctionCompose(t *testing.T) {
 					WithComposedResourceObserver(ComposedResourceObserverFn(func(_ context.Context, _ resource.Composite) (ComposedResourceStates, error) {
 						return nil, nil
 					})),
-					WithComposedResourceGarbageCollector(ComposedResourceGarbageCollectorFn(func(_ context.Context, _ metav1.Object, _, _ ComposedResourceStates) error {
+					WithComposedResourceGarbageCollector(ComposedResourceGarbageCollectorFn(func(_ context.Context, _ metav1.Object, _, _ ComposedResourceStates, _ v1.GarbageCollectionPolicy) error {
 						return nil
 					})),
 				},
@@ -672,7 +674,7 @@ func TestFunctionCompose(t *testing.T) {
 					WithComposedResourceObserver(ComposedResourceObserverFn(func(_ context.Context, _ resource.Composite) (ComposedResourceStates, error) {
 						return nil, nil
 					})),
-					WithComposedResourceGarbageCollector(ComposedResourceGarbageCollectorFn(func(_ context.Context, _ metav1.Object, _, _ ComposedResourceStates) error {
+					WithComposedResourceGarbageCollector(ComposedResourceGarbageCollectorFn(func(_ context.Context, _ metav1.Object, _, _ ComposedResourceStates, _ v1.GarbageCollectionPolicy) error {
 						return nil
 					})),
 				},
@@ -736,7 +738,7 @@ func TestFunctionCompose(t *testing.T) {
 					WithComposedResourceObserver(ComposedResourceObserverFn(func(_ context.Context, _ resource.Composite) (ComposedResourceStates, error) {
 						return nil, nil
 					})),
-					WithComposedResourceGarbageCollector(ComposedResourceGarbageCollectorFn(func(_ context.Context, _ metav1.Object, _, _ ComposedResourceStates) error {
+					WithComposedResourceGarbageCollector(ComposedResourceGarbageCollectorFn(func(_ context.Context, _ metav1.Object, _, _ ComposedResourceStates, _ v1.GarbageCollectionPolicy) error {
 						return nil
 					})),
 				},
@@ -810,7 +812,7 @@ func TestFunctionCompose(t *testing.T) {
 					WithComposedResourceObserver(ComposedResourceObserverFn(func(_ context.Context, _ resource.Composite) (ComposedResourceStates, error) {
 						return nil, nil
 					})),
-					WithComposedResourceGarbageCollector(ComposedResourceGarbageCollectorFn(func(_ context.Context, _ metav1.Object, _, _ ComposedResourceStates) error {
+					WithComposedResourceGarbageCollector(ComposedResourceGarbageCollectorFn(func(_ context.Context, _ metav1.Object, _, _ ComposedResourceStates, _ v1.GarbageCollectionPolicy) error {
 						return nil
 					})),
 				},
@@ -875,7 +877,7 @@ func TestFunctionCompose(t *testing.T) {
 					WithComposedResourceObserver(ComposedResourceObserverFn(func(_ context.Context, _ resource.Composite) (ComposedResourceStates, error) {
 						return nil, nil
 					})),
-					WithComposedResourceGarbageCollector(ComposedResourceGarbageCollectorFn(func(_ context.Context, _ metav1.Object, _, _ ComposedResourceStates) error {
+					WithComposedResourceGarbageCollector(ComposedResourceGarbageCollectorFn(func(_ context.Context, _ metav1.Object, _, _ ComposedResourceStates, _ v1.GarbageCollectionPolicy) error {
 						return nil
 					})),
 				},
@@ -929,7 +931,7 @@ func TestFunctionCompose(t *testing.T) {
 					WithComposedResourceObserver(ComposedResourceObserverFn(func(_ context.Context, _ resource.Composite) (ComposedResourceStates, error) {
 						return nil, nil
 					})),
-					WithComposedResourceGarbageCollector(ComposedResourceGarbageCollectorFn(func(_ context.Context, _ metav1.Object, _, _ ComposedResourceStates) error {
+					WithComposedResourceGarbageCollector(ComposedResourceGarbageCollectorFn(func(_ context.Context, _ metav1.Object, _, _ ComposedResourceStates, _ v1.GarbageCollectionPolicy) error {
 						return nil
 					})),
 				},
@@ -983,7 +985,7 @@ func TestFunctionCompose(t *testing.T) {
 					WithComposedResourceObserver(ComposedResourceObserverFn(func(_ context.Context, _ resource.Composite) (ComposedResourceStates, error) {
 						return nil, nil
 					})),
-					WithComposedResourceGarbageCollector(ComposedResourceGarbageCollectorFn(func(_ context.Context, _ metav1.Object, _, _ ComposedResourceStates) error {
+					WithComposedResourceGarbageCollector(ComposedResourceGarbageCollectorFn(func(_ context.Context, _ metav1.Object, _, _ ComposedResourceStates, _ v1.GarbageCollectionPolicy) error {
 						return nil
 					})),
 				},
@@ -1048,7 +1050,7 @@ func TestFunctionCompose(t *testing.T) {
 					WithComposedResourceObserver(ComposedResourceObserverFn(func(_ context.Context, _ resource.Composite) (ComposedResourceStates, error) {
 						return nil, nil
 					})),
-					WithComposedResourceGarbageCollector(ComposedResourceGarbageCollectorFn(func(_ context.Context, _ metav1.Object, _, _ ComposedResourceStates) error {
+					WithComposedResourceGarbageCollector(ComposedResourceGarbageCollectorFn(func(_ context.Context, _ metav1.Object, _, _ ComposedResourceStates, _ v1.GarbageCollectionPolicy) error {
 						return nil
 					})),
 				},
@@ -1238,7 +1240,7 @@ func TestFunctionCompose(t *testing.T) {
 						}
 						return r, nil
 					})),
-					WithComposedResourceGarbageCollector(ComposedResourceGarbageCollectorFn(func(_ context.Context, _ metav1.Object, _, _ ComposedResourceStates) error {
+					WithComposedResourceGarbageCollector(ComposedResourceGarbageCollectorFn(func(_ context.Context, _ metav1.Object, _, _ ComposedResourceStates, _ v1.GarbageCollectionPolicy) error {
 						return nil
 					})),
 				},
@@ -1353,6 +1355,81 @@ func TestFunctionCompose(t *testing.T) {
 				err: nil,
 			},
 		},
+		"GarbageCollectionBlocked": {
+			reason: "We should keep composing, but not garbage collect or forget composed resources, when garbage collection would exceed the Composition's limits",
+			params: params{
+				c: &test.MockClient{
+					MockPatch: test.NewMockPatchFn(nil, func(obj client.Object) error {
+						// We should keep referencing the resources we didn't
+						// garbage collect.
+						if xr, ok := obj.(*composite.Unstructured); ok && len(xr.GetResourceReferences()) != 2 {
+							return errors.Errorf("want 2 resource references, got %d", len(xr.GetResourceReferences()))
+						}
+						return nil
+					}),
+					MockStatusPatch: test.NewMockSubResourcePatchFn(nil),
+				},
+				uc: &test.MockClient{
+					MockGet: test.NewMockGetFn(errBoom),
+				},
+				r: FunctionRunnerFn(func(_ context.Context, _ string, _ *fnv1.RunFunctionRequest) (rsp *fnv1.RunFunctionResponse, err error) {
+					return &fnv1.RunFunctionResponse{}, nil
+				}),
+				o: []FunctionComposerOption{
+					WithCompositeConnectionDetailsFetcher(ConnectionDetailsFetcherFn(func(_ context.Context, _ ConnectionSecretOwner) (managed.ConnectionDetails, error) {
+						return nil, nil
+					})),
+					WithComposedResourceObserver(ComposedResourceObserverFn(func(_ context.Context, _ resource.Composite) (ComposedResourceStates, error) {
+						observed := ComposedResourceStates{}
+						for _, name := range []ResourceName{"a", "b"} {
+							cd := composed.New()
+							cd.SetAPIVersion("test.crossplane.io/v1")
+							cd.SetKind("Composed")
+							cd.SetName(string(name))
+							cd.SetUID(types.UID("uid-" + name))
+							cd.SetOwnerReferences([]metav1.OwnerReference{{Controller: ptr.To(true), UID: "cool-xr"}})
+							observed[name] = ComposedResourceState{Resource: cd}
+						}
+						return observed, nil
+					})),
+					WithComposedResourceGarbageCollector(ComposedResourceGarbageCollectorFn(func(_ context.Context, _ metav1.Object, _, _ ComposedResourceStates, _ v1.GarbageCollectionPolicy) error {
+						return errBoom
+					})),
+				},
+			},
+			args: args{
+				xr: func() *composite.Unstructured {
+					xr := WithParentLabel()
+					xr.SetUID("cool-xr")
+					return xr
+				}(),
+				req: CompositionRequest{
+					Revision: &v1.CompositionRevision{
+						Spec: v1.CompositionRevisionSpec{
+							Pipeline: []v1.PipelineStep{
+								{
+									Step:        "run-cool-function",
+									FunctionRef: v1.FunctionReference{Name: "cool-function"},
+								},
+							},
+							GarbageCollection: &v1.GarbageCollection{MaxDeletions: ptr.To[int32](1)},
+						},
+					},
+				},
+			},
+			want: want{
+				res: CompositionResult{
+					GarbageCollectionBlocked: GarbageCollectionBlockedError{
+						Resources: []ResourceName{"a", "b"},
+						Composed:  2,
+						Token: func() string {
+							h := sha256.Sum256([]byte("uid-a,uid-b"))
+							return hex.EncodeToString(h[:])[:16]
+						}(),
+					},
+				},
+			},
+		},
 		"ResourceReferencesWithoutObservedResources": {
 			reason: "When XR has resourceRefs but the actual resources don't exist, the function should use a deterministic name (same as resourceRefs).",
 			params: params{
@@ -1405,7 +1482,7 @@ func TestFunctionCompose(t *testing.T) {
 						// Return empty observed resources - simulating that the resources don't exist in cluster
 						return ComposedResourceStates{}, nil
 					})),
-					WithComposedResourceGarbageCollector(ComposedResourceGarbageCollectorFn(func(_ context.Context, _ metav1.Object, _, _ ComposedResourceStates) error {
+					WithComposedResourceGarbageCollector(ComposedResourceGarbageCollectorFn(func(_ context.Context, _ metav1.Object, _, _ ComposedResourceStates, _ v1.GarbageCollectionPolicy) error {
 						return nil
 					})),
 				},
@@ -1882,6 +1959,352 @@ func TestAsState(t *testing.T) {
 	}
 }
 
+func TestCheckGarbageCollectionLimits(t *testing.T) {
+	owner := &fake.Composite{
+		ObjectMeta: metav1.ObjectMeta{
+			UID: "cool-xr",
+		},
+	}
+	controlled := func(uid string, annotations map[string]string) ComposedResourceState {
+		return ComposedResourceState{Resource: &fake.Composed{
+			ObjectMeta: metav1.ObjectMeta{
+				UID:         types.UID(uid),
+				Annotations: annotations,
+				OwnerReferences: []metav1.OwnerReference{{
+					Controller: ptr.To(true),
+					UID:        "cool-xr",
+				}},
+			},
+		}}
+	}
+	observed := ComposedResourceStates{
+		"a": controlled("uid-a", nil),
+		"b": controlled("uid-b", nil),
+		"c": controlled("uid-c", nil),
+		"d": controlled("uid-d", nil),
+	}
+
+	tokenFor := func(uids string) string {
+		h := sha256.Sum256([]byte(uids))
+		return hex.EncodeToString(h[:])[:16]
+	}
+
+	// The token that confirms deleting resources a, b, and c.
+	token := tokenFor("uid-a,uid-b,uid-c")
+
+	type args struct {
+		owner    metav1.Object
+		observed ComposedResourceStates
+		desired  ComposedResourceStates
+		gc       *v1.GarbageCollection
+	}
+
+	type want struct {
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   want
+	}{
+		"NoLimits": {
+			reason: "We should allow any garbage collection if the Composition doesn't configure limits.",
+			args: args{
+				owner:    owner,
+				observed: observed,
+				desired:  ComposedResourceStates{},
+				gc:       &v1.GarbageCollection{},
+			},
+			want: want{
+				err: nil,
+			},
+		},
+		"WithinMaxDeletions": {
+			reason: "We should allow garbage collection that deletes no more than the maximum number of resources.",
+			args: args{
+				owner:    owner,
+				observed: observed,
+				desired:  ComposedResourceStates{"c": ComposedResourceState{}, "d": ComposedResourceState{}},
+				gc:       &v1.GarbageCollection{MaxDeletions: ptr.To[int32](2)},
+			},
+			want: want{
+				err: nil,
+			},
+		},
+		"ExceedsMaxDeletions": {
+			reason: "We should block garbage collection that deletes more than the maximum number of resources.",
+			args: args{
+				owner:    owner,
+				observed: observed,
+				desired:  ComposedResourceStates{"d": ComposedResourceState{}},
+				gc:       &v1.GarbageCollection{MaxDeletions: ptr.To[int32](2)},
+			},
+			want: want{
+				err: GarbageCollectionBlockedError{Resources: []ResourceName{"a", "b", "c"}, Composed: 4, Token: token},
+			},
+		},
+		"ExceedsMaxDeletionPercent": {
+			reason: "We should block garbage collection that deletes more than the maximum percentage of resources.",
+			args: args{
+				owner:    owner,
+				observed: observed,
+				desired:  ComposedResourceStates{"d": ComposedResourceState{}},
+				gc:       &v1.GarbageCollection{MaxDeletionPercent: ptr.To[int32](50)},
+			},
+			want: want{
+				err: GarbageCollectionBlockedError{Resources: []ResourceName{"a", "b", "c"}, Composed: 4, Token: token},
+			},
+		},
+		"OrphansDontCount": {
+			reason: "Resources that would be orphaned rather than deleted shouldn't count toward the limits.",
+			args: args{
+				owner: owner,
+				observed: ComposedResourceStates{
+					"a": controlled("uid-a", nil),
+					"b": controlled("uid-b", map[string]string{v1.AnnotationKeyGarbageCollectionPolicy: string(v1.GarbageCollectionPolicyOrphan)}),
+				},
+				desired: ComposedResourceStates{},
+				gc:      &v1.GarbageCollection{MaxDeletions: ptr.To[int32](1)},
+			},
+			want: want{
+				err: nil,
+			},
+		},
+		"Confirmed": {
+			reason: "We should allow garbage collection that exceeds the limits if the owner's annotation confirms it.",
+			args: args{
+				owner: &fake.Composite{
+					ObjectMeta: metav1.ObjectMeta{
+						UID: "cool-xr",
+						Annotations: map[string]string{
+							v1.AnnotationKeyGarbageCollectionConfirmation: token,
+						},
+					},
+				},
+				observed: observed,
+				desired:  ComposedResourceStates{"d": ComposedResourceState{}},
+				gc:       &v1.GarbageCollection{MaxDeletions: ptr.To[int32](2)},
+			},
+			want: want{
+				err: nil,
+			},
+		},
+		"ConfirmedDifferentResources": {
+			reason: "A confirmation of one deletion shouldn't confirm the deletion of different resources.",
+			args: args{
+				owner: &fake.Composite{
+					ObjectMeta: metav1.ObjectMeta{
+						UID: "cool-xr",
+						Annotations: map[string]string{
+							v1.AnnotationKeyGarbageCollectionConfirmation: token,
+						},
+					},
+				},
+				observed: observed,
+				desired:  ComposedResourceStates{},
+				gc:       &v1.GarbageCollection{MaxDeletions: ptr.To[int32](2)},
+			},
+			want: want{
+				err: GarbageCollectionBlockedError{Resources: []ResourceName{"a", "b", "c", "d"}, Composed: 4, Token: tokenFor("uid-a,uid-b,uid-c,uid-d")},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			err := CheckGarbageCollectionLimits(tc.args.owner, tc.args.observed, tc.args.desired, tc.args.gc, v1.GarbageCollectionPolicyDelete)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nCheckGarbageCollectionLimits(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestFunctionComposerPlan(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	xr := composite.New()
+	xr.SetUID("cool-xr")
+
+	cd := func(name, spec string, o ...metav1.OwnerReference) *composed.Unstructured {
+		u := composed.New()
+		u.SetAPIVersion("example.org/v1")
+		u.SetKind("CoolComposed")
+		u.SetName(name)
+		u.SetOwnerReferences(o)
+		if spec != "" {
+			u.Object["spec"] = map[string]any{"cool": spec}
+		}
+		return u
+	}
+	controller := metav1.OwnerReference{Controller: ptr.To(true), UID: "cool-xr"}
+	ref := func(name string) corev1.ObjectReference {
+		return corev1.ObjectReference{APIVersion: "example.org/v1", Kind: "CoolComposed", Name: name}
+	}
+
+	// A server-side apply dry-run that returns the object with the supplied
+	// spec, and the metadata the API server would set.
+	dryRun := func(specs map[string]string) test.MockPatchFn {
+		return func(_ context.Context, obj client.Object, _ client.Patch, _ ...client.PatchOption) error {
+			u := obj.(*composed.Unstructured) //nolint:forcetypeassert // We only pass *composed.Unstructured.
+			u.SetResourceVersion("2")
+			u.SetOwnerReferences([]metav1.OwnerReference{controller})
+			u.Object["spec"] = map[string]any{"cool": specs[u.GetName()]}
+			return nil
+		}
+	}
+
+	type params struct {
+		c client.Client
+	}
+
+	type args struct {
+		observed ComposedResourceStates
+		desired  ComposedResourceStates
+		gc       *v1.GarbageCollection
+		policy   v1.GarbageCollectionPolicy
+	}
+
+	type want struct {
+		plan *CompositionPlan
+		err  error
+	}
+
+	cases := map[string]struct {
+		reason string
+		params params
+		args   args
+		want   want
+	}{
+		"DryRunError": {
+			reason: "We should return any error encountered dry-run applying a composed resource.",
+			params: params{
+				c: &test.MockClient{MockPatch: test.NewMockPatchFn(errBoom)},
+			},
+			args: args{
+				observed: ComposedResourceStates{"existing": ComposedResourceState{Resource: cd("existing", "a", controller)}},
+				desired:  ComposedResourceStates{"existing": ComposedResourceState{Resource: cd("existing", "a")}},
+				policy:   v1.GarbageCollectionPolicyDelete,
+			},
+			want: want{
+				err: errors.Wrapf(errBoom, errFmtDryRunApplyCD, "existing"),
+			},
+		},
+		"ManagedFieldsUpgrade": {
+			reason: "We shouldn't dry-run apply a composed resource whose managed fields we'd upgrade first, but note that we'd update it.",
+			params: params{
+				// We'd return an error if we tried to dry-run apply.
+				c: &test.MockClient{MockPatch: test.NewMockPatchFn(errBoom)},
+			},
+			args: args{
+				observed: ComposedResourceStates{"existing": ComposedResourceState{Resource: func() *composed.Unstructured {
+					u := cd("existing", "a", controller)
+					u.SetCreationTimestamp(metav1.Now())
+					u.SetManagedFields([]metav1.ManagedFieldsEntry{{Manager: "crossplane", Operation: metav1.ManagedFieldsOperationUpdate}})
+					return u
+				}()}},
+				desired: ComposedResourceStates{"existing": ComposedResourceState{Resource: cd("existing", "a")}},
+				policy:  v1.GarbageCollectionPolicyDelete,
+			},
+			want: want{
+				plan: &CompositionPlan{Changes: []PlannedChange{
+					{
+						Action:       PlannedActionUpdate,
+						ResourceName: "existing",
+						Resource:     ref("existing"),
+						Note:         "managed fields would be upgraded from client-side to server-side apply; other changes can't be planned until they are",
+					},
+				}},
+			},
+		},
+		"Plan": {
+			reason: "We should plan to create new composed resources, update changed ones, and delete or orphan undesired ones.",
+			params: params{
+				c: &test.MockClient{MockPatch: dryRun(map[string]string{"unchanged": "a", "changed": "b"})},
+			},
+			args: args{
+				observed: ComposedResourceStates{
+					"unchanged": ComposedResourceState{Resource: cd("unchanged", "a", controller)},
+					"changed":   ComposedResourceState{Resource: cd("changed", "a", controller)},
+					"deleted":   ComposedResourceState{Resource: cd("deleted", "a", controller)},
+					"orphaned": ComposedResourceState{Resource: func() *composed.Unstructured {
+						u := cd("orphaned", "a", controller)
+						u.SetAnnotations(map[string]string{v1.AnnotationKeyGarbageCollectionPolicy: string(v1.GarbageCollectionPolicyOrphan)})
+						return u
+					}()},
+					// We don't control this resource, so we wouldn't delete it.
+					"uncontrolled": ComposedResourceState{Resource: cd("uncontrolled", "a")},
+				},
+				desired: ComposedResourceStates{
+					"unchanged": ComposedResourceState{Resource: cd("unchanged", "a")},
+					"changed":   ComposedResourceState{Resource: cd("changed", "b")},
+					"created":   ComposedResourceState{Resource: cd("created", "a")},
+				},
+				policy: v1.GarbageCollectionPolicyDelete,
+			},
+			want: want{
+				plan: &CompositionPlan{Changes: []PlannedChange{
+					{Action: PlannedActionUpdate, ResourceName: "changed", Resource: ref("changed")},
+					{Action: PlannedActionCreate, ResourceName: "created", Resource: ref("created")},
+					{Action: PlannedActionDelete, ResourceName: "deleted", Resource: ref("deleted")},
+					{Action: PlannedActionOrphan, ResourceName: "orphaned", Resource: ref("orphaned")},
+				}},
+			},
+		},
+		"GarbageCollectionBlocked": {
+			reason: "We should report that the Composition's garbage collection limits would block deleting undesired composed resources.",
+			params: params{
+				c: &test.MockClient{MockPatch: dryRun(map[string]string{"kept": "a"})},
+			},
+			args: args{
+				observed: ComposedResourceStates{
+					"kept": ComposedResourceState{Resource: cd("kept", "a", controller)},
+					"deleted": ComposedResourceState{Resource: func() *composed.Unstructured {
+						u := cd("deleted", "a", controller)
+						u.SetUID("uid-deleted")
+						return u
+					}()},
+				},
+				desired: ComposedResourceStates{
+					"kept": ComposedResourceState{Resource: cd("kept", "a")},
+				},
+				gc:     &v1.GarbageCollection{MaxDeletions: ptr.To[int32](0)},
+				policy: v1.GarbageCollectionPolicyDelete,
+			},
+			want: want{
+				plan: &CompositionPlan{
+					Changes: []PlannedChange{
+						{Action: PlannedActionDelete, ResourceName: "deleted", Resource: ref("deleted"), Note: "blocked by the Composition's garbage collection limits"},
+					},
+					GarbageCollectionBlocked: &GarbageCollectionBlockedError{
+						Resources: []ResourceName{"deleted"},
+						Composed:  2,
+						Token: func() string {
+							h := sha256.Sum256([]byte("uid-deleted"))
+							return hex.EncodeToString(h[:])[:16]
+						}(),
+					},
+				},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			c := NewFunctionComposer(tc.params.c, tc.params.c, nil)
+
+			plan, err := c.Plan(context.Background(), xr, tc.args.observed, tc.args.desired, tc.args.gc, tc.args.policy)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nPlan(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.plan, plan); diff != "" {
+				t.Errorf("\n%s\nPlan(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
 func TestGarbageCollectComposedResources(t *testing.T) {
 	errBoom := errors.New("boom")
 
@@ -1894,6 +2317,7 @@ func TestGarbageCollectComposedResources(t *testing.T) {
 		owner    metav1.Object
 		observed ComposedResourceStates
 		desired  ComposedResourceStates
+		policy   v1.GarbageCollectionPolicy
 	}
 
 	type want struct {
@@ -2074,6 +2498,203 @@ func TestGarbageCollectComposedResources(t *testing.T) {
 				err: nil,
 			},
 		},
+		"SuccessfulOrphan": {
+			reason: "We should remove our owner reference from, but not delete, an observed resource that is not desired if the policy is Orphan.",
+			params: params{
+				client: &test.MockClient{
+					MockUpdate: func(_ context.Context, obj client.Object, _ ...client.UpdateOption) error {
+						want := []metav1.OwnerReference{{UID: "cool-usage"}}
+						if diff := cmp.Diff(want, obj.GetOwnerReferences()); diff != "" {
+							return errors.Errorf("unexpected owner references: -want, +got:\n%s", diff)
+						}
+						if obj.GetLabels()[xcrd.LabelKeyNamePrefixForComposed] != "" {
+							return errors.New("resource still has composed resource labels")
+						}
+						return nil
+					},
+					// We know Delete wasn't called because it's nil and would
+					// panic if it was.
+				},
+			},
+			args: args{
+				owner: &fake.Composite{
+					ObjectMeta: metav1.ObjectMeta{
+						UID: "cool-xr",
+					},
+				},
+				observed: ComposedResourceStates{
+					"undesired-resource": ComposedResourceState{
+						Resource: &fake.Composed{
+							ObjectMeta: metav1.ObjectMeta{
+								OwnerReferences: []metav1.OwnerReference{
+									{
+										// This resource is controlled by the XR.
+										Controller: ptr.To(true),
+										UID:        "cool-xr",
+									},
+									{
+										// This owner reference isn't ours.
+										UID: "cool-usage",
+									},
+								},
+								Labels: map[string]string{
+									xcrd.LabelKeyNamePrefixForComposed: "cool-xr",
+								},
+							},
+						},
+					},
+				},
+				policy: v1.GarbageCollectionPolicyOrphan,
+			},
+			want: want{
+				err: nil,
+			},
+		},
+		"OrphanError": {
+			reason: "We should return any error encountered orphaning the resource.",
+			params: params{
+				client: &test.MockClient{
+					MockUpdate: test.NewMockUpdateFn(errBoom),
+				},
+			},
+			args: args{
+				owner: &fake.Composite{
+					ObjectMeta: metav1.ObjectMeta{
+						UID: "cool-xr",
+					},
+				},
+				observed: ComposedResourceStates{
+					"undesired-resource": ComposedResourceState{
+						Resource: &fake.Composed{
+							ObjectMeta: metav1.ObjectMeta{
+								// This resource is controlled by the XR.
+								OwnerReferences: []metav1.OwnerReference{{
+									Controller: ptr.To(true),
+									UID:        "cool-xr",
+								}},
+							},
+						},
+					},
+				},
+				policy: v1.GarbageCollectionPolicyOrphan,
+			},
+			want: want{
+				err: errors.Wrapf(errBoom, errFmtOrphanCD, "undesired-resource", "", ""),
+			},
+		},
+		"ComposedResourceAnnotationOverridesPolicy": {
+			reason: "A composed resource's garbage collection policy annotation should take precedence over the XR's annotation.",
+			params: params{
+				client: &test.MockClient{
+					MockUpdate: test.NewMockUpdateFn(nil),
+					// We know Delete wasn't called because it's nil and would
+					// panic if it was.
+				},
+			},
+			args: args{
+				owner: &fake.Composite{
+					ObjectMeta: metav1.ObjectMeta{
+						UID: "cool-xr",
+						Annotations: map[string]string{
+							v1.AnnotationKeyGarbageCollectionPolicy: string(v1.GarbageCollectionPolicyDelete),
+						},
+					},
+				},
+				observed: ComposedResourceStates{
+					"undesired-resource": ComposedResourceState{
+						Resource: &fake.Composed{
+							ObjectMeta: metav1.ObjectMeta{
+								// This resource is controlled by the XR.
+								OwnerReferences: []metav1.OwnerReference{{
+									Controller: ptr.To(true),
+									UID:        "cool-xr",
+								}},
+								Annotations: map[string]string{
+									v1.AnnotationKeyGarbageCollectionPolicy: string(v1.GarbageCollectionPolicyOrphan),
+								},
+							},
+						},
+					},
+				},
+				policy: v1.GarbageCollectionPolicyDelete,
+			},
+			want: want{
+				err: nil,
+			},
+		},
+		"CompositeResourceAnnotationOverridesPolicy": {
+			reason: "An XR's garbage collection policy annotation should take precedence over the supplied policy.",
+			params: params{
+				client: &test.MockClient{
+					MockUpdate: test.NewMockUpdateFn(nil),
+					// We know Delete wasn't called because it's nil and would
+					// panic if it was.
+				},
+			},
+			args: args{
+				owner: &fake.Composite{
+					ObjectMeta: metav1.ObjectMeta{
+						UID: "cool-xr",
+						Annotations: map[string]string{
+							v1.AnnotationKeyGarbageCollectionPolicy: string(v1.GarbageCollectionPolicyOrphan),
+						},
+					},
+				},
+				observed: ComposedResourceStates{
+					"undesired-resource": ComposedResourceState{
+						Resource: &fake.Composed{
+							ObjectMeta: metav1.ObjectMeta{
+								// This resource is controlled by the XR.
+								OwnerReferences: []metav1.OwnerReference{{
+									Controller: ptr.To(true),
+									UID:        "cool-xr",
+								}},
+							},
+						},
+					},
+				},
+				policy: v1.GarbageCollectionPolicyDelete,
+			},
+			want: want{
+				err: nil,
+			},
+		},
+		"InvalidPolicyAnnotation": {
+			reason: "We should return an error, rather than delete the resource, if its garbage collection policy annotation is invalid.",
+			params: params{
+				client: &test.MockClient{
+					// We know Update and Delete weren't called because they're
+					// nil and would panic if they were.
+				},
+			},
+			args: args{
+				owner: &fake.Composite{
+					ObjectMeta: metav1.ObjectMeta{
+						UID: "cool-xr",
+					},
+				},
+				observed: ComposedResourceStates{
+					"undesired-resource": ComposedResourceState{
+						Resource: &fake.Composed{
+							ObjectMeta: metav1.ObjectMeta{
+								// This resource is controlled by the XR.
+								OwnerReferences: []metav1.OwnerReference{{
+									Controller: ptr.To(true),
+									UID:        "cool-xr",
+								}},
+								Annotations: map[string]string{
+									v1.AnnotationKeyGarbageCollectionPolicy: "Keep",
+								},
+							},
+						},
+					},
+				},
+				policy: v1.GarbageCollectionPolicyDelete,
+			},
+			want: want{
+				err: errors.Wrapf(errors.Errorf(errFmtInvalidGCPolicy, v1.AnnotationKeyGarbageCollectionPolicy, "Keep"), errFmtGCPolicy, "undesired-resource"),
+			},
+		},
 		"SuccessfulNoop": {
 			reason: "We should not delete an observed resource from the API server if it is desired.",
 			params: params{
@@ -2116,7 +2737,7 @@ func TestGarbageCollectComposedResources(t *testing.T) {
 		t.Run(name, func(t *testing.T) {
 			d := NewDeletingComposedResourceGarbageCollector(tc.params.client)
 
-			err := d.GarbageCollectComposedResources(tc.args.ctx, tc.args.owner, tc.args.observed, tc.args.desired)
+			err := d.GarbageCollectComposedResources(tc.args.ctx, tc.args.owner, tc.args.observed, tc.args.desired, tc.args.policy)
 			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
 				t.Errorf("\n%s\nGarbageCollectComposedResources(...): -want, +got:\n%s", tc.reason, diff)
 			}
@@ -18,10 +18,19 @@ package composite
 
 import (
 	"fmt"
+	"strings"
+	"time"
 
+	"github.com/crossplane/crossplane-runtime/v2/pkg/errors"
 	"github.com/crossplane/crossplane-runtime/v2/pkg/resource"
+
+	v1 "github.com/crossplane/crossplane/apis/v2/apiextensions/v1"
 )
 
+// The maximum number of composed resource names a
+// GarbageCollectionBlockedError includes in its message.
+const maxBlockedResourceNames = 10
+
 // A ComposedResourceError allows the resource composer to return context around
 // the composition error.
 type ComposedResourceError struct {
@@ -45,3 +54,60 @@ func (e ComposedResourceError) Error() string {
 func (e ComposedResourceError) Unwrap() error {
 	return e.Err
 }
+
+// A GarbageCollectionBlockedError indicates that garbage collecting composed
+// resources that are no longer desired would delete more of them than the
+// Composition's garbage collection limits allow.
+type GarbageCollectionBlockedError struct {
+	// Resources are the names of the composed resources that would be
+	// deleted.
+	Resources []ResourceName `json:"resources"`
+	// Composed is the number of composed resources the composite resource
+	// had before garbage collection.
+	Composed int `json:"composed"`
+	// Token confirms the deletion when set as the composite resource's
+	// garbage collection confirmation annotation.
+	Token string `json:"token"`
+}
+
+// Error implements errors.Error.
+func (e GarbageCollectionBlockedError) Error() string {
+	names := make([]string, 0, len(e.Resources))
+	for i, n := range e.Resources {
+		if i == maxBlockedResourceNames {
+			names = append(names, fmt.Sprintf("and %d more", len(e.Resources)-i))
+			break
+		}
+		names = append(names, string(n))
+	}
+	return fmt.Sprintf("refusing to delete %d of %d composed resources (%s) because that would exceed the Composition's garbage collection limits; set the %s annotation to %q to confirm the deletion",
+		len(e.Resources), e.Composed, strings.Join(names, ", "), v1.AnnotationKeyGarbageCollectionConfirmation, e.Token)
+}
+
+// A RolloutHeldError is returned with the composite resource's current
+// CompositionRevision when a Composition's rollout strategy holds the
+// composite resource at that revision instead of moving it to the latest one.
+type RolloutHeldError struct {
+	// Current is the name of the revision the composite resource is held at.
+	Current string
+	// Latest is the name of the revision the composite resource isn't moved
+	// to yet.
+	Latest string
+	// Reason explains why the composite resource is held.
+	Reason string
+	// RetryAfter is how long to wait before checking whether the composite
+	// resource may move to the latest revision.
+	RetryAfter time.Duration
+}
+
+// Error implements errors.Error.
+func (e *RolloutHeldError) Error() string {
+	return fmt.Sprintf("holding composite resource at CompositionRevision %s instead of %s: %s", e.Current, e.Latest, e.Reason)
+}
+
+// IsRolloutHeld returns true if the supplied error indicates that a
+// Composition's rollout strategy held a composite resource at its current
+// CompositionRevision.
+func IsRolloutHeld(err error) bool {
+	return errors.As(err, new(*RolloutHeldError))
+}
@@ -19,9 +19,11 @@ package composite
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"math/rand"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/google/go-cmp/cmp"
@@ -52,6 +54,7 @@ import (
 	"github.com/crossplane/crossplane/v2/internal/controller/apiextensions/composite/dependency"
 	"github.com/crossplane/crossplane/v2/internal/engine"
 	"github.com/crossplane/crossplane/v2/internal/features"
+	"github.com/crossplane/crossplane/v2/internal/xfn"
 )
 
 const (
@@ -70,7 +73,9 @@ const (
 	errSelectComp       = "cannot select Composition"
 	errSelectCompRev    = "cannot select CompositionRevision"
 	errFetchComp        = "cannot fetch Composition"
+	errRollback         = "cannot roll back CompositionRevision"
 	errConfigure        = "cannot configure composite resource"
+	errPlanNoComp       = "cannot plan composite resource that hasn't selected a Composition"
 	errPublish          = "cannot publish connection details"
 	errWatch            = "cannot watch resource for changes"
 	errCompose          = "cannot compose resources"
@@ -94,11 +99,15 @@ const (
 	reasonPaused                  event.Reason = "ReconciliationPaused"
 	reasonNamespaceOverridden     event.Reason = "NamespaceOverridden"
 	reasonReconcileRequestHandled event.Reason = "ReconcileRequestHandled"
+	reasonGarbageCollection       event.Reason = "GarbageCollectComposedResources"
+	reasonPlan                    event.Reason = "PlanComposedResources"
+	reasonRollback                event.Reason = "RollbackCompositionRevision"
 )
 
 // Condition reasons.
 const (
-	reasonFatalError xpv2.ConditionReason = "FatalError"
+	reasonFatalError               xpv2.ConditionReason = "FatalError"
+	reasonGarbageCollectionBlocked xpv2.ConditionReason = "GarbageCollectionBlocked"
 )
 
 // ControllerName returns the recommended name for controllers that use this
@@ -198,6 +207,39 @@ func (fn CompositionRevisionFetcherFn) Fetch(ctx context.Context, cr resource.Co
 	return fn(ctx, cr)
 }
 
+// A CompositionRevisionResolver resolves the CompositionRevision a
+// CompositionRevisionFetcher would fetch for the supplied composite resource,
+// without updating the composite resource.
+type CompositionRevisionResolver interface {
+	Resolve(ctx context.Context, cr resource.Composite) (*v1.CompositionRevision, error)
+}
+
+// A CompositionRevisionResolverFn resolves the CompositionRevision a
+// CompositionRevisionFetcher would fetch for the supplied composite resource.
+type CompositionRevisionResolverFn func(ctx context.Context, cr resource.Composite) (*v1.CompositionRevision, error)
+
+// Resolve the CompositionRevision for the supplied composite resource.
+func (fn CompositionRevisionResolverFn) Resolve(ctx context.Context, cr resource.Composite) (*v1.CompositionRevision, error) {
+	return fn(ctx, cr)
+}
+
+// A RevisionRollbacker rolls a composite resource back to a previous
+// CompositionRevision.
+type RevisionRollbacker interface {
+	// Rollback the supplied composite resource from the supplied revision.
+	// Returns the revision it was rolled back to, or nil if it wasn't.
+	Rollback(ctx context.Context, cr resource.Composite, rev *v1.CompositionRevision) (*v1.CompositionRevision, error)
+}
+
+// A RevisionRollbackerFn rolls a composite resource back to a previous
+// CompositionRevision.
+type RevisionRollbackerFn func(ctx context.Context, cr resource.Composite, rev *v1.CompositionRevision) (*v1.CompositionRevision, error)
+
+// Rollback the supplied composite resource from the supplied revision.
+func (fn RevisionRollbackerFn) Rollback(ctx context.Context, cr resource.Composite, rev *v1.CompositionRevision) (*v1.CompositionRevision, error) {
+	return fn(ctx, cr, rev)
+}
+
 // A Configurator configures a composite resource using its composition.
 type Configurator interface {
 	Configure(ctx context.Context, cr resource.Composite, rev *v1.CompositionRevision) error
@@ -215,6 +257,9 @@ func (fn ConfiguratorFn) Configure(ctx context.Context, cr resource.Composite, r
 // It should be treated as immutable.
 type CompositionRequest struct {
 	Revision *v1.CompositionRevision
+
+	// Plan the composition without changing any composed resources.
+	Plan bool
 }
 
 // A CompositionResult is the result of the composition process.
@@ -236,6 +281,85 @@ type CompositionResult struct {
 
 	// TTL for this composition result.
 	TTL time.Duration
+
+	// The changes composition would make to composed resources. Only set
+	// when the request was to plan composition.
+	Plan *CompositionPlan
+
+	// Why composition didn't garbage collect composed resources that are no
+	// longer desired, if it was blocked by the Composition's garbage
+	// collection limits.
+	GarbageCollectionBlocked error
+}
+
+// A PlannedAction is an action composition would take on a composed resource.
+type PlannedAction string
+
+// Planned actions.
+const (
+	PlannedActionCreate PlannedAction = "Create"
+	PlannedActionUpdate PlannedAction = "Update"
+	PlannedActionDelete PlannedAction = "Delete"
+	PlannedActionOrphan PlannedAction = "Orphan"
+)
+
+// A PlannedChange is a change composition would make to a composed resource.
+type PlannedChange struct {
+	Action       PlannedAction          `json:"action"`
+	ResourceName ResourceName           `json:"resourceName"`
+	Resource     corev1.ObjectReference `json:"resource"`
+
+	// Note explains the change, if composition can't fully plan it.
+	Note string `json:"note,omitempty"`
+}
+
+// The maximum number of planned changes a CompositionPlan's description
+// includes.
+const maxPlannedChanges = 10
+
+// A CompositionPlan describes the changes composition would make to composed
+// resources. Composed resources that wouldn't change are omitted.
+type CompositionPlan struct {
+	Changes []PlannedChange `json:"changes"`
+
+	// GarbageCollectionBlocked is set if the Composition's garbage collection
+	// limits would block deleting the composed resources the plan deletes.
+	GarbageCollectionBlocked *GarbageCollectionBlockedError `json:"garbageCollectionBlocked,omitempty"`
+}
+
+// String describes the planned changes. It counts all changes, but only
+// describes the first few.
+func (p *CompositionPlan) String() string {
+	counts := map[PlannedAction]int{}
+	changes := make([]string, 0, min(len(p.Changes), maxPlannedChanges+1))
+	for i, c := range p.Changes {
+		counts[c.Action]++
+		if i >= maxPlannedChanges {
+			continue
+		}
+		name := c.Resource.Name
+		if c.Resource.Namespace != "" {
+			name = c.Resource.Namespace + "/" + name
+		}
+		change := fmt.Sprintf("%s %q (%s %s)", strings.ToLower(string(c.Action)), c.ResourceName, c.Resource.Kind, name)
+		if c.Note != "" {
+			change += " - " + c.Note
+		}
+		changes = append(changes, change)
+	}
+	if len(p.Changes) > maxPlannedChanges {
+		changes = append(changes, fmt.Sprintf("and %d more", len(p.Changes)-maxPlannedChanges))
+	}
+
+	summary := fmt.Sprintf("Composition would create %d, update %d, delete %d, and orphan %d composed resources",
+		counts[PlannedActionCreate], counts[PlannedActionUpdate], counts[PlannedActionDelete], counts[PlannedActionOrphan])
+	if len(changes) > 0 {
+		summary += ": " + strings.Join(changes, "; ")
+	}
+	if p.GarbageCollectionBlocked != nil {
+		summary += ". Garbage collection would be blocked: " + p.GarbageCollectionBlocked.Error()
+	}
+	return summary
 }
 
 // A CompositionTarget is the target of a composition event or condition.
@@ -345,6 +469,23 @@ func WithCompositionRevisionFetcher(f CompositionRevisionFetcher) ReconcilerOpti
 	}
 }
 
+// WithCompositionRevisionResolver specifies how the composition revision to be
+// used should be resolved in plan mode, without updating the composite
+// resource.
+func WithCompositionRevisionResolver(rr CompositionRevisionResolver) ReconcilerOption {
+	return func(r *Reconciler) {
+		r.revision.CompositionRevisionResolver = rr
+	}
+}
+
+// WithRevisionRollbacker specifies how composite resources should be rolled
+// back to a previous composition revision.
+func WithRevisionRollbacker(rb RevisionRollbacker) ReconcilerOption {
+	return func(r *Reconciler) {
+		r.revision.RevisionRollbacker = rb
+	}
+}
+
 // WithCompositeFinalizer specifies how the composition to be used should be
 // selected.
 // WithCompositeFinalizer specifies which Finalizer should be used to finalize
@@ -430,6 +571,8 @@ func WithCircuitBreaker(cb circuit.Breaker) ReconcilerOption {
 
 type revision struct {
 	CompositionRevisionFetcher
+	CompositionRevisionResolver
+	RevisionRollbacker
 }
 
 // A WatchStarter can start a new watch. XR controllers use this to dynamically
@@ -471,13 +614,17 @@ type compositeResource struct {
 
 // NewReconciler returns a new Reconciler of composite resources.
 func NewReconciler(cached client.Client, of schema.GroupVersionKind, opts ...ReconcilerOption) *Reconciler {
+	fetcher := NewAPIRevisionFetcher(cached)
+
 	r := &Reconciler{
 		client: cached,
 
 		gvk: of,
 
 		revision: revision{
-			CompositionRevisionFetcher: NewAPIRevisionFetcher(cached),
+			CompositionRevisionFetcher:  fetcher,
+			CompositionRevisionResolver: fetcher,
+			RevisionRollbacker:          NewAPIRevisionRollbacker(cached),
 		},
 
 		composite: compositeResource{
@@ -667,45 +814,77 @@ func (r *Reconciler) Reconcile(ctx context.Context, req reconcile.Request) (reco
 		return reconcile.Result{}, err
 	}
 
-	orig := xr.GetCompositionReference()
-	if err := r.composite.SelectComposition(ctx, xr); err != nil {
-		if kerrors.IsConflict(err) {
-			return reconcile.Result{Requeue: true}, nil
-		}
-
-		err = errors.Wrap(err, errSelectComp)
-		r.record.Event(xr, event.Warning(reasonResolve, err))
+	// In plan mode we run the function pipeline, but only report the changes
+	// we'd make to composed resources. Plan mode mustn't change the XR's
+	// spec, so we don't select a Composition or CompositionRevision, roll
+	// back, or configure the XR. We plan using the revision the XR would use.
+	plan := xr.GetAnnotations()[v1.AnnotationKeyCompositionPlan] == "true"
+	if plan && xr.GetCompositionReference() == nil {
+		err := errors.New(errPlanNoComp)
+		r.record.Event(xr, event.Warning(reasonPlan, err))
 		status.MarkConditions(xpv2.ReconcileError(err))
 		_ = r.client.Status().Update(updateCtx, xr)
 
 		return reconcile.Result{}, err
 	}
 
-	if compRef := xr.GetCompositionReference(); compRef != nil && (orig == nil || *compRef != *orig) {
-		r.record.Event(xr, event.Normal(reasonResolve, fmt.Sprintf("Successfully selected composition: %s", compRef.Name)))
-	}
+	if !plan {
+		orig := xr.GetCompositionReference()
+		if err := r.composite.SelectComposition(ctx, xr); err != nil {
+			if kerrors.IsConflict(err) {
+				return reconcile.Result{Requeue: true}, nil
+			}
 
-	origCompRev := xr.GetCompositionRevisionReference()
-	if err := r.composite.SelectCompositionRevision(ctx, xr); err != nil {
-		if kerrors.IsConflict(err) {
-			return reconcile.Result{Requeue: true}, nil
+			err = errors.Wrap(err, errSelectComp)
+			r.record.Event(xr, event.Warning(reasonResolve, err))
+			status.MarkConditions(xpv2.ReconcileError(err))
+			_ = r.client.Status().Update(updateCtx, xr)
+
+			return reconcile.Result{}, err
 		}
-		err = errors.Wrap(err, errSelectCompRev)
-		r.record.Event(xr, event.Warning(reasonResolve, err))
-		status.MarkConditions(xpv2.ReconcileError(err))
-		_ = r.client.Status().Update(updateCtx, xr)
 
-		return reconcile.Result{}, err
-	}
+		if compRef := xr.GetCompositionReference(); compRef != nil && (orig == nil || *compRef != *orig) {
+			r.record.Event(xr, event.Normal(reasonResolve, fmt.Sprintf("Successfully selected composition: %s", compRef.Name)))
+		}
 
-	if compRevRef := xr.GetCompositionRevisionReference(); compRevRef != nil && (origCompRev == nil || *compRevRef != *origCompRev) {
-		r.record.Event(xr, event.Normal(reasonResolve, fmt.Sprintf("Successfully selected composition revision: %s", compRevRef.Name)))
+		origCompRev := xr.GetCompositionRevisionReference()
+		if err := r.composite.SelectCompositionRevision(ctx, xr); err != nil {
+			if kerrors.IsConflict(err) {
+				return reconcile.Result{Requeue: true}, nil
+			}
+			err = errors.Wrap(err, errSelectCompRev)
+			r.record.Event(xr, event.Warning(reasonResolve, err))
+			status.MarkConditions(xpv2.ReconcileError(err))
+			_ = r.client.Status().Update(updateCtx, xr)
+
+			return reconcile.Result{}, err
+		}
+
+		if compRevRef := xr.GetCompositionRevisionReference(); compRevRef != nil && (origCompRev == nil || *compRevRef != *origCompRev) {
+			r.record.Event(xr, event.Normal(reasonResolve, fmt.Sprintf("Successfully selected composition revision: %s", compRevRef.Name)))
+		}
 	}
 
-	// Select (if there is a new one) and fetch the composition revision.
+	// Select (if there is a new one) and fetch the composition revision. In
+	// plan mode we only resolve the revision we'd select.
 	origRev := xr.GetCompositionRevisionReference()
 
-	rev, err := r.revision.Fetch(ctx, xr)
+	fetch := r.revision.Fetch
+	if plan {
+		fetch = r.revision.Resolve
+	}
+	rev, err := fetch(ctx, xr)
+
+	// The Composition's rollout strategy is holding this XR at its current
+	// revision. This isn't an error - we compose using the current revision,
+	// and check again once the XR might be allowed to move.
+	var held *RolloutHeldError
+	if errors.As(err, &held) {
+		log.Debug("Holding composite resource at its current composition revision", "current", held.Current, "latest", held.Latest, "reason", held.Reason)
+		r.record.Event(xr, event.Normal(reasonCompositionUpdatePolicy, held.Error()))
+		err = nil
+	}
+
 	if err != nil {
 		log.Debug(errFetchComp, "error", err)
 
@@ -721,6 +900,44 @@ func (r *Reconciler) Reconcile(ctx context.Context, req reconcile.Request) (reco
 		return reconcile.Result{}, err
 	}
 
+	// Roll back to the last revision under which the XR was ready if it
+	// failed soon after automatically updating to this revision. We don't
+	// roll back in plan mode, which mustn't change the XR's spec. We don't
+	// roll back if the XR just updated either - its conditions describe the
+	// revision it updated from.
+	var prev *v1.CompositionRevision
+	if cur := xr.GetCompositionRevisionReference(); !plan && origRev != nil && cur != nil && *cur == *origRev {
+		prev, err = r.revision.Rollback(ctx, xr, rev)
+		if err != nil {
+			log.Debug(errRollback, "error", err)
+
+			if kerrors.IsConflict(err) {
+				return reconcile.Result{Requeue: true}, nil
+			}
+
+			err = errors.Wrap(err, errRollback)
+			r.record.Event(xr, event.Warning(reasonRollback, err))
+			status.MarkConditions(xpv2.ReconcileError(err))
+			_ = r.client.Status().Update(updateCtx, xr)
+
+			return reconcile.Result{}, err
+		}
+	}
+
+	switch {
+	case prev != nil:
+		msg := fmt.Sprintf("Rolled back from composition revision %s to %s, the last revision under which the composite resource was ready. Set the composition update policy to Automatic to update again.", rev.GetName(), prev.GetName())
+		log.Debug("Rolled back composition revision", "from", rev.GetName(), "to", prev.GetName())
+		r.record.Event(xr, event.Warning(reasonRollback, errors.New(msg)))
+		status.MarkConditions(v1.RolledBack(msg))
+		rev = prev
+	case xr.GetCondition(v1.TypeRolledBack).Status == corev1.ConditionTrue:
+		// The XR was rolled back, but now automatically updates again.
+		if pol := xr.GetCompositionUpdatePolicy(); pol == nil || *pol != xpv2.UpdateManual {
+			status.MarkConditions(v1.NotRolledBack())
+		}
+	}
+
 	if rev := xr.GetCompositionRevisionReference(); rev != nil && (origRev == nil || *rev != *origRev) {
 		r.record.Event(xr, event.Normal(reasonResolve, fmt.Sprintf("Selected composition revision: %s", rev.Name)))
 	}
@@ -742,22 +959,25 @@ func (r *Reconciler) Reconcile(ctx context.Context, req reconcile.Request) (reco
 		return reconcile.Result{}, err
 	}
 
-	if err := r.composite.Configure(ctx, xr, rev); err != nil {
-		log.Debug(errConfigure, "error", err)
+	// Configuring the XR may update it, so we don't in plan mode.
+	if !plan {
+		if err := r.composite.Configure(ctx, xr, rev); err != nil {
+			log.Debug(errConfigure, "error", err)
 
-		if kerrors.IsConflict(err) {
-			return reconcile.Result{Requeue: true}, nil
-		}
+			if kerrors.IsConflict(err) {
+				return reconcile.Result{Requeue: true}, nil
+			}
 
-		err = errors.Wrap(err, errConfigure)
-		r.record.Event(xr, event.Warning(reasonCompose, err))
-		status.MarkConditions(xpv2.ReconcileError(err))
-		_ = r.client.Status().Update(updateCtx, xr)
+			err = errors.Wrap(err, errConfigure)
+			r.record.Event(xr, event.Warning(reasonCompose, err))
+			status.MarkConditions(xpv2.ReconcileError(err))
+			_ = r.client.Status().Update(updateCtx, xr)
 
-		return reconcile.Result{}, err
+			return reconcile.Result{}, err
+		}
 	}
 
-	res, err := r.resource.Compose(ctx, xr, CompositionRequest{Revision: rev})
+	res, err := r.resource.Compose(ctx, xr, CompositionRequest{Revision: rev, Plan: plan})
 	if err != nil {
 		log.Debug(errCompose, "error", err)
 
@@ -786,7 +1006,7 @@ func (r *Reconciler) Reconcile(ctx context.Context, req reconcile.Request) (reco
 				}
 			}
 		}
-		status.MarkConditions(xpv2.ReconcileError(err))
+		status.MarkConditions(xfn.ReconcileError(err))
 
 		resultMeta := r.handleCommonCompositionResult(updateCtx, res, xr)
 		// We encountered a fatal error. For any custom status conditions that were
@@ -806,6 +1026,32 @@ func (r *Reconciler) Reconcile(ctx context.Context, req reconcile.Request) (reco
 		return reconcile.Result{}, err
 	}
 
+	if res.Plan != nil {
+		// The Planned condition only describes the first few changes. The
+		// plan event's annotation contains the entire plan. We record it
+		// every time we plan, so the latest event always describes the
+		// latest plan.
+		msg := res.Plan.String()
+		log.Debug("Planned changes to composed resources", "plan", msg)
+		e := event.Normal(reasonPlan, msg)
+		if j, err := json.Marshal(res.Plan); err == nil {
+			e.Annotations[v1.AnnotationKeyCompositionPlan] = string(j)
+		}
+		r.record.Event(xr, e)
+		status.MarkConditions(v1.Planned(msg))
+
+		result := reconcile.Result{RequeueAfter: jitter(r.effectivePollInterval(xr))}
+		if !cmp.Equal(statusBefore, xr.Object["status"]) {
+			return result, errors.Wrap(r.client.Status().Update(updateCtx, xr), errUpdateStatus)
+		}
+		return result, nil
+	}
+
+	// We're not in plan mode. If we were, say we're not anymore.
+	if xr.GetCondition(v1.TypePlanned).Status == corev1.ConditionTrue {
+		status.MarkConditions(v1.NotPlanned())
+	}
+
 	// Watch every kind this controller's XRs depend on - the resources they
 	// compose and the resources their functions require. The tracker was
 	// updated with this XR's dependencies as it was composed above.
@@ -892,6 +1138,14 @@ func (r *Reconciler) Reconcile(ctx context.Context, req reconcile.Request) (reco
 		synced = xpv2.ReconcileError(errors.New(errSyncResources)).WithMessage(fmt.Sprintf("Unsynced resources: %s", resource.StableNAndSomeMore(resource.DefaultFirstN, unsynced)))
 	}
 
+	// We composed resources, but didn't garbage collect the ones that are no
+	// longer desired. Someone needs to confirm the deletion.
+	if res.GarbageCollectionBlocked != nil {
+		r.record.Event(xr, event.Warning(reasonGarbageCollection, res.GarbageCollectionBlocked))
+		synced = xpv2.ReconcileError(res.GarbageCollectionBlocked)
+		synced.Reason = reasonGarbageCollectionBlocked
+	}
+
 	ready := xpv2.Available()
 	if len(unready) > 0 {
 		ready = xpv2.Creating().WithMessage(fmt.Sprintf("Unready resources: %s", resource.StableNAndSomeMore(resource.DefaultFirstN, unready)))
@@ -926,6 +1180,12 @@ func (r *Reconciler) Reconcile(ctx context.Context, req reconcile.Request) (reco
 		result = reconcile.Result{RequeueAfter: jitter(res.TTL)}
 	}
 
+	// Make sure we check again when a held rollout might let this XR move
+	// to the latest revision, even if we wouldn't otherwise requeue.
+	if held != nil && !result.Requeue && (result.RequeueAfter == 0 || held.RetryAfter < result.RequeueAfter) {
+		result = reconcile.Result{RequeueAfter: held.RetryAfter}
+	}
+
 	if !cmp.Equal(statusBefore, xr.Object["status"]) {
 		return result, errors.Wrap(r.client.Status().Update(updateCtx, xr), errUpdateStatus)
 	}
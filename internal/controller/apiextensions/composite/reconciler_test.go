@@ -410,6 +410,42 @@ func TestReconcile(t *testing.T) {
 				r: reconcile.Result{},
 			},
 		},
+		"GarbageCollectionBlocked": {
+			reason: "We should mark the XR as not synced if our Composer couldn't garbage collect composed resources.",
+			args: args{
+				c: &test.MockClient{
+					MockGet: test.NewMockGetFn(nil),
+					MockStatusUpdate: WantComposite(t, NewComposite(func(xr *composite.Unstructured) {
+						synced := xpv2.ReconcileError(errBoom)
+						synced.Reason = reasonGarbageCollectionBlocked
+						xr.SetCompositionReference(&corev1.ObjectReference{})
+						xr.SetConditions(v1.WatchCircuitClosed(), synced, xpv2.Available())
+					})),
+				},
+				opts: []ReconcilerOption{
+					WithCompositeFinalizer(resource.NewNopFinalizer()),
+					WithCompositionSelector(CompositionSelectorFn(func(_ context.Context, cr resource.Composite) error {
+						cr.SetCompositionReference(&corev1.ObjectReference{})
+						return nil
+					})),
+					WithCompositionRevisionFetcher(CompositionRevisionFetcherFn(func(_ context.Context, _ resource.Composite) (*v1.CompositionRevision, error) {
+						return NewCompositionRevision(), nil
+					})),
+					WithConfigurator(ConfiguratorFn(func(_ context.Context, _ resource.Composite, _ *v1.CompositionRevision) error {
+						return nil
+					})),
+					WithComposer(ComposerFn(func(_ context.Context, _ *composite.Unstructured, _ CompositionRequest) (CompositionResult, error) {
+						return CompositionResult{GarbageCollectionBlocked: errBoom}, nil
+					})),
+					WithConnectionPublishers(ConnectionPublisherFn(func(_ context.Context, _ ConnectionSecretOwner, _ managed.ConnectionDetails) (published bool, err error) {
+						return false, nil
+					})),
+				},
+			},
+			want: want{
+				r: reconcile.Result{},
+			},
+		},
 		"ComposedResourcesNotReady": {
 			reason: "We should requeue if any of our composed resources are not yet ready.",
 			args: args{
@@ -538,6 +574,257 @@ func TestReconcile(t *testing.T) {
 				r: reconcile.Result{},
 			},
 		},
+		"CompositionRevisionRolloutHeld": {
+			reason: "We should compose using the current revision, and requeue when we might move to the latest revision, if a rollout holds us at our current revision.",
+			args: args{
+				c: &test.MockClient{
+					MockGet: WithComposite(t, NewComposite()),
+					MockStatusUpdate: WantComposite(t, NewComposite(func(cr *composite.Unstructured) {
+						cr.SetCompositionReference(&corev1.ObjectReference{})
+						cr.SetConditions(v1.WatchCircuitClosed(), xpv2.ReconcileSuccess(), xpv2.Available())
+					})),
+				},
+				opts: []ReconcilerOption{
+					WithCompositeFinalizer(resource.NewNopFinalizer()),
+					WithCompositionSelector(CompositionSelectorFn(func(_ context.Context, cr resource.Composite) error {
+						cr.SetCompositionReference(&corev1.ObjectReference{})
+						return nil
+					})),
+					WithCompositionRevisionFetcher(CompositionRevisionFetcherFn(func(_ context.Context, _ resource.Composite) (*v1.CompositionRevision, error) {
+						return NewCompositionRevision(), &RolloutHeldError{Current: "current", Latest: "latest", Reason: "waiting", RetryAfter: 30 * time.Second}
+					})),
+					WithConfigurator(ConfiguratorFn(func(_ context.Context, _ resource.Composite, _ *v1.CompositionRevision) error {
+						return nil
+					})),
+					WithComposer(ComposerFn(func(_ context.Context, _ *composite.Unstructured, _ CompositionRequest) (CompositionResult, error) {
+						return CompositionResult{}, nil
+					})),
+				},
+			},
+			want: want{
+				r: reconcile.Result{RequeueAfter: 30 * time.Second},
+			},
+		},
+		"CompositionRevisionRolledBack": {
+			reason: "We should compose using the revision we rolled back to, and record the rollback in our status.",
+			args: args{
+				c: &test.MockClient{
+					MockGet: WithComposite(t, NewComposite(func(cr *composite.Unstructured) {
+						cr.SetCompositionRevisionReference(&corev1.LocalObjectReference{Name: "cool-rev-2"})
+					})),
+					MockStatusUpdate: WantComposite(t, NewComposite(func(cr *composite.Unstructured) {
+						cr.SetCompositionRevisionReference(&corev1.LocalObjectReference{Name: "cool-rev-2"})
+						cr.SetCompositionReference(&corev1.ObjectReference{})
+						cr.SetConditions(
+							v1.WatchCircuitClosed(),
+							v1.RolledBack("Rolled back from composition revision cool-rev-2 to cool-rev-1, the last revision under which the composite resource was ready. Set the composition update policy to Automatic to update again."),
+							xpv2.ReconcileSuccess(),
+							xpv2.Available(),
+						)
+					})),
+				},
+				opts: []ReconcilerOption{
+					WithCompositeFinalizer(resource.NewNopFinalizer()),
+					WithCompositionSelector(CompositionSelectorFn(func(_ context.Context, cr resource.Composite) error {
+						cr.SetCompositionReference(&corev1.ObjectReference{})
+						return nil
+					})),
+					WithCompositionRevisionFetcher(CompositionRevisionFetcherFn(func(_ context.Context, _ resource.Composite) (*v1.CompositionRevision, error) {
+						rev := NewCompositionRevision()
+						rev.SetName("cool-rev-2")
+						return rev, nil
+					})),
+					WithRevisionRollbacker(RevisionRollbackerFn(func(_ context.Context, _ resource.Composite, _ *v1.CompositionRevision) (*v1.CompositionRevision, error) {
+						rev := NewCompositionRevision()
+						rev.SetName("cool-rev-1")
+						return rev, nil
+					})),
+					WithConfigurator(ConfiguratorFn(func(_ context.Context, _ resource.Composite, _ *v1.CompositionRevision) error {
+						return nil
+					})),
+					WithComposer(ComposerFn(func(_ context.Context, _ *composite.Unstructured, req CompositionRequest) (CompositionResult, error) {
+						if diff := cmp.Diff("cool-rev-1", req.Revision.GetName()); diff != "" {
+							t.Errorf("Compose(...): -want revision, +got revision:\n%s", diff)
+						}
+						return CompositionResult{}, nil
+					})),
+				},
+			},
+			want: want{
+				r: reconcile.Result{},
+			},
+		},
+		"RollbackError": {
+			reason: "We should return any error encountered rolling back the composition revision.",
+			args: args{
+				c: &test.MockClient{
+					MockGet: WithComposite(t, NewComposite(func(cr *composite.Unstructured) {
+						cr.SetCompositionRevisionReference(&corev1.LocalObjectReference{Name: "cool-rev"})
+					})),
+					MockStatusUpdate: WantComposite(t, NewComposite(func(cr *composite.Unstructured) {
+						cr.SetCompositionRevisionReference(&corev1.LocalObjectReference{Name: "cool-rev"})
+						cr.SetCompositionReference(&corev1.ObjectReference{})
+						cr.SetConditions(v1.WatchCircuitClosed(), xpv2.ReconcileError(errors.Wrap(errBoom, errRollback)))
+					})),
+				},
+				opts: []ReconcilerOption{
+					WithCompositeFinalizer(resource.NewNopFinalizer()),
+					WithCompositionSelector(CompositionSelectorFn(func(_ context.Context, cr resource.Composite) error {
+						cr.SetCompositionReference(&corev1.ObjectReference{})
+						return nil
+					})),
+					WithCompositionRevisionFetcher(CompositionRevisionFetcherFn(func(_ context.Context, _ resource.Composite) (*v1.CompositionRevision, error) {
+						return NewCompositionRevision(), nil
+					})),
+					WithRevisionRollbacker(RevisionRollbackerFn(func(_ context.Context, _ resource.Composite, _ *v1.CompositionRevision) (*v1.CompositionRevision, error) {
+						return nil, errBoom
+					})),
+				},
+			},
+			want: want{
+				r:   reconcile.Result{},
+				err: cmpopts.AnyError,
+			},
+		},
+		"PlanWithoutComposition": {
+			reason: "We should return an error if we can't plan a composite resource because it hasn't selected a Composition, rather than selecting one.",
+			args: args{
+				c: &test.MockClient{
+					MockGet: WithComposite(t, NewComposite(func(cr *composite.Unstructured) {
+						cr.SetAnnotations(map[string]string{v1.AnnotationKeyCompositionPlan: "true"})
+					})),
+					MockStatusUpdate: WantComposite(t, NewComposite(func(cr *composite.Unstructured) {
+						cr.SetAnnotations(map[string]string{v1.AnnotationKeyCompositionPlan: "true"})
+						cr.SetConditions(v1.WatchCircuitClosed(), xpv2.ReconcileError(errors.New(errPlanNoComp)))
+					})),
+				},
+				opts: []ReconcilerOption{
+					WithCompositeFinalizer(resource.NewNopFinalizer()),
+					// We'd return an error if we tried to select a Composition.
+					WithCompositionSelector(CompositionSelectorFn(func(_ context.Context, _ resource.Composite) error {
+						return errBoom
+					})),
+				},
+			},
+			want: want{
+				r:   reconcile.Result{},
+				err: cmpopts.AnyError,
+			},
+		},
+		"Planned": {
+			reason: "In plan mode we should resolve the composition revision without selecting or fetching it, and report the plan without rolling back or configuring the composite resource.",
+			args: args{
+				c: &test.MockClient{
+					MockGet: WithComposite(t, NewComposite(func(cr *composite.Unstructured) {
+						cr.SetAnnotations(map[string]string{v1.AnnotationKeyCompositionPlan: "true"})
+						cr.SetCompositionReference(&corev1.ObjectReference{Name: "cool-comp"})
+						cr.SetCompositionRevisionReference(&corev1.LocalObjectReference{Name: "cool-rev"})
+					})),
+					MockStatusUpdate: WantComposite(t, NewComposite(func(cr *composite.Unstructured) {
+						cr.SetAnnotations(map[string]string{v1.AnnotationKeyCompositionPlan: "true"})
+						cr.SetCompositionReference(&corev1.ObjectReference{Name: "cool-comp"})
+						cr.SetCompositionRevisionReference(&corev1.LocalObjectReference{Name: "cool-rev"})
+						cr.SetConditions(
+							v1.WatchCircuitClosed(),
+							v1.Planned(`Composition would create 1, update 0, delete 0, and orphan 0 composed resources: create "bucket" (Bucket cool-xr-bucket)`),
+						)
+					})),
+				},
+				opts: []ReconcilerOption{
+					WithCompositeFinalizer(resource.NewNopFinalizer()),
+					// We'd return an error if we tried to select a
+					// Composition or CompositionRevision, fetch a
+					// CompositionRevision, roll back, or configure the
+					// composite resource.
+					WithCompositionSelector(CompositionSelectorFn(func(_ context.Context, _ resource.Composite) error {
+						return errBoom
+					})),
+					WithCompositionRevisionSelector(CompositionRevisionSelectorFn(func(_ context.Context, _ resource.Composite) error {
+						return errBoom
+					})),
+					WithCompositionRevisionFetcher(CompositionRevisionFetcherFn(func(_ context.Context, _ resource.Composite) (*v1.CompositionRevision, error) {
+						return nil, errBoom
+					})),
+					WithCompositionRevisionResolver(CompositionRevisionResolverFn(func(_ context.Context, _ resource.Composite) (*v1.CompositionRevision, error) {
+						rev := NewCompositionRevision()
+						rev.SetName("cool-rev-2")
+						return rev, nil
+					})),
+					WithRevisionRollbacker(RevisionRollbackerFn(func(_ context.Context, _ resource.Composite, _ *v1.CompositionRevision) (*v1.CompositionRevision, error) {
+						return nil, errBoom
+					})),
+					WithConfigurator(ConfiguratorFn(func(_ context.Context, _ resource.Composite, _ *v1.CompositionRevision) error {
+						return errBoom
+					})),
+					WithComposer(ComposerFn(func(_ context.Context, _ *composite.Unstructured, req CompositionRequest) (CompositionResult, error) {
+						if diff := cmp.Diff("cool-rev-2", req.Revision.GetName()); diff != "" {
+							t.Errorf("Compose(...): -want revision, +got revision:\n%s", diff)
+						}
+						if !req.Plan {
+							t.Errorf("Compose(...): want plan request, got %+v", req)
+						}
+						return CompositionResult{Plan: &CompositionPlan{Changes: []PlannedChange{
+							{Action: PlannedActionCreate, ResourceName: "bucket", Resource: corev1.ObjectReference{Kind: "Bucket", Name: "cool-xr-bucket"}},
+						}}}, nil
+					})),
+					WithRecorder(newTestRecorder(
+						eventArgs{
+							Kind: compositeKind,
+							Event: event.Event{
+								Type:    event.Type(corev1.EventTypeNormal),
+								Reason:  reasonPlan,
+								Message: `Composition would create 1, update 0, delete 0, and orphan 0 composed resources: create "bucket" (Bucket cool-xr-bucket)`,
+								Annotations: map[string]string{
+									v1.AnnotationKeyCompositionPlan: `{"changes":[{"action":"Create","resourceName":"bucket","resource":{"kind":"Bucket","name":"cool-xr-bucket"}}]}`,
+								},
+							},
+						},
+					)),
+				},
+			},
+			want: want{
+				r: reconcile.Result{},
+			},
+		},
+		"RollbackSkippedAfterRevisionUpdate": {
+			reason: "We shouldn't roll back a composite resource that just updated to a new revision, because its conditions describe the revision it updated from.",
+			args: args{
+				c: &test.MockClient{
+					MockGet: WithComposite(t, NewComposite(func(cr *composite.Unstructured) {
+						cr.SetCompositionRevisionReference(&corev1.LocalObjectReference{Name: "cool-rev-1"})
+					})),
+					MockStatusUpdate: WantComposite(t, NewComposite(func(cr *composite.Unstructured) {
+						cr.SetCompositionRevisionReference(&corev1.LocalObjectReference{Name: "cool-rev-2"})
+						cr.SetCompositionReference(&corev1.ObjectReference{})
+						cr.SetConditions(v1.WatchCircuitClosed(), xpv2.ReconcileSuccess(), xpv2.Available())
+					})),
+				},
+				opts: []ReconcilerOption{
+					WithCompositeFinalizer(resource.NewNopFinalizer()),
+					WithCompositionSelector(CompositionSelectorFn(func(_ context.Context, cr resource.Composite) error {
+						cr.SetCompositionReference(&corev1.ObjectReference{})
+						return nil
+					})),
+					WithCompositionRevisionFetcher(CompositionRevisionFetcherFn(func(_ context.Context, cr resource.Composite) (*v1.CompositionRevision, error) {
+						cr.SetCompositionRevisionReference(&corev1.LocalObjectReference{Name: "cool-rev-2"})
+						return NewCompositionRevision(), nil
+					})),
+					// We'd return an error if we tried to roll back.
+					WithRevisionRollbacker(RevisionRollbackerFn(func(_ context.Context, _ resource.Composite, _ *v1.CompositionRevision) (*v1.CompositionRevision, error) {
+						return nil, errBoom
+					})),
+					WithConfigurator(ConfiguratorFn(func(_ context.Context, _ resource.Composite, _ *v1.CompositionRevision) error {
+						return nil
+					})),
+					WithComposer(ComposerFn(func(_ context.Context, _ *composite.Unstructured, _ CompositionRequest) (CompositionResult, error) {
+						return CompositionResult{}, nil
+					})),
+				},
+			},
+			want: want{
+				r: reconcile.Result{},
+			},
+		},
 		"ReconciliationPausedSuccessful": {
 			reason: `If a composite resource has the pause annotation with value "true", there should be no further requeue requests.`,
 			args: args{
@@ -1432,6 +1719,77 @@ func TestEffectivePollInterval(t *testing.T) {
 	}
 }
 
+func TestCompositionPlanString(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		plan   *CompositionPlan
+		want   string
+	}{
+		"NoChanges": {
+			reason: "A plan with no changes should only summarize the counts.",
+			plan:   &CompositionPlan{},
+			want:   "Composition would create 0, update 0, delete 0, and orphan 0 composed resources",
+		},
+		"Changes": {
+			reason: "A plan with changes should summarize the counts and describe each change.",
+			plan: &CompositionPlan{
+				Changes: []PlannedChange{
+					{Action: PlannedActionCreate, ResourceName: "bucket", Resource: corev1.ObjectReference{Kind: "Bucket", Name: "cool-xr-bucket"}},
+					{Action: PlannedActionDelete, ResourceName: "config", Resource: corev1.ObjectReference{Kind: "ConfigMap", Namespace: "default", Name: "cool-xr-config"}},
+				},
+			},
+			want: `Composition would create 1, update 0, delete 1, and orphan 0 composed resources: ` +
+				`create "bucket" (Bucket cool-xr-bucket); delete "config" (ConfigMap default/cool-xr-config)`,
+		},
+		"ChangeWithNote": {
+			reason: "A change composition couldn't fully plan should include its note.",
+			plan: &CompositionPlan{
+				Changes: []PlannedChange{
+					{Action: PlannedActionUpdate, ResourceName: "bucket", Resource: corev1.ObjectReference{Kind: "Bucket", Name: "cool-xr-bucket"}, Note: "cool note"},
+				},
+			},
+			want: `Composition would create 0, update 1, delete 0, and orphan 0 composed resources: ` +
+				`update "bucket" (Bucket cool-xr-bucket) - cool note`,
+		},
+		"ManyChanges": {
+			reason: "A plan with many changes should count them all, but only describe the first few.",
+			plan: func() *CompositionPlan {
+				p := &CompositionPlan{}
+				for i := range maxPlannedChanges + 2 {
+					p.Changes = append(p.Changes, PlannedChange{Action: PlannedActionCreate, ResourceName: ResourceName(fmt.Sprintf("r%02d", i)), Resource: corev1.ObjectReference{Kind: "Bucket", Name: fmt.Sprintf("b%02d", i)}})
+				}
+				return p
+			}(),
+			want: `Composition would create 12, update 0, delete 0, and orphan 0 composed resources: ` +
+				`create "r00" (Bucket b00); create "r01" (Bucket b01); create "r02" (Bucket b02); create "r03" (Bucket b03); ` +
+				`create "r04" (Bucket b04); create "r05" (Bucket b05); create "r06" (Bucket b06); create "r07" (Bucket b07); ` +
+				`create "r08" (Bucket b08); create "r09" (Bucket b09); and 2 more`,
+		},
+		"GarbageCollectionBlocked": {
+			reason: "A plan whose deletions garbage collection limits would block should say so.",
+			plan: &CompositionPlan{
+				Changes: []PlannedChange{
+					{Action: PlannedActionDelete, ResourceName: "bucket", Resource: corev1.ObjectReference{Kind: "Bucket", Name: "cool-xr-bucket"}, Note: "blocked"},
+				},
+				GarbageCollectionBlocked: &GarbageCollectionBlockedError{Resources: []ResourceName{"bucket"}, Composed: 1, Token: "cool-token"},
+			},
+			want: `Composition would create 0, update 0, delete 1, and orphan 0 composed resources: ` +
+				`delete "bucket" (Bucket cool-xr-bucket) - blocked. ` +
+				`Garbage collection would be blocked: refusing to delete 1 of 1 composed resources (bucket) because that would exceed the Composition's garbage collection limits; ` +
+				`set the crossplane.io/confirm-garbage-collection annotation to "cool-token" to confirm the deletion`,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := tc.plan.String()
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\np.String(): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
 func TestReconcilePollIntervalAnnotation(t *testing.T) {
 	now := metav1.Now()
 
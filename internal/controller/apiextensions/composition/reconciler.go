@@ -52,12 +52,15 @@ const (
 	errOwnRev          = "cannot own CompositionRevision"
 	errUpdateRevStatus = "cannot update CompositionRevision status"
 	errUpdateRevSpec   = "cannot update CompositionRevision spec"
+	errListComposites  = "cannot list composite resources"
+	errRollout         = "cannot roll out CompositionRevision"
 )
 
 // Event reasons.
 const (
 	reasonCreateRev event.Reason = "CreateRevision"
 	reasonUpdateRev event.Reason = "UpdateRevision"
+	reasonRollout   event.Reason = "RolloutRevision"
 )
 
 // Setup adds a controller that reconciles Compositions by creating new
@@ -100,6 +103,7 @@ func NewReconciler(mgr manager.Manager, opts ...ReconcilerOption) *Reconciler {
 		client: mgr.GetClient(),
 		log:    logging.NewNopLogger(),
 		record: event.NewNopRecorder(),
+		now:    time.Now,
 	}
 
 	for _, f := range opts {
@@ -116,6 +120,7 @@ type Reconciler struct {
 
 	log    logging.Logger
 	record event.Recorder
+	now    func() time.Time
 }
 
 // Reconcile a Composition.
@@ -217,7 +222,24 @@ func (r *Reconciler) Reconcile(ctx context.Context, req reconcile.Request) (reco
 	// We start from revision 1, so 0 indicates we didn't find one.
 	if existingRev > 0 {
 		log.Debug("No new revision needed.", "current-revision", existingRev)
-		return reconcile.Result{}, nil
+
+		// Progress the rollout of our latest revision, if any. We'll start
+		// rolling out a new revision when we reconcile it after creating it.
+		after, err := r.rollout(ctx, comp, v1.LatestRevision(comp, rl.Items))
+		if err != nil {
+			log.Debug(errRollout, "error", err)
+
+			if kerrors.IsConflict(err) {
+				return reconcile.Result{Requeue: true}, nil
+			}
+
+			err = errors.Wrap(err, errRollout)
+			r.record.Event(comp, event.Warning(reasonRollout, err))
+
+			return reconcile.Result{}, err
+		}
+
+		return reconcile.Result{RequeueAfter: after}, nil
 	}
 
 	if err := r.client.Create(ctx, NewCompositionRevision(comp, latestRev+1)); err != nil {
@@ -0,0 +1,215 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package composition
+
+import (
+	"context"
+	"math"
+	"slices"
+	"strconv"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kunstructured "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/event"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/resource/unstructured/composite"
+
+	v1 "github.com/crossplane/crossplane/apis/v2/apiextensions/v1"
+	xpv2 "github.com/crossplane/crossplane/apis/v2/core/v2"
+)
+
+// Rollout strategy defaults. These match the defaults of the RolloutStrategy
+// API type.
+const (
+	defaultRolloutCanaryPercent       = 10
+	defaultRolloutBatchSize           = 50
+	defaultRolloutPause               = 5 * time.Minute
+	defaultRolloutMaxUnhealthyPercent = 10
+
+	// How long an XR that uses the latest revision may be unready before it
+	// counts as unhealthy, unless the latest revision's rollback policy
+	// configures a ready grace period. This matches the default of the
+	// Rollback API type's ready grace period.
+	defaultRolloutReadyGracePeriod = 5 * time.Minute
+
+	// How long to wait before checking whether a halted rollout may resume.
+	rolloutHaltedRetryAfter = 1 * time.Minute
+
+	// The shortest time to wait before starting the next wave, e.g. when
+	// a rollout doesn't pause between waves.
+	rolloutMinRetryAfter = 1 * time.Second
+)
+
+// rollout progresses the rollout of the supplied latest revision of the
+// supplied Composition, and records its progress in the revision's status. It
+// returns how long to wait before progressing the rollout again, or zero if
+// the rollout is complete.
+//
+// A rollout moves the composite resources (XRs) that use a Composition and
+// automatically update to its latest revision in waves, in the order returned
+// by v1.RolloutOrder. The first (canary) wave starts when the rollout starts.
+// Each subsequent wave starts a pause after the previous wave started. No wave
+// starts while too many XRs that already use the latest revision have been
+// unready for longer than a grace period. XRs are often briefly unready after
+// they move to a new revision.
+//
+// We list the Composition's XRs once per wave, rather than once per XR. Each
+// XR only needs the revision's status to tell whether it may move.
+func (r *Reconciler) rollout(ctx context.Context, comp *v1.Composition, latest *v1.CompositionRevision) (time.Duration, error) {
+	ro := comp.Spec.Rollout
+	if ro == nil || latest == nil {
+		return 0, nil
+	}
+	if st := latest.Status.Rollout; st != nil && st.Complete {
+		return 0, nil
+	}
+
+	gvk := schema.FromAPIVersionAndKind(comp.Spec.CompositeTypeRef.APIVersion, comp.Spec.CompositeTypeRef.Kind)
+	xrs := &kunstructured.UnstructuredList{}
+	xrs.SetGroupVersionKind(gvk.GroupVersion().WithKind(gvk.Kind + "List"))
+	if err := r.client.List(ctx, xrs); err != nil {
+		return 0, errors.Wrap(err, errListComposites)
+	}
+
+	grace := defaultRolloutReadyGracePeriod
+	if rb := latest.Spec.Rollback; rb != nil && rb.ReadyGracePeriodSeconds != nil {
+		grace = time.Duration(*rb.ReadyGracePeriodSeconds) * time.Second
+	}
+
+	now := r.now()
+	orders := make([]string, 0, len(xrs.Items))
+	updated, unready := 0, 0
+	for _, u := range xrs.Items {
+		xr := asComposite(u)
+
+		// We only care about XRs that would automatically update to the
+		// latest revision of this Composition.
+		if ref := xr.GetCompositionReference(); ref == nil || ref.Name != comp.GetName() {
+			continue
+		}
+		if pol := xr.GetCompositionUpdatePolicy(); pol != nil && *pol == xpv2.UpdateManual {
+			continue
+		}
+		orders = append(orders, v1.RolloutOrder(latest, xr.GetUID()))
+
+		if ref := xr.GetCompositionRevisionReference(); ref != nil && ref.Name == latest.GetName() {
+			updated++
+			if unhealthy(xr, grace, now) {
+				unready++
+			}
+		}
+	}
+	slices.Sort(orders)
+
+	canary := int32(defaultRolloutCanaryPercent)
+	if ro.CanaryPercent != nil {
+		canary = *ro.CanaryPercent
+	}
+	batch := int32(defaultRolloutBatchSize)
+	if ro.BatchSize != nil && *ro.BatchSize > 0 {
+		batch = *ro.BatchSize
+	}
+	pause := defaultRolloutPause
+	if ro.PauseSeconds != nil {
+		pause = time.Duration(*ro.PauseSeconds) * time.Second
+	}
+	maxUnhealthy := int32(defaultRolloutMaxUnhealthyPercent)
+	if ro.MaxUnhealthyPercent != nil {
+		maxUnhealthy = *ro.MaxUnhealthyPercent
+	}
+
+	st := latest.Status.Rollout.DeepCopy()
+	halted := updated > 0 && unready*100 > int(maxUnhealthy)*updated
+	started := true
+
+	switch {
+	case st == nil:
+		// Start the first (canary) wave.
+		st = &v1.RolloutStatus{Wave: 0, WaveStartTime: metav1.NewTime(now)}
+	case halted:
+		// Don't start the next wave while the rollout is halted.
+		started = false
+	case !now.Before(st.WaveStartTime.Add(pause)):
+		// Start the next wave. We start one wave at a time, so a rollout
+		// that was halted for longer than a pause doesn't start several
+		// overdue waves at once.
+		st.Wave++
+		st.WaveStartTime = metav1.NewTime(now)
+	default:
+		started = false
+	}
+	st.Halted = halted
+
+	// At least one XR moves in the canary wave. XRs may come and go during
+	// the rollout, so we never move the rollout order backward.
+	canaries := max(int(math.Ceil(float64(len(orders))*float64(canary)/100)), 1)
+	n := min(canaries+int(st.Wave)*int(batch), len(orders))
+	if n > 0 && orders[n-1] > st.Through {
+		st.Through = orders[n-1]
+	}
+	st.Complete = n == len(orders) && !halted
+
+	if !equality.Semantic.DeepEqual(latest.Status.Rollout, st) {
+		latest.Status.Rollout = st
+		if err := r.client.Status().Update(ctx, latest); err != nil {
+			return 0, errors.Wrap(err, errUpdateRevStatus)
+		}
+	}
+	if started {
+		r.record.Event(comp, event.Normal(reasonRollout, "Started rollout wave", "revision", latest.GetName(), "wave", strconv.Itoa(int(st.Wave))))
+	}
+
+	switch {
+	case st.Complete:
+		return 0, nil
+	case st.Halted:
+		return rolloutHaltedRetryAfter, nil
+	default:
+		return max(st.WaveStartTime.Add(pause).Sub(now), rolloutMinRetryAfter), nil
+	}
+}
+
+// unhealthy returns true if the supplied XR has been unready for longer than
+// the supplied grace period, measured from when it last became unready or last
+// moved to a new revision, whichever is later.
+func unhealthy(xr *composite.Unstructured, grace time.Duration, now time.Time) bool {
+	c := xr.GetCondition(xpv2.TypeReady)
+	if c.Status != corev1.ConditionFalse {
+		return false
+	}
+	since := c.LastTransitionTime.Time
+	if t, err := time.Parse(time.RFC3339, xr.GetAnnotations()[v1.AnnotationKeyCompositionRevisionUpdateTime]); err == nil && t.After(since) {
+		since = t
+	}
+	return now.Sub(since) > grace
+}
+
+// asComposite returns the supplied composite resource using the schema under
+// which it references a Composition. Only legacy cluster scoped composite
+// resources use the legacy schema.
+func asComposite(u kunstructured.Unstructured) *composite.Unstructured {
+	xr := &composite.Unstructured{Unstructured: u, Schema: composite.SchemaModern}
+	if xr.GetCompositionReference() == nil {
+		xr.Schema = composite.SchemaLegacy
+	}
+	return xr
+}
@@ -0,0 +1,340 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package composition
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kunstructured "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/event"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/resource/unstructured/composite"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/test"
+
+	v1 "github.com/crossplane/crossplane/apis/v2/apiextensions/v1"
+	xpv2 "github.com/crossplane/crossplane/apis/v2/core/v2"
+)
+
+func TestRollout(t *testing.T) {
+	errBoom := errors.New("boom")
+	now := time.Now().Truncate(time.Second)
+	pause := 5 * time.Minute
+
+	comp := &v1.Composition{
+		ObjectMeta: metav1.ObjectMeta{Name: "cool-composition"},
+		Spec: v1.CompositionSpec{
+			CompositeTypeRef: v1.TypeReference{APIVersion: "example.org/v1", Kind: "XCool"},
+			Rollout: &v1.RolloutStrategy{
+				CanaryPercent:       ptr.To[int32](25),
+				BatchSize:           ptr.To[int32](2),
+				PauseSeconds:        ptr.To(int64(pause.Seconds())),
+				MaxUnhealthyPercent: ptr.To[int32](10),
+			},
+		},
+	}
+	latest := func(st *v1.RolloutStatus) *v1.CompositionRevision {
+		return &v1.CompositionRevision{
+			ObjectMeta: metav1.ObjectMeta{Name: "cool-composition-new"},
+			Status:     v1.CompositionRevisionStatus{Rollout: st},
+		}
+	}
+
+	// An XR that uses our Composition.
+	xr := func(uid, rev string, ready corev1.ConditionStatus) kunstructured.Unstructured {
+		u := composite.New()
+		u.SetUID(types.UID(uid))
+		u.SetCompositionReference(&corev1.ObjectReference{Name: comp.GetName()})
+		u.SetCompositionRevisionReference(&corev1.LocalObjectReference{Name: rev})
+		u.SetConditions(xpv2.Condition{Type: xpv2.TypeReady, Status: ready})
+		return u.Unstructured
+	}
+	// An XR that moved to the latest revision, and became unready, at the
+	// supplied times.
+	settling := func(uid string, moved, unready time.Time) kunstructured.Unstructured {
+		u := composite.New()
+		u.SetUID(types.UID(uid))
+		u.SetCompositionReference(&corev1.ObjectReference{Name: comp.GetName()})
+		u.SetCompositionRevisionReference(&corev1.LocalObjectReference{Name: "cool-composition-new"})
+		if !moved.IsZero() {
+			u.SetAnnotations(map[string]string{v1.AnnotationKeyCompositionRevisionUpdateTime: moved.UTC().Format(time.RFC3339)})
+		}
+		u.SetConditions(xpv2.Condition{Type: xpv2.TypeReady, Status: corev1.ConditionFalse, LastTransitionTime: metav1.NewTime(unready)})
+		return u.Unstructured
+	}
+	fleet := []kunstructured.Unstructured{
+		xr("a", "cool-composition-old", corev1.ConditionTrue),
+		xr("b", "cool-composition-old", corev1.ConditionTrue),
+		xr("c", "cool-composition-old", corev1.ConditionTrue),
+		xr("d", "cool-composition-old", corev1.ConditionTrue),
+	}
+
+	// The fleet in rollout order.
+	orders := make([]string, 0, len(fleet))
+	for _, u := range fleet {
+		orders = append(orders, v1.RolloutOrder(latest(nil), u.GetUID()))
+	}
+	slices.Sort(orders)
+
+	list := func(items ...kunstructured.Unstructured) test.MockListFn {
+		return test.NewMockListFn(nil, func(obj client.ObjectList) error {
+			obj.(*kunstructured.UnstructuredList).Items = items //nolint:forcetypeassert // We only list unstructured XRs.
+			return nil
+		})
+	}
+
+	type args struct {
+		client client.Client
+		comp   *v1.Composition
+		latest *v1.CompositionRevision
+	}
+
+	type want struct {
+		after time.Duration
+		st    *v1.RolloutStatus
+		err   error
+	}
+
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   want
+	}{
+		"NoRollout": {
+			reason: "We shouldn't do anything if the Composition doesn't have a rollout strategy.",
+			args: args{
+				client: &test.MockClient{},
+				comp:   &v1.Composition{},
+				latest: latest(nil),
+			},
+			want: want{},
+		},
+		"RolloutComplete": {
+			reason: "We shouldn't do anything if the rollout is complete.",
+			args: args{
+				client: &test.MockClient{},
+				comp:   comp,
+				latest: latest(&v1.RolloutStatus{Complete: true}),
+			},
+			want: want{
+				st: &v1.RolloutStatus{Complete: true},
+			},
+		},
+		"ListCompositesError": {
+			reason: "We should return any error encountered listing composite resources.",
+			args: args{
+				client: &test.MockClient{MockList: test.NewMockListFn(errBoom)},
+				comp:   comp,
+				latest: latest(nil),
+			},
+			want: want{
+				err: errors.Wrap(errBoom, errListComposites),
+			},
+		},
+		"UpdateStatusError": {
+			reason: "We should return any error encountered recording the rollout's progress.",
+			args: args{
+				client: &test.MockClient{
+					MockList:         list(fleet...),
+					MockStatusUpdate: test.NewMockSubResourceUpdateFn(errBoom),
+				},
+				comp:   comp,
+				latest: latest(nil),
+			},
+			want: want{
+				st:  &v1.RolloutStatus{Wave: 0, WaveStartTime: metav1.NewTime(now), Through: orders[0]},
+				err: errors.Wrap(errBoom, errUpdateRevStatus),
+			},
+		},
+		"StartCanaryWave": {
+			reason: "We should start the canary wave now if the rollout hasn't started.",
+			args: args{
+				client: &test.MockClient{
+					MockList:         list(fleet...),
+					MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil),
+				},
+				comp:   comp,
+				latest: latest(nil),
+			},
+			want: want{
+				after: pause,
+				st:    &v1.RolloutStatus{Wave: 0, WaveStartTime: metav1.NewTime(now), Through: orders[0]},
+			},
+		},
+		"WaveNotDue": {
+			reason: "We shouldn't start the next wave until a pause after the latest wave started.",
+			args: args{
+				// We'd return an error if we tried to update the status.
+				client: &test.MockClient{
+					MockList:         list(fleet...),
+					MockStatusUpdate: test.NewMockSubResourceUpdateFn(errBoom),
+				},
+				comp:   comp,
+				latest: latest(&v1.RolloutStatus{Wave: 0, WaveStartTime: metav1.NewTime(now.Add(-time.Minute)), Through: orders[0]}),
+			},
+			want: want{
+				after: pause - time.Minute,
+				st:    &v1.RolloutStatus{Wave: 0, WaveStartTime: metav1.NewTime(now.Add(-time.Minute)), Through: orders[0]},
+			},
+		},
+		"StartNextWave": {
+			reason: "We should start the next wave now, not when it was due, if a pause passed since the latest wave started.",
+			args: args{
+				client: &test.MockClient{
+					MockList:         list(fleet...),
+					MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil),
+				},
+				comp:   comp,
+				latest: latest(&v1.RolloutStatus{Wave: 0, WaveStartTime: metav1.NewTime(now.Add(-3 * pause)), Through: orders[0]}),
+			},
+			want: want{
+				after: pause,
+				st:    &v1.RolloutStatus{Wave: 1, WaveStartTime: metav1.NewTime(now), Through: orders[2]},
+			},
+		},
+		"Halted": {
+			reason: "We shouldn't start the next wave while too many composite resources using the latest revision aren't ready.",
+			args: args{
+				client: &test.MockClient{
+					MockList: list(
+						xr("a", "cool-composition-new", corev1.ConditionFalse),
+						xr("b", "cool-composition-old", corev1.ConditionTrue),
+						xr("c", "cool-composition-old", corev1.ConditionTrue),
+						xr("d", "cool-composition-old", corev1.ConditionTrue),
+					),
+					MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil),
+				},
+				comp:   comp,
+				latest: latest(&v1.RolloutStatus{Wave: 0, WaveStartTime: metav1.NewTime(now.Add(-3 * pause)), Through: orders[0]}),
+			},
+			want: want{
+				after: rolloutHaltedRetryAfter,
+				st:    &v1.RolloutStatus{Wave: 0, WaveStartTime: metav1.NewTime(now.Add(-3 * pause)), Through: orders[0], Halted: true},
+			},
+		},
+		"NotHaltedWhileBecomingReady": {
+			reason: "We shouldn't count a composite resource that became unready within the ready grace period as unhealthy.",
+			args: args{
+				client: &test.MockClient{
+					MockList: list(
+						settling("a", time.Time{}, now.Add(-time.Minute)),
+						xr("b", "cool-composition-old", corev1.ConditionTrue),
+						xr("c", "cool-composition-old", corev1.ConditionTrue),
+						xr("d", "cool-composition-old", corev1.ConditionTrue),
+					),
+					MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil),
+				},
+				comp:   comp,
+				latest: latest(&v1.RolloutStatus{Wave: 0, WaveStartTime: metav1.NewTime(now.Add(-3 * pause)), Through: orders[0]}),
+			},
+			want: want{
+				after: pause,
+				st:    &v1.RolloutStatus{Wave: 1, WaveStartTime: metav1.NewTime(now), Through: orders[2]},
+			},
+		},
+		"NotHaltedAfterRecentMove": {
+			reason: "We shouldn't count a composite resource that moved to the latest revision within the ready grace period as unhealthy, even if it was unready before it moved.",
+			args: args{
+				client: &test.MockClient{
+					MockList: list(
+						settling("a", now.Add(-time.Minute), now.Add(-time.Hour)),
+						xr("b", "cool-composition-old", corev1.ConditionTrue),
+						xr("c", "cool-composition-old", corev1.ConditionTrue),
+						xr("d", "cool-composition-old", corev1.ConditionTrue),
+					),
+					MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil),
+				},
+				comp:   comp,
+				latest: latest(&v1.RolloutStatus{Wave: 0, WaveStartTime: metav1.NewTime(now.Add(-3 * pause)), Through: orders[0]}),
+			},
+			want: want{
+				after: pause,
+				st:    &v1.RolloutStatus{Wave: 1, WaveStartTime: metav1.NewTime(now), Through: orders[2]},
+			},
+		},
+		"HaltedPastGracePeriod": {
+			reason: "We should count a composite resource that has been unready for longer than the latest revision's ready grace period as unhealthy.",
+			args: args{
+				client: &test.MockClient{
+					MockList: list(
+						settling("a", now.Add(-2*time.Minute), now.Add(-2*time.Minute)),
+						xr("b", "cool-composition-old", corev1.ConditionTrue),
+						xr("c", "cool-composition-old", corev1.ConditionTrue),
+						xr("d", "cool-composition-old", corev1.ConditionTrue),
+					),
+					MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil),
+				},
+				comp: comp,
+				latest: func() *v1.CompositionRevision {
+					rev := latest(&v1.RolloutStatus{Wave: 0, WaveStartTime: metav1.NewTime(now.Add(-3 * pause)), Through: orders[0]})
+					rev.Spec.Rollback = &v1.Rollback{Policy: v1.RollbackPolicyAutomatic, ReadyGracePeriodSeconds: ptr.To[int64](60)}
+					return rev
+				}(),
+			},
+			want: want{
+				after: rolloutHaltedRetryAfter,
+				st:    &v1.RolloutStatus{Wave: 0, WaveStartTime: metav1.NewTime(now.Add(-3 * pause)), Through: orders[0], Halted: true},
+			},
+		},
+		"Complete": {
+			reason: "We should complete the rollout once the started waves include every composite resource.",
+			args: args{
+				client: &test.MockClient{
+					MockList:         list(fleet...),
+					MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil),
+				},
+				comp:   comp,
+				latest: latest(&v1.RolloutStatus{Wave: 1, WaveStartTime: metav1.NewTime(now.Add(-pause)), Through: orders[2]}),
+			},
+			want: want{
+				st: &v1.RolloutStatus{Wave: 2, WaveStartTime: metav1.NewTime(now), Through: orders[3], Complete: true},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			r := &Reconciler{
+				client: tc.args.client,
+				log:    logging.NewNopLogger(),
+				record: event.NewNopRecorder(),
+				now:    func() time.Time { return now },
+			}
+
+			after, err := r.rollout(context.Background(), tc.args.comp, tc.args.latest)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nr.rollout(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.after, after); diff != "" {
+				t.Errorf("\n%s\nr.rollout(...): -want, +got:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.st, tc.args.latest.Status.Rollout); diff != "" {
+				t.Errorf("\n%s\nr.rollout(...): -want rollout status, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -279,6 +279,12 @@ func (r *Reconciler) Reconcile(ctx context.Context, req reconcile.Request) (reco
 		// Add step metadata to context for use by downstream components like InspectedRunner.
 		stepCtx := step.ContextWithStepMetaForOperations(ctx, traceID, fn.Step, int32(stepIndex), op.GetName(), string(op.GetUID()))
 
+		// Limit how many times the function may be called to satisfy its
+		// requirements, if the step configures a limit.
+		if fn.Requirements != nil && fn.Requirements.MaxIterations != nil {
+			stepCtx = xfn.ContextWithMaxRequirementsIterations(stepCtx, *fn.Requirements.MaxIterations)
+		}
+
 		rsp, err := r.pipeline.RunFunction(stepCtx, fn.FunctionRef.Name, req)
 		if err != nil {
 			op.Status.Failures++
@@ -286,7 +292,7 @@ func (r *Reconciler) Reconcile(ctx context.Context, req reconcile.Request) (reco
 			log.Debug("Cannot run operation pipeline step", "error", err, "failures", op.Status.Failures)
 			err = errors.Wrapf(err, "failed to invoke pipeline step %q", fn.Step)
 			r.record.Event(op, event.Warning(reasonFunctionInvocation, err))
-			status.MarkConditions(xpv2.ReconcileError(err))
+			status.MarkConditions(xfn.ReconcileError(err))
 			_ = r.client.Status().Update(ctx, op)
 
 			return reconcile.Result{}, err
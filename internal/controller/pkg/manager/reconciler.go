@@ -462,6 +462,14 @@ func (r *Reconciler) Reconcile(ctx context.Context, req reconcile.Request) (reco
 	pr.SetCommonLabels(p.GetCommonLabels())
 	pr.SetCommonAnnotations(p.GetCommonAnnotations())
 
+	// Function runners read the call policy from the active revision, so they
+	// don't need to fetch the Function for every call.
+	if f, ok := p.(*v1.Function); ok {
+		if fr, ok := pr.(*v1.FunctionRevision); ok {
+			fr.Spec.CallPolicy = f.Spec.CallPolicy.DeepCopy()
+		}
+	}
+
 	if r.setPackageRuntimeManagedFields != nil {
 		r.setPackageRuntimeManagedFields(p, pr)
 	}
@@ -20,6 +20,7 @@ import (
 	"context"
 	"io"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	corev1 "k8s.io/api/core/v1"
@@ -306,6 +307,56 @@ func TestReconcile(t *testing.T) {
 				r: reconcile.Result{Requeue: false},
 			},
 		},
+		"SuccessfulFunctionCallPolicyPropagated": {
+			reason: "We should propagate a Function's call policy to its revision.",
+			args: args{
+				req: reconcile.Request{NamespacedName: types.NamespacedName{Name: "test"}},
+				rec: &Reconciler{
+					newPackage:             func() v1.Package { return &v1.Function{} },
+					newPackageRevision:     func() v1.PackageRevision { return &v1.FunctionRevision{} },
+					newPackageRevisionList: func() v1.PackageRevisionList { return &v1.FunctionRevisionList{} },
+					kube: resource.ClientApplicator{
+						Client: &test.MockClient{
+							MockGet: test.NewMockGetFn(nil, func(o client.Object) error {
+								p := o.(*v1.Function)
+								p.SetName("test")
+								p.SetGroupVersionKind(v1.FunctionGroupVersionKind)
+								p.Spec.CallPolicy = &v1.FunctionCallPolicy{
+									Timeout: &metav1.Duration{Duration: 30 * time.Second},
+								}
+								return nil
+							}),
+							MockList:         test.NewMockListFn(kerrors.NewNotFound(schema.GroupResource{}, "")),
+							MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil),
+						},
+						Applicator: resource.ApplyFn(func(_ context.Context, o client.Object, _ ...resource.ApplyOption) error {
+							want := &v1.FunctionCallPolicy{
+								Timeout: &metav1.Duration{Duration: 30 * time.Second},
+							}
+							if diff := cmp.Diff(want, o.(*v1.FunctionRevision).Spec.CallPolicy); diff != "" {
+								t.Errorf("-want, +got:\n%s", diff)
+							}
+							return nil
+						}),
+					},
+					pkg: &fake.MockClient{
+						MockGet: fake.NewMockGetFn(&xpkg.Package{
+							Digest:          "sha256:1234567890123456789012345678901234567890123456789012345678901234",
+							Version:         "v1.0.0",
+							Source:          "xpkg.crossplane.io/test",
+							ResolvedVersion: "v1.0.0",
+							ResolvedSource:  "xpkg.crossplane.io/test",
+						}, nil),
+					},
+					log:        testLog,
+					record:     event.NewNopRecorder(),
+					conditions: conditions.ObservedGenerationPropagationManager{},
+				},
+			},
+			want: want{
+				r: reconcile.Result{Requeue: false},
+			},
+		},
 		"SuccessfulNoExistingRevisionsAutoActivatePullAlways": {
 			reason: "We should be active and requeue after wait on successful creation of the first revision with auto activation and package pull policy Always.",
 			args: args{
@@ -53,6 +53,14 @@ const (
 	// automatically protecting Providers from deletion when they still have
 	// active managed resources. Requires EnableBetaUsages to also be enabled.
 	EnableAlphaProviderDeletionProtection feature.Flag = "EnableAlphaProviderDeletionProtection"
+
+	// EnableAlphaFunctionCircuitBreaker enables alpha support for failing
+	// fast when calls to a composition function keep failing.
+	EnableAlphaFunctionCircuitBreaker feature.Flag = "EnableAlphaFunctionCircuitBreaker"
+
+	// EnableAlphaFunctionObservedDelta enables alpha support for sending
+	// functions that support it only the changes to the observed state.
+	EnableAlphaFunctionObservedDelta feature.Flag = "EnableAlphaFunctionObservedDelta"
 )
 
 // Beta Feature Flags.
@@ -26,11 +26,17 @@ import (
 	kerrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/fields"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/selection"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/managedfields"
+	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/errors"
 )
 
 // storeKey uniquely identifies a resource in the fake client's store.
@@ -59,6 +65,24 @@ type InMemoryClient struct {
 	// updated tracks resources written via Update or Status().Update().
 	// The last Status().Update for the XR is the final output.
 	updated []unstructured.Unstructured
+
+	// typeConverter is used to server-side apply resources. Server-side apply
+	// is disabled when it's nil.
+	typeConverter managedfields.TypeConverter
+
+	// managers caches a server-side apply field manager per kind.
+	managers map[schema.GroupVersionKind]*managedfields.FieldManager
+
+	// indexes are the field indexes List can filter by, per kind.
+	indexes map[schema.GroupVersionKind]map[string]index
+}
+
+// An index extracts field index values from objects of a kind.
+type index struct {
+	// obj is an empty object of the indexed kind. Stored resources are
+	// converted to its type before extract is called.
+	obj     client.Object
+	extract client.IndexerFunc
 }
 
 // NewInMemoryClient returns a new InMemoryClient pre-populated with the
@@ -78,11 +102,23 @@ func NewInMemoryClient(s *runtime.Scheme, resources ...unstructured.Unstructured
 	}
 
 	return &InMemoryClient{
-		store:  store,
-		scheme: s,
+		store:   store,
+		scheme:  s,
+		indexes: make(map[schema.GroupVersionKind]map[string]index),
 	}
 }
 
+// EnableServerSideApply makes the client merge writes into the resources it
+// stores the way an API server would, tracking the fields each field manager
+// owns in metadata.managedFields. The supplied TypeConverter determines how
+// each kind is merged. Without server-side apply the client stores each write
+// as is. Status writes replace the stored status either way; see
+// inMemoryStatusWriter.
+func (c *InMemoryClient) EnableServerSideApply(tc managedfields.TypeConverter) {
+	c.typeConverter = tc
+	c.managers = make(map[schema.GroupVersionKind]*managedfields.FieldManager)
+}
+
 // Applied returns all resources that were written via SSA Patch calls.
 func (c *InMemoryClient) Applied() []unstructured.Unstructured {
 	return slices.Clone(c.applied)
@@ -136,19 +172,16 @@ func (c *InMemoryClient) Get(_ context.Context, key client.ObjectKey, obj client
 }
 
 // List lists resources from the in-memory store, filtering by GVK, namespace,
-// and label selector.
+// label selector, and field selector. Field selectors must match a field index
+// added using IndexField. The list may be unstructured, or of a kind the
+// client's scheme knows.
 func (c *InMemoryClient) List(_ context.Context, list client.ObjectList, opts ...client.ListOption) error {
 	listOpts := &client.ListOptions{}
 	for _, o := range opts {
 		o.ApplyToList(listOpts)
 	}
 
-	ul, ok := list.(*unstructured.UnstructuredList)
-	if !ok {
-		return kerrors.NewInternalError(nil)
-	}
-
-	gvk := ul.GroupVersionKind()
+	gvk := c.gvkFor(list)
 	// List GVKs are typically FooList; strip the "List" suffix for item matching.
 	itemGVK := gvk
 	if kind := gvk.Kind; len(kind) > 4 && kind[len(kind)-4:] == "List" {
@@ -175,43 +208,133 @@ func (c *InMemoryClient) List(_ context.Context, list client.ObjectList, opts ..
 			continue
 		}
 
+		// Field selector.
+		if listOpts.FieldSelector != nil {
+			ok, err := c.matchesFields(itemGVK, r, listOpts.FieldSelector)
+			if err != nil {
+				return err
+			}
+			if !ok {
+				continue
+			}
+		}
+
 		items = append(items, *r.DeepCopy())
 	}
 
-	ul.Items = items
+	if ul, ok := list.(*unstructured.UnstructuredList); ok {
+		ul.Items = items
+		return nil
+	}
+
+	// For typed lists, convert via the runtime converter.
+	ul := &unstructured.UnstructuredList{Items: items}
+	return errors.Wrapf(runtime.DefaultUnstructuredConverter.FromUnstructured(ul.UnstructuredContent(), list), "cannot convert %s", gvk)
+}
+
+// IndexField adds a field index that List can filter by. Unlike a
+// controller-runtime cache, the index is evaluated when List is called.
+func (c *InMemoryClient) IndexField(_ context.Context, obj client.Object, field string, extract client.IndexerFunc) error {
+	gvk := c.gvkFor(obj)
+	if c.indexes[gvk] == nil {
+		c.indexes[gvk] = make(map[string]index)
+	}
+	c.indexes[gvk][field] = index{obj: obj, extract: extract}
 	return nil
 }
 
+// matchesFields returns true if the supplied resource matches the supplied
+// field selector, using the field indexes for the supplied kind.
+func (c *InMemoryClient) matchesFields(gvk schema.GroupVersionKind, u unstructured.Unstructured, sel fields.Selector) (bool, error) {
+	for _, req := range sel.Requirements() {
+		if req.Operator != selection.Equals && req.Operator != selection.DoubleEquals {
+			return false, errors.Errorf("field selector %q: only equality is supported", req.Field)
+		}
+
+		idx, ok := c.indexes[gvk][req.Field]
+		if !ok {
+			return false, errors.Errorf("index with name %s does not exist for %s", req.Field, gvk)
+		}
+
+		obj, ok := idx.obj.DeepCopyObject().(client.Object)
+		if !ok {
+			return false, errors.Errorf("cannot copy indexed %s", gvk)
+		}
+		copyInto(obj, &u)
+
+		if !slices.Contains(idx.extract(obj), req.Value) {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
 // Create stores a new resource.
-func (c *InMemoryClient) Create(_ context.Context, obj client.Object, _ ...client.CreateOption) error {
+func (c *InMemoryClient) Create(_ context.Context, obj client.Object, opts ...client.CreateOption) error {
 	u := toUnstructured(obj, c.scheme)
 	key := keyForUnstructured(u)
+
+	co := &client.CreateOptions{}
+	co.ApplyOptions(opts)
+
+	u, err := c.manage(key, u, co.FieldManager)
+	if err != nil {
+		return err
+	}
+
 	c.store[key] = *u
+	copyInto(obj, u)
 	return nil
 }
 
 // Update stores the updated resource and records it.
-func (c *InMemoryClient) Update(_ context.Context, obj client.Object, _ ...client.UpdateOption) error {
+func (c *InMemoryClient) Update(_ context.Context, obj client.Object, opts ...client.UpdateOption) error {
 	u := toUnstructured(obj, c.scheme)
 	key := keyForUnstructured(u)
+
+	uo := &client.UpdateOptions{}
+	uo.ApplyOptions(opts)
+
+	u, err := c.manage(key, u, uo.FieldManager)
+	if err != nil {
+		return err
+	}
+
 	c.store[key] = *u
 	c.updated = append(c.updated, *u.DeepCopy())
+	copyInto(obj, u)
 	return nil
 }
 
 // Patch handles SSA Apply patches by capturing the applied resource. For other
-// patch types it behaves like Update.
-func (c *InMemoryClient) Patch(_ context.Context, obj client.Object, patch client.Patch, _ ...client.PatchOption) error {
+// patch types it behaves like Update. When server-side apply is enabled Apply
+// patches are merged into the stored resource, and the merged resource is
+// written back to obj.
+func (c *InMemoryClient) Patch(_ context.Context, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
 	u := toUnstructured(obj, c.scheme)
 	key := keyForUnstructured(u)
 
+	po := &client.PatchOptions{}
+	po.ApplyOptions(opts)
+
 	if patch.Type() == types.ApplyPatchType {
-		c.applied = append(c.applied, *u.DeepCopy())
-		c.store[key] = *u
+		merged, err := c.apply(key, u, po.FieldManager, ptr.Deref(po.Force, false))
+		if err != nil {
+			return err
+		}
+		c.applied = append(c.applied, *merged.DeepCopy())
+		c.store[key] = *merged
+		copyInto(obj, merged)
 		return nil
 	}
 
+	u, err := c.manage(key, u, po.FieldManager)
+	if err != nil {
+		return err
+	}
+
 	c.store[key] = *u
+	copyInto(obj, u)
 	return nil
 }
 
@@ -273,6 +396,91 @@ func (c *InMemoryClient) IsObjectNamespaced(_ runtime.Object) (bool, error) {
 	return true, nil
 }
 
+// apply server-side applies the supplied resource to the stored resource with
+// the supplied key, if server-side apply is enabled. It returns the merged
+// resource. If server-side apply is disabled it returns the supplied resource.
+func (c *InMemoryClient) apply(key storeKey, u *unstructured.Unstructured, manager string, force bool) (*unstructured.Unstructured, error) {
+	if c.typeConverter == nil {
+		return u, nil
+	}
+	if manager == "" {
+		return nil, kerrors.NewBadRequest("fieldManager is required for apply requests")
+	}
+
+	fm, err := c.fieldManagerFor(key.GroupVersionKind)
+	if err != nil {
+		return nil, err
+	}
+
+	out, err := fm.Apply(c.live(key), u, manager, force)
+	if err != nil {
+		return nil, err
+	}
+
+	return managed(out)
+}
+
+// manage records the supplied field manager as the owner of any fields the
+// supplied resource changes, relative to the stored resource with the
+// supplied key. It returns the resource with updated managed fields. If
+// server-side apply is disabled it returns the supplied resource.
+func (c *InMemoryClient) manage(key storeKey, u *unstructured.Unstructured, manager string) (*unstructured.Unstructured, error) {
+	if c.typeConverter == nil {
+		return u, nil
+	}
+	if manager == "" {
+		manager = DefaultFieldManager
+	}
+
+	fm, err := c.fieldManagerFor(key.GroupVersionKind)
+	if err != nil {
+		return nil, err
+	}
+
+	out, err := fm.Update(c.live(key), u, manager)
+	if err != nil {
+		return nil, err
+	}
+
+	return managed(out)
+}
+
+// live returns a copy of the stored resource with the supplied key, or an empty
+// resource of the key's kind if none is stored.
+func (c *InMemoryClient) live(key storeKey) *unstructured.Unstructured {
+	if stored, ok := c.store[key]; ok {
+		return stored.DeepCopy()
+	}
+	u := &unstructured.Unstructured{}
+	u.SetGroupVersionKind(key.GroupVersionKind)
+	return u
+}
+
+// fieldManagerFor returns a server-side apply field manager for the supplied
+// kind.
+func (c *InMemoryClient) fieldManagerFor(gvk schema.GroupVersionKind) (*managedfields.FieldManager, error) {
+	if fm, ok := c.managers[gvk]; ok {
+		return fm, nil
+	}
+	fm, err := newFieldManager(c.typeConverter, gvk)
+	if err != nil {
+		return nil, errors.Wrapf(err, "cannot create field manager for %s", gvk)
+	}
+	c.managers[gvk] = fm
+	return fm, nil
+}
+
+// managed returns the supplied object, as written by a field manager, as an
+// unstructured resource.
+func managed(obj runtime.Object) (*unstructured.Unstructured, error) {
+	u, ok := obj.(*unstructured.Unstructured)
+	if !ok {
+		return nil, errors.Errorf("field manager returned %T, not an unstructured resource", obj)
+	}
+	clearManagedFieldsTimes(u)
+	return u, nil
+}
+
 // inMemoryStatusWriter handles Status().Update() and Status().Patch() calls.
 //
 // It exists to solve a specific problem in the reconciler's control flow.
@@ -162,6 +162,154 @@ func TestInMemoryClientPatch(t *testing.T) {
 	}
 }
 
+func TestInMemoryClientServerSideApply(t *testing.T) {
+	type apply struct {
+		manager string
+		force   bool
+		spec    map[string]any
+	}
+	type args struct {
+		store   []unstructured.Unstructured
+		applies []apply
+	}
+	type want struct {
+		err      error
+		spec     map[string]any
+		managers []string
+	}
+
+	thing := func(spec map[string]any) unstructured.Unstructured {
+		return unstructured.Unstructured{Object: map[string]any{
+			"apiVersion": "example.org/v1",
+			"kind":       "Thing",
+			"metadata":   map[string]any{"name": "my-thing"},
+			"spec":       spec,
+		}}
+	}
+
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   want
+	}{
+		"RemoveFieldOwnedByApplier": {
+			reason: "A field the only field manager that owns it stops applying should be removed.",
+			args: args{
+				applies: []apply{
+					{manager: "xp", force: true, spec: map[string]any{"a": "1", "b": "2"}},
+					{manager: "xp", force: true, spec: map[string]any{"a": "1"}},
+				},
+			},
+			want: want{
+				spec:     map[string]any{"a": "1"},
+				managers: []string{"xp"},
+			},
+		},
+		"RetainFieldOwnedByAnotherManager": {
+			reason: "A field another field manager also owns should be retained when a field manager stops applying it.",
+			args: args{
+				applies: []apply{
+					{manager: "one", force: true, spec: map[string]any{"a": "1", "b": "2"}},
+					{manager: "two", force: true, spec: map[string]any{"b": "2"}},
+					{manager: "one", force: true, spec: map[string]any{"a": "1"}},
+				},
+			},
+			want: want{
+				spec:     map[string]any{"a": "1", "b": "2"},
+				managers: []string{"one", "two"},
+			},
+		},
+		"RetainUnmanagedObservedField": {
+			reason: "Fields of a stored resource without managed fields should be owned by before-first-apply, and retained.",
+			args: args{
+				store: []unstructured.Unstructured{thing(map[string]any{"a": "1", "other": "value"})},
+				applies: []apply{
+					{manager: "xp", force: true, spec: map[string]any{"a": "2"}},
+				},
+			},
+			want: want{
+				spec:     map[string]any{"a": "2", "other": "value"},
+				managers: []string{"xp", "before-first-apply"},
+			},
+		},
+		"ConflictWithoutForce": {
+			reason: "Applying a different value for a field another field manager owns should conflict unless forced.",
+			args: args{
+				applies: []apply{
+					{manager: "one", force: true, spec: map[string]any{"a": "1"}},
+					{manager: "two", spec: map[string]any{"a": "2"}},
+				},
+			},
+			want: want{
+				err: cmpopts.AnyError,
+			},
+		},
+		"MissingFieldManager": {
+			reason: "An apply patch without a field manager should return an error.",
+			args: args{
+				applies: []apply{
+					{spec: map[string]any{"a": "1"}},
+				},
+			},
+			want: want{
+				err: cmpopts.AnyError,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			tconv, err := NewTypeConverter(nil)
+			if err != nil {
+				t.Fatalf("NewTypeConverter(...): %v", err)
+			}
+
+			c := NewInMemoryClient(runtime.NewScheme(), tc.args.store...)
+			c.EnableServerSideApply(tconv)
+
+			for _, a := range tc.args.applies {
+				obj := thing(a.spec)
+				opts := []client.PatchOption{client.FieldOwner(a.manager)}
+				if a.force {
+					opts = append(opts, client.ForceOwnership)
+				}
+				//nolint:staticcheck // The reconciler still uses client.Patch with Apply.
+				if err = c.Patch(context.Background(), &obj, client.Apply, opts...); err != nil {
+					break
+				}
+			}
+
+			if diff := cmp.Diff(tc.want.err, err, cmpopts.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nPatch(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if tc.want.err != nil {
+				return
+			}
+
+			got := &unstructured.Unstructured{}
+			got.SetGroupVersionKind(schema.GroupVersionKind{Group: "example.org", Version: "v1", Kind: "Thing"})
+			if err := c.Get(context.Background(), types.NamespacedName{Name: "my-thing"}, got); err != nil {
+				t.Fatalf("Get(...): %v", err)
+			}
+
+			if diff := cmp.Diff(tc.want.spec, got.Object["spec"]); diff != "" {
+				t.Errorf("\n%s\nPatch(...): -want spec, +got spec:\n%s", tc.reason, diff)
+			}
+
+			managers := make([]string, 0, len(got.GetManagedFields()))
+			for _, mf := range got.GetManagedFields() {
+				managers = append(managers, mf.Manager)
+				if mf.Time != nil {
+					t.Errorf("\n%s\nPatch(...): managed fields entry for %q has a time, want none", tc.reason, mf.Manager)
+				}
+			}
+			if diff := cmp.Diff(tc.want.managers, managers, cmpopts.SortSlices(func(a, b string) bool { return a < b })); diff != "" {
+				t.Errorf("\n%s\nPatch(...): -want managers, +got managers:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
 func TestInMemoryClientDelete(t *testing.T) {
 	cm := unstructured.Unstructured{Object: map[string]any{
 		"apiVersion": "v1",
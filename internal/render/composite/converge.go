@@ -0,0 +1,126 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package composite
+
+import (
+	"slices"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/structpb"
+	kunstructured "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/xcrd"
+
+	"github.com/crossplane/crossplane/v2/internal/xfn"
+	renderv1alpha1 "github.com/crossplane/crossplane/v2/proto/render/v1alpha1"
+)
+
+// Converged returns true if two consecutive reconciles produced the same XR
+// and composed resources.
+func Converged(prev, cur *renderv1alpha1.CompositeOutput) bool {
+	if !proto.Equal(prev.GetCompositeResource(), cur.GetCompositeResource()) {
+		return false
+	}
+	return slices.EqualFunc(prev.GetComposedResources(), cur.GetComposedResources(), func(a, b *structpb.Struct) bool {
+		return proto.Equal(a, b)
+	})
+}
+
+// NextInput returns the input for the reconcile after the one that produced
+// the supplied output. The output XR becomes the input XR, and the composed
+// resources the reconcile applied become the observed resources, with any of
+// the input's composed resource patches applied.
+func NextInput(in *renderv1alpha1.CompositeInput, out *renderv1alpha1.CompositeOutput) (*renderv1alpha1.CompositeInput, error) {
+	xr := &kunstructured.Unstructured{}
+	if err := xfn.FromStruct(xr, out.GetCompositeResource()); err != nil {
+		return nil, errors.Wrap(err, "cannot convert composite resource from protobuf")
+	}
+
+	patches := make(map[string][]map[string]any, len(in.GetComposedResourcePatches()))
+	for _, p := range in.GetComposedResourcePatches() {
+		patches[p.GetResourceName()] = append(patches[p.GetResourceName()], p.GetPatch().AsMap())
+	}
+
+	// Observed resources that belong to composed XRs are unaffected by this
+	// XR's reconcile. Keep them for RenderNested.
+	observed := make([]*structpb.Struct, 0, len(in.GetObservedResources())+len(out.GetComposedResources()))
+	for _, s := range in.GetObservedResources() {
+		u := &kunstructured.Unstructured{}
+		if err := xfn.FromStruct(u, s); err != nil {
+			return nil, errors.Wrap(err, "cannot convert observed resource from protobuf")
+		}
+		if belongsTo(u, xr.GetUID()) {
+			continue
+		}
+		observed = append(observed, s)
+	}
+
+	for _, s := range out.GetComposedResources() {
+		cd := &kunstructured.Unstructured{}
+		if err := xfn.FromStruct(cd, s); err != nil {
+			return nil, errors.Wrap(err, "cannot convert composed resource from protobuf")
+		}
+		for _, p := range patches[xcrd.GetCompositionResourceName(cd)] {
+			cd.Object = MergePatch(cd.Object, p)
+		}
+		o, err := xfn.AsStruct(cd)
+		if err != nil {
+			return nil, errors.Wrap(err, "cannot convert observed resource to protobuf")
+		}
+		observed = append(observed, o)
+	}
+
+	next, _ := proto.Clone(in).(*renderv1alpha1.CompositeInput)
+	next.CompositeResource = out.GetCompositeResource()
+	next.ObservedResources = observed
+
+	return next, nil
+}
+
+// MergePatch applies a JSON merge patch, as described by RFC 7386, to the
+// supplied object. It returns the patched object.
+func MergePatch(obj, patch map[string]any) map[string]any {
+	if obj == nil {
+		obj = map[string]any{}
+	}
+	out, _ := mergePatch(obj, patch).(map[string]any)
+	return out
+}
+
+func mergePatch(obj, patch any) any {
+	p, ok := patch.(map[string]any)
+	if !ok {
+		// A patch that isn't an object replaces the target.
+		return patch
+	}
+
+	o, ok := obj.(map[string]any)
+	if !ok {
+		o = map[string]any{}
+	}
+
+	for k, v := range p {
+		if v == nil {
+			delete(o, k)
+			continue
+		}
+		o[k] = mergePatch(o[k], v)
+	}
+
+	return o
+}
@@ -0,0 +1,228 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package composite
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"google.golang.org/protobuf/testing/protocmp"
+	"google.golang.org/protobuf/types/known/structpb"
+
+	renderv1alpha1 "github.com/crossplane/crossplane/v2/proto/render/v1alpha1"
+)
+
+func TestMergePatch(t *testing.T) {
+	type args struct {
+		obj   map[string]any
+		patch map[string]any
+	}
+
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   map[string]any
+	}{
+		"AddField": {
+			reason: "A patch should add fields that don't exist in the object.",
+			args: args{
+				obj:   map[string]any{"spec": map[string]any{"size": "small"}},
+				patch: map[string]any{"status": map[string]any{"id": "cool"}},
+			},
+			want: map[string]any{
+				"spec":   map[string]any{"size": "small"},
+				"status": map[string]any{"id": "cool"},
+			},
+		},
+		"MergeNestedObjects": {
+			reason: "A patch should merge nested objects rather than replace them.",
+			args: args{
+				obj:   map[string]any{"status": map[string]any{"id": "cool", "region": "us"}},
+				patch: map[string]any{"status": map[string]any{"id": "cooler"}},
+			},
+			want: map[string]any{"status": map[string]any{"id": "cooler", "region": "us"}},
+		},
+		"ReplaceArrays": {
+			reason: "A patch should replace arrays wholesale.",
+			args: args{
+				obj: map[string]any{"status": map[string]any{"conditions": []any{
+					map[string]any{"type": "Synced", "status": "True"},
+				}}},
+				patch: map[string]any{"status": map[string]any{"conditions": []any{
+					map[string]any{"type": "Ready", "status": "True"},
+				}}},
+			},
+			want: map[string]any{"status": map[string]any{"conditions": []any{
+				map[string]any{"type": "Ready", "status": "True"},
+			}}},
+		},
+		"DeleteField": {
+			reason: "A null value in a patch should delete the field.",
+			args: args{
+				obj:   map[string]any{"status": map[string]any{"id": "cool", "region": "us"}},
+				patch: map[string]any{"status": map[string]any{"region": nil}},
+			},
+			want: map[string]any{"status": map[string]any{"id": "cool"}},
+		},
+		"NilObject": {
+			reason: "A patch applied to a nil object should produce the patch.",
+			args: args{
+				patch: map[string]any{"status": map[string]any{"id": "cool"}},
+			},
+			want: map[string]any{"status": map[string]any{"id": "cool"}},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := MergePatch(tc.args.obj, tc.args.patch)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nMergePatch(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestNextInput(t *testing.T) {
+	xr := mustStruct(map[string]any{
+		"apiVersion": "example.org/v1alpha1",
+		"kind":       "XBucket",
+		"metadata":   map[string]any{"name": "my-bucket", "uid": "xr-uid"},
+	})
+	controllerRef := func(uid string) []any {
+		return []any{map[string]any{
+			"apiVersion": "example.org/v1alpha1",
+			"kind":       "XBucket",
+			"name":       "my-bucket",
+			"uid":        uid,
+			"controller": true,
+		}}
+	}
+
+	type args struct {
+		in  *renderv1alpha1.CompositeInput
+		out *renderv1alpha1.CompositeOutput
+	}
+	type want struct {
+		next *renderv1alpha1.CompositeInput
+		err  error
+	}
+
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   want
+	}{
+		"AppliedBecomeObserved": {
+			reason: "The output XR should become the input XR, and applied composed resources should become observed resources with patches applied. Observed resources belonging to other XRs should be kept.",
+			args: args{
+				in: &renderv1alpha1.CompositeInput{
+					CompositeResource: mustStruct(map[string]any{
+						"apiVersion": "example.org/v1alpha1",
+						"kind":       "XBucket",
+						"metadata":   map[string]any{"name": "my-bucket"},
+					}),
+					ObservedResources: []*structpb.Struct{
+						mustStruct(map[string]any{
+							"apiVersion": "example.org/v1alpha1",
+							"kind":       "Bucket",
+							"metadata": map[string]any{
+								"name":            "old-bucket",
+								"ownerReferences": controllerRef("xr-uid"),
+							},
+						}),
+						mustStruct(map[string]any{
+							"apiVersion": "example.org/v1alpha1",
+							"kind":       "Bucket",
+							"metadata": map[string]any{
+								"name":            "nested-bucket",
+								"ownerReferences": controllerRef("nested-uid"),
+							},
+						}),
+					},
+					Reconciles: 3,
+					ComposedResourcePatches: []*renderv1alpha1.ComposedResourcePatch{{
+						ResourceName: "bucket",
+						Patch: mustStruct(map[string]any{
+							"status": map[string]any{"id": "cool"},
+						}),
+					}},
+				},
+				out: &renderv1alpha1.CompositeOutput{
+					CompositeResource: xr,
+					ComposedResources: []*structpb.Struct{
+						mustStruct(map[string]any{
+							"apiVersion": "example.org/v1alpha1",
+							"kind":       "Bucket",
+							"metadata": map[string]any{
+								"name":            "my-bucket-abcde",
+								"annotations":     map[string]any{"crossplane.io/composition-resource-name": "bucket"},
+								"ownerReferences": controllerRef("xr-uid"),
+							},
+						}),
+					},
+				},
+			},
+			want: want{
+				next: &renderv1alpha1.CompositeInput{
+					CompositeResource: xr,
+					ObservedResources: []*structpb.Struct{
+						mustStruct(map[string]any{
+							"apiVersion": "example.org/v1alpha1",
+							"kind":       "Bucket",
+							"metadata": map[string]any{
+								"name":            "nested-bucket",
+								"ownerReferences": controllerRef("nested-uid"),
+							},
+						}),
+						mustStruct(map[string]any{
+							"apiVersion": "example.org/v1alpha1",
+							"kind":       "Bucket",
+							"metadata": map[string]any{
+								"name":            "my-bucket-abcde",
+								"annotations":     map[string]any{"crossplane.io/composition-resource-name": "bucket"},
+								"ownerReferences": controllerRef("xr-uid"),
+							},
+							"status": map[string]any{"id": "cool"},
+						}),
+					},
+					Reconciles: 3,
+					ComposedResourcePatches: []*renderv1alpha1.ComposedResourcePatch{{
+						ResourceName: "bucket",
+						Patch: mustStruct(map[string]any{
+							"status": map[string]any{"id": "cool"},
+						}),
+					}},
+				},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			next, err := NextInput(tc.args.in, tc.args.out)
+
+			if diff := cmp.Diff(tc.want.err, err, cmpopts.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nNextInput(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.next, next, protocmp.Transform()); diff != "" {
+				t.Errorf("\n%s\nNextInput(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
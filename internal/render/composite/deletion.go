@@ -0,0 +1,103 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package composite
+
+import (
+	"context"
+
+	"google.golang.org/protobuf/types/known/structpb"
+	corev1 "k8s.io/api/core/v1"
+	kunstructured "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/errors"
+
+	apis "github.com/crossplane/crossplane/apis/v2"
+	"github.com/crossplane/crossplane/v2/internal/render"
+	"github.com/crossplane/crossplane/v2/internal/xfn"
+	renderv1alpha1 "github.com/crossplane/crossplane/v2/proto/render/v1alpha1"
+)
+
+// SimulateDeletions simulates deleting the output's deleted resources, taking
+// the input's usages and any composed usages into account. It returns when
+// each deleted resource would be deleted.
+func SimulateDeletions(ctx context.Context, in *renderv1alpha1.CompositeInput, out *renderv1alpha1.CompositeOutput) ([]*renderv1alpha1.ResourceDeletion, error) {
+	s := runtime.NewScheme()
+	if err := corev1.AddToScheme(s); err != nil {
+		return nil, errors.Wrap(err, "cannot add core/v1 to scheme")
+	}
+	if err := apis.AddToScheme(s); err != nil {
+		return nil, errors.Wrap(err, "cannot add Crossplane APIs to scheme")
+	}
+
+	// The world as it would be just before garbage collection: everything
+	// that was observed or required, the supplied usages, the composed
+	// resources, and the resources about to be deleted. Later resources
+	// replace earlier resources with the same key.
+	idx := make(map[resourceKey]int)
+	var store []kunstructured.Unstructured
+	add := func(what string, ss []*structpb.Struct) error {
+		for _, s := range ss {
+			u := &kunstructured.Unstructured{}
+			if err := xfn.FromStruct(u, s); err != nil {
+				return errors.Wrapf(err, "cannot convert %s from protobuf", what)
+			}
+			if i, ok := idx[keyFor(u)]; ok {
+				store[i] = *u
+				continue
+			}
+			idx[keyFor(u)] = len(store)
+			store = append(store, *u)
+		}
+		return nil
+	}
+
+	for _, r := range []struct {
+		what string
+		ss   []*structpb.Struct
+	}{
+		{what: "observed resource", ss: in.GetObservedResources()},
+		{what: "required resource", ss: in.GetRequiredResources()},
+		{what: "usage", ss: in.GetUsages()},
+		{what: "composed resource", ss: out.GetComposedResources()},
+	} {
+		if err := add(r.what, r.ss); err != nil {
+			return nil, err
+		}
+	}
+
+	// A resource may be deleted in more than one reconcile, e.g. if it was
+	// recreated in between. It's only deleted once.
+	seen := make(map[resourceKey]bool)
+	deleting := make([]kunstructured.Unstructured, 0, len(out.GetDeletedResources()))
+	for _, s := range out.GetDeletedResources() {
+		u := &kunstructured.Unstructured{}
+		if err := xfn.FromStruct(u, s); err != nil {
+			return nil, errors.Wrap(err, "cannot convert deleted resource from protobuf")
+		}
+		if seen[keyFor(u)] {
+			continue
+		}
+		seen[keyFor(u)] = true
+		deleting = append(deleting, *u)
+	}
+	if err := add("deleted resource", out.GetDeletedResources()); err != nil {
+		return nil, err
+	}
+
+	return render.SimulateDeletions(ctx, render.NewInMemoryClient(s, store...), deleting)
+}
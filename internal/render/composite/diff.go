@@ -0,0 +1,112 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package composite
+
+import (
+	kunstructured "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/xcrd"
+
+	"github.com/crossplane/crossplane/v2/internal/render"
+	"github.com/crossplane/crossplane/v2/internal/xfn"
+	renderv1alpha1 "github.com/crossplane/crossplane/v2/proto/render/v1alpha1"
+)
+
+// A resourceKey identifies a resource by kind, namespace, and name.
+type resourceKey struct {
+	schema.GroupVersionKind
+	types.NamespacedName
+}
+
+func keyFor(u *kunstructured.Unstructured) resourceKey {
+	return resourceKey{
+		GroupVersionKind: u.GroupVersionKind(),
+		NamespacedName:   types.NamespacedName{Namespace: u.GetNamespace(), Name: u.GetName()},
+	}
+}
+
+// Diff returns how each of the output's composed and deleted resources differ
+// from the input's observed resources. Composed resources that don't differ
+// from the observed resource are omitted.
+func Diff(in *renderv1alpha1.CompositeInput, out *renderv1alpha1.CompositeOutput) ([]*renderv1alpha1.ResourceDiff, error) {
+	observed := make(map[resourceKey]*kunstructured.Unstructured, len(in.GetObservedResources()))
+	for _, s := range in.GetObservedResources() {
+		u := &kunstructured.Unstructured{}
+		if err := xfn.FromStruct(u, s); err != nil {
+			return nil, errors.Wrap(err, "cannot convert observed resource from protobuf")
+		}
+		observed[keyFor(u)] = u
+	}
+
+	var diffs []*renderv1alpha1.ResourceDiff
+
+	for _, s := range out.GetComposedResources() {
+		u := &kunstructured.Unstructured{}
+		if err := xfn.FromStruct(u, s); err != nil {
+			return nil, errors.Wrap(err, "cannot convert composed resource from protobuf")
+		}
+
+		o, ok := observed[keyFor(u)]
+		if !ok {
+			diffs = append(diffs, newResourceDiff(u, renderv1alpha1.ChangeType_CHANGE_TYPE_ADDED, nil))
+			continue
+		}
+
+		patch, err := render.JSONPatch(o, u)
+		if err != nil {
+			return nil, errors.Wrapf(err, "cannot diff composed resource %q", u.GetName())
+		}
+		if len(patch) == 0 {
+			continue
+		}
+		diffs = append(diffs, newResourceDiff(u, renderv1alpha1.ChangeType_CHANGE_TYPE_CHANGED, patch))
+	}
+
+	// Deleted resources accumulate across reconciles. Only report resources
+	// that were observed, and only once. A later reconcile may delete a
+	// resource an earlier reconcile created.
+	seen := make(map[resourceKey]bool, len(out.GetDeletedResources()))
+	for _, s := range out.GetDeletedResources() {
+		u := &kunstructured.Unstructured{}
+		if err := xfn.FromStruct(u, s); err != nil {
+			return nil, errors.Wrap(err, "cannot convert deleted resource from protobuf")
+		}
+		k := keyFor(u)
+		if _, ok := observed[k]; !ok || seen[k] {
+			continue
+		}
+		seen[k] = true
+		diffs = append(diffs, newResourceDiff(u, renderv1alpha1.ChangeType_CHANGE_TYPE_REMOVED, nil))
+	}
+
+	return diffs, nil
+}
+
+func newResourceDiff(u *kunstructured.Unstructured, change renderv1alpha1.ChangeType, patch []*renderv1alpha1.JSONPatchOperation) *renderv1alpha1.ResourceDiff {
+	return &renderv1alpha1.ResourceDiff{
+		ApiVersion:              u.GetAPIVersion(),
+		Kind:                    u.GetKind(),
+		Namespace:               u.GetNamespace(),
+		Name:                    u.GetName(),
+		CompositionResourceName: xcrd.GetCompositionResourceName(u),
+		Change:                  change,
+		Patch:                   patch,
+	}
+}
@@ -0,0 +1,247 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package composite
+
+import (
+	"context"
+
+	"google.golang.org/protobuf/types/known/structpb"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kunstructured "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/logging"
+	ucomposite "github.com/crossplane/crossplane-runtime/v2/pkg/resource/unstructured/composite"
+
+	apiextensionsv1 "github.com/crossplane/crossplane/apis/v2/apiextensions/v1"
+	"github.com/crossplane/crossplane/v2/internal/xfn"
+	renderv1alpha1 "github.com/crossplane/crossplane/v2/proto/render/v1alpha1"
+)
+
+// MaxDepth is the maximum depth of a tree of XRs render will recurse into. It
+// protects against Compositions that (directly or indirectly) compose an XR of
+// their own type.
+const MaxDepth = 10
+
+// RenderNested renders each composed resource in out that is itself an XR,
+// appending the results to out.NestedComposites. A composed resource is an XR
+// if its Group+Kind matches one of the input's CompositeResourceDefinitions.
+//
+// The input's observed resources may include resources composed by composed
+// XRs, i.e. resources controlled by an XR other than the input XR. They're
+// passed to each nested render, which keeps the ones it controls. If a composed
+// XR was observed its UID is preserved, so that the resources it composed
+// remain controlled by it.
+func RenderNested(ctx context.Context, log logging.Logger, in *renderv1alpha1.CompositeInput, out *renderv1alpha1.CompositeOutput, depth int) error {
+	xr := &kunstructured.Unstructured{}
+	if err := xfn.FromStruct(xr, out.GetCompositeResource()); err != nil {
+		return errors.Wrap(err, "cannot convert composite resource from protobuf")
+	}
+
+	var (
+		observed []kunstructured.Unstructured
+		deferred []*structpb.Struct
+	)
+	for _, s := range in.GetObservedResources() {
+		u := &kunstructured.Unstructured{}
+		if err := xfn.FromStruct(u, s); err != nil {
+			return errors.Wrap(err, "cannot convert observed resource from protobuf")
+		}
+		if !belongsTo(u, xr.GetUID()) {
+			deferred = append(deferred, s)
+			continue
+		}
+		observed = append(observed, *u)
+	}
+
+	for _, s := range out.GetComposedResources() {
+		cd := &kunstructured.Unstructured{}
+		if err := xfn.FromStruct(cd, s); err != nil {
+			return errors.Wrap(err, "cannot convert composed resource from protobuf")
+		}
+
+		def, err := matchDefinition(cd.GroupVersionKind(), in.GetCompositeResourceDefinitions())
+		if err != nil {
+			return errors.Wrapf(err, "cannot match composed resource %q to a composite resource definition", cd.GetName())
+		}
+		if def == nil {
+			// Not an XR.
+			continue
+		}
+
+		if depth+1 >= MaxDepth {
+			return errors.Errorf("cannot render composed composite resource %q: exceeded maximum depth of %d nested composite resources", cd.GetName(), MaxDepth)
+		}
+
+		for _, o := range observed {
+			if o.GroupVersionKind() == cd.GroupVersionKind() && o.GetNamespace() == cd.GetNamespace() && o.GetName() == cd.GetName() {
+				cd.SetUID(o.GetUID())
+				break
+			}
+		}
+
+		d, err := decodeDefinition(def)
+		if err != nil {
+			return errors.Wrapf(err, "cannot decode composite resource definition for composed resource %q", cd.GetName())
+		}
+
+		nxr := ucomposite.New(ucomposite.WithSchema(d.Schema))
+		nxr.Object = cd.Object
+
+		comp, err := selectComposition(nxr, d, in.GetCompositions())
+		if err != nil {
+			return errors.Wrapf(err, "cannot select composition for composed composite resource %q", cd.GetName())
+		}
+
+		xs, err := xfn.AsStruct(cd)
+		if err != nil {
+			return errors.Wrap(err, "cannot convert composed composite resource to protobuf")
+		}
+
+		nout, err := render(ctx, log, &renderv1alpha1.CompositeInput{
+			CompositeResource:            xs,
+			Composition:                  comp,
+			Functions:                    in.GetFunctions(),
+			ObservedResources:            deferred,
+			RequiredResources:            in.GetRequiredResources(),
+			Credentials:                  in.GetCredentials(),
+			RequiredSchemas:              in.GetRequiredSchemas(),
+			CompositeResourceDefinition:  def,
+			Recursive:                    true,
+			CompositeResourceDefinitions: in.GetCompositeResourceDefinitions(),
+			Compositions:                 in.GetCompositions(),
+			Reconciles:                   in.GetReconciles(),
+			ComposedResourcePatches:      in.GetComposedResourcePatches(),
+			ServerSideApply:              in.GetServerSideApply(),
+			Validate:                     in.GetValidate(),
+			Diff:                         in.GetDiff(),
+			Trace:                        in.GetTrace(),
+			Fixtures:                     in.GetFixtures(),
+			Usages:                       in.GetUsages(),
+			SimulateDeletions:            in.GetSimulateDeletions(),
+		}, depth+1)
+		if nout != nil {
+			out.NestedComposites = append(out.NestedComposites, nout)
+		}
+		if err != nil {
+			return errors.Wrapf(err, "cannot render composed composite resource %q", cd.GetName())
+		}
+	}
+
+	return nil
+}
+
+// belongsTo returns true if the supplied observed resource belongs to the XR
+// with the supplied UID. An observed resource without a controller reference
+// is assumed to belong to the XR.
+func belongsTo(o *kunstructured.Unstructured, uid types.UID) bool {
+	c := metav1.GetControllerOf(o)
+	return c == nil || c.UID == uid
+}
+
+// matchDefinition returns the CompositeResourceDefinition whose composite
+// Group+Kind matches the supplied GVK, or nil if none does.
+func matchDefinition(gvk schema.GroupVersionKind, defs []*structpb.Struct) (*structpb.Struct, error) {
+	for _, def := range defs {
+		d, err := decodeDefinition(def)
+		if err != nil {
+			return nil, err
+		}
+		if d.GroupKind == gvk.GroupKind() {
+			return def, nil
+		}
+	}
+	return nil, nil
+}
+
+// selectComposition selects the Composition for a composed XR from the
+// supplied Compositions. It mirrors the XR controller's chain of composition
+// selectors, except that it never selects a Composition at random.
+func selectComposition(xr *ucomposite.Unstructured, d *definition, comps []*structpb.Struct) (*structpb.Struct, error) {
+	apiVersion, kind := xr.GetObjectKind().GroupVersionKind().ToAPIVersionAndKind()
+
+	type candidate struct {
+		comp *apiextensionsv1.Composition
+		s    *structpb.Struct
+	}
+
+	compatible := make([]candidate, 0, len(comps))
+	for _, s := range comps {
+		comp := &apiextensionsv1.Composition{}
+		if err := xfn.FromStruct(comp, s); err != nil {
+			return nil, errors.Wrap(err, "cannot convert composition from protobuf")
+		}
+		if comp.Spec.CompositeTypeRef.APIVersion != apiVersion || comp.Spec.CompositeTypeRef.Kind != kind {
+			continue
+		}
+		compatible = append(compatible, candidate{comp: comp, s: s})
+	}
+
+	named := func(name string) (*structpb.Struct, error) {
+		for _, c := range compatible {
+			if c.comp.GetName() == name {
+				return c.s, nil
+			}
+		}
+		return nil, errors.Errorf("no composition named %q is compatible with %s %s", name, apiVersion, kind)
+	}
+
+	if d.EnforcedComposition != "" {
+		return named(d.EnforcedComposition)
+	}
+
+	if ref := xr.GetCompositionReference(); ref != nil {
+		return named(ref.Name)
+	}
+
+	sel := xr.GetCompositionSelector()
+	if sel == nil && d.DefaultComposition != "" {
+		return named(d.DefaultComposition)
+	}
+
+	ls := labels.Everything()
+	if sel != nil {
+		s, err := metav1.LabelSelectorAsSelector(sel)
+		if err != nil {
+			return nil, errors.Wrap(err, "cannot parse composition selector")
+		}
+		ls = s
+	}
+
+	var selected []candidate
+	for _, c := range compatible {
+		if ls.Matches(labels.Set(c.comp.GetLabels())) {
+			selected = append(selected, c)
+		}
+	}
+
+	switch len(selected) {
+	case 0:
+		return nil, errors.Errorf("no composition is compatible with %s %s", apiVersion, kind)
+	case 1:
+		return selected[0].s, nil
+	default:
+		names := make([]string, len(selected))
+		for i, c := range selected {
+			names[i] = c.comp.GetName()
+		}
+		return nil, errors.Errorf("%d compositions are compatible with %s %s (%v) - set a composition reference or selector to choose one", len(names), apiVersion, kind, names)
+	}
+}
@@ -0,0 +1,455 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package composite
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"google.golang.org/protobuf/types/known/structpb"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kunstructured "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/logging"
+	ucomposite "github.com/crossplane/crossplane-runtime/v2/pkg/resource/unstructured/composite"
+
+	"github.com/crossplane/crossplane/v2/internal/render/rendertest"
+	renderv1alpha1 "github.com/crossplane/crossplane/v2/proto/render/v1alpha1"
+)
+
+func TestRenderNested(t *testing.T) {
+	xrd := func(kind, plural string) *structpb.Struct {
+		return mustStruct(map[string]any{
+			"apiVersion": "apiextensions.crossplane.io/v2",
+			"kind":       "CompositeResourceDefinition",
+			"metadata":   map[string]any{"name": plural + ".example.org"},
+			"spec": map[string]any{
+				"group": "example.org",
+				"names": map[string]any{"kind": kind, "plural": plural},
+				"scope": "Cluster",
+				"versions": []any{
+					map[string]any{"name": "v1alpha1", "served": true, "referenceable": true},
+				},
+			},
+		})
+	}
+	comp := func(name, kind string) *structpb.Struct {
+		return mustStruct(map[string]any{
+			"apiVersion": "apiextensions.crossplane.io/v1",
+			"kind":       "Composition",
+			"metadata":   map[string]any{"name": name},
+			"spec": map[string]any{
+				"compositeTypeRef": map[string]any{"apiVersion": "example.org/v1alpha1", "kind": kind},
+				"mode":             "Pipeline",
+				"pipeline": []any{
+					map[string]any{"step": "compose", "functionRef": map[string]any{"name": "function-compose"}},
+				},
+			},
+		})
+	}
+	xr := func(kind, name string, uid types.UID, owner *metav1.OwnerReference, resourceName string) *structpb.Struct {
+		u := &kunstructured.Unstructured{}
+		u.SetAPIVersion("example.org/v1alpha1")
+		u.SetKind(kind)
+		u.SetName(name)
+		u.SetUID(uid)
+		if owner != nil {
+			u.SetOwnerReferences([]metav1.OwnerReference{*owner})
+		}
+		if resourceName != "" {
+			u.SetAnnotations(map[string]string{"crossplane.io/composition-resource-name": resourceName})
+		}
+		return mustStruct(u.Object)
+	}
+	cm := func(name string, owner *metav1.OwnerReference, resourceName string) *structpb.Struct {
+		u := &kunstructured.Unstructured{}
+		u.SetAPIVersion("v1")
+		u.SetKind("ConfigMap")
+		u.SetName(name)
+		if owner != nil {
+			u.SetOwnerReferences([]metav1.OwnerReference{*owner})
+		}
+		if resourceName != "" {
+			u.SetAnnotations(map[string]string{"crossplane.io/composition-resource-name": resourceName})
+		}
+		return mustStruct(u.Object)
+	}
+	controller := func(kind, name string, uid types.UID) *metav1.OwnerReference {
+		return &metav1.OwnerReference{
+			APIVersion:         "example.org/v1alpha1",
+			Kind:               kind,
+			Name:               name,
+			UID:                uid,
+			Controller:         ptr.To(true),
+			BlockOwnerDeletion: ptr.To(true),
+		}
+	}
+
+	// The parent XR composes a child XR, which composes a ConfigMap. An XLoop
+	// composes another XLoop, so rendering it never terminates on its own.
+	fn := &rendertest.ComposeFunctionServer{
+		Compose: func(s *structpb.Struct) map[string]*structpb.Struct {
+			o := &kunstructured.Unstructured{Object: s.AsMap()}
+			switch o.GetKind() {
+			case "XParent":
+				return map[string]*structpb.Struct{"child": xr("XChild", "child", "", nil, "")}
+			case "XChild":
+				return map[string]*structpb.Struct{"cm": cm("child-cm", nil, "")}
+			case "XLoop":
+				return map[string]*structpb.Struct{"loop": xr("XLoop", o.GetName()+"-loop", "", nil, "")}
+			}
+			return nil
+		},
+	}
+
+	// nested describes a composed XR's render output.
+	type nested struct {
+		// name of the composed XR.
+		name string
+		// composed resource names, mapped to the UID of their controller.
+		composed map[string]types.UID
+		// deleted resource names.
+		deleted []string
+	}
+	type want struct {
+		nested  []nested
+		deleted []string
+		// errContains is a substring of the expected error, if any.
+		errContains string
+	}
+
+	cases := map[string]struct {
+		reason string
+		input  func(addr string) *renderv1alpha1.CompositeInput
+		want   want
+	}{
+		"ComposedXRMatchedToDefinition": {
+			reason: "A composed resource whose kind matches one of the supplied XRDs should be rendered using its compatible Composition.",
+			input: func(addr string) *renderv1alpha1.CompositeInput {
+				return &renderv1alpha1.CompositeInput{
+					CompositeResource:            xr("XParent", "parent", "parent-uid", nil, ""),
+					Composition:                  comp("parent", "XParent"),
+					CompositeResourceDefinition:  xrd("XParent", "xparents"),
+					Functions:                    []*renderv1alpha1.FunctionInput{{Name: "function-compose", Address: addr}},
+					Recursive:                    true,
+					CompositeResourceDefinitions: []*structpb.Struct{xrd("XParent", "xparents"), xrd("XChild", "xchildren")},
+					Compositions:                 []*structpb.Struct{comp("parent", "XParent"), comp("child", "XChild")},
+				}
+			},
+			want: want{
+				nested: []nested{{name: "child", composed: map[string]types.UID{"child-cm": ""}}},
+			},
+		},
+		"ComposedResourceNotMatchedToDefinition": {
+			reason: "A composed XR whose kind matches none of the supplied XRDs should not be rendered.",
+			input: func(addr string) *renderv1alpha1.CompositeInput {
+				return &renderv1alpha1.CompositeInput{
+					CompositeResource:            xr("XParent", "parent", "parent-uid", nil, ""),
+					Composition:                  comp("parent", "XParent"),
+					CompositeResourceDefinition:  xrd("XParent", "xparents"),
+					Functions:                    []*renderv1alpha1.FunctionInput{{Name: "function-compose", Address: addr}},
+					Recursive:                    true,
+					CompositeResourceDefinitions: []*structpb.Struct{xrd("XParent", "xparents")},
+					Compositions:                 []*structpb.Struct{comp("parent", "XParent"), comp("child", "XChild")},
+				}
+			},
+			want: want{},
+		},
+		"ObservedUIDPreserved": {
+			reason: "A composed XR that was observed should keep its UID, so the resources it composes are controlled by it.",
+			input: func(addr string) *renderv1alpha1.CompositeInput {
+				return &renderv1alpha1.CompositeInput{
+					CompositeResource:           xr("XParent", "parent", "parent-uid", nil, ""),
+					Composition:                 comp("parent", "XParent"),
+					CompositeResourceDefinition: xrd("XParent", "xparents"),
+					Functions:                   []*renderv1alpha1.FunctionInput{{Name: "function-compose", Address: addr}},
+					ObservedResources: []*structpb.Struct{
+						xr("XChild", "child", "child-uid", controller("XParent", "parent", "parent-uid"), "child"),
+					},
+					Recursive:                    true,
+					CompositeResourceDefinitions: []*structpb.Struct{xrd("XParent", "xparents"), xrd("XChild", "xchildren")},
+					Compositions:                 []*structpb.Struct{comp("parent", "XParent"), comp("child", "XChild")},
+				}
+			},
+			want: want{
+				nested: []nested{{name: "child", composed: map[string]types.UID{"child-cm": "child-uid"}}},
+			},
+		},
+		"ObservedResourcesDeferred": {
+			reason: "Observed resources controlled by a composed XR should be left to that XR's render, which should garbage collect the ones it no longer desires.",
+			input: func(addr string) *renderv1alpha1.CompositeInput {
+				return &renderv1alpha1.CompositeInput{
+					CompositeResource:           xr("XParent", "parent", "parent-uid", nil, ""),
+					Composition:                 comp("parent", "XParent"),
+					CompositeResourceDefinition: xrd("XParent", "xparents"),
+					Functions:                   []*renderv1alpha1.FunctionInput{{Name: "function-compose", Address: addr}},
+					ObservedResources: []*structpb.Struct{
+						xr("XChild", "child", "child-uid", controller("XParent", "parent", "parent-uid"), "child"),
+						cm("old-cm", controller("XChild", "child", "child-uid"), "old"),
+					},
+					Recursive:                    true,
+					CompositeResourceDefinitions: []*structpb.Struct{xrd("XParent", "xparents"), xrd("XChild", "xchildren")},
+					Compositions:                 []*structpb.Struct{comp("parent", "XParent"), comp("child", "XChild")},
+				}
+			},
+			want: want{
+				nested: []nested{{
+					name:     "child",
+					composed: map[string]types.UID{"child-cm": "child-uid"},
+					deleted:  []string{"old-cm"},
+				}},
+			},
+		},
+		"MaxDepthExceeded": {
+			reason: "A Composition that composes an XR of its own type should return an error once the tree is MaxDepth deep.",
+			input: func(addr string) *renderv1alpha1.CompositeInput {
+				return &renderv1alpha1.CompositeInput{
+					CompositeResource:            xr("XLoop", "loop", "", nil, ""),
+					Composition:                  comp("loop", "XLoop"),
+					CompositeResourceDefinition:  xrd("XLoop", "xloops"),
+					Functions:                    []*renderv1alpha1.FunctionInput{{Name: "function-compose", Address: addr}},
+					Recursive:                    true,
+					CompositeResourceDefinitions: []*structpb.Struct{xrd("XLoop", "xloops")},
+					Compositions:                 []*structpb.Struct{comp("loop", "XLoop")},
+				}
+			},
+			want: want{errContains: "exceeded maximum depth of 10 nested composite resources"},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			addr := rendertest.StartFunctionServer(t, fn)
+
+			out, err := Render(t.Context(), logging.NewNopLogger(), tc.input(addr))
+
+			if tc.want.errContains != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.want.errContains) {
+					t.Fatalf("\n%s\nRender(...): want error containing %q, got %v", tc.reason, tc.want.errContains, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("\n%s\nRender(...): unexpected error: %v", tc.reason, err)
+			}
+
+			got := make([]nested, 0, len(out.GetNestedComposites()))
+			for _, n := range out.GetNestedComposites() {
+				composed := map[string]types.UID{}
+				for _, s := range n.GetComposedResources() {
+					u := &kunstructured.Unstructured{Object: s.AsMap()}
+					var uid types.UID
+					if c := metav1.GetControllerOf(u); c != nil {
+						uid = c.UID
+					}
+					composed[u.GetName()] = uid
+				}
+				got = append(got, nested{
+					name:     (&kunstructured.Unstructured{Object: n.GetCompositeResource().AsMap()}).GetName(),
+					composed: composed,
+					deleted:  names(n.GetDeletedResources()),
+				})
+			}
+
+			// We only know the UIDs of composed XRs that were observed.
+			// Others get a generated UID, which we don't compare.
+			ignoreGenerated := cmp.FilterValues(func(a, b types.UID) bool { return a == "" || b == "" }, cmp.Ignore())
+			if diff := cmp.Diff(tc.want.nested, got, cmp.AllowUnexported(nested{}), cmpopts.EquateEmpty(), ignoreGenerated); diff != "" {
+				t.Errorf("\n%s\nRender(...): -want nested, +got nested:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.deleted, names(out.GetDeletedResources()), cmpopts.EquateEmpty()); diff != "" {
+				t.Errorf("\n%s\nRender(...): -want deleted, +got deleted:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func names(ss []*structpb.Struct) []string {
+	n := make([]string, 0, len(ss))
+	for _, s := range ss {
+		n = append(n, (&kunstructured.Unstructured{Object: s.AsMap()}).GetName())
+	}
+	return n
+}
+
+func TestSelectComposition(t *testing.T) {
+	comp := func(name, kind string, labels map[string]any) *structpb.Struct {
+		meta := map[string]any{"name": name}
+		if labels != nil {
+			meta["labels"] = labels
+		}
+		return mustStruct(map[string]any{
+			"apiVersion": "apiextensions.crossplane.io/v1",
+			"kind":       "Composition",
+			"metadata":   meta,
+			"spec": map[string]any{
+				"compositeTypeRef": map[string]any{
+					"apiVersion": "example.org/v1alpha1",
+					"kind":       kind,
+				},
+			},
+		})
+	}
+
+	type args struct {
+		ref   *corev1.ObjectReference
+		sel   *metav1.LabelSelector
+		def   *definition
+		comps []*structpb.Struct
+	}
+	type want struct {
+		name            string
+		wantErrContains []string
+	}
+
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   want
+	}{
+		"OnlyCompatibleComposition": {
+			reason: "With no reference, selector or XRD default the only compatible Composition should be selected.",
+			args: args{
+				def: &definition{},
+				comps: []*structpb.Struct{
+					comp("incompatible", "XOther", nil),
+					comp("compatible", "XNested", nil),
+				},
+			},
+			want: want{name: "compatible"},
+		},
+		"AmbiguousCompositions": {
+			reason: "With no reference, selector or XRD default several compatible Compositions should return an error naming them, not a random choice.",
+			args: args{
+				def: &definition{},
+				comps: []*structpb.Struct{
+					comp("a", "XNested", nil),
+					comp("b", "XNested", nil),
+				},
+			},
+			want: want{wantErrContains: []string{"2 compositions are compatible", "a", "b"}},
+		},
+		"NoCompatibleComposition": {
+			reason: "An XR with no compatible Compositions should return an error.",
+			args: args{
+				def:   &definition{},
+				comps: []*structpb.Struct{comp("incompatible", "XOther", nil)},
+			},
+			want: want{wantErrContains: []string{"no composition is compatible"}},
+		},
+		"EnforcedComposition": {
+			reason: "The XRD's enforced Composition should take precedence over the XR's reference.",
+			args: args{
+				ref: &corev1.ObjectReference{Name: "referenced"},
+				def: &definition{EnforcedComposition: "enforced"},
+				comps: []*structpb.Struct{
+					comp("referenced", "XNested", nil),
+					comp("enforced", "XNested", nil),
+				},
+			},
+			want: want{name: "enforced"},
+		},
+		"ReferencedComposition": {
+			reason: "The XR's Composition reference should take precedence over the XRD's default.",
+			args: args{
+				ref: &corev1.ObjectReference{Name: "referenced"},
+				def: &definition{DefaultComposition: "default"},
+				comps: []*structpb.Struct{
+					comp("referenced", "XNested", nil),
+					comp("default", "XNested", nil),
+				},
+			},
+			want: want{name: "referenced"},
+		},
+		"MissingReferencedComposition": {
+			reason: "A reference to a Composition that wasn't supplied should return an error.",
+			args: args{
+				ref:   &corev1.ObjectReference{Name: "missing"},
+				def:   &definition{},
+				comps: []*structpb.Struct{comp("other", "XNested", nil)},
+			},
+			want: want{wantErrContains: []string{"no composition named \"missing\""}},
+		},
+		"DefaultComposition": {
+			reason: "The XRD's default Composition should be selected when the XR has no reference or selector.",
+			args: args{
+				def: &definition{DefaultComposition: "default"},
+				comps: []*structpb.Struct{
+					comp("other", "XNested", nil),
+					comp("default", "XNested", nil),
+				},
+			},
+			want: want{name: "default"},
+		},
+		"SelectedComposition": {
+			reason: "The XR's Composition selector should take precedence over the XRD's default.",
+			args: args{
+				sel: &metav1.LabelSelector{MatchLabels: map[string]string{"provider": "aws"}},
+				def: &definition{DefaultComposition: "default"},
+				comps: []*structpb.Struct{
+					comp("default", "XNested", map[string]any{"provider": "gcp"}),
+					comp("aws", "XNested", map[string]any{"provider": "aws"}),
+				},
+			},
+			want: want{name: "aws"},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			xr := ucomposite.New(ucomposite.WithSchema(ucomposite.SchemaModern))
+			xr.SetAPIVersion("example.org/v1alpha1")
+			xr.SetKind("XNested")
+			xr.SetName("nested")
+			if tc.args.ref != nil {
+				xr.SetCompositionReference(tc.args.ref)
+			}
+			if tc.args.sel != nil {
+				xr.SetCompositionSelector(tc.args.sel)
+			}
+
+			got, err := selectComposition(xr, tc.args.def, tc.args.comps)
+
+			// Error assertions use substring matching so we can check that
+			// the error names the candidate Compositions.
+			if len(tc.want.wantErrContains) > 0 {
+				if err == nil {
+					t.Fatalf("\n%s\nselectComposition(...): expected error, got nil", tc.reason)
+				}
+				for _, sub := range tc.want.wantErrContains {
+					if !strings.Contains(err.Error(), sub) {
+						t.Errorf("\n%s\nselectComposition(...): error %q does not contain %q", tc.reason, err.Error(), sub)
+					}
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("\n%s\nselectComposition(...): unexpected error: %v", tc.reason, err)
+			}
+
+			gotName := got.GetFields()["metadata"].GetStructValue().GetFields()["name"].GetStringValue()
+			if gotName != tc.want.name {
+				t.Errorf("\n%s\nselectComposition(...): want composition %q, got %q", tc.reason, tc.want.name, gotName)
+			}
+		})
+	}
+}
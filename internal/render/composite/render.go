@@ -52,12 +52,98 @@ import (
 	"github.com/crossplane/crossplane/v2/internal/render"
 	"github.com/crossplane/crossplane/v2/internal/ssa"
 	"github.com/crossplane/crossplane/v2/internal/xfn"
+	"github.com/crossplane/crossplane/v2/internal/xfn/inspected"
 	renderv1alpha1 "github.com/crossplane/crossplane/v2/proto/render/v1alpha1"
 )
 
 // Render runs one real XR reconcile loop using the real reconciler engine
-// backed by a fake in-memory client.
+// backed by a fake in-memory client. If the input is recursive it also
+// renders any composed resources that are themselves XRs.
 func Render(ctx context.Context, log logging.Logger, in *renderv1alpha1.CompositeInput) (*renderv1alpha1.CompositeOutput, error) {
+	return render(ctx, log, in, 0)
+}
+
+// render renders the input XR, reconciling it up to the requested number of
+// times. The depth is the number of composite resources above the input XR in
+// the tree being rendered.
+func render(ctx context.Context, log logging.Logger, in *renderv1alpha1.CompositeInput, depth int) (*renderv1alpha1.CompositeOutput, error) {
+	var (
+		out     *renderv1alpha1.CompositeOutput
+		deleted []*structpb.Struct
+		events  []*renderv1alpha1.Event
+		traces  []*renderv1alpha1.StepTrace
+	)
+
+	cur := in
+	n := max(in.GetReconciles(), 1)
+	for i := range n {
+		o, err := renderOnce(ctx, log, cur)
+		if o != nil {
+			for _, t := range o.GetTraces() {
+				t.Reconcile = i + 1
+			}
+			deleted = append(deleted, o.GetDeletedResources()...)
+			events = append(events, o.GetEvents()...)
+			traces = append(traces, o.GetTraces()...)
+			o.DeletedResources = slices.Clone(deleted)
+			o.Events = slices.Clone(events)
+			o.Traces = slices.Clone(traces)
+			o.Reconciles = i + 1
+		}
+		if err != nil {
+			// Includes partial output on a pipeline fatal result. See
+			// renderOnce.
+			return o, err
+		}
+
+		converged := out != nil && Converged(out, o)
+		out = o
+		if converged || i+1 == n {
+			break
+		}
+
+		cur, err = NextInput(in, o)
+		if err != nil {
+			return nil, errors.Wrapf(err, "cannot build input for reconcile %d", i+2)
+		}
+	}
+
+	if in.GetDiff() {
+		d, err := Diff(in, out)
+		if err != nil {
+			return nil, errors.Wrap(err, "cannot diff rendered resources")
+		}
+		out.Diffs = d
+	}
+
+	if in.GetSimulateDeletions() {
+		d, err := SimulateDeletions(ctx, in, out)
+		if err != nil {
+			return nil, errors.Wrap(err, "cannot simulate deletions")
+		}
+		out.Deletions = d
+	}
+
+	if !in.GetRecursive() {
+		return out, nil
+	}
+
+	// Like the top-level pipeline, a composed XR's pipeline returning a
+	// fatal result produces partial output. RenderNested records it in out,
+	// so we return both.
+	if err := RenderNested(ctx, log, in, out, depth); err != nil {
+		var pfe *composite.PipelineFatalError
+		if errors.As(err, &pfe) {
+			return out, err
+		}
+		return nil, err
+	}
+
+	return out, nil
+}
+
+// renderOnce runs one reconcile of the input XR.
+func renderOnce(ctx context.Context, log logging.Logger, in *renderv1alpha1.CompositeInput) (*renderv1alpha1.CompositeOutput, error) {
 	s := runtime.NewScheme()
 	if err := corev1.AddToScheme(s); err != nil {
 		return nil, errors.Wrap(err, "cannot add core/v1 to scheme")
@@ -76,7 +162,16 @@ func Render(ctx context.Context, log logging.Logger, in *renderv1alpha1.Composit
 	}
 	gvk := peek.GroupVersionKind()
 
-	cschema, err := selectSchema(gvk, in.GetCompositeResourceDefinition())
+	def := in.GetCompositeResourceDefinition()
+	if def == nil && in.GetRecursive() {
+		d, err := matchDefinition(gvk, in.GetCompositeResourceDefinitions())
+		if err != nil {
+			return nil, errors.Wrap(err, "cannot match composite resource definition")
+		}
+		def = d
+	}
+
+	cschema, err := selectSchema(gvk, def)
 	if err != nil {
 		return nil, errors.Wrap(err, "cannot select composite resource schema")
 	}
@@ -101,13 +196,18 @@ func Render(ctx context.Context, log logging.Logger, in *renderv1alpha1.Composit
 	// Set a resourceVersion to avoid "object has no resource version" errors.
 	xr.SetResourceVersion("999")
 
-	// Convert observed resources from protobuf.
+	// Convert observed resources from protobuf. When rendering recursively
+	// the observed resources may include resources composed by composed
+	// XRs. We leave those to the nested render calls.
 	observed := make([]kunstructured.Unstructured, 0, len(in.GetObservedResources()))
 	for _, s := range in.GetObservedResources() {
 		u := &kunstructured.Unstructured{}
 		if err := xfn.FromStruct(u, s); err != nil {
 			return nil, errors.Wrap(err, "cannot convert observed resource from protobuf")
 		}
+		if in.GetRecursive() && !belongsTo(u, xr.GetUID()) {
+			continue
+		}
 		observed = append(observed, *u)
 	}
 
@@ -163,7 +263,16 @@ func Render(ctx context.Context, log logging.Logger, in *renderv1alpha1.Composit
 
 	c := render.NewInMemoryClient(s, store...)
 
-	runner, err := render.NewFunctionRunner(in.GetFunctions())
+	// A diff is only useful if it reflects what an API server would store.
+	if in.GetServerSideApply() || in.GetDiff() {
+		tc, err := TypeConverter(in, def)
+		if err != nil {
+			return nil, errors.Wrap(err, "cannot build server-side apply type converter")
+		}
+		c.EnableServerSideApply(tc)
+	}
+
+	runner, err := render.NewRunner(in.GetFunctions(), in.GetFixtures())
 	if err != nil {
 		return nil, errors.Wrap(err, "cannot connect to functions")
 	}
@@ -177,8 +286,15 @@ func Render(ctx context.Context, log logging.Logger, in *renderv1alpha1.Composit
 	rsf := render.NewRecordingRequiredSchemasFetcher(sf)
 	rrf := render.NewRecordingRequiredResourcesFetcher(xfn.NewExistingRequiredResourcesFetcher(c))
 
-	fc := composite.NewFunctionComposer(c, c,
-		xfn.NewFetchingFunctionRunner(runner, rrf, rsf),
+	// Like the Pipeline Inspector, trace requests before and responses after
+	// requirement fetching.
+	var fr composite.FunctionRunner = xfn.NewFetchingFunctionRunner(runner, rrf, rsf)
+	ti := render.NewTracingPipelineInspector()
+	if in.GetTrace() {
+		fr = inspected.NewRunner(fr, ti, inspected.WithLogger(log))
+	}
+
+	fc := composite.NewFunctionComposer(c, c, fr,
 		composite.WithComposedResourceObserver(
 			composite.NewExistingComposedResourceObserver(c, c,
 				composite.NewSecretConnectionDetailsFetcher(c))),
@@ -206,6 +322,11 @@ func Render(ctx context.Context, log logging.Logger, in *renderv1alpha1.Composit
 				return rev, nil
 			},
 		)),
+		composite.WithCompositionRevisionResolver(composite.CompositionRevisionResolverFn(
+			func(_ context.Context, _ xpresource.Composite) (*apiextensionsv1.CompositionRevision, error) {
+				return rev, nil
+			},
+		)),
 		composite.WithConfigurator(composite.NewConfiguratorChain(
 			composite.NewAPINamingConfigurator(c),
 			composite.NewAPIConfigurator(c),
@@ -238,6 +359,16 @@ func Render(ctx context.Context, log logging.Logger, in *renderv1alpha1.Composit
 			u.GetNamespace() == xr.GetNamespace() &&
 			u.GetName() == xr.GetName()
 	}, rec, rrf, rsf)
+	if berr == nil {
+		out.Traces = ti.Traces()
+	}
+	if berr == nil && in.GetValidate() {
+		vr, err := Validate(ctx, in, def, out)
+		if err != nil {
+			return nil, errors.Join(rerr, errors.Wrap(err, "cannot validate rendered resources"))
+		}
+		out.ValidationResults = vr
+	}
 	switch {
 	case berr != nil:
 		// Surface BuildOutput's failure. If reconcile also failed, join
@@ -283,60 +414,92 @@ func selectSchema(gvk schema.GroupVersionKind, def *structpb.Struct) (ucomposite
 		return ucomposite.SchemaModern, nil
 	}
 
+	d, err := decodeDefinition(def)
+	if err != nil {
+		return ucomposite.SchemaModern, err
+	}
+
+	// Match only on Group+Kind: GetCompositeGroupVersionKind returns the
+	// XRD's referenceable version, but the input XR may legitimately be
+	// submitted under a served-but-not-referenceable version of the same
+	// XRD. Schema selection depends on XRD identity (Group+Kind), not on
+	// which served version the XR happens to use.
+	if d.GroupKind != gvk.GroupKind() {
+		return ucomposite.SchemaModern, errors.Errorf("CompositeResourceDefinition %q (composite Group=%s Kind=%s) does not match the input XR's Group=%s Kind=%s",
+			d.Name, d.GroupKind.Group, d.GroupKind.Kind, gvk.Group, gvk.Kind)
+	}
+
+	return d.Schema, nil
+}
+
+// A definition is the subset of a v1 or v2 CompositeResourceDefinition that
+// render needs.
+type definition struct {
+	Name      string
+	GroupKind schema.GroupKind
+	Schema    ucomposite.Schema
+
+	// DefaultComposition and EnforcedComposition are the names of the XRD's
+	// default and enforced Compositions, if any.
+	DefaultComposition  string
+	EnforcedComposition string
+}
+
+// decodeDefinition decodes a v1 or v2 CompositeResourceDefinition. See
+// selectSchema for how the composite.Schema is determined.
+func decodeDefinition(def *structpb.Struct) (*definition, error) {
 	const legacyClusterScope = string(apiextensionsv1.CompositeResourceScopeLegacyCluster)
 
 	u := &kunstructured.Unstructured{}
 	if err := xfn.FromStruct(u, def); err != nil {
-		return ucomposite.SchemaModern, errors.Wrap(err, "cannot decode CompositeResourceDefinition")
+		return nil, errors.Wrap(err, "cannot decode CompositeResourceDefinition")
 	}
 
-	var (
-		xrdGVK  schema.GroupVersionKind
-		xrdName = u.GetName()
-		cschema = ucomposite.SchemaModern
-	)
+	d := &definition{Name: u.GetName(), Schema: ucomposite.SchemaModern}
 
 	switch u.GetAPIVersion() {
 	case apiextensionsv1.SchemeGroupVersion.String():
 		xrd := &apiextensionsv1.CompositeResourceDefinition{}
 		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(u.Object, xrd); err != nil {
-			return ucomposite.SchemaModern, errors.Wrapf(err, "cannot decode v1 CompositeResourceDefinition %q", xrdName)
+			return nil, errors.Wrapf(err, "cannot decode v1 CompositeResourceDefinition %q", d.Name)
 		}
-		xrdGVK = xrd.GetCompositeGroupVersionKind()
+		d.GroupKind = xrd.GetCompositeGroupVersionKind().GroupKind()
 		if ptr.Deref(xrd.Spec.Scope, apiextensionsv1.CompositeResourceScopeLegacyCluster) == apiextensionsv1.CompositeResourceScopeLegacyCluster {
-			cschema = ucomposite.SchemaLegacy
+			d.Schema = ucomposite.SchemaLegacy
+		}
+		if ref := xrd.Spec.DefaultCompositionRef; ref != nil {
+			d.DefaultComposition = ref.Name
+		}
+		if ref := xrd.Spec.EnforcedCompositionRef; ref != nil {
+			d.EnforcedComposition = ref.Name
 		}
 	case apiextensionsv2.SchemeGroupVersion.String():
 		xrd := &apiextensionsv2.CompositeResourceDefinition{}
 		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(u.Object, xrd); err != nil {
-			return ucomposite.SchemaModern, errors.Wrapf(err, "cannot decode v2 CompositeResourceDefinition %q", xrdName)
+			return nil, errors.Wrapf(err, "cannot decode v2 CompositeResourceDefinition %q", d.Name)
 		}
-		xrdGVK = xrd.GetCompositeGroupVersionKind()
+		d.GroupKind = xrd.GetCompositeGroupVersionKind().GroupKind()
 		// Counterintuitively, we can see LegacyCluster on v2 objects. See
-		// function comment.
+		// selectSchema's comment.
 		if string(xrd.Spec.Scope) == legacyClusterScope {
-			cschema = ucomposite.SchemaLegacy
+			d.Schema = ucomposite.SchemaLegacy
+		}
+		if ref := xrd.Spec.DefaultCompositionRef; ref != nil {
+			d.DefaultComposition = ref.Name
+		}
+		if ref := xrd.Spec.EnforcedCompositionRef; ref != nil {
+			d.EnforcedComposition = ref.Name
 		}
 	default:
-		return ucomposite.SchemaModern, errors.Errorf("CompositeResourceDefinition %q has unrecognized apiVersion %q (expected one of %v)",
-			xrdName, u.GetAPIVersion(),
+		return nil, errors.Errorf("CompositeResourceDefinition %q has unrecognized apiVersion %q (expected one of %v)",
+			d.Name, u.GetAPIVersion(),
 			[]string{
 				apiextensionsv1.SchemeGroupVersion.String(),
 				apiextensionsv2.SchemeGroupVersion.String(),
 			})
 	}
 
-	// Match only on Group+Kind: GetCompositeGroupVersionKind returns the
-	// XRD's referenceable version, but the input XR may legitimately be
-	// submitted under a served-but-not-referenceable version of the same
-	// XRD. Schema selection depends on XRD identity (Group+Kind), not on
-	// which served version the XR happens to use.
-	if xrdGVK.Group != gvk.Group || xrdGVK.Kind != gvk.Kind {
-		return ucomposite.SchemaModern, errors.Errorf("CompositeResourceDefinition %q (composite Group=%s Kind=%s) does not match the input XR's Group=%s Kind=%s",
-			xrdName, xrdGVK.Group, xrdGVK.Kind, gvk.Group, gvk.Kind)
-	}
-
-	return cschema, nil
+	return d, nil
 }
 
 // CheckObservedResources validates that all observed resources will be
@@ -110,6 +110,7 @@ func TestRender(t *testing.T) {
 					Events: []*renderv1alpha1.Event{
 						{Type: "Normal", Reason: "SelectComposition", Message: "Successfully selected composition: bucket-composition"},
 					},
+					Reconciles: 1,
 				},
 			},
 		},
@@ -173,6 +174,7 @@ func TestRender(t *testing.T) {
 					Events: []*renderv1alpha1.Event{
 						{Type: "Normal", Reason: "SelectComposition", Message: "Successfully selected composition: modern-composition"},
 					},
+					Reconciles: 1,
 				},
 			},
 		},
@@ -234,6 +236,7 @@ func TestRender(t *testing.T) {
 					Events: []*renderv1alpha1.Event{
 						{Type: "Normal", Reason: "SelectComposition", Message: "Successfully selected composition: legacy-composition"},
 					},
+					Reconciles: 1,
 				},
 			},
 		},
@@ -296,6 +299,7 @@ func TestRender(t *testing.T) {
 					Events: []*renderv1alpha1.Event{
 						{Type: "Normal", Reason: "SelectComposition", Message: "Successfully selected composition: legacy-composition"},
 					},
+					Reconciles: 1,
 				},
 			},
 		},
@@ -401,6 +405,7 @@ func TestRender(t *testing.T) {
 					Events: []*renderv1alpha1.Event{
 						{Type: "Normal", Reason: "SelectComposition", Message: "Successfully selected composition: legacy-composition"},
 					},
+					Reconciles: 1,
 				},
 			},
 		},
@@ -567,6 +572,78 @@ func TestSelectSchema(t *testing.T) {
 	}
 }
 
+func TestRenderFixtures(t *testing.T) {
+	// input returns an XR that became Ready at the supplied time, and whose
+	// Composition composes a ConfigMap.
+	input := func(lastTransitionTime string, fns []*renderv1alpha1.FunctionInput, fx *renderv1alpha1.FunctionFixtures) *renderv1alpha1.CompositeInput {
+		return &renderv1alpha1.CompositeInput{
+			CompositeResource: mustStruct(map[string]any{
+				"apiVersion": "example.org/v1alpha1",
+				"kind":       "XBucket",
+				"metadata":   map[string]any{"name": "my-bucket"},
+				"status": map[string]any{
+					"conditions": []any{
+						map[string]any{"type": "Ready", "status": "True", "reason": "Available", "lastTransitionTime": lastTransitionTime},
+					},
+				},
+			}),
+			Composition: mustStruct(map[string]any{
+				"metadata": map[string]any{"name": "bucket-composition"},
+				"spec": map[string]any{
+					"compositeTypeRef": map[string]any{
+						"apiVersion": "example.org/v1alpha1",
+						"kind":       "XBucket",
+					},
+					"mode": "Pipeline",
+					"pipeline": []any{
+						map[string]any{
+							"step":        "compose",
+							"functionRef": map[string]any{"name": "function-compose"},
+						},
+					},
+				},
+			}),
+			Functions: fns,
+			Fixtures:  fx,
+		}
+	}
+
+	addr := rendertest.StartFunctionServer(t, &rendertest.ComposeFunctionServer{
+		Compose: func(_ *structpb.Struct) map[string]*structpb.Struct {
+			return map[string]*structpb.Struct{"bucket": mustStruct(map[string]any{
+				"apiVersion": "v1",
+				"kind":       "ConfigMap",
+				"metadata":   map[string]any{"name": "my-bucket"},
+			})}
+		},
+	})
+	dir := t.TempDir()
+
+	recorded, err := Render(t.Context(), logging.NewNopLogger(), input("2026-01-01T00:00:00Z",
+		[]*renderv1alpha1.FunctionInput{{Name: "function-compose", Address: addr}},
+		&renderv1alpha1.FunctionFixtures{Directory: dir, Mode: renderv1alpha1.FixtureMode_FIXTURE_MODE_RECORD}))
+	if err != nil {
+		t.Fatalf("\nRender(...) in record mode: unexpected error: %v", err)
+	}
+
+	// Replaying doesn't need any functions. The XR differs from the recorded
+	// one only in volatile fields, which shouldn't affect which fixture is
+	// replayed.
+	replayed, err := Render(t.Context(), logging.NewNopLogger(), input("2026-01-02T00:00:00Z",
+		nil,
+		&renderv1alpha1.FunctionFixtures{Directory: dir, Mode: renderv1alpha1.FixtureMode_FIXTURE_MODE_REPLAY}))
+	if err != nil {
+		t.Fatalf("\nRender(...) in replay mode: unexpected error: %v", err)
+	}
+
+	if diff := cmp.Diff(recorded, replayed, cmpopts.EquateEmpty(), protocmp.Transform(), ignoreTimestamps); diff != "" {
+		t.Errorf("\nRender(...): replaying recorded fixtures should produce the recorded output: -recorded, +replayed:\n%s", diff)
+	}
+	if len(replayed.GetComposedResources()) != 1 {
+		t.Errorf("\nRender(...): want 1 composed resource from the replayed function response, got %d", len(replayed.GetComposedResources()))
+	}
+}
+
 func TestRenderErrors(t *testing.T) {
 	// Constants for the FATAL case shared between request construction and
 	// expected-result assertions.
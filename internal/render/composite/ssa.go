@@ -0,0 +1,131 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package composite
+
+import (
+	"encoding/json"
+	"maps"
+	"strings"
+
+	"google.golang.org/protobuf/types/known/structpb"
+	kunstructured "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/managedfields"
+	"k8s.io/kube-openapi/pkg/validation/spec"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/errors"
+
+	"github.com/crossplane/crossplane/v2/internal/render"
+	"github.com/crossplane/crossplane/v2/internal/xfn"
+	renderv1alpha1 "github.com/crossplane/crossplane/v2/proto/render/v1alpha1"
+)
+
+// TypeConverter returns the TypeConverter render uses to server-side apply
+// resources. It uses the input's required schemas, and the schemas of the
+// supplied XRD and the input's XRDs. An XRD's schema takes precedence over a
+// required schema for the same kind.
+func TypeConverter(in *renderv1alpha1.CompositeInput, def *structpb.Struct) (managedfields.TypeConverter, error) {
+	schemas, err := render.OpenAPISchemas(in.GetRequiredSchemas())
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot get schemas from required schemas")
+	}
+
+	defs := in.GetCompositeResourceDefinitions()
+	if def != nil {
+		defs = append([]*structpb.Struct{def}, defs...)
+	}
+
+	for _, d := range defs {
+		ds, err := DefinitionSchemas(d)
+		if err != nil {
+			return nil, errors.Wrap(err, "cannot get schemas from composite resource definition")
+		}
+		maps.Copy(schemas, ds)
+	}
+
+	return render.NewTypeConverter(schemas)
+}
+
+// DefinitionSchemas returns an OpenAPI schema for each version of the composite
+// resource the supplied v1 or v2 CompositeResourceDefinition defines, keyed by
+// name. Each schema declares its GVK using x-kubernetes-group-version-kind.
+//
+// An XRD's schema describes only the XR's spec and status. Each returned
+// schema adds apiVersion, kind, and metadata. Crossplane adds fields to XRs
+// that their XRD's schema doesn't include, so metadata and any other unknown
+// fields are merged as if they had no schema.
+func DefinitionSchemas(def *structpb.Struct) (map[string]*spec.Schema, error) {
+	u := &kunstructured.Unstructured{}
+	if err := xfn.FromStruct(u, def); err != nil {
+		return nil, errors.Wrap(err, "cannot decode CompositeResourceDefinition")
+	}
+
+	group, _, _ := kunstructured.NestedString(u.Object, "spec", "group")
+	kind, _, _ := kunstructured.NestedString(u.Object, "spec", "names", "kind")
+	versions, _, _ := kunstructured.NestedSlice(u.Object, "spec", "versions")
+
+	schemas := make(map[string]*spec.Schema, len(versions))
+	for _, raw := range versions {
+		v, ok := raw.(map[string]any)
+		if !ok {
+			continue
+		}
+		version, _, _ := kunstructured.NestedString(v, "name")
+		props, ok, _ := kunstructured.NestedMap(v, "schema", "openAPIV3Schema")
+		if !ok {
+			continue
+		}
+
+		b, err := json.Marshal(props)
+		if err != nil {
+			return nil, errors.Wrapf(err, "cannot marshal schema for version %q of CompositeResourceDefinition %q", version, u.GetName())
+		}
+		s := &spec.Schema{}
+		if err := json.Unmarshal(b, s); err != nil {
+			return nil, errors.Wrapf(err, "cannot parse schema for version %q of CompositeResourceDefinition %q", version, u.GetName())
+		}
+
+		if s.Properties == nil {
+			s.Properties = map[string]spec.Schema{}
+		}
+		s.Type = spec.StringOrArray{"object"}
+		s.Properties["apiVersion"] = *spec.StringProperty()
+		s.Properties["kind"] = *spec.StringProperty()
+		s.Properties["metadata"] = spec.Schema{
+			SchemaProps:      spec.SchemaProps{Type: spec.StringOrArray{"object"}},
+			VendorExtensible: spec.VendorExtensible{Extensions: spec.Extensions{"x-kubernetes-preserve-unknown-fields": true}},
+		}
+		s.AddExtension("x-kubernetes-group-version-kind", []any{
+			map[string]any{"group": group, "version": version, "kind": kind},
+		})
+
+		// Name schemas the way Kubernetes names CRD schemas in its OpenAPI
+		// documents, e.g. org.example.v1.XBucket.
+		schemas[reverseDomain(group)+"."+version+"."+kind] = s
+	}
+
+	return schemas, nil
+}
+
+// reverseDomain reverses the supplied DNS name, e.g. example.org becomes
+// org.example.
+func reverseDomain(name string) string {
+	parts := strings.Split(name, ".")
+	for i, j := 0, len(parts)-1; i < j; i, j = i+1, j-1 {
+		parts[i], parts[j] = parts[j], parts[i]
+	}
+	return strings.Join(parts, ".")
+}
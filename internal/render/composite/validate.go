@@ -0,0 +1,118 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package composite
+
+import (
+	"context"
+
+	"google.golang.org/protobuf/types/known/structpb"
+	extv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	kunstructured "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/xcrd"
+
+	apiextensionsv1 "github.com/crossplane/crossplane/apis/v2/apiextensions/v1"
+	apiextensionsv2 "github.com/crossplane/crossplane/apis/v2/apiextensions/v2"
+	"github.com/crossplane/crossplane/v2/internal/render"
+	"github.com/crossplane/crossplane/v2/internal/xfn"
+	renderv1alpha1 "github.com/crossplane/crossplane/v2/proto/render/v1alpha1"
+)
+
+// Validate validates the output XR and composed resources against their
+// schemas. It uses the input's required schemas, and the schemas of the CRDs
+// the supplied XRD and the input's XRDs define. An XRD's schema takes
+// precedence over a required schema for the same kind. It returns a result
+// for each resource that failed validation.
+func Validate(ctx context.Context, in *renderv1alpha1.CompositeInput, def *structpb.Struct, out *renderv1alpha1.CompositeOutput) ([]*renderv1alpha1.ValidationResult, error) {
+	schemas, err := render.ValidationSchemas(in.GetRequiredSchemas())
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot get schemas from required schemas")
+	}
+
+	defs := in.GetCompositeResourceDefinitions()
+	if def != nil {
+		defs = append([]*structpb.Struct{def}, defs...)
+	}
+
+	for _, d := range defs {
+		crd, err := DefinitionCRD(d)
+		if err != nil {
+			return nil, errors.Wrap(err, "cannot render CRD for composite resource definition")
+		}
+		for _, v := range crd.Spec.Versions {
+			if v.Schema == nil || v.Schema.OpenAPIV3Schema == nil {
+				continue
+			}
+			gvk := schema.GroupVersionKind{Group: crd.Spec.Group, Version: v.Name, Kind: crd.Spec.Names.Kind}
+			schemas[gvk] = v.Schema.OpenAPIV3Schema
+		}
+	}
+
+	v, err := render.NewSchemaValidator(schemas)
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot create schema validator")
+	}
+
+	resources := make([]*structpb.Struct, 0, len(out.GetComposedResources())+1)
+	if out.GetCompositeResource() != nil {
+		resources = append(resources, out.GetCompositeResource())
+	}
+	resources = append(resources, out.GetComposedResources()...)
+
+	var results []*renderv1alpha1.ValidationResult
+	for _, s := range resources {
+		u := &kunstructured.Unstructured{}
+		if err := xfn.FromStruct(u, s); err != nil {
+			return nil, errors.Wrap(err, "cannot convert resource from protobuf")
+		}
+		errs, ok := v.Validate(ctx, u)
+		if !ok || len(errs) == 0 {
+			continue
+		}
+		results = append(results, render.AsValidationResult(u, errs))
+	}
+
+	return results, nil
+}
+
+// DefinitionCRD returns the CRD the XRD controller would create for the
+// supplied v1 or v2 CompositeResourceDefinition.
+func DefinitionCRD(def *structpb.Struct) (*extv1.CustomResourceDefinition, error) {
+	u := &kunstructured.Unstructured{}
+	if err := xfn.FromStruct(u, def); err != nil {
+		return nil, errors.Wrap(err, "cannot decode CompositeResourceDefinition")
+	}
+
+	// The XRD controller works with v1 XRDs. v1 is the storage version, and
+	// XRDs don't use conversion, so a v2 XRD decodes as a v1 XRD. See
+	// selectSchema. The exception is the scope, which defaults to Namespaced
+	// in v2 but LegacyCluster in v1.
+	xrd := &apiextensionsv1.CompositeResourceDefinition{}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(u.Object, xrd); err != nil {
+		return nil, errors.Wrapf(err, "cannot decode CompositeResourceDefinition %q", u.GetName())
+	}
+	if u.GetAPIVersion() == apiextensionsv2.SchemeGroupVersion.String() && xrd.Spec.Scope == nil {
+		scope := apiextensionsv1.CompositeResourceScopeNamespaced
+		xrd.Spec.Scope = &scope
+	}
+
+	crd, err := xcrd.ForCompositeResource(xrd)
+	return crd, errors.Wrapf(err, "cannot render CRD for CompositeResourceDefinition %q", u.GetName())
+}
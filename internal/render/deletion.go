@@ -0,0 +1,199 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package render
+
+import (
+	"context"
+	"fmt"
+
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/xcrd"
+
+	legacy "github.com/crossplane/crossplane/apis/v2/apiextensions/v1beta1"
+	"github.com/crossplane/crossplane/apis/v2/protection/v1beta1"
+	"github.com/crossplane/crossplane/v2/internal/protection"
+	"github.com/crossplane/crossplane/v2/internal/protection/usage"
+	renderv1alpha1 "github.com/crossplane/crossplane/v2/proto/render/v1alpha1"
+)
+
+// SimulateDeletions simulates deleting the supplied resources from the
+// supplied client, in waves. Each wave deletes every resource whose deletion
+// isn't blocked:
+//
+//   - Crossplane's Usage admission webhook blocks the deletion of a resource
+//     while any usage of it exists.
+//   - The Usage controller blocks the deletion of a usage while the resource
+//     using the used resource exists.
+//
+// It returns a ResourceDeletion for each supplied resource, in the order they
+// were supplied. The client must contain the supplied resources, and any usages
+// and resources using them. SimulateDeletions adds field indexes to the client,
+// so it can only be called once per client.
+func SimulateDeletions(ctx context.Context, c *InMemoryClient, deleting []unstructured.Unstructured) ([]*renderv1alpha1.ResourceDeletion, error) {
+	f, err := usage.NewFinder(c, c)
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot create usage finder")
+	}
+
+	out := make([]*renderv1alpha1.ResourceDeletion, len(deleting))
+	pending := make([]int, len(deleting))
+	for i := range deleting {
+		out[i] = &renderv1alpha1.ResourceDeletion{
+			ApiVersion:              deleting[i].GetAPIVersion(),
+			Kind:                    deleting[i].GetKind(),
+			Namespace:               deleting[i].GetNamespace(),
+			Name:                    deleting[i].GetName(),
+			CompositionResourceName: xcrd.GetCompositionResourceName(&deleting[i]),
+		}
+		pending[i] = i
+	}
+
+	for wave := int32(1); len(pending) > 0; wave++ {
+		// Decide which deletions succeed before making any of them, so
+		// deletions in the same wave don't unblock each other.
+		var blocked, deletable []int
+		for _, i := range pending {
+			reason, err := blockedBy(ctx, c, f, &deleting[i])
+			if err != nil {
+				return nil, errors.Wrapf(err, "cannot determine whether deletion of %s %q is blocked", deleting[i].GetKind(), deleting[i].GetName())
+			}
+			out[i].BlockedReason = reason
+			if reason != "" {
+				blocked = append(blocked, i)
+				continue
+			}
+			deletable = append(deletable, i)
+		}
+
+		// No deletions succeeded, so none will. Each blocked deletion's
+		// reason is why it's blocked indefinitely.
+		if len(deletable) == 0 {
+			break
+		}
+
+		for _, i := range deletable {
+			if err := c.Delete(ctx, &deleting[i]); err != nil {
+				return nil, errors.Wrapf(err, "cannot delete %s %q", deleting[i].GetKind(), deleting[i].GetName())
+			}
+			out[i].Wave = wave
+		}
+		pending = blocked
+	}
+
+	return out, nil
+}
+
+// blockedBy returns why the deletion of the supplied resource is blocked, or
+// an empty string if it isn't.
+func blockedBy(ctx context.Context, c *InMemoryClient, f *usage.Finder, u *unstructured.Unstructured) (string, error) {
+	uu, isUsage, err := asUsage(u)
+	if err != nil {
+		return "", err
+	}
+
+	// The Usage controller removes a usage's finalizer once the resource
+	// using the used resource is gone.
+	if by := usedBy(uu, isUsage); by != nil {
+		using := &unstructured.Unstructured{}
+		using.SetAPIVersion(by.APIVersion)
+		using.SetKind(by.Kind)
+		key := types.NamespacedName{Namespace: ptr.Deref(by.ResourceRef.Namespace, u.GetNamespace()), Name: by.ResourceRef.Name}
+		err := c.Get(ctx, key, using)
+		if err == nil {
+			return fmt.Sprintf("waiting for the using resource (a %q named %q) to be deleted", by.Kind, by.ResourceRef.Name), nil
+		}
+		if !kerrors.IsNotFound(err) {
+			return "", errors.Wrapf(err, "cannot get using resource %s %q", by.Kind, by.ResourceRef.Name)
+		}
+	}
+
+	usages, err := f.FindUsageOf(ctx, u)
+	if err != nil {
+		return "", errors.Wrap(err, "cannot find usages")
+	}
+	if len(usages) == 0 {
+		return "", nil
+	}
+	return inUseMessage(usages), nil
+}
+
+// usedBy returns the resource using the resource the supplied usage protects,
+// if the usage references it by name.
+func usedBy(u protection.Usage, isUsage bool) *protection.Resource {
+	if !isUsage {
+		return nil
+	}
+	by := u.GetUsedBy()
+	if by == nil || by.ResourceRef == nil {
+		return nil
+	}
+	return by
+}
+
+// inUseMessage returns a message describing the supplied usages, like the one
+// Crossplane's Usage admission webhook returns.
+func inUseMessage(u []protection.Usage) string {
+	first := u[0]
+	uu := first.Unwrap()
+
+	id := fmt.Sprintf("%q", uu.GetName())
+	if uu.GetNamespace() != "" {
+		id = fmt.Sprintf("%q (in namespace %q)", uu.GetName(), uu.GetNamespace())
+	}
+
+	if by := first.GetUsedBy(); by != nil && by.ResourceRef != nil {
+		return fmt.Sprintf("in use by %d usage(s), including the %T %s by resource %s/%s", len(u), uu, id, by.Kind, by.ResourceRef.Name)
+	}
+	if r := ptr.Deref(first.GetReason(), ""); r != "" {
+		return fmt.Sprintf("in use by %d usage(s), including the %T %s with reason: %q", len(u), uu, id, r)
+	}
+	return fmt.Sprintf("in use by %d usage(s), including the %T %s", len(u), uu, id)
+}
+
+// asUsage returns the supplied resource as a usage, and true, if it's a Usage,
+// ClusterUsage, or legacy Usage.
+func asUsage(u *unstructured.Unstructured) (protection.Usage, bool, error) {
+	var (
+		pu  protection.Usage
+		obj runtime.Object
+	)
+
+	switch u.GroupVersionKind() {
+	case v1beta1.UsageGroupVersionKind:
+		iu := &protection.InternalUsage{}
+		pu, obj = iu, &iu.Usage
+	case v1beta1.ClusterUsageGroupVersionKind:
+		iu := &protection.InternalClusterUsage{}
+		pu, obj = iu, &iu.ClusterUsage
+	case legacy.UsageGroupVersionKind:
+		iu := &protection.InternalLegacyUsage{}
+		pu, obj = iu, &iu.Usage //nolint:staticcheck // Usage is deprecated but we still need to support it.
+	default:
+		return nil, false, nil
+	}
+
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(u.Object, obj); err != nil {
+		return nil, false, errors.Wrapf(err, "cannot convert %s %q", u.GetKind(), u.GetName())
+	}
+	return pu, true, nil
+}
@@ -0,0 +1,174 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package render
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/protobuf/testing/protocmp"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	apis "github.com/crossplane/crossplane/apis/v2"
+	renderv1alpha1 "github.com/crossplane/crossplane/v2/proto/render/v1alpha1"
+)
+
+func TestSimulateDeletions(t *testing.T) {
+	s := runtime.NewScheme()
+	if err := apis.AddToScheme(s); err != nil {
+		t.Fatal(err)
+	}
+
+	db := unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "example.org/v1",
+		"kind":       "Database",
+		"metadata": map[string]any{
+			"name":      "db",
+			"namespace": "default",
+			"annotations": map[string]any{
+				"crossplane.io/composition-resource-name": "database",
+			},
+		},
+	}}
+	app := unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "example.org/v1",
+		"kind":       "App",
+		"metadata":   map[string]any{"name": "app", "namespace": "default"},
+	}}
+	usageBy := unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "protection.crossplane.io/v1beta1",
+		"kind":       "Usage",
+		"metadata":   map[string]any{"name": "app-uses-db", "namespace": "default"},
+		"spec": map[string]any{
+			"of": map[string]any{
+				"apiVersion":  "example.org/v1",
+				"kind":        "Database",
+				"resourceRef": map[string]any{"name": "db"},
+			},
+			"by": map[string]any{
+				"apiVersion":  "example.org/v1",
+				"kind":        "App",
+				"resourceRef": map[string]any{"name": "app"},
+			},
+		},
+	}}
+	usageReason := unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "protection.crossplane.io/v1beta1",
+		"kind":       "Usage",
+		"metadata":   map[string]any{"name": "protect-db", "namespace": "default"},
+		"spec": map[string]any{
+			"of": map[string]any{
+				"apiVersion":  "example.org/v1",
+				"kind":        "Database",
+				"resourceRef": map[string]any{"name": "db"},
+			},
+			"reason": "production data",
+		},
+	}}
+
+	type args struct {
+		store    []unstructured.Unstructured
+		deleting []unstructured.Unstructured
+	}
+
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   []*renderv1alpha1.ResourceDeletion
+	}{
+		"NoUsages": {
+			reason: "Resources without usages should all be deleted in the first wave.",
+			args: args{
+				store:    []unstructured.Unstructured{db, app},
+				deleting: []unstructured.Unstructured{db, app},
+			},
+			want: []*renderv1alpha1.ResourceDeletion{
+				{ApiVersion: "example.org/v1", Kind: "Database", Namespace: "default", Name: "db", CompositionResourceName: "database", Wave: 1},
+				{ApiVersion: "example.org/v1", Kind: "App", Namespace: "default", Name: "app", Wave: 1},
+			},
+		},
+		"UsageByResource": {
+			reason: "A used resource should be deleted after its usage, which should be deleted after the using resource.",
+			args: args{
+				store:    []unstructured.Unstructured{db, app, usageBy},
+				deleting: []unstructured.Unstructured{db, usageBy, app},
+			},
+			want: []*renderv1alpha1.ResourceDeletion{
+				{ApiVersion: "example.org/v1", Kind: "Database", Namespace: "default", Name: "db", CompositionResourceName: "database", Wave: 3},
+				{ApiVersion: "protection.crossplane.io/v1beta1", Kind: "Usage", Namespace: "default", Name: "app-uses-db", Wave: 2},
+				{ApiVersion: "example.org/v1", Kind: "App", Namespace: "default", Name: "app", Wave: 1},
+			},
+		},
+		"UsageWithReason": {
+			reason: "A resource with a usage that isn't being deleted should be blocked indefinitely.",
+			args: args{
+				store:    []unstructured.Unstructured{db, usageReason},
+				deleting: []unstructured.Unstructured{db},
+			},
+			want: []*renderv1alpha1.ResourceDeletion{
+				{
+					ApiVersion:              "example.org/v1",
+					Kind:                    "Database",
+					Namespace:               "default",
+					Name:                    "db",
+					CompositionResourceName: "database",
+					BlockedReason:           `in use by 1 usage(s), including the *v1beta1.Usage "protect-db" (in namespace "default") with reason: "production data"`,
+				},
+			},
+		},
+		"UsingResourceNotDeleted": {
+			reason: "A usage whose using resource isn't being deleted should be blocked indefinitely, as should the resource it protects.",
+			args: args{
+				store:    []unstructured.Unstructured{db, app, usageBy},
+				deleting: []unstructured.Unstructured{db, usageBy},
+			},
+			want: []*renderv1alpha1.ResourceDeletion{
+				{
+					ApiVersion:              "example.org/v1",
+					Kind:                    "Database",
+					Namespace:               "default",
+					Name:                    "db",
+					CompositionResourceName: "database",
+					BlockedReason:           `in use by 1 usage(s), including the *v1beta1.Usage "app-uses-db" (in namespace "default") by resource App/app`,
+				},
+				{
+					ApiVersion:    "protection.crossplane.io/v1beta1",
+					Kind:          "Usage",
+					Namespace:     "default",
+					Name:          "app-uses-db",
+					BlockedReason: `waiting for the using resource (a "App" named "app") to be deleted`,
+				},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			c := NewInMemoryClient(s, tc.args.store...)
+
+			got, err := SimulateDeletions(context.Background(), c, tc.args.deleting)
+			if err != nil {
+				t.Fatalf("\n%s\nSimulateDeletions(...): unexpected error: %v", tc.reason, err)
+			}
+			if diff := cmp.Diff(tc.want, got, protocmp.Transform()); diff != "" {
+				t.Errorf("\n%s\nSimulateDeletions(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
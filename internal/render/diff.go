@@ -0,0 +1,120 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package render
+
+import (
+	"maps"
+	"reflect"
+	"slices"
+	"strings"
+
+	"google.golang.org/protobuf/types/known/structpb"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/errors"
+
+	renderv1alpha1 "github.com/crossplane/crossplane/v2/proto/render/v1alpha1"
+)
+
+// JSON patch (RFC 6902) operations.
+const (
+	OpAdd     = "add"
+	OpRemove  = "remove"
+	OpReplace = "replace"
+)
+
+// ignoredPaths are fields that change whenever a resource is written, and so
+// aren't interesting when diffing resources.
+var ignoredPaths = map[string]bool{
+	"/metadata/managedFields":   true,
+	"/metadata/resourceVersion": true,
+}
+
+// JSONPatch returns a JSON patch (RFC 6902) that transforms the observed
+// resource into the desired resource. Objects are compared field by field, in
+// sorted order. Arrays are replaced as a whole, because the JSON patch of an
+// array depends on whether it's a set, a map, or an atomic list.
+func JSONPatch(observed, desired *unstructured.Unstructured) ([]*renderv1alpha1.JSONPatchOperation, error) {
+	ops, err := diffObjects("", observed.UnstructuredContent(), desired.UnstructuredContent())
+	return ops, errors.Wrap(err, "cannot compute JSON patch")
+}
+
+func diffObjects(path string, observed, desired map[string]any) ([]*renderv1alpha1.JSONPatchOperation, error) {
+	keys := slices.Collect(maps.Keys(observed))
+	for k := range desired {
+		if _, ok := observed[k]; !ok {
+			keys = append(keys, k)
+		}
+	}
+	slices.Sort(keys)
+
+	var ops []*renderv1alpha1.JSONPatchOperation
+	for _, k := range keys {
+		p := path + "/" + escapePointer(k)
+		if ignoredPaths[p] {
+			continue
+		}
+
+		o, inObserved := observed[k]
+		d, inDesired := desired[k]
+
+		switch {
+		case !inDesired:
+			ops = append(ops, &renderv1alpha1.JSONPatchOperation{Op: OpRemove, Path: p})
+		case !inObserved:
+			op, err := newOperation(OpAdd, p, d)
+			if err != nil {
+				return nil, err
+			}
+			ops = append(ops, op)
+		default:
+			om, oIsMap := o.(map[string]any)
+			dm, dIsMap := d.(map[string]any)
+			if oIsMap && dIsMap {
+				nested, err := diffObjects(p, om, dm)
+				if err != nil {
+					return nil, err
+				}
+				ops = append(ops, nested...)
+				continue
+			}
+			if reflect.DeepEqual(o, d) {
+				continue
+			}
+			op, err := newOperation(OpReplace, p, d)
+			if err != nil {
+				return nil, err
+			}
+			ops = append(ops, op)
+		}
+	}
+
+	return ops, nil
+}
+
+func newOperation(op, path string, value any) (*renderv1alpha1.JSONPatchOperation, error) {
+	v, err := structpb.NewValue(value)
+	if err != nil {
+		return nil, errors.Wrapf(err, "cannot convert value at %s to protobuf", path)
+	}
+	return &renderv1alpha1.JSONPatchOperation{Op: op, Path: path, Value: v}, nil
+}
+
+// escapePointer escapes the supplied key for use in a JSON pointer (RFC 6901).
+func escapePointer(key string) string {
+	return strings.NewReplacer("~", "~0", "/", "~1").Replace(key)
+}
@@ -0,0 +1,130 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package render
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"google.golang.org/protobuf/testing/protocmp"
+	"google.golang.org/protobuf/types/known/structpb"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/test"
+
+	renderv1alpha1 "github.com/crossplane/crossplane/v2/proto/render/v1alpha1"
+)
+
+func TestJSONPatch(t *testing.T) {
+	type args struct {
+		observed map[string]any
+		desired  map[string]any
+	}
+	type want struct {
+		ops []*renderv1alpha1.JSONPatchOperation
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   want
+	}{
+		"Unchanged": {
+			reason: "Identical resources should produce an empty patch.",
+			args: args{
+				observed: map[string]any{"spec": map[string]any{"region": "us-east-1"}},
+				desired:  map[string]any{"spec": map[string]any{"region": "us-east-1"}},
+			},
+			want: want{},
+		},
+		"AddRemoveReplace": {
+			reason: "Fields only in the desired resource should be added, fields only in the observed resource removed, and changed fields replaced, in sorted order.",
+			args: args{
+				observed: map[string]any{"spec": map[string]any{
+					"region": "us-east-1",
+					"size":   int64(1),
+				}},
+				desired: map[string]any{"spec": map[string]any{
+					"region": "eu-west-1",
+					"tags":   map[string]any{"team": "a"},
+				}},
+			},
+			want: want{
+				ops: []*renderv1alpha1.JSONPatchOperation{
+					{Op: OpReplace, Path: "/spec/region", Value: structpb.NewStringValue("eu-west-1")},
+					{Op: OpRemove, Path: "/spec/size"},
+					{Op: OpAdd, Path: "/spec/tags", Value: structpb.NewStructValue(&structpb.Struct{Fields: map[string]*structpb.Value{
+						"team": structpb.NewStringValue("a"),
+					}})},
+				},
+			},
+		},
+		"ReplaceArray": {
+			reason: "A changed array should be replaced as a whole.",
+			args: args{
+				observed: map[string]any{"spec": map[string]any{"zones": []any{"a", "b"}}},
+				desired:  map[string]any{"spec": map[string]any{"zones": []any{"a", "c"}}},
+			},
+			want: want{
+				ops: []*renderv1alpha1.JSONPatchOperation{
+					{Op: OpReplace, Path: "/spec/zones", Value: structpb.NewListValue(&structpb.ListValue{Values: []*structpb.Value{
+						structpb.NewStringValue("a"),
+						structpb.NewStringValue("c"),
+					}})},
+				},
+			},
+		},
+		"EscapeSpecialCharacters": {
+			reason: "Keys containing / or ~ should be escaped per RFC 6901.",
+			args: args{
+				observed: map[string]any{"metadata": map[string]any{"labels": map[string]any{"example.org/a~b": "x"}}},
+				desired:  map[string]any{"metadata": map[string]any{"labels": map[string]any{"example.org/a~b": "y"}}},
+			},
+			want: want{
+				ops: []*renderv1alpha1.JSONPatchOperation{
+					{Op: OpReplace, Path: "/metadata/labels/example.org~1a~0b", Value: structpb.NewStringValue("y")},
+				},
+			},
+		},
+		"IgnoreVolatileFields": {
+			reason: "Changes to managed fields and resource version should be ignored.",
+			args: args{
+				observed: map[string]any{"metadata": map[string]any{"resourceVersion": "1"}},
+				desired: map[string]any{"metadata": map[string]any{
+					"resourceVersion": "2",
+					"managedFields":   []any{map[string]any{"manager": "crossplane"}},
+				}},
+			},
+			want: want{},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			ops, err := JSONPatch(&unstructured.Unstructured{Object: tc.args.observed}, &unstructured.Unstructured{Object: tc.args.desired})
+
+			if diff := cmp.Diff(tc.want.ops, ops, protocmp.Transform(), cmpopts.EquateEmpty()); diff != "" {
+				t.Errorf("\n%s\nJSONPatch(...): -want, +got:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nJSONPatch(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
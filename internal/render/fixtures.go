@@ -0,0 +1,216 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package render
+
+import (
+	"context"
+	"encoding/json"
+	"io/fs"
+	"path/filepath"
+
+	"github.com/spf13/afero"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/structpb"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/errors"
+
+	"github.com/crossplane/crossplane/v2/internal/xfn"
+	"github.com/crossplane/crossplane/v2/internal/xfn/inspected"
+	fnv1 "github.com/crossplane/crossplane/v2/proto/fn/v1"
+)
+
+// A fixture is a function request and the function's response to it.
+type fixture struct {
+	Request  json.RawMessage `json:"request"`
+	Response json.RawMessage `json:"response"`
+}
+
+// FixtureKey returns the key of the fixture for the supplied request. It's the
+// xfn.Tag of the request, ignoring the request's metadata, its credentials,
+// and any volatile resource fields. We don't use the tag in the request's
+// metadata because the FetchingFunctionRunner doesn't update it when it adds
+// required resources to a request. We ignore credentials because they're
+// redacted from recorded requests, and may differ between recording and
+// replaying.
+func FixtureKey(req *fnv1.RunFunctionRequest) string {
+	r := proto.CloneOf(req)
+	r.Meta = nil
+	r.Credentials = nil
+
+	for _, st := range []*fnv1.State{r.GetObserved(), r.GetDesired()} {
+		stripVolatile(st.GetComposite().GetResource())
+		for _, cd := range st.GetResources() {
+			stripVolatile(cd.GetResource())
+		}
+	}
+	for _, rs := range []map[string]*fnv1.Resources{r.GetExtraResources(), r.GetRequiredResources()} {
+		for _, items := range rs {
+			for _, i := range items.GetItems() {
+				stripVolatile(i.GetResource())
+			}
+		}
+	}
+
+	return xfn.Tag(r)
+}
+
+// stripVolatile removes fields that may change between two otherwise identical
+// requests from the supplied resource. The API server bumps resourceVersion
+// and managedFields on every write, and a condition's lastTransitionTime
+// depends on when the resource was rendered or recorded.
+func stripVolatile(r *structpb.Struct) {
+	meta := r.GetFields()["metadata"].GetStructValue().GetFields()
+	delete(meta, "resourceVersion")
+	delete(meta, "managedFields")
+
+	for _, c := range r.GetFields()["status"].GetStructValue().GetFields()["conditions"].GetListValue().GetValues() {
+		delete(c.GetStructValue().GetFields(), "lastTransitionTime")
+	}
+}
+
+// A fixtureStore reads and writes fixture files. Fixture files are stored at
+// function-name/key.json.
+type fixtureStore struct {
+	fs afero.Afero
+}
+
+// A FixtureOption configures a RecordingFunctionRunner or a
+// ReplayingFunctionRunner.
+type FixtureOption func(s *fixtureStore)
+
+// WithFixtureFilesystem specifies which filesystem implementation to store
+// fixtures in. The runner will ignore its path argument and store fixtures at
+// the root of this filesystem.
+func WithFixtureFilesystem(fs afero.Fs) FixtureOption {
+	return func(s *fixtureStore) {
+		s.fs = afero.Afero{Fs: fs}
+	}
+}
+
+func newFixtureStore(path string, o ...FixtureOption) *fixtureStore {
+	s := &fixtureStore{fs: afero.Afero{Fs: afero.NewBasePathFs(afero.NewOsFs(), path)}}
+	for _, fn := range o {
+		fn(s)
+	}
+	return s
+}
+
+func (s *fixtureStore) path(name string, req *fnv1.RunFunctionRequest) string {
+	return filepath.Join(name, FixtureKey(req)+".json")
+}
+
+func (s *fixtureStore) Write(name string, req *fnv1.RunFunctionRequest, rsp *fnv1.RunFunctionResponse) error {
+	p := s.path(name, req)
+
+	f := fixture{}
+	var err error
+	if f.Request, err = protojson.Marshal(inspected.SanitizeRequest(req)); err != nil {
+		return errors.Wrapf(err, "cannot marshal request for fixture %s", p)
+	}
+	if f.Response, err = protojson.Marshal(rsp); err != nil {
+		return errors.Wrapf(err, "cannot marshal response for fixture %s", p)
+	}
+
+	// Indenting the fixture makes the file deterministic, and easier to
+	// review when it's committed alongside tests.
+	b, err := json.MarshalIndent(f, "", "  ")
+	if err != nil {
+		return errors.Wrapf(err, "cannot marshal fixture %s", p)
+	}
+
+	if err := s.fs.MkdirAll(name, 0o700); err != nil {
+		return errors.Wrapf(err, "cannot create fixture directory for function %q", name)
+	}
+	return errors.Wrapf(s.fs.WriteFile(p, append(b, '\n'), 0o600), "cannot write fixture %s", p)
+}
+
+func (s *fixtureStore) Read(name string, req *fnv1.RunFunctionRequest) (*fnv1.RunFunctionResponse, error) {
+	p := s.path(name, req)
+
+	b, err := s.fs.ReadFile(p)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, errors.Errorf("no fixture %s for function %q - record fixtures for this request first", p, name)
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "cannot read fixture %s", p)
+	}
+
+	f := fixture{}
+	if err := json.Unmarshal(b, &f); err != nil {
+		return nil, errors.Wrapf(err, "cannot unmarshal fixture %s", p)
+	}
+	rsp := &fnv1.RunFunctionResponse{}
+	if err := protojson.Unmarshal(f.Response, rsp); err != nil {
+		return nil, errors.Wrapf(err, "cannot unmarshal response from fixture %s", p)
+	}
+	return rsp, nil
+}
+
+// A RecordingFunctionRunner wraps another Runner. It records each response
+// the wrapped runner returns to a fixture file.
+type RecordingFunctionRunner struct {
+	wrapped Runner
+	store   *fixtureStore
+}
+
+// NewRecordingFunctionRunner returns a RecordingFunctionRunner that records
+// fixtures under the supplied path.
+func NewRecordingFunctionRunner(wrapped Runner, path string, o ...FixtureOption) *RecordingFunctionRunner {
+	return &RecordingFunctionRunner{wrapped: wrapped, store: newFixtureStore(path, o...)}
+}
+
+// RunFunction runs the named function using the wrapped runner, and records
+// its response. Errors aren't recorded.
+func (r *RecordingFunctionRunner) RunFunction(ctx context.Context, name string, req *fnv1.RunFunctionRequest) (*fnv1.RunFunctionResponse, error) {
+	rsp, err := r.wrapped.RunFunction(ctx, name, req)
+	if err != nil {
+		return nil, err
+	}
+	if err := r.store.Write(name, req, rsp); err != nil {
+		return nil, errors.Wrapf(err, "cannot record response from function %q", name)
+	}
+	return rsp, nil
+}
+
+// Close closes the wrapped runner.
+func (r *RecordingFunctionRunner) Close() error {
+	return r.wrapped.Close()
+}
+
+// A ReplayingFunctionRunner returns function responses from fixture files,
+// without running any functions.
+type ReplayingFunctionRunner struct {
+	store *fixtureStore
+}
+
+// NewReplayingFunctionRunner returns a ReplayingFunctionRunner that replays
+// fixtures from under the supplied path.
+func NewReplayingFunctionRunner(path string, o ...FixtureOption) *ReplayingFunctionRunner {
+	return &ReplayingFunctionRunner{store: newFixtureStore(path, o...)}
+}
+
+// RunFunction returns the recorded response to the supplied request. It
+// returns an error if there's no recorded response.
+func (r *ReplayingFunctionRunner) RunFunction(_ context.Context, name string, req *fnv1.RunFunctionRequest) (*fnv1.RunFunctionResponse, error) {
+	return r.store.Read(name, req)
+}
+
+// Close does nothing.
+func (r *ReplayingFunctionRunner) Close() error {
+	return nil
+}
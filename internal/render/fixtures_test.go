@@ -0,0 +1,204 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package render
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/spf13/afero"
+	"google.golang.org/protobuf/testing/protocmp"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/structpb"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/test"
+
+	fnv1 "github.com/crossplane/crossplane/v2/proto/fn/v1"
+)
+
+type MockRunner struct {
+	MockRunFunction func(ctx context.Context, name string, req *fnv1.RunFunctionRequest) (*fnv1.RunFunctionResponse, error)
+}
+
+func (r *MockRunner) RunFunction(ctx context.Context, name string, req *fnv1.RunFunctionRequest) (*fnv1.RunFunctionResponse, error) {
+	return r.MockRunFunction(ctx, name, req)
+}
+
+func (r *MockRunner) Close() error {
+	return nil
+}
+
+func TestRecordAndReplay(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	req := &fnv1.RunFunctionRequest{
+		Meta: &fnv1.RequestMeta{Tag: "tag"},
+		Credentials: map[string]*fnv1.Credentials{
+			"secret": {Source: &fnv1.Credentials_CredentialData{CredentialData: &fnv1.CredentialData{
+				Data: map[string][]byte{"password": []byte("hunter2")},
+			}}},
+		},
+	}
+	rsp := &fnv1.RunFunctionResponse{Meta: &fnv1.ResponseMeta{Tag: "tag", Ttl: durationpb.New(time.Minute)}}
+
+	// observed returns a request whose observed XR was written at the
+	// supplied resourceVersion and became Ready at the supplied time.
+	observed := func(resourceVersion, lastTransitionTime string) *fnv1.RunFunctionRequest {
+		xr, _ := structpb.NewStruct(map[string]any{
+			"apiVersion": "example.org/v1",
+			"kind":       "XR",
+			"metadata":   map[string]any{"name": "xr", "resourceVersion": resourceVersion},
+			"status": map[string]any{
+				"conditions": []any{
+					map[string]any{"type": "Ready", "status": "True", "lastTransitionTime": lastTransitionTime},
+				},
+			},
+		})
+		return &fnv1.RunFunctionRequest{Observed: &fnv1.State{Composite: &fnv1.Resource{Resource: xr}}}
+	}
+
+	type args struct {
+		wrapped Runner
+		record  *fnv1.RunFunctionRequest
+		replay  *fnv1.RunFunctionRequest
+	}
+	type want struct {
+		recordErr error
+		replayRsp *fnv1.RunFunctionResponse
+		replayErr bool
+	}
+
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   want
+	}{
+		"Replay": {
+			reason: "A recorded response should be replayed for an identical request.",
+			args: args{
+				wrapped: &MockRunner{MockRunFunction: func(_ context.Context, _ string, _ *fnv1.RunFunctionRequest) (*fnv1.RunFunctionResponse, error) {
+					return rsp, nil
+				}},
+				record: req,
+				replay: req,
+			},
+			want: want{
+				replayRsp: rsp,
+			},
+		},
+		"ReplayIgnoresRequestMeta": {
+			reason: "A recorded response should be replayed for a request that differs only in its metadata.",
+			args: args{
+				wrapped: &MockRunner{MockRunFunction: func(_ context.Context, _ string, _ *fnv1.RunFunctionRequest) (*fnv1.RunFunctionResponse, error) {
+					return rsp, nil
+				}},
+				record: req,
+				replay: &fnv1.RunFunctionRequest{
+					Meta:        &fnv1.RequestMeta{Tag: "different"},
+					Credentials: req.GetCredentials(),
+				},
+			},
+			want: want{
+				replayRsp: rsp,
+			},
+		},
+		"ReplayIgnoresCredentials": {
+			reason: "A recorded response should be replayed for a request that differs only in its credentials.",
+			args: args{
+				wrapped: &MockRunner{MockRunFunction: func(_ context.Context, _ string, _ *fnv1.RunFunctionRequest) (*fnv1.RunFunctionResponse, error) {
+					return rsp, nil
+				}},
+				record: req,
+				replay: &fnv1.RunFunctionRequest{
+					Meta: &fnv1.RequestMeta{Tag: "tag"},
+					Credentials: map[string]*fnv1.Credentials{
+						"secret": {Source: &fnv1.Credentials_CredentialData{CredentialData: &fnv1.CredentialData{
+							Data: map[string][]byte{"password": []byte("correct-horse-battery-staple")},
+						}}},
+					},
+				},
+			},
+			want: want{
+				replayRsp: rsp,
+			},
+		},
+		"ReplayIgnoresVolatileFields": {
+			reason: "A recorded response should be replayed for a request that differs only in resource versions and condition transition times.",
+			args: args{
+				wrapped: &MockRunner{MockRunFunction: func(_ context.Context, _ string, _ *fnv1.RunFunctionRequest) (*fnv1.RunFunctionResponse, error) {
+					return rsp, nil
+				}},
+				record: observed("1", "2026-01-01T00:00:00Z"),
+				replay: observed("2", "2026-01-02T00:00:00Z"),
+			},
+			want: want{
+				replayRsp: rsp,
+			},
+		},
+		"NoFixture": {
+			reason: "Replaying a request that wasn't recorded should return an error.",
+			args: args{
+				wrapped: &MockRunner{MockRunFunction: func(_ context.Context, _ string, _ *fnv1.RunFunctionRequest) (*fnv1.RunFunctionResponse, error) {
+					return rsp, nil
+				}},
+				record: req,
+				replay: observed("1", "2026-01-01T00:00:00Z"),
+			},
+			want: want{
+				replayErr: true,
+			},
+		},
+		"FunctionError": {
+			reason: "An error running the function should be returned, and not recorded.",
+			args: args{
+				wrapped: &MockRunner{MockRunFunction: func(_ context.Context, _ string, _ *fnv1.RunFunctionRequest) (*fnv1.RunFunctionResponse, error) {
+					return nil, errBoom
+				}},
+				record: req,
+				replay: req,
+			},
+			want: want{
+				recordErr: errBoom,
+				replayErr: true,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			fs := afero.NewMemMapFs()
+
+			rec := NewRecordingFunctionRunner(tc.args.wrapped, "", WithFixtureFilesystem(fs))
+			_, err := rec.RunFunction(context.Background(), "function-dummy", tc.args.record)
+			if diff := cmp.Diff(tc.want.recordErr, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nRecordingFunctionRunner.RunFunction(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+
+			rep := NewReplayingFunctionRunner("", WithFixtureFilesystem(fs))
+			got, err := rep.RunFunction(context.Background(), "function-dummy", tc.args.replay)
+			if diff := cmp.Diff(tc.want.replayRsp, got, protocmp.Transform()); diff != "" {
+				t.Errorf("\n%s\nReplayingFunctionRunner.RunFunction(...): -want, +got:\n%s", tc.reason, diff)
+			}
+			if (err != nil) != tc.want.replayErr {
+				t.Errorf("\n%s\nReplayingFunctionRunner.RunFunction(...): want error %t, got %v", tc.reason, tc.want.replayErr, err)
+			}
+		})
+	}
+}
@@ -44,11 +44,14 @@ import (
 	watchrec "github.com/crossplane/crossplane/v2/internal/controller/ops/watched"
 	"github.com/crossplane/crossplane/v2/internal/render"
 	"github.com/crossplane/crossplane/v2/internal/xfn"
+	"github.com/crossplane/crossplane/v2/internal/xfn/inspected"
 	renderv1alpha1 "github.com/crossplane/crossplane/v2/proto/render/v1alpha1"
 )
 
 // Render runs one real Operation reconcile loop using the real reconciler
-// engine backed by a fake in-memory client.
+// engine backed by a fake in-memory client. If the input configures retries it
+// runs the reconcile loop until the Operation completes or reaches its retry
+// limit.
 func Render(ctx context.Context, log logging.Logger, in *renderv1alpha1.OperationInput) (*renderv1alpha1.OperationOutput, error) {
 	s := runtime.NewScheme()
 	if err := corev1.AddToScheme(s); err != nil {
@@ -86,7 +89,19 @@ func Render(ctx context.Context, log logging.Logger, in *renderv1alpha1.Operatio
 	}
 	c := render.NewInMemoryClient(s, store...)
 
-	runner, err := render.NewFunctionRunner(in.GetFunctions())
+	if in.GetServerSideApply() {
+		schemas, err := render.OpenAPISchemas(in.GetRequiredSchemas())
+		if err != nil {
+			return nil, errors.Wrap(err, "cannot get schemas from required schemas")
+		}
+		tc, err := render.NewTypeConverter(schemas)
+		if err != nil {
+			return nil, errors.Wrap(err, "cannot build server-side apply type converter")
+		}
+		c.EnableServerSideApply(tc)
+	}
+
+	runner, err := render.NewRunner(in.GetFunctions(), in.GetFixtures())
 	if err != nil {
 		return nil, errors.Wrap(err, "cannot connect to functions")
 	}
@@ -102,8 +117,18 @@ func Render(ctx context.Context, log logging.Logger, in *renderv1alpha1.Operatio
 
 	rec := &render.EventRecorder{}
 
+	// Like the Pipeline Inspector, trace requests before and responses after
+	// requirement fetching.
+	var fr xfn.FunctionRunner = xfn.NewFetchingFunctionRunner(runner, rrf, rsf)
+	ser := NewScriptedErrorFunctionRunner(fr, in.GetRetries().GetFunctionErrors())
+	fr = ser
+	ti := render.NewTracingPipelineInspector()
+	if in.GetTrace() {
+		fr = inspected.NewRunner(fr, ti, inspected.WithLogger(log))
+	}
+
 	r := oprec.NewReconciler(c,
-		oprec.WithFunctionRunner(xfn.NewFetchingFunctionRunner(runner, rrf, rsf)),
+		oprec.WithFunctionRunner(fr),
 		oprec.WithCapabilityChecker(xfn.CapabilityCheckerFn(
 			func(_ context.Context, _ []string, _ ...string) error {
 				return nil
@@ -120,7 +145,61 @@ func Render(ctx context.Context, log logging.Logger, in *renderv1alpha1.Operatio
 		Name:      op.GetName(),
 	}}
 
-	_, rerr := r.Reconcile(ctx, req)
+	n, err := MaxAttempts(op, in.GetRetries())
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot determine maximum attempts")
+	}
+
+	// Like the Operation controller, retry the Operation until the
+	// reconciler stops returning errors - either because the Operation
+	// completed or because it reached its retry limit.
+	var (
+		rerr     error
+		failed   error
+		attempts []*renderv1alpha1.OperationAttempt
+	)
+	for i := range n {
+		ser.SetAttempt(i + 1)
+		nevents, ntraces := len(rec.Events()), len(ti.Traces())
+
+		_, rerr = r.Reconcile(ctx, req)
+
+		// Scripted errors are expected when simulating retries. Remember
+		// any other error, like a function returning a fatal result.
+		var se *ScriptedError
+		if rerr != nil && !errors.As(rerr, &se) {
+			failed = rerr
+		}
+
+		for _, t := range ti.Traces()[ntraces:] {
+			t.Reconcile = i + 1
+		}
+
+		a, err := newAttempt(ctx, c, op, i+1, rec.Events()[nevents:], rerr)
+		if err != nil {
+			return nil, errors.Wrapf(err, "cannot record attempt %d", i+1)
+		}
+		attempts = append(attempts, a)
+
+		if rerr == nil {
+			break
+		}
+	}
+
+	// The reconciler doesn't return an error from the attempt that reaches
+	// the retry limit, so the last attempt doesn't tell us whether the
+	// Operation failed. Return the last error that wasn't scripted, unless
+	// the Operation went on to succeed.
+	if in.GetRetries() != nil {
+		ok, err := succeeded(ctx, c, op)
+		if err != nil {
+			return nil, errors.Wrap(err, "cannot determine whether Operation succeeded")
+		}
+		rerr = failed
+		if ok {
+			rerr = nil
+		}
+	}
 
 	// Always build the output, even on reconcile error: the recording
 	// fetchers and the EventRecorder may have captured useful state — in
@@ -133,6 +212,12 @@ func Render(ctx context.Context, log logging.Logger, in *renderv1alpha1.Operatio
 			u.GetNamespace() == op.GetNamespace() &&
 			u.GetName() == op.GetName()
 	}, rec, rrf, rsf)
+	if berr == nil {
+		out.Traces = ti.Traces()
+	}
+	if berr == nil && in.GetRetries() != nil {
+		out.Attempts = attempts
+	}
 	switch {
 	case berr != nil:
 		// Surface buildOutput's failure. If reconcile also failed, join
@@ -155,6 +240,46 @@ func Render(ctx context.Context, log logging.Logger, in *renderv1alpha1.Operatio
 	return out, nil
 }
 
+// succeeded returns true if the supplied Operation succeeded.
+func succeeded(ctx context.Context, c *render.InMemoryClient, op *kunstructured.Unstructured) (bool, error) {
+	cur := &kunstructured.Unstructured{}
+	cur.SetGroupVersionKind(op.GroupVersionKind())
+	if err := c.Get(ctx, types.NamespacedName{Namespace: op.GetNamespace(), Name: op.GetName()}, cur); err != nil {
+		return false, errors.Wrap(err, "cannot get Operation")
+	}
+
+	o := &opsv1alpha1.Operation{}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(cur.Object, o); err != nil {
+		return false, errors.Wrap(err, "cannot convert Operation")
+	}
+	return o.GetCondition(opsv1alpha1.TypeSucceeded).Status == corev1.ConditionTrue, nil
+}
+
+// newAttempt records an attempt to reconcile the supplied Operation.
+func newAttempt(ctx context.Context, c *render.InMemoryClient, op *kunstructured.Unstructured, attempt int32, events []*renderv1alpha1.Event, rerr error) (*renderv1alpha1.OperationAttempt, error) {
+	a := &renderv1alpha1.OperationAttempt{Attempt: attempt, Events: events}
+	if rerr != nil {
+		a.Error = rerr.Error()
+	}
+
+	cur := &kunstructured.Unstructured{}
+	cur.SetGroupVersionKind(op.GroupVersionKind())
+	if err := c.Get(ctx, types.NamespacedName{Namespace: op.GetNamespace(), Name: op.GetName()}, cur); err != nil {
+		return nil, errors.Wrap(err, "cannot get Operation")
+	}
+
+	st, ok := cur.Object["status"].(map[string]any)
+	if !ok {
+		return a, nil
+	}
+	s, err := structpb.NewStruct(st)
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot convert Operation status to protobuf")
+	}
+	a.Status = s
+	return a, nil
+}
+
 // NewFromCronOperation produces the Operation a CronOperation would create.
 func NewFromCronOperation(in *renderv1alpha1.CronOperationInput) (*renderv1alpha1.CronOperationOutput, error) {
 	co := &opsv1alpha1.CronOperation{}
@@ -137,6 +137,9 @@ func TestRenderErrors(t *testing.T) {
 		hasOutput               bool
 		requiredResources       int
 		wantSelector            *fnv1.ResourceSelector
+		// attempts is the expected number of recorded attempts. Checked
+		// only when hasOutput.
+		attempts int
 	}
 
 	cases := map[string]struct {
@@ -185,6 +188,92 @@ func TestRenderErrors(t *testing.T) {
 				wantSelector:      wantSelector,
 			},
 		},
+		"PipelineFatalWithRetries": {
+			reason: "When simulating retries, a pipeline step that returns SEVERITY_FATAL on the last attempt must still return *PipelineFatalError, alongside the partial OperationOutput and its recorded attempts.",
+			input: func(t *testing.T) *renderv1alpha1.OperationInput {
+				t.Helper()
+				addr := rendertest.StartFunctionServer(t, &rendertest.FatalFunctionServer{
+					RequirementName: requirementName,
+					Selector:        wantSelector,
+					FatalMessage:    fatalMsg,
+				})
+				return &renderv1alpha1.OperationInput{
+					Operation: mustStruct(map[string]any{
+						"apiVersion": "ops.crossplane.io/v1alpha1",
+						"kind":       "Operation",
+						"metadata": map[string]any{
+							"name":      "my-operation",
+							"namespace": "default",
+						},
+						"spec": map[string]any{
+							"mode": "Pipeline",
+							"pipeline": []any{
+								map[string]any{
+									"step":        stepName,
+									"functionRef": map[string]any{"name": "function-extra-resources"},
+								},
+							},
+						},
+					}),
+					Functions: []*renderv1alpha1.FunctionInput{
+						{Name: "function-extra-resources", Address: addr},
+					},
+					Retries: &renderv1alpha1.OperationRetries{MaxAttempts: 2},
+				}
+			},
+			want: want{
+				pipelineFatal:     true,
+				fatalStep:         stepName,
+				fatalMessage:      fatalMsg,
+				hasOutput:         true,
+				requiredResources: 1,
+				wantSelector:      wantSelector,
+				attempts:          2,
+			},
+		},
+		"PipelineFatalWithDefaultRetryLimit": {
+			reason: "When simulating retries, a pipeline step that returns SEVERITY_FATAL on every attempt must still return *PipelineFatalError when the Operation reaches its default retry limit. The attempt that reaches the limit returns no error.",
+			input: func(t *testing.T) *renderv1alpha1.OperationInput {
+				t.Helper()
+				addr := rendertest.StartFunctionServer(t, &rendertest.FatalFunctionServer{
+					RequirementName: requirementName,
+					Selector:        wantSelector,
+					FatalMessage:    fatalMsg,
+				})
+				return &renderv1alpha1.OperationInput{
+					Operation: mustStruct(map[string]any{
+						"apiVersion": "ops.crossplane.io/v1alpha1",
+						"kind":       "Operation",
+						"metadata": map[string]any{
+							"name":      "my-operation",
+							"namespace": "default",
+						},
+						"spec": map[string]any{
+							"mode": "Pipeline",
+							"pipeline": []any{
+								map[string]any{
+									"step":        stepName,
+									"functionRef": map[string]any{"name": "function-extra-resources"},
+								},
+							},
+						},
+					}),
+					Functions: []*renderv1alpha1.FunctionInput{
+						{Name: "function-extra-resources", Address: addr},
+					},
+					Retries: &renderv1alpha1.OperationRetries{},
+				}
+			},
+			want: want{
+				pipelineFatal:     true,
+				fatalStep:         stepName,
+				fatalMessage:      fatalMsg,
+				hasOutput:         true,
+				requiredResources: 1,
+				wantSelector:      wantSelector,
+				attempts:          6,
+			},
+		},
 		"NonFatalReconcileErrorWraps": {
 			reason: "A pipeline step whose function name is not registered in FunctionInput causes the reconciler to fail with an 'unknown function' error — a non-fatal failure mode. The error must NOT be a *PipelineFatalError, and no partial output must be returned.",
 			input: func(t *testing.T) *renderv1alpha1.OperationInput {
@@ -248,6 +337,9 @@ func TestRenderErrors(t *testing.T) {
 			if out == nil {
 				t.Fatalf("%s\nRender(...) returned nil output; want non-nil with RequiredResources populated", tc.reason)
 			}
+			if got := len(out.GetAttempts()); got != tc.want.attempts {
+				t.Errorf("%s\nlen(out.Attempts) = %d, want %d", tc.reason, got, tc.want.attempts)
+			}
 			if got := len(out.GetRequiredResources()); got != tc.want.requiredResources {
 				t.Fatalf("%s\nlen(out.RequiredResources) = %d, want %d; out=%v", tc.reason, got, tc.want.requiredResources, out)
 			}
@@ -268,6 +360,113 @@ func TestRenderErrors(t *testing.T) {
 	}
 }
 
+func TestRenderRetries(t *testing.T) {
+	const errMsg = `failed to invoke pipeline step "run": boom`
+
+	operation := func(retryLimit int64) *structpb.Struct {
+		return mustStruct(map[string]any{
+			"apiVersion": "ops.crossplane.io/v1alpha1",
+			"kind":       "Operation",
+			"metadata": map[string]any{
+				"name":      "my-operation",
+				"namespace": "default",
+			},
+			"spec": map[string]any{
+				"mode":       "Pipeline",
+				"retryLimit": retryLimit,
+				"pipeline": []any{
+					map[string]any{
+						"step":        "run",
+						"functionRef": map[string]any{"name": "function-dummy"},
+					},
+				},
+			},
+		})
+	}
+	failed := &renderv1alpha1.Event{Type: "Warning", Reason: "FunctionInvocation", Message: errMsg}
+
+	type want struct {
+		attempts  []*renderv1alpha1.OperationAttempt
+		failures  float64
+		succeeded string
+	}
+
+	cases := map[string]struct {
+		reason string
+		input  *renderv1alpha1.OperationInput
+		want   want
+	}{
+		"RetryLimitReached": {
+			reason: "An Operation that fails every attempt should be retried until it reaches its retry limit, then marked failed.",
+			input: &renderv1alpha1.OperationInput{
+				Operation: operation(2),
+				Retries: &renderv1alpha1.OperationRetries{
+					FunctionErrors: []*renderv1alpha1.ScriptedFunctionError{
+						{Attempt: 1, FunctionName: "function-dummy", Message: "boom"},
+						{Attempt: 2, Message: "boom"},
+					},
+				},
+			},
+			want: want{
+				attempts: []*renderv1alpha1.OperationAttempt{
+					{Attempt: 1, Error: errMsg, Events: []*renderv1alpha1.Event{failed}},
+					{Attempt: 2, Error: errMsg, Events: []*renderv1alpha1.Event{failed}},
+					{Attempt: 3},
+				},
+				failures:  2,
+				succeeded: "PipelineError",
+			},
+		},
+		"MaxAttempts": {
+			reason: "Render should stop retrying after the maximum number of attempts, even if the Operation hasn't reached its retry limit.",
+			input: &renderv1alpha1.OperationInput{
+				Operation: operation(5),
+				Retries: &renderv1alpha1.OperationRetries{
+					MaxAttempts: 1,
+					FunctionErrors: []*renderv1alpha1.ScriptedFunctionError{
+						{Attempt: 1, Message: "boom"},
+					},
+				},
+			},
+			want: want{
+				attempts: []*renderv1alpha1.OperationAttempt{
+					{Attempt: 1, Error: errMsg, Events: []*renderv1alpha1.Event{failed}},
+				},
+				failures:  1,
+				succeeded: "PipelineRunning",
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			out, err := Render(t.Context(), logging.NewNopLogger(), tc.input)
+			if err != nil {
+				t.Fatalf("\n%s\nRender(...): unexpected error: %v", tc.reason, err)
+			}
+
+			if diff := cmp.Diff(tc.want.attempts, out.GetAttempts(), cmpopts.EquateEmpty(), protocmp.Transform(), protocmp.IgnoreFields(&renderv1alpha1.OperationAttempt{}, "status")); diff != "" {
+				t.Errorf("\n%s\nRender(...): -want attempts, +got attempts:\n%s", tc.reason, diff)
+			}
+
+			st := out.GetOperation().GetFields()["status"].GetStructValue()
+			if got := st.GetFields()["failures"].GetNumberValue(); got != tc.want.failures {
+				t.Errorf("\n%s\nRender(...): want status.failures %v, got %v", tc.reason, tc.want.failures, got)
+			}
+
+			got := ""
+			for _, c := range st.GetFields()["conditions"].GetListValue().GetValues() {
+				if c.GetStructValue().GetFields()["type"].GetStringValue() == "Succeeded" {
+					got = c.GetStructValue().GetFields()["reason"].GetStringValue()
+				}
+			}
+			if got != tc.want.succeeded {
+				t.Errorf("\n%s\nRender(...): want Succeeded condition reason %q, got %q", tc.reason, tc.want.succeeded, got)
+			}
+		})
+	}
+}
+
 func mustStruct(m map[string]any) *structpb.Struct {
 	s, err := structpb.NewStruct(m)
 	if err != nil {
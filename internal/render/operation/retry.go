@@ -0,0 +1,97 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package operation
+
+import (
+	"context"
+
+	kunstructured "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/errors"
+
+	opsv1alpha1 "github.com/crossplane/crossplane/apis/v2/ops/v1alpha1"
+	oprec "github.com/crossplane/crossplane/v2/internal/controller/ops/operation"
+	"github.com/crossplane/crossplane/v2/internal/xfn"
+	fnv1 "github.com/crossplane/crossplane/v2/proto/fn/v1"
+	renderv1alpha1 "github.com/crossplane/crossplane/v2/proto/render/v1alpha1"
+)
+
+// MaxAttempts returns the maximum number of times to reconcile the supplied
+// Operation. It defaults to one more than the Operation's retry limit, which
+// is enough for the reconciler to mark the Operation failed if every attempt
+// fails.
+func MaxAttempts(op *kunstructured.Unstructured, r *renderv1alpha1.OperationRetries) (int32, error) {
+	if r == nil {
+		return 1, nil
+	}
+	if r.GetMaxAttempts() > 0 {
+		return r.GetMaxAttempts(), nil
+	}
+
+	o := &opsv1alpha1.Operation{}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(op.Object, o); err != nil {
+		return 0, errors.Wrap(err, "cannot convert Operation")
+	}
+	return int32(ptr.Deref(o.Spec.RetryLimit, oprec.DefaultRetryLimit)) + 1, nil //nolint:gosec // Retry limits are small.
+}
+
+// A ScriptedError is an error returned by a ScriptedErrorFunctionRunner.
+type ScriptedError struct {
+	Message string
+}
+
+// Error returns the scripted error message.
+func (e *ScriptedError) Error() string {
+	return e.Message
+}
+
+// A ScriptedErrorFunctionRunner returns scripted errors instead of calling
+// functions, to simulate functions failing during particular attempts to
+// reconcile an Operation.
+type ScriptedErrorFunctionRunner struct {
+	wrapped xfn.FunctionRunner
+	errs    []*renderv1alpha1.ScriptedFunctionError
+	attempt int32
+}
+
+// NewScriptedErrorFunctionRunner returns a ScriptedErrorFunctionRunner that
+// returns the supplied errors, and otherwise calls the wrapped runner.
+func NewScriptedErrorFunctionRunner(wrapped xfn.FunctionRunner, errs []*renderv1alpha1.ScriptedFunctionError) *ScriptedErrorFunctionRunner {
+	return &ScriptedErrorFunctionRunner{wrapped: wrapped, errs: errs, attempt: 1}
+}
+
+// SetAttempt sets the current attempt, starting at 1.
+func (r *ScriptedErrorFunctionRunner) SetAttempt(attempt int32) {
+	r.attempt = attempt
+}
+
+// RunFunction returns the first error scripted for the current attempt and the
+// named function, if any. Otherwise it calls the wrapped runner.
+func (r *ScriptedErrorFunctionRunner) RunFunction(ctx context.Context, name string, req *fnv1.RunFunctionRequest) (*fnv1.RunFunctionResponse, error) {
+	for _, e := range r.errs {
+		if e.GetAttempt() != r.attempt {
+			continue
+		}
+		if e.GetFunctionName() != "" && e.GetFunctionName() != name {
+			continue
+		}
+		return nil, &ScriptedError{Message: e.GetMessage()}
+	}
+	return r.wrapped.RunFunction(ctx, name, req)
+}
@@ -26,6 +26,7 @@ import (
 	"testing"
 
 	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/structpb"
 
 	fnv1 "github.com/crossplane/crossplane/v2/proto/fn/v1"
 )
@@ -73,6 +74,33 @@ func (s *FatalFunctionServer) RunFunction(_ context.Context, _ *fnv1.RunFunction
 	}, nil
 }
 
+// ComposeFunctionServer is an in-process gRPC FunctionRunnerService server
+// that adds composed resources to the desired state. It lets tests render
+// trees of composite resources, where a Composition composes XRs that are in
+// turn rendered using another Composition.
+type ComposeFunctionServer struct {
+	fnv1.UnimplementedFunctionRunnerServiceServer
+
+	// Compose returns the composed resources to desire for the supplied
+	// observed composite resource, keyed by composition resource name.
+	Compose func(xr *structpb.Struct) map[string]*structpb.Struct
+}
+
+// RunFunction implements fnv1.FunctionRunnerServiceServer.
+func (s *ComposeFunctionServer) RunFunction(_ context.Context, req *fnv1.RunFunctionRequest) (*fnv1.RunFunctionResponse, error) {
+	d := req.GetDesired()
+	if d == nil {
+		d = &fnv1.State{}
+	}
+	if d.GetResources() == nil {
+		d.Resources = map[string]*fnv1.Resource{}
+	}
+	for name, r := range s.Compose(req.GetObserved().GetComposite().GetResource()) {
+		d.Resources[name] = &fnv1.Resource{Resource: r}
+	}
+	return &fnv1.RunFunctionResponse{Desired: d}, nil
+}
+
 // StartFunctionServer starts an in-process gRPC server registered with the
 // supplied FunctionRunnerServiceServer and returns its TCP address. The
 // server is stopped automatically when the test ends.
@@ -18,6 +18,7 @@ package render
 
 import (
 	"context"
+	"io"
 
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
@@ -37,6 +38,33 @@ const waitForReady = `{
 	}]
 }`
 
+// A Runner runs composition functions by name. It must be closed when it's no
+// longer needed.
+type Runner interface {
+	xfn.FunctionRunner
+	io.Closer
+}
+
+// NewRunner returns a Runner for the supplied functions. If fixtures are
+// supplied it records function responses to them, or replays function
+// responses from them.
+func NewRunner(fns []*renderv1alpha1.FunctionInput, fx *renderv1alpha1.FunctionFixtures) (Runner, error) {
+	if fx.GetMode() == renderv1alpha1.FixtureMode_FIXTURE_MODE_REPLAY {
+		return NewReplayingFunctionRunner(fx.GetDirectory()), nil
+	}
+
+	r, err := NewFunctionRunner(fns)
+	if err != nil {
+		return nil, err
+	}
+
+	if fx.GetMode() == renderv1alpha1.FixtureMode_FIXTURE_MODE_RECORD {
+		return NewRecordingFunctionRunner(r, fx.GetDirectory()), nil
+	}
+
+	return r, nil
+}
+
 // A FunctionRunner runs composition functions by name via gRPC. It maps
 // function names to pre-established gRPC connections.
 type FunctionRunner struct {
@@ -18,11 +18,14 @@ package render
 
 import (
 	"encoding/json"
+	"maps"
 
 	"google.golang.org/protobuf/encoding/protojson"
 	"google.golang.org/protobuf/types/known/structpb"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/client-go/openapi"
 	"k8s.io/kube-openapi/pkg/spec3"
+	"k8s.io/kube-openapi/pkg/validation/spec"
 
 	"github.com/crossplane/crossplane-runtime/v2/pkg/errors"
 )
@@ -41,15 +44,9 @@ func NewInMemoryOpenAPIClient(docs []*structpb.Struct) (*InMemoryOpenAPIClient,
 	paths := make(map[string]openapi.GroupVersion)
 
 	for _, doc := range docs {
-		b, err := protojson.Marshal(doc)
+		b, parsed, err := parseOpenAPIDocument(doc)
 		if err != nil {
-			return nil, errors.Wrap(err, "cannot marshal OpenAPI document to JSON")
-		}
-
-		// Parse to extract group-versions from the schemas' GVK annotations.
-		parsed := &spec3.OpenAPI{}
-		if err := json.Unmarshal(b, parsed); err != nil {
-			return nil, errors.Wrap(err, "cannot parse OpenAPI document")
+			return nil, err
 		}
 
 		if parsed.Components == nil {
@@ -59,23 +56,12 @@ func NewInMemoryOpenAPIClient(docs []*structpb.Struct) (*InMemoryOpenAPIClient,
 		// Discover which API group-versions this document covers by
 		// inspecting x-kubernetes-group-version-kind on each schema.
 		for _, s := range parsed.Components.Schemas {
-			gvks, ok := s.Extensions["x-kubernetes-group-version-kind"].([]any)
-			if !ok {
-				continue
-			}
-			for _, raw := range gvks {
-				gvk, ok := raw.(map[string]any)
-				if !ok {
-					continue
-				}
-				g, _ := gvk["group"].(string)
-				v, _ := gvk["version"].(string)
-
+			for _, gvk := range groupVersionKinds(s) {
 				var pathKey string
-				if g == "" {
-					pathKey = "api/" + v
+				if gvk.Group == "" {
+					pathKey = "api/" + gvk.Version
 				} else {
-					pathKey = "apis/" + g + "/" + v
+					pathKey = "apis/" + gvk.Group + "/" + gvk.Version
 				}
 
 				// Store the document bytes under this path key. If
@@ -113,3 +99,63 @@ func (g *inMemoryGroupVersion) Schema(_ string) ([]byte, error) {
 func (g *inMemoryGroupVersion) ServerRelativeURL() string {
 	return ""
 }
+
+// OpenAPISchemas returns the component schemas of the supplied OpenAPI v3
+// documents, keyed by name. If several documents contain a schema of the same
+// name the last one wins. Kubernetes serves a document per group-version, so
+// schemas like ObjectMeta appear in every document.
+func OpenAPISchemas(docs []*structpb.Struct) (map[string]*spec.Schema, error) {
+	schemas := make(map[string]*spec.Schema)
+
+	for _, doc := range docs {
+		_, parsed, err := parseOpenAPIDocument(doc)
+		if err != nil {
+			return nil, err
+		}
+		if parsed.Components == nil {
+			continue
+		}
+		maps.Copy(schemas, parsed.Components.Schemas)
+	}
+
+	return schemas, nil
+}
+
+// parseOpenAPIDocument parses an OpenAPI v3 document. It returns the document
+// as JSON, and parsed.
+func parseOpenAPIDocument(doc *structpb.Struct) ([]byte, *spec3.OpenAPI, error) {
+	b, err := protojson.Marshal(doc)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "cannot marshal OpenAPI document to JSON")
+	}
+
+	parsed := &spec3.OpenAPI{}
+	if err := json.Unmarshal(b, parsed); err != nil {
+		return nil, nil, errors.Wrap(err, "cannot parse OpenAPI document")
+	}
+
+	return b, parsed, nil
+}
+
+// groupVersionKinds returns the GVKs listed in the supplied schema's
+// x-kubernetes-group-version-kind extension, if any.
+func groupVersionKinds(s *spec.Schema) []schema.GroupVersionKind {
+	raw, ok := s.Extensions["x-kubernetes-group-version-kind"].([]any)
+	if !ok {
+		return nil
+	}
+
+	gvks := make([]schema.GroupVersionKind, 0, len(raw))
+	for _, r := range raw {
+		gvk, ok := r.(map[string]any)
+		if !ok {
+			continue
+		}
+		g, _ := gvk["group"].(string)
+		v, _ := gvk["version"].(string)
+		k, _ := gvk["kind"].(string)
+		gvks = append(gvks, schema.GroupVersionKind{Group: g, Version: v, Kind: k})
+	}
+
+	return gvks
+}
@@ -0,0 +1,165 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package render
+
+import (
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/managedfields"
+	"k8s.io/kube-openapi/pkg/validation/spec"
+	"sigs.k8s.io/structured-merge-diff/v6/typed"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/errors"
+)
+
+// DefaultFieldManager is the field manager the InMemoryClient records for
+// writes that don't specify one. An API server derives the field manager from
+// the client's user agent, which for Crossplane is "crossplane".
+const DefaultFieldManager = "crossplane"
+
+// NewTypeConverter returns a TypeConverter that tells server-side apply how to
+// merge each kind of resource. It uses the supplied OpenAPI schemas, keyed by
+// name, for any kind one of them declares via x-kubernetes-group-version-kind.
+// It merges other kinds like an API server merges a CRD without a schema -
+// maps are merged key by key, and lists are atomic.
+func NewTypeConverter(schemas map[string]*spec.Schema) (managedfields.TypeConverter, error) {
+	tc := &typeConverter{
+		known:   make(map[schema.GroupVersionKind]bool),
+		deduced: managedfields.NewDeducedTypeConverter(),
+	}
+
+	for _, s := range schemas {
+		for _, gvk := range groupVersionKinds(s) {
+			tc.known[gvk] = true
+		}
+	}
+
+	if len(tc.known) == 0 {
+		return tc, nil
+	}
+
+	// Preserve unknown fields, like an API server does for CRDs. Crossplane
+	// adds fields to XRs (e.g. spec.crossplane) that their XRD's schema
+	// doesn't include.
+	s, err := managedfields.NewTypeConverter(schemas, true)
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot build type converter from OpenAPI schemas")
+	}
+	tc.schema = s
+
+	return tc, nil
+}
+
+// A typeConverter converts objects of known kinds using their OpenAPI schema,
+// and objects of other kinds using a deduced schema.
+type typeConverter struct {
+	known   map[schema.GroupVersionKind]bool
+	schema  managedfields.TypeConverter
+	deduced managedfields.TypeConverter
+}
+
+// ObjectToTyped converts the supplied object to a typed value.
+func (c *typeConverter) ObjectToTyped(obj runtime.Object, opts ...typed.ValidationOptions) (*typed.TypedValue, error) {
+	if c.known[obj.GetObjectKind().GroupVersionKind()] {
+		return c.schema.ObjectToTyped(obj, opts...)
+	}
+	return c.deduced.ObjectToTyped(obj, opts...)
+}
+
+// TypedToObject converts the supplied typed value to an object. This doesn't
+// depend on the value's schema.
+func (c *typeConverter) TypedToObject(v *typed.TypedValue) (runtime.Object, error) {
+	return c.deduced.TypedToObject(v)
+}
+
+// An unstructuredConverter converts unstructured objects between versions of
+// the same kind. Render stores only one version of each kind, so conversion
+// just sets the object's apiVersion. This is what an API server does for a CRD
+// with the None conversion strategy.
+type unstructuredConverter struct{}
+
+// Convert copies in to out.
+func (unstructuredConverter) Convert(in, out, _ any) error {
+	i, ok := in.(*unstructured.Unstructured)
+	if !ok {
+		return errors.Errorf("cannot convert %T: not unstructured", in)
+	}
+	o, ok := out.(*unstructured.Unstructured)
+	if !ok {
+		return errors.Errorf("cannot convert to %T: not unstructured", out)
+	}
+	i.DeepCopyInto(o)
+	return nil
+}
+
+// ConvertToVersion returns a copy of in with its apiVersion set to the target.
+func (unstructuredConverter) ConvertToVersion(in runtime.Object, target runtime.GroupVersioner) (runtime.Object, error) {
+	u, ok := in.(*unstructured.Unstructured)
+	if !ok {
+		return nil, errors.Errorf("cannot convert %T: not unstructured", in)
+	}
+	gvk, ok := target.KindForGroupVersionKinds([]schema.GroupVersionKind{u.GroupVersionKind()})
+	if !ok {
+		return nil, errors.Errorf("cannot convert %s to %s", u.GroupVersionKind(), target.Identifier())
+	}
+	out := u.DeepCopy()
+	out.SetGroupVersionKind(gvk)
+	return out, nil
+}
+
+// ConvertFieldLabel returns the supplied label and value unchanged.
+func (unstructuredConverter) ConvertFieldLabel(_ schema.GroupVersionKind, label, value string) (string, string, error) {
+	return label, value, nil
+}
+
+// An unstructuredCreater creates empty unstructured objects.
+type unstructuredCreater struct{}
+
+// New returns an empty unstructured object of the supplied kind.
+func (unstructuredCreater) New(gvk schema.GroupVersionKind) (runtime.Object, error) {
+	u := &unstructured.Unstructured{}
+	u.SetGroupVersionKind(gvk)
+	return u, nil
+}
+
+// A nopDefaulter doesn't default objects. Render doesn't know the defaults of
+// the kinds it renders.
+type nopDefaulter struct{}
+
+// Default does nothing.
+func (nopDefaulter) Default(_ runtime.Object) {}
+
+// newFieldManager returns a server-side apply field manager for the supplied
+// kind.
+func newFieldManager(tc managedfields.TypeConverter, gvk schema.GroupVersionKind) (*managedfields.FieldManager, error) {
+	return managedfields.NewDefaultCRDFieldManager(tc, unstructuredConverter{}, nopDefaulter{}, unstructuredCreater{}, gvk, gvk.GroupVersion(), "", nil)
+}
+
+// clearManagedFieldsTimes removes the timestamps from the supplied object's
+// managed fields. An API server records when each field manager last wrote the
+// object, but render output must be deterministic.
+func clearManagedFieldsTimes(u *unstructured.Unstructured) {
+	mfs := u.GetManagedFields()
+	if len(mfs) == 0 {
+		return
+	}
+	for i := range mfs {
+		mfs[i].Time = nil
+	}
+	u.SetManagedFields(mfs)
+}
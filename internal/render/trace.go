@@ -0,0 +1,121 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package render
+
+import (
+	"context"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/structpb"
+
+	pipelinev1alpha1 "github.com/crossplane/crossplane-runtime/v2/apis/pipelineinspector/proto/v1alpha1"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/errors"
+
+	"github.com/crossplane/crossplane/v2/internal/xfn/inspected"
+	fnv1 "github.com/crossplane/crossplane/v2/proto/fn/v1"
+	renderv1alpha1 "github.com/crossplane/crossplane/v2/proto/render/v1alpha1"
+)
+
+// A TracingPipelineInspector records the request and response of each function
+// pipeline step. It satisfies the inspected.PipelineInspector interface. It
+// redacts sensitive data the same way the Pipeline Inspector sidecar's
+// SocketPipelineInspector does.
+type TracingPipelineInspector struct {
+	traces []*renderv1alpha1.StepTrace
+
+	// spans maps the span ID of each step call to its trace, so responses
+	// can be matched to requests.
+	spans map[string]*renderv1alpha1.StepTrace
+}
+
+// NewTracingPipelineInspector returns a new TracingPipelineInspector.
+func NewTracingPipelineInspector() *TracingPipelineInspector {
+	return &TracingPipelineInspector{spans: make(map[string]*renderv1alpha1.StepTrace)}
+}
+
+// EmitRequest records the supplied request.
+func (i *TracingPipelineInspector) EmitRequest(_ context.Context, req *fnv1.RunFunctionRequest, meta *pipelinev1alpha1.StepMeta) error {
+	if meta == nil {
+		return errors.New("step metadata is required to trace pipeline request")
+	}
+
+	s, err := traceStruct(inspected.SanitizeRequest(req))
+	if err != nil {
+		return errors.Wrapf(err, "cannot trace request for function %s", meta.GetFunctionName())
+	}
+
+	t := &renderv1alpha1.StepTrace{
+		Reconcile:    1,
+		Step:         meta.GetStepName(),
+		StepIndex:    meta.GetStepIndex(),
+		FunctionName: meta.GetFunctionName(),
+		Request:      s,
+	}
+	i.traces = append(i.traces, t)
+	i.spans[meta.GetSpanId()] = t
+
+	return nil
+}
+
+// EmitResponse records the supplied response and error.
+func (i *TracingPipelineInspector) EmitResponse(_ context.Context, rsp *fnv1.RunFunctionResponse, fnErr error, meta *pipelinev1alpha1.StepMeta) error {
+	if meta == nil {
+		return errors.New("step metadata is required to trace pipeline response")
+	}
+
+	t, ok := i.spans[meta.GetSpanId()]
+	if !ok {
+		return errors.Errorf("cannot trace response for function %s: no request was traced", meta.GetFunctionName())
+	}
+	delete(i.spans, meta.GetSpanId())
+
+	if fnErr != nil {
+		t.Error = fnErr.Error()
+	}
+
+	if rsp == nil {
+		return nil
+	}
+
+	s, err := traceStruct(inspected.SanitizeResponse(rsp))
+	if err != nil {
+		return errors.Wrapf(err, "cannot trace response for function %s", meta.GetFunctionName())
+	}
+	t.Response = s
+
+	return nil
+}
+
+// Traces returns all recorded traces.
+func (i *TracingPipelineInspector) Traces() []*renderv1alpha1.StepTrace {
+	out := make([]*renderv1alpha1.StepTrace, len(i.traces))
+	copy(out, i.traces)
+	return out
+}
+
+func traceStruct(m proto.Message) (*structpb.Struct, error) {
+	bs, err := protojson.Marshal(m)
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot marshal message to json")
+	}
+	s := &structpb.Struct{}
+	if err := s.UnmarshalJSON(bs); err != nil {
+		return nil, errors.Wrap(err, "cannot unmarshal message from json")
+	}
+	return s, nil
+}
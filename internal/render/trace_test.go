@@ -0,0 +1,122 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package render
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/protobuf/testing/protocmp"
+	"google.golang.org/protobuf/types/known/structpb"
+
+	pipelinev1alpha1 "github.com/crossplane/crossplane-runtime/v2/apis/pipelineinspector/proto/v1alpha1"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/errors"
+
+	fnv1 "github.com/crossplane/crossplane/v2/proto/fn/v1"
+	renderv1alpha1 "github.com/crossplane/crossplane/v2/proto/render/v1alpha1"
+)
+
+func TestTracingPipelineInspector(t *testing.T) {
+	meta := &pipelinev1alpha1.StepMeta{
+		StepName:     "compose",
+		StepIndex:    1,
+		FunctionName: "function-dummy",
+		SpanId:       "span",
+	}
+
+	type args struct {
+		req   *fnv1.RunFunctionRequest
+		rsp   *fnv1.RunFunctionResponse
+		fnErr error
+	}
+
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   []*renderv1alpha1.StepTrace
+	}{
+		"RedactCredentials": {
+			reason: "Credentials in the request should be redacted.",
+			args: args{
+				req: &fnv1.RunFunctionRequest{
+					Credentials: map[string]*fnv1.Credentials{
+						"secret": {Source: &fnv1.Credentials_CredentialData{CredentialData: &fnv1.CredentialData{
+							Data: map[string][]byte{"password": []byte("hunter2")},
+						}}},
+					},
+				},
+				rsp: &fnv1.RunFunctionResponse{Meta: &fnv1.ResponseMeta{Tag: "tag"}},
+			},
+			want: []*renderv1alpha1.StepTrace{{
+				Reconcile:    1,
+				Step:         "compose",
+				StepIndex:    1,
+				FunctionName: "function-dummy",
+				Request: &structpb.Struct{Fields: map[string]*structpb.Value{
+					"credentials": structpb.NewStructValue(&structpb.Struct{Fields: map[string]*structpb.Value{
+						"secret": structpb.NewStructValue(&structpb.Struct{Fields: map[string]*structpb.Value{
+							"credentialData": structpb.NewStructValue(&structpb.Struct{Fields: map[string]*structpb.Value{
+								"data": structpb.NewStructValue(&structpb.Struct{Fields: map[string]*structpb.Value{
+									// base64 of **REDACTED**
+									"password": structpb.NewStringValue("KipSRURBQ1RFRCoq"),
+								}}),
+							}}),
+						}}),
+					}}),
+				}},
+				Response: &structpb.Struct{Fields: map[string]*structpb.Value{
+					"meta": structpb.NewStructValue(&structpb.Struct{Fields: map[string]*structpb.Value{
+						"tag": structpb.NewStringValue("tag"),
+					}}),
+				}},
+			}},
+		},
+		"FunctionError": {
+			reason: "An error calling the function should be recorded.",
+			args: args{
+				req:   &fnv1.RunFunctionRequest{},
+				fnErr: errors.New("boom"),
+			},
+			want: []*renderv1alpha1.StepTrace{{
+				Reconcile:    1,
+				Step:         "compose",
+				StepIndex:    1,
+				FunctionName: "function-dummy",
+				Request:      &structpb.Struct{Fields: map[string]*structpb.Value{}},
+				Error:        "boom",
+			}},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			i := NewTracingPipelineInspector()
+
+			if err := i.EmitRequest(context.Background(), tc.args.req, meta); err != nil {
+				t.Fatalf("\n%s\nEmitRequest(...): unexpected error: %v", tc.reason, err)
+			}
+			if err := i.EmitResponse(context.Background(), tc.args.rsp, tc.args.fnErr, meta); err != nil {
+				t.Fatalf("\n%s\nEmitResponse(...): unexpected error: %v", tc.reason, err)
+			}
+
+			if diff := cmp.Diff(tc.want, i.Traces(), protocmp.Transform()); diff != "" {
+				t.Errorf("\n%s\nTraces(): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
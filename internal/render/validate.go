@@ -0,0 +1,215 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package render
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+
+	"google.golang.org/protobuf/types/known/structpb"
+	"k8s.io/apiextensions-apiserver/pkg/apis/apiextensions"
+	extv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	structuralschema "k8s.io/apiextensions-apiserver/pkg/apiserver/schema"
+	"k8s.io/apiextensions-apiserver/pkg/apiserver/schema/cel"
+	"k8s.io/apiextensions-apiserver/pkg/apiserver/schema/listtype"
+	"k8s.io/apiextensions-apiserver/pkg/apiserver/schema/objectmeta"
+	"k8s.io/apiextensions-apiserver/pkg/apiserver/schema/pruning"
+	"k8s.io/apiextensions-apiserver/pkg/apiserver/validation"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	celconfig "k8s.io/apiserver/pkg/apis/cel"
+	"k8s.io/apiserver/pkg/cel/openapi/resolver"
+	"k8s.io/kube-openapi/pkg/validation/spec"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/xcrd"
+
+	renderv1alpha1 "github.com/crossplane/crossplane/v2/proto/render/v1alpha1"
+)
+
+// refPrefix is the prefix for local schema references in OpenAPI v3 documents.
+const refPrefix = "#/components/schemas/"
+
+// ValidationSchemas returns the schema of each kind described by the supplied
+// OpenAPI v3 documents. References to other schemas are flattened inline.
+// Schemas shared by several kinds (e.g. DeleteOptions) are skipped.
+func ValidationSchemas(docs []*structpb.Struct) (map[schema.GroupVersionKind]*extv1.JSONSchemaProps, error) {
+	schemas := make(map[schema.GroupVersionKind]*extv1.JSONSchemaProps)
+
+	for _, doc := range docs {
+		_, parsed, err := parseOpenAPIDocument(doc)
+		if err != nil {
+			return nil, err
+		}
+		if parsed.Components == nil {
+			continue
+		}
+
+		schemaOf := func(ref string) (*spec.Schema, bool) {
+			s, ok := parsed.Components.Schemas[strings.TrimPrefix(ref, refPrefix)]
+			return s, ok
+		}
+
+		for name, s := range parsed.Components.Schemas {
+			gvks := groupVersionKinds(s)
+			if len(gvks) != 1 {
+				continue
+			}
+
+			flattened, err := resolver.PopulateRefs(schemaOf, refPrefix+name)
+			if err != nil {
+				return nil, errors.Wrapf(err, "cannot flatten schema references for %s", gvks[0])
+			}
+
+			b, err := json.Marshal(flattened)
+			if err != nil {
+				return nil, errors.Wrapf(err, "cannot marshal schema for %s", gvks[0])
+			}
+			props := &extv1.JSONSchemaProps{}
+			if err := json.Unmarshal(b, props); err != nil {
+				return nil, errors.Wrapf(err, "cannot parse schema for %s", gvks[0])
+			}
+			schemas[gvks[0]] = props
+		}
+	}
+
+	return schemas, nil
+}
+
+// A SchemaValidator validates resources against their schemas, the way an API
+// server validates custom resources.
+type SchemaValidator struct {
+	schemas map[schema.GroupVersionKind]*validationSchema
+}
+
+type validationSchema struct {
+	validator validation.SchemaValidator
+
+	// structural is nil if the schema isn't structural. An API server
+	// requires a CRD's schema to be structural, but the schemas of some
+	// built-in types aren't.
+	structural *structuralschema.Structural
+
+	// cel is nil if the schema doesn't have any x-kubernetes-validations
+	// rules, or isn't structural.
+	cel *cel.Validator
+}
+
+// NewSchemaValidator returns a SchemaValidator that validates resources of
+// each of the supplied kinds against the supplied schema.
+func NewSchemaValidator(schemas map[schema.GroupVersionKind]*extv1.JSONSchemaProps) (*SchemaValidator, error) {
+	v := &SchemaValidator{schemas: make(map[schema.GroupVersionKind]*validationSchema, len(schemas))}
+
+	for gvk, s := range schemas {
+		internal := &apiextensions.JSONSchemaProps{}
+		if err := extv1.Convert_v1_JSONSchemaProps_To_apiextensions_JSONSchemaProps(s, internal, nil); err != nil {
+			return nil, errors.Wrapf(err, "cannot convert schema for %s", gvk)
+		}
+
+		sv, _, err := validation.NewSchemaValidator(internal)
+		if err != nil {
+			return nil, errors.Wrapf(err, "cannot create schema validator for %s", gvk)
+		}
+		vs := &validationSchema{validator: sv}
+
+		if ss, err := structuralschema.NewStructural(internal); err == nil && len(structuralschema.ValidateStructural(nil, ss)) == 0 {
+			vs.structural = ss
+			vs.cel = cel.NewValidator(ss, true, celconfig.PerCallLimit)
+		}
+
+		v.schemas[gvk] = vs
+	}
+
+	return v, nil
+}
+
+// Validate the supplied resource against the schema of its kind. It returns
+// false if there's no schema for the resource's kind.
+func (v *SchemaValidator) Validate(ctx context.Context, u *unstructured.Unstructured) (field.ErrorList, bool) {
+	s, ok := v.schemas[u.GroupVersionKind()]
+	if !ok {
+		return nil, false
+	}
+
+	errs := validation.ValidateCustomResource(nil, u.UnstructuredContent(), s.validator)
+
+	if s.structural == nil {
+		return errs, true
+	}
+
+	// An API server silently prunes fields its schema doesn't declare, or
+	// rejects them if the client asks for strict field validation. Either
+	// way they're probably a mistake.
+	unknown := pruning.PruneWithOptions(u.DeepCopy().UnstructuredContent(), s.structural, true, structuralschema.UnknownFieldPathOptions{TrackUnknownFieldPaths: true})
+	for _, path := range unknown {
+		errs = append(errs, &field.Error{Type: field.ErrorTypeForbidden, Field: path, Detail: "unknown field"})
+	}
+
+	errs = append(errs, objectmeta.Validate(nil, u.Object, s.structural, false)...)
+	errs = append(errs, listtype.ValidateListSetsAndMaps(nil, s.structural, u.Object)...)
+
+	if s.cel == nil {
+		return errs, true
+	}
+
+	// Like an API server, don't evaluate CEL rules against an object with
+	// missing or mistyped fields.
+	if blocking(errs) {
+		return append(errs, &field.Error{
+			Type:     field.ErrorTypeInvalid,
+			BadValue: field.OmitValueType{},
+			Detail:   "some validation rules were not checked because the object was invalid; correct the existing errors to complete validation",
+		}), true
+	}
+
+	cerrs, _ := s.cel.Validate(ctx, nil, s.structural, u.Object, nil, celconfig.RuntimeCELCostBudget)
+	return append(errs, cerrs...), true
+}
+
+// blocking returns true if the supplied errors would stop an API server from
+// evaluating CEL validation rules.
+func blocking(errs field.ErrorList) bool {
+	for _, err := range errs {
+		if err.Type == field.ErrorTypeNotSupported || err.Type == field.ErrorTypeRequired || err.Type == field.ErrorTypeTooLong || err.Type == field.ErrorTypeTooMany || err.Type == field.ErrorTypeTypeInvalid {
+			return true
+		}
+	}
+	return false
+}
+
+// AsValidationResult returns a ValidationResult describing the supplied
+// resource and its validation errors.
+func AsValidationResult(u *unstructured.Unstructured, errs field.ErrorList) *renderv1alpha1.ValidationResult {
+	r := &renderv1alpha1.ValidationResult{
+		ApiVersion:              u.GetAPIVersion(),
+		Kind:                    u.GetKind(),
+		Namespace:               u.GetNamespace(),
+		Name:                    u.GetName(),
+		CompositionResourceName: xcrd.GetCompositionResourceName(u),
+		Errors:                  make([]*renderv1alpha1.ValidationError, 0, len(errs)),
+	}
+	for _, err := range errs {
+		r.Errors = append(r.Errors, &renderv1alpha1.ValidationError{
+			Field:   err.Field,
+			Type:    string(err.Type),
+			Message: err.ErrorBody(),
+		})
+	}
+	return r
+}
@@ -0,0 +1,165 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package render
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	extv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+func TestSchemaValidatorValidate(t *testing.T) {
+	gvk := schema.GroupVersionKind{Group: "example.org", Version: "v1", Kind: "Bucket"}
+
+	schemas := map[schema.GroupVersionKind]*extv1.JSONSchemaProps{
+		gvk: {
+			Type: "object",
+			Properties: map[string]extv1.JSONSchemaProps{
+				"apiVersion": {Type: "string"},
+				"kind":       {Type: "string"},
+				"metadata":   {Type: "object"},
+				"spec": {
+					Type:     "object",
+					Required: []string{"region"},
+					Properties: map[string]extv1.JSONSchemaProps{
+						"region": {Type: "string"},
+						"replicas": {
+							Type: "integer",
+						},
+						"maxReplicas": {
+							Type: "integer",
+						},
+					},
+					XValidations: extv1.ValidationRules{
+						{Rule: "!has(self.replicas) || !has(self.maxReplicas) || self.replicas <= self.maxReplicas", Message: "replicas must not exceed maxReplicas"},
+					},
+				},
+			},
+		},
+	}
+
+	bucket := func(spec map[string]any) *unstructured.Unstructured {
+		return &unstructured.Unstructured{Object: map[string]any{
+			"apiVersion": "example.org/v1",
+			"kind":       "Bucket",
+			"metadata":   map[string]any{"name": "my-bucket"},
+			"spec":       spec,
+		}}
+	}
+
+	// We only compare the field and type of each error. The messages come
+	// from the API server's validation libraries.
+	type fieldError struct {
+		Field string
+		Type  field.ErrorType
+	}
+
+	type want struct {
+		errs []fieldError
+		ok   bool
+	}
+
+	cases := map[string]struct {
+		reason string
+		u      *unstructured.Unstructured
+		want   want
+	}{
+		"Valid": {
+			reason: "A resource that matches its schema should have no errors.",
+			u:      bucket(map[string]any{"region": "us-east-1", "replicas": int64(1), "maxReplicas": int64(2)}),
+			want:   want{ok: true},
+		},
+		"MissingRequiredField": {
+			reason: "A resource missing a required field should return a required error.",
+			u:      bucket(map[string]any{"replicas": int64(1)}),
+			want: want{
+				errs: []fieldError{
+					{Field: "spec.region", Type: field.ErrorTypeRequired},
+					// CEL rules aren't evaluated for an object with a missing
+					// required field.
+					{Type: field.ErrorTypeInvalid},
+				},
+				ok: true,
+			},
+		},
+		"WrongType": {
+			reason: "A field of the wrong type should return a type error.",
+			u:      bucket(map[string]any{"region": "us-east-1", "replicas": "one"}),
+			want: want{
+				errs: []fieldError{
+					{Field: "spec.replicas", Type: field.ErrorTypeTypeInvalid},
+					{Type: field.ErrorTypeInvalid},
+				},
+				ok: true,
+			},
+		},
+		"UnknownField": {
+			reason: "A field the schema doesn't declare should return an error.",
+			u:      bucket(map[string]any{"region": "us-east-1", "regoin": "us-east-1"}),
+			want: want{
+				errs: []fieldError{{Field: "spec.regoin", Type: field.ErrorTypeForbidden}},
+				ok:   true,
+			},
+		},
+		"CELRuleViolated": {
+			reason: "A resource that violates an x-kubernetes-validations rule should return an error.",
+			u:      bucket(map[string]any{"region": "us-east-1", "replicas": int64(3), "maxReplicas": int64(2)}),
+			want: want{
+				errs: []fieldError{{Field: "spec", Type: field.ErrorTypeInvalid}},
+				ok:   true,
+			},
+		},
+		"NoSchema": {
+			reason: "A resource of a kind without a schema shouldn't be validated.",
+			u: &unstructured.Unstructured{Object: map[string]any{
+				"apiVersion": "example.org/v1",
+				"kind":       "Other",
+				"metadata":   map[string]any{"name": "my-other"},
+			}},
+			want: want{ok: false},
+		},
+	}
+
+	v, err := NewSchemaValidator(schemas)
+	if err != nil {
+		t.Fatalf("NewSchemaValidator(...): %v", err)
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			errs, ok := v.Validate(context.Background(), tc.u)
+
+			got := make([]fieldError, 0, len(errs))
+			for _, e := range errs {
+				got = append(got, fieldError{Field: e.Field, Type: e.Type})
+			}
+
+			if diff := cmp.Diff(tc.want.errs, got, cmpopts.EquateEmpty()); diff != "" {
+				t.Errorf("\n%s\nValidate(...): -want errors, +got errors:\n%s", tc.reason, diff)
+			}
+			if ok != tc.want.ok {
+				t.Errorf("\n%s\nValidate(...): want ok %t, got %t", tc.reason, tc.want.ok, ok)
+			}
+		})
+	}
+}
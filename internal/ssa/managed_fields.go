@@ -56,6 +56,10 @@ func PrefixMatch(prefix string) FieldManagerMatcher {
 // apply. See https://github.com/kubernetes/kubernetes/issues/99003 for details.
 type ManagedFieldsUpgrader interface {
 	Upgrade(ctx context.Context, obj client.Object) error
+
+	// NeedsUpgrade returns true if Upgrade would change the supplied object's
+	// managed fields.
+	NeedsUpgrade(obj client.Object) bool
 }
 
 // A NopManagedFieldsUpgrader does nothing.
@@ -66,6 +70,11 @@ func (u *NopManagedFieldsUpgrader) Upgrade(_ context.Context, _ client.Object) e
 	return nil
 }
 
+// NeedsUpgrade always returns false.
+func (u *NopManagedFieldsUpgrader) NeedsUpgrade(_ client.Object) bool {
+	return false
+}
+
 // A PatchingManagedFieldsUpgrader uses a JSON patch to upgrade an object's
 // managed fields from client-side to server-side apply. The upgrade is a no-op
 // if the object does not need upgrading.
@@ -107,20 +116,8 @@ func (u *PatchingManagedFieldsUpgrader) Upgrade(ctx context.Context, obj client.
 		return nil
 	}
 
-	foundSSA := false
-	foundBFA := false
-	idxBFA := -1
-
-	for i, e := range obj.GetManagedFields() {
-		if u.matcher(e.Manager) {
-			foundSSA = true
-		}
-
-		if e.Manager == "before-first-apply" {
-			foundBFA = true
-			idxBFA = i
-		}
-	}
+	foundSSA, idxBFA := u.managers(obj)
+	foundBFA := idxBFA >= 0
 
 	switch {
 	// If our SSA field manager exists and the before-first-apply field manager
@@ -153,3 +150,30 @@ func (u *PatchingManagedFieldsUpgrader) Upgrade(ctx context.Context, obj client.
 		return errors.Wrap(resource.IgnoreNotFound(u.client.Patch(ctx, obj, client.RawPatch(types.JSONPatchType, p))), "cannot clear field managers")
 	}
 }
+
+// NeedsUpgrade returns true if Upgrade would change the supplied object's
+// managed fields, i.e. if the object exists and we haven't finished upgrading
+// it from client-side to server-side apply.
+func (u *PatchingManagedFieldsUpgrader) NeedsUpgrade(obj client.Object) bool {
+	if !meta.WasCreated(obj) {
+		return false
+	}
+	foundSSA, idxBFA := u.managers(obj)
+	return !foundSSA || idxBFA >= 0
+}
+
+// managers returns whether the supplied object has our SSA field manager, and
+// the index of its before-first-apply field manager, or -1 if it has none.
+func (u *PatchingManagedFieldsUpgrader) managers(obj client.Object) (foundSSA bool, idxBFA int) {
+	idxBFA = -1
+	for i, e := range obj.GetManagedFields() {
+		if u.matcher(e.Manager) {
+			foundSSA = true
+		}
+
+		if e.Manager == "before-first-apply" {
+			idxBFA = i
+		}
+	}
+	return foundSSA, idxBFA
+}
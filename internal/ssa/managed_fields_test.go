@@ -473,3 +473,91 @@ func TestPatchingManagedFieldsUpgrader(t *testing.T) {
 		})
 	}
 }
+
+func TestPatchingManagedFieldsUpgraderNeedsUpgrade(t *testing.T) {
+	fieldManagerSSA := "apiextensions.crossplane.io/test"
+	fieldManagerOld := "crossplane"
+
+	type args struct {
+		obj client.Object
+	}
+	type want struct {
+		needs bool
+	}
+
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   want
+	}{
+		"ObjectNotCreated": {
+			reason: "Should return false if object doesn't exist (no CreationTimestamp)",
+			args: args{
+				obj: &corev1.ConfigMap{},
+			},
+			want: want{
+				needs: false,
+			},
+		},
+		"AlreadyUpgraded": {
+			reason: "Should return false if SSA manager exists and no before-first-apply",
+			args: args{
+				obj: &corev1.ConfigMap{
+					ObjectMeta: metav1.ObjectMeta{
+						CreationTimestamp: metav1.Now(),
+						ManagedFields: []metav1.ManagedFieldsEntry{
+							{Manager: fieldManagerSSA, Operation: metav1.ManagedFieldsOperationApply},
+						},
+					},
+				},
+			},
+			want: want{
+				needs: false,
+			},
+		},
+		"UpgradeNotStarted": {
+			reason: "Should return true if neither SSA manager nor before-first-apply exists",
+			args: args{
+				obj: &corev1.ConfigMap{
+					ObjectMeta: metav1.ObjectMeta{
+						CreationTimestamp: metav1.Now(),
+						ManagedFields: []metav1.ManagedFieldsEntry{
+							{Manager: fieldManagerOld, Operation: metav1.ManagedFieldsOperationUpdate},
+						},
+					},
+				},
+			},
+			want: want{
+				needs: true,
+			},
+		},
+		"UpgradeNotFinished": {
+			reason: "Should return true if both SSA manager and before-first-apply exist",
+			args: args{
+				obj: &corev1.ConfigMap{
+					ObjectMeta: metav1.ObjectMeta{
+						CreationTimestamp: metav1.Now(),
+						ManagedFields: []metav1.ManagedFieldsEntry{
+							{Manager: fieldManagerSSA, Operation: metav1.ManagedFieldsOperationApply},
+							{Manager: "before-first-apply", Operation: metav1.ManagedFieldsOperationUpdate},
+						},
+					},
+				},
+			},
+			want: want{
+				needs: true,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			u := NewPatchingManagedFieldsUpgrader(&test.MockClient{}, ExactMatch(fieldManagerSSA))
+			got := u.NeedsUpgrade(tc.args.obj)
+
+			if diff := cmp.Diff(tc.want.needs, got); diff != "" {
+				t.Errorf("\n%s\nNeedsUpgrade(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,102 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+this file except in compliance with the License. You may obtain a copy of the
+License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed
+under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+CONDITIONS OF ANY KIND, either express or implied. See the License for the
+specific language governing permissions and limitations under the License.
+*/
+
+package breaker
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	_ Metrics = &NopMetrics{}
+	_ Metrics = &PrometheusMetrics{}
+)
+
+// NopMetrics does nothing.
+type NopMetrics struct{}
+
+// Transition does nothing.
+func (n NopMetrics) Transition(_ string, _ State) {}
+
+// Reject does nothing.
+func (n NopMetrics) Reject(_ string) {}
+
+// PrometheusMetrics for the circuit breaker.
+type PrometheusMetrics struct {
+	state       *prometheus.GaugeVec
+	transitions *prometheus.CounterVec
+	rejected    *prometheus.CounterVec
+}
+
+// NewPrometheusMetrics creates a new PrometheusMetrics.
+func NewPrometheusMetrics() *PrometheusMetrics {
+	return &PrometheusMetrics{
+		state: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Subsystem: "function",
+				Name:      "circuit_breaker_state",
+				Help:      "Current state of each function's circuit breaker. The gauge is 1 for the current state and 0 for other states.",
+			},
+			[]string{"function_name", "state"},
+		),
+		transitions: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Subsystem: "function",
+				Name:      "circuit_breaker_transitions_total",
+				Help:      "Total number of times each function's circuit breaker changed to each state.",
+			},
+			[]string{"function_name", "state"},
+		),
+		rejected: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Subsystem: "function",
+				Name:      "circuit_breaker_rejected_total",
+				Help:      "Total number of function calls rejected because the function's circuit breaker was open.",
+			},
+			[]string{"function_name"},
+		),
+	}
+}
+
+// Transition records that the named function's circuit changed state.
+func (m *PrometheusMetrics) Transition(name string, s State) {
+	for _, st := range []State{StateClosed, StateOpen, StateHalfOpen} {
+		v := 0.0
+		if st == s {
+			v = 1
+		}
+		m.state.With(prometheus.Labels{"function_name": name, "state": string(st)}).Set(v)
+	}
+	m.transitions.With(prometheus.Labels{"function_name": name, "state": string(s)}).Inc()
+}
+
+// Reject records that a call to the named function was rejected.
+func (m *PrometheusMetrics) Reject(name string) {
+	m.rejected.With(prometheus.Labels{"function_name": name}).Inc()
+}
+
+// Describe describes the Prometheus metrics.
+func (m *PrometheusMetrics) Describe(ch chan<- *prometheus.Desc) {
+	m.state.Describe(ch)
+	m.transitions.Describe(ch)
+	m.rejected.Describe(ch)
+}
+
+// Collect collects the Prometheus metrics.
+func (m *PrometheusMetrics) Collect(ch chan<- prometheus.Metric) {
+	m.state.Collect(ch)
+	m.transitions.Collect(ch)
+	m.rejected.Collect(ch)
+}
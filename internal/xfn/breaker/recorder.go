@@ -0,0 +1,101 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+this file except in compliance with the License. You may obtain a copy of the
+License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed
+under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+CONDITIONS OF ANY KIND, either express or implied. See the License for the
+specific language governing permissions and limitations under the License.
+*/
+
+package breaker
+
+import (
+	"context"
+
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/errors"
+
+	pkgv1 "github.com/crossplane/crossplane/apis/v2/pkg/v1"
+)
+
+// A RevisionStateRecorder records a function's circuit state as the
+// CircuitClosed condition of its active FunctionRevision.
+type RevisionStateRecorder struct {
+	client  client.Client
+	elected <-chan struct{}
+}
+
+// A RevisionStateRecorderOption configures a RevisionStateRecorder.
+type RevisionStateRecorderOption func(r *RevisionStateRecorder)
+
+// WithElected configures the RevisionStateRecorder to record state only once
+// the supplied channel is closed, i.e. once this replica is elected leader.
+// Each replica has its own circuit breakers, so replicas that record state
+// would otherwise overwrite each other's conditions. Every replica reports its
+// circuit state using metrics.
+func WithElected(elected <-chan struct{}) RevisionStateRecorderOption {
+	return func(r *RevisionStateRecorder) {
+		r.elected = elected
+	}
+}
+
+// NewRevisionStateRecorder returns a StateRecorder that records a function's
+// circuit state as the CircuitClosed condition of its active FunctionRevision.
+func NewRevisionStateRecorder(c client.Client, opts ...RevisionStateRecorderOption) *RevisionStateRecorder {
+	elected := make(chan struct{})
+	close(elected)
+
+	r := &RevisionStateRecorder{client: c, elected: elected}
+	for _, fn := range opts {
+		fn(r)
+	}
+
+	return r
+}
+
+// RecordState records the named function's circuit state.
+func (r *RevisionStateRecorder) RecordState(ctx context.Context, name string, s State, message string) error {
+	select {
+	case <-r.elected:
+	default:
+		// We're not the leader. Another replica records state.
+		return nil
+	}
+
+	c := pkgv1.CircuitClosed()
+	switch s {
+	case StateOpen:
+		c = pkgv1.CircuitOpen()
+	case StateHalfOpen:
+		c = pkgv1.CircuitHalfOpen()
+	case StateClosed:
+	}
+	c = c.WithMessage(message)
+
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		l := &pkgv1.FunctionRevisionList{}
+		if err := r.client.List(ctx, l, client.MatchingLabels{pkgv1.LabelParentPackage: name}); err != nil {
+			return errors.Wrap(err, "cannot list FunctionRevisions")
+		}
+
+		for i := range l.Items {
+			fr := &l.Items[i]
+			if fr.GetDesiredState() != pkgv1.PackageRevisionActive {
+				continue
+			}
+
+			fr.SetConditions(c)
+			return errors.Wrapf(r.client.Status().Update(ctx, fr), "cannot update status of FunctionRevision %q", fr.GetName())
+		}
+
+		return nil
+	})
+}
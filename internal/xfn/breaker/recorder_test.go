@@ -0,0 +1,100 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+this file except in compliance with the License. You may obtain a copy of the
+License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed
+under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+CONDITIONS OF ANY KIND, either express or implied. See the License for the
+specific language governing permissions and limitations under the License.
+*/
+
+package breaker
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/test"
+
+	xpv2 "github.com/crossplane/crossplane/apis/v2/core/v2"
+	pkgv1 "github.com/crossplane/crossplane/apis/v2/pkg/v1"
+)
+
+func TestRecordState(t *testing.T) {
+	elected := make(chan struct{})
+	close(elected)
+
+	type params struct {
+		opts []RevisionStateRecorderOption
+	}
+
+	type want struct {
+		updated []xpv2.Condition
+	}
+
+	cases := map[string]struct {
+		reason string
+		params params
+		want   want
+	}{
+		"Leader": {
+			reason: "We should record state if this replica is the leader.",
+			params: params{
+				opts: []RevisionStateRecorderOption{WithElected(elected)},
+			},
+			want: want{
+				updated: []xpv2.Condition{pkgv1.CircuitOpen().WithMessage("boom")},
+			},
+		},
+		"NotLeader": {
+			reason: "We shouldn't record state if this replica isn't the leader.",
+			params: params{
+				opts: []RevisionStateRecorderOption{WithElected(make(chan struct{}))},
+			},
+			want: want{},
+		},
+		"LeaderElectionDisabled": {
+			reason: "We should record state if we weren't told to wait to be elected leader.",
+			want: want{
+				updated: []xpv2.Condition{pkgv1.CircuitOpen().WithMessage("boom")},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var updated []xpv2.Condition
+
+			c := &test.MockClient{
+				MockList: test.NewMockListFn(nil, func(obj client.ObjectList) error {
+					obj.(*pkgv1.FunctionRevisionList).Items = []pkgv1.FunctionRevision{
+						{Spec: pkgv1.FunctionRevisionSpec{PackageRevisionSpec: pkgv1.PackageRevisionSpec{DesiredState: pkgv1.PackageRevisionActive}}},
+					}
+					return nil
+				}),
+				MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil, func(obj client.Object) error {
+					updated = append(updated, obj.(*pkgv1.FunctionRevision).Status.Conditions...)
+					return nil
+				}),
+			}
+
+			r := NewRevisionStateRecorder(c, tc.params.opts...)
+			if err := r.RecordState(context.Background(), "cool-fn", StateOpen, "boom"); err != nil {
+				t.Fatalf("\n%s\nr.RecordState(...): %v", tc.reason, err)
+			}
+
+			if diff := cmp.Diff(tc.want.updated, updated, cmpopts.IgnoreFields(xpv2.Condition{}, "LastTransitionTime")); diff != "" {
+				t.Errorf("\n%s\nr.RecordState(...): -want updated conditions, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,387 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+this file except in compliance with the License. You may obtain a copy of the
+License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed
+under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+CONDITIONS OF ANY KIND, either express or implied. See the License for the
+specific language governing permissions and limitations under the License.
+*/
+
+// Package breaker implements a function runner that stops calling functions
+// that are failing.
+package breaker
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/logging"
+
+	xpv2 "github.com/crossplane/crossplane/apis/v2/core/v2"
+	fnv1 "github.com/crossplane/crossplane/v2/proto/fn/v1"
+)
+
+// ReasonCircuitOpen indicates that a resource couldn't be reconciled because a
+// function it uses is failing, and its circuit is open.
+const ReasonCircuitOpen xpv2.ConditionReason = "FunctionCircuitOpen"
+
+// Circuit breaker defaults.
+const (
+	DefaultErrorRate    = 0.5
+	DefaultMinCalls     = 10
+	DefaultWindow       = 1 * time.Minute
+	DefaultOpenDuration = 30 * time.Second
+)
+
+// A State of a function's circuit.
+type State string
+
+// Circuit states.
+const (
+	// StateClosed circuits call the function.
+	StateClosed State = "Closed"
+
+	// StateOpen circuits reject calls to the function without calling it.
+	StateOpen State = "Open"
+
+	// StateHalfOpen circuits call the function once to probe whether it has
+	// recovered, and reject all other calls until the probe completes.
+	StateHalfOpen State = "HalfOpen"
+)
+
+// Metrics for the circuit breaker.
+type Metrics interface {
+	// Transition records that the named function's circuit changed state.
+	Transition(name string, s State)
+
+	// Reject records that a call to the named function was rejected.
+	Reject(name string)
+}
+
+// A StateRecorder records a function's circuit state, for example in the
+// status of the function's active revision.
+type StateRecorder interface {
+	// RecordState records the named function's circuit state. The message
+	// explains why the circuit is in its state.
+	RecordState(ctx context.Context, name string, s State, message string) error
+}
+
+// A StateRecorderFn is a function that records a function's circuit state.
+type StateRecorderFn func(ctx context.Context, name string, s State, message string) error
+
+// RecordState records the named function's circuit state.
+func (fn StateRecorderFn) RecordState(ctx context.Context, name string, s State, message string) error {
+	return fn(ctx, name, s, message)
+}
+
+// A FunctionRunner runs a composition function.
+type FunctionRunner interface {
+	// RunFunction runs the named composition function with the given request.
+	RunFunction(ctx context.Context, name string, req *fnv1.RunFunctionRequest) (*fnv1.RunFunctionResponse, error)
+}
+
+// A CircuitOpenError is returned when a call to a function is rejected because
+// its circuit is open.
+type CircuitOpenError struct {
+	// Function whose circuit is open.
+	Function string
+
+	// Until is when the circuit will next allow a call to probe whether the
+	// function has recovered. It's zero if a probe is already in flight.
+	Until time.Time
+}
+
+// Error returns the error message.
+func (e *CircuitOpenError) Error() string {
+	if e.Until.IsZero() {
+		return fmt.Sprintf("circuit breaker for function %q is open because too many recent calls failed; probing whether the function has recovered", e.Function)
+	}
+	return fmt.Sprintf("circuit breaker for function %q is open because too many recent calls failed; not calling it until %s", e.Function, e.Until.Format(time.RFC3339))
+}
+
+// IsCircuitOpen returns true if the supplied error was caused by a function's
+// circuit being open.
+func IsCircuitOpen(err error) bool {
+	e := &CircuitOpenError{}
+	return errors.As(err, &e)
+}
+
+// ReconcileError returns a ReconcileError condition for the supplied error. Its
+// reason is ReasonCircuitOpen if the error was caused by a function's circuit
+// being open.
+func ReconcileError(err error) xpv2.Condition {
+	c := xpv2.ReconcileError(err)
+	if IsCircuitOpen(err) {
+		c.Reason = ReasonCircuitOpen
+	}
+	return c
+}
+
+// A Runner is a FunctionRunner that wraps another FunctionRunner. It tracks
+// calls to each function, and opens the function's circuit when too many
+// calls fail. An open circuit fails fast, without calling the function. After
+// a while the circuit half-opens and allows one call through to probe whether
+// the function has recovered. The circuit closes if the probe succeeds, and
+// opens again if it fails.
+type Runner struct {
+	wrapped  FunctionRunner
+	metrics  Metrics
+	recorder StateRecorder
+	log      logging.Logger
+
+	errorRate    float64
+	minCalls     int
+	window       time.Duration
+	openDuration time.Duration
+
+	mx       sync.Mutex
+	circuits map[string]*circuit
+}
+
+// A RunnerOption configures a Runner.
+type RunnerOption func(*Runner)
+
+// WithMetrics sets the metrics used by the Runner.
+func WithMetrics(m Metrics) RunnerOption {
+	return func(r *Runner) {
+		r.metrics = m
+	}
+}
+
+// WithLogger sets the logger used by the Runner.
+func WithLogger(l logging.Logger) RunnerOption {
+	return func(r *Runner) {
+		r.log = l
+	}
+}
+
+// WithStateRecorder sets the recorder the Runner uses to record each time a
+// function's circuit changes state.
+func WithStateRecorder(sr StateRecorder) RunnerOption {
+	return func(r *Runner) {
+		r.recorder = sr
+	}
+}
+
+// WithErrorRate sets the fraction of calls to a function that must fail within
+// a window for its circuit to open. For example 0.5 opens the circuit when
+// half the calls fail.
+func WithErrorRate(rate float64) RunnerOption {
+	return func(r *Runner) {
+		r.errorRate = rate
+	}
+}
+
+// WithMinCalls sets how many calls to a function must be made within a window
+// before its circuit may open. This prevents a few failures from opening the
+// circuit of a rarely called function.
+func WithMinCalls(n int) RunnerOption {
+	return func(r *Runner) {
+		r.minCalls = n
+	}
+}
+
+// WithWindow sets how long the Runner counts calls to a function for before it
+// starts counting again.
+func WithWindow(d time.Duration) RunnerOption {
+	return func(r *Runner) {
+		r.window = d
+	}
+}
+
+// WithOpenDuration sets how long a function's circuit stays open before it
+// half-opens to probe whether the function has recovered.
+func WithOpenDuration(d time.Duration) RunnerOption {
+	return func(r *Runner) {
+		r.openDuration = d
+	}
+}
+
+// NewRunner creates a new circuit breaking Runner that wraps the supplied
+// FunctionRunner.
+func NewRunner(wrapped FunctionRunner, opts ...RunnerOption) *Runner {
+	r := &Runner{
+		wrapped:      wrapped,
+		metrics:      &NopMetrics{},
+		recorder:     StateRecorderFn(func(_ context.Context, _ string, _ State, _ string) error { return nil }),
+		log:          logging.NewNopLogger(),
+		errorRate:    DefaultErrorRate,
+		minCalls:     DefaultMinCalls,
+		window:       DefaultWindow,
+		openDuration: DefaultOpenDuration,
+		circuits:     make(map[string]*circuit),
+	}
+
+	for _, fn := range opts {
+		fn(r)
+	}
+
+	return r
+}
+
+// RunFunction runs the named function, unless its circuit is open.
+func (r *Runner) RunFunction(ctx context.Context, name string, req *fnv1.RunFunctionRequest) (*fnv1.RunFunctionResponse, error) {
+	probe, t, err := r.allow(name, time.Now())
+	r.transitioned(ctx, name, t)
+	if err != nil {
+		r.metrics.Reject(name)
+		return nil, err
+	}
+
+	rsp, err := r.wrapped.RunFunction(ctx, name, req)
+
+	// Don't blame the function if our caller gave up on it. Release the
+	// probe so another call can try.
+	if err != nil && ctx.Err() != nil {
+		r.release(name, probe)
+		return rsp, err
+	}
+
+	r.transitioned(ctx, name, r.record(name, probe, err, time.Now()))
+
+	return rsp, err
+}
+
+// A circuit tracks calls to one function.
+type circuit struct {
+	state State
+
+	// Calls and failures since windowStart, while closed.
+	windowStart time.Time
+	calls       int
+	failures    int
+
+	// When an open circuit will half-open.
+	until time.Time
+
+	// Whether a half-open circuit's probe is in flight.
+	probing bool
+}
+
+// A transition of a function's circuit. Its state is empty if the circuit
+// didn't change state.
+type transition struct {
+	state   State
+	message string
+}
+
+func (r *Runner) transitioned(ctx context.Context, name string, t transition) {
+	if t.state == "" {
+		return
+	}
+
+	log := r.log.WithValues("function", name, "state", t.state)
+	log.Info("Function circuit breaker changed state", "reason", t.message)
+	r.metrics.Transition(name, t.state)
+
+	if err := r.recorder.RecordState(ctx, name, t.state, t.message); err != nil {
+		log.Info("Cannot record function circuit breaker state", "error", err)
+	}
+}
+
+// allow returns whether a call may be made to the named function, and whether
+// the call is a probe of a half-open circuit.
+func (r *Runner) allow(name string, now time.Time) (bool, transition, error) {
+	r.mx.Lock()
+	defer r.mx.Unlock()
+
+	c, ok := r.circuits[name]
+	if !ok {
+		c = &circuit{state: StateClosed, windowStart: now}
+		r.circuits[name] = c
+	}
+
+	switch c.state {
+	case StateOpen:
+		if now.Before(c.until) {
+			return false, transition{}, &CircuitOpenError{Function: name, Until: c.until}
+		}
+		c.state = StateHalfOpen
+		c.probing = true
+		return true, transition{state: StateHalfOpen, message: "Probing whether the function has recovered"}, nil
+	case StateHalfOpen:
+		if c.probing {
+			return false, transition{}, &CircuitOpenError{Function: name}
+		}
+		c.probing = true
+		return true, transition{}, nil
+	case StateClosed:
+	}
+
+	if now.Sub(c.windowStart) > r.window {
+		c.windowStart = now
+		c.calls = 0
+		c.failures = 0
+	}
+
+	return false, transition{}, nil
+}
+
+// release allows another call to probe a half-open circuit.
+func (r *Runner) release(name string, probe bool) {
+	if !probe {
+		return
+	}
+
+	r.mx.Lock()
+	defer r.mx.Unlock()
+
+	if c := r.circuits[name]; c.state == StateHalfOpen {
+		c.probing = false
+	}
+}
+
+// record records the result of a call to the named function.
+func (r *Runner) record(name string, probe bool, err error, now time.Time) transition {
+	r.mx.Lock()
+	defer r.mx.Unlock()
+
+	c := r.circuits[name]
+
+	if probe {
+		if c.state != StateHalfOpen {
+			return transition{}
+		}
+		if err != nil {
+			c.state = StateOpen
+			c.probing = false
+			c.until = now.Add(r.openDuration)
+			return transition{state: StateOpen, message: fmt.Sprintf("Probe call failed: %s", err)}
+		}
+		c.state = StateClosed
+		c.probing = false
+		c.windowStart = now
+		c.calls = 0
+		c.failures = 0
+		return transition{state: StateClosed, message: "Probe call succeeded"}
+	}
+
+	// Ignore calls that were in flight when the circuit opened.
+	if c.state != StateClosed {
+		return transition{}
+	}
+
+	c.calls++
+	if err == nil {
+		return transition{}
+	}
+	c.failures++
+
+	if c.calls < r.minCalls || float64(c.failures)/float64(c.calls) < r.errorRate {
+		return transition{}
+	}
+
+	msg := fmt.Sprintf("%d of the last %d calls failed within %s. Last error: %s", c.failures, c.calls, r.window, err)
+	c.state = StateOpen
+	c.until = now.Add(r.openDuration)
+	return transition{state: StateOpen, message: msg}
+}
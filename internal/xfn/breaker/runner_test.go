@@ -0,0 +1,195 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+this file except in compliance with the License. You may obtain a copy of the
+License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed
+under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+CONDITIONS OF ANY KIND, either express or implied. See the License for the
+specific language governing permissions and limitations under the License.
+*/
+
+package breaker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	fnv1 "github.com/crossplane/crossplane/v2/proto/fn/v1"
+)
+
+// A call to a function, and whether the wrapped runner should fail it.
+type call struct {
+	fail bool
+
+	// Sleep before making the call.
+	sleep time.Duration
+}
+
+// The outcome of a call.
+type outcome string
+
+const (
+	called   outcome = "Called"
+	failed   outcome = "Failed"
+	rejected outcome = "Rejected"
+)
+
+func TestRunFunction(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	type want struct {
+		outcomes    []outcome
+		transitions []State
+	}
+
+	cases := map[string]struct {
+		reason string
+		o      []RunnerOption
+		calls  []call
+		want   want
+	}{
+		"BelowMinCalls": {
+			reason: "The circuit shouldn't open until the minimum number of calls have been made.",
+			o:      []RunnerOption{WithMinCalls(3)},
+			calls:  []call{{fail: true}, {fail: true}},
+			want: want{
+				outcomes: []outcome{failed, failed},
+			},
+		},
+		"BelowErrorRate": {
+			reason: "The circuit shouldn't open while the error rate is below the threshold.",
+			o:      []RunnerOption{WithMinCalls(2), WithErrorRate(0.6)},
+			calls:  []call{{}, {fail: true}, {}, {fail: true}},
+			want: want{
+				outcomes: []outcome{called, failed, called, failed},
+			},
+		},
+		"Open": {
+			reason: "The circuit should open once the error rate reaches the threshold, and reject calls while open.",
+			o:      []RunnerOption{WithMinCalls(2), WithOpenDuration(time.Hour)},
+			calls:  []call{{}, {fail: true}, {}},
+			want: want{
+				outcomes:    []outcome{called, failed, rejected},
+				transitions: []State{StateOpen},
+			},
+		},
+		"WindowResets": {
+			reason: "Calls from a previous window shouldn't count toward the error rate.",
+			o:      []RunnerOption{WithMinCalls(2), WithErrorRate(0.6), WithWindow(10 * time.Millisecond)},
+			calls:  []call{{fail: true}, {sleep: 20 * time.Millisecond}, {fail: true}},
+			want: want{
+				outcomes: []outcome{failed, called, failed},
+			},
+		},
+		"HalfOpenProbeSucceeds": {
+			reason: "The circuit should half-open after the open duration, and close if its probe succeeds.",
+			o:      []RunnerOption{WithMinCalls(1), WithOpenDuration(10 * time.Millisecond)},
+			calls:  []call{{fail: true}, {}, {sleep: 20 * time.Millisecond}, {}},
+			want: want{
+				outcomes:    []outcome{failed, rejected, called, called},
+				transitions: []State{StateOpen, StateHalfOpen, StateClosed},
+			},
+		},
+		"HalfOpenProbeFails": {
+			reason: "The circuit should open again if its probe fails.",
+			o:      []RunnerOption{WithMinCalls(1), WithOpenDuration(10 * time.Millisecond)},
+			calls:  []call{{fail: true}, {sleep: 20 * time.Millisecond, fail: true}, {}},
+			want: want{
+				outcomes:    []outcome{failed, failed, rejected},
+				transitions: []State{StateOpen, StateHalfOpen, StateOpen},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var fail bool
+			wrapped := FunctionRunnerFn(func(_ context.Context, _ string, _ *fnv1.RunFunctionRequest) (*fnv1.RunFunctionResponse, error) {
+				if fail {
+					return nil, errBoom
+				}
+				return &fnv1.RunFunctionResponse{}, nil
+			})
+
+			transitions := []State{}
+			rec := StateRecorderFn(func(_ context.Context, _ string, s State, _ string) error {
+				transitions = append(transitions, s)
+				return nil
+			})
+
+			r := NewRunner(wrapped, append([]RunnerOption{WithStateRecorder(rec)}, tc.o...)...)
+
+			outcomes := make([]outcome, 0, len(tc.calls))
+			for _, c := range tc.calls {
+				time.Sleep(c.sleep)
+				fail = c.fail
+
+				_, err := r.RunFunction(context.Background(), "cool-fn", &fnv1.RunFunctionRequest{})
+				switch {
+				case IsCircuitOpen(err):
+					outcomes = append(outcomes, rejected)
+				case err != nil:
+					outcomes = append(outcomes, failed)
+				default:
+					outcomes = append(outcomes, called)
+				}
+			}
+
+			if diff := cmp.Diff(tc.want.outcomes, outcomes); diff != "" {
+				t.Errorf("\n%s\nr.RunFunction(...): -want outcomes, +got outcomes:\n%s", tc.reason, diff)
+			}
+
+			want := tc.want.transitions
+			if want == nil {
+				want = []State{}
+			}
+			if diff := cmp.Diff(want, transitions); diff != "" {
+				t.Errorf("\n%s\nr.RunFunction(...): -want transitions, +got transitions:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestRunFunctionCallerGaveUp(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	wrapped := FunctionRunnerFn(func(ctx context.Context, _ string, _ *fnv1.RunFunctionRequest) (*fnv1.RunFunctionResponse, error) {
+		return nil, ctx.Err()
+	})
+
+	r := NewRunner(wrapped, WithMinCalls(1))
+
+	// Calls that fail because our caller gave up shouldn't open the circuit.
+	for range 3 {
+		if _, err := r.RunFunction(ctx, "cool-fn", &fnv1.RunFunctionRequest{}); IsCircuitOpen(err) {
+			t.Fatalf("r.RunFunction(...): want the circuit to stay closed, got: %v", err)
+		}
+	}
+}
+
+func TestReconcileError(t *testing.T) {
+	err := fmt.Errorf("cannot compose resources: %w", &CircuitOpenError{Function: "cool-fn", Until: time.Now()})
+
+	if diff := cmp.Diff(ReasonCircuitOpen, ReconcileError(err).Reason); diff != "" {
+		t.Errorf("\nReconcileError(...): -want reason, +got reason:\n%s", diff)
+	}
+}
+
+// FunctionRunnerFn is a function that can run a function.
+type FunctionRunnerFn func(ctx context.Context, name string, req *fnv1.RunFunctionRequest) (*fnv1.RunFunctionResponse, error)
+
+// RunFunction runs the named function.
+func (fn FunctionRunnerFn) RunFunction(ctx context.Context, name string, req *fnv1.RunFunctionRequest) (*fnv1.RunFunctionResponse, error) {
+	return fn(ctx, name, req)
+}
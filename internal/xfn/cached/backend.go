@@ -0,0 +1,172 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+this file except in compliance with the License. You may obtain a copy of the
+License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed
+under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+CONDITIONS OF ANY KIND, either express or implied. See the License for the
+specific language governing permissions and limitations under the License.
+*/
+
+package cached
+
+import (
+	"context"
+	"io/fs"
+	"path/filepath"
+	"time"
+
+	"github.com/spf13/afero"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/errors"
+)
+
+// A Backend stores cached responses. Keys are like function-name/request-tag.
+// Values are serialized CachedRunFunctionResponses.
+type Backend interface {
+	// Read returns the value stored at the supplied key. It returns an
+	// error that satisfies errors.Is(err, fs.ErrNotExist) if there's no
+	// value.
+	Read(ctx context.Context, key string) ([]byte, error)
+
+	// Write stores the supplied value at the supplied key, replacing any
+	// existing value. Concurrent readers must see either the old or the new
+	// value, never a partial value.
+	Write(ctx context.Context, key string, value []byte) error
+
+	// Delete deletes the value stored at the supplied key. It returns an
+	// error that satisfies errors.Is(err, fs.ErrNotExist) if there's no
+	// value.
+	Delete(ctx context.Context, key string) error
+
+	// List returns all stored keys.
+	List(ctx context.Context) ([]BackendEntry, error)
+}
+
+// A BackendEntry describes a value stored in a Backend.
+type BackendEntry struct {
+	// Key of the value.
+	Key string `json:"key"`
+
+	// Size of the value in bytes.
+	Size int64 `json:"size"`
+
+	// Modified is when the value was last written.
+	Modified time.Time `json:"modified"`
+}
+
+// A FilesystemBackend stores cached responses as files. Each key is a path
+// relative to the root of its filesystem.
+type FilesystemBackend struct {
+	fs afero.Afero
+}
+
+// NewFilesystemBackend returns a Backend that stores cached responses as files
+// at the root of the supplied filesystem. Wrap your desired filesystem with
+// afero.BasePathFS to cache files under a specific path.
+func NewFilesystemBackend(fs afero.Fs) *FilesystemBackend {
+	return &FilesystemBackend{fs: afero.Afero{Fs: fs}}
+}
+
+// Read the file at the supplied key.
+func (b *FilesystemBackend) Read(_ context.Context, key string) ([]byte, error) {
+	return b.fs.ReadFile(b.path(key))
+}
+
+// Write the file at the supplied key.
+func (b *FilesystemBackend) Write(_ context.Context, key string, value []byte) error {
+	path := b.path(key)
+	dir := filepath.Dir(path)
+	if err := b.fs.MkdirAll(dir, 0o700); err != nil {
+		return errors.Wrapf(err, "cannot create directory %s", dir)
+	}
+
+	// Write and rename a temp file to make our write 'atomic'. This ensure
+	// we won't overwrite a cache file that we're currently reading.
+	tmp, err := b.fs.TempFile(dir, "")
+	if err != nil {
+		return errors.Wrap(err, "cannot create temporary file")
+	}
+
+	if _, err := tmp.Write(value); err != nil {
+		_ = tmp.Close()
+		return errors.Wrap(err, "cannot write temporary file")
+	}
+
+	if err := tmp.Close(); err != nil {
+		return errors.Wrap(err, "cannot close temporary file")
+	}
+
+	return errors.Wrapf(b.fs.Rename(tmp.Name(), path), "cannot rename temporary file to %s", path)
+}
+
+// Delete the file at the supplied key.
+func (b *FilesystemBackend) Delete(_ context.Context, key string) error {
+	return b.fs.Remove(b.path(key))
+}
+
+// path returns the path of the file at the supplied key. Keys are always
+// relative to the root of the filesystem.
+func (b *FilesystemBackend) path(key string) string {
+	return filepath.Join("/", key)
+}
+
+// List all files. List also deletes any empty directories it finds.
+func (b *FilesystemBackend) List(ctx context.Context) ([]BackendEntry, error) {
+	var entries []BackendEntry
+
+	iofs := afero.NewIOFS(b.fs)
+	err := fs.WalkDir(iofs, "/", func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		// Stop walking if our context is cancelled.
+		select {
+		case <-ctx.Done():
+			return fs.SkipAll
+		default:
+		}
+
+		if d.IsDir() {
+			// Don't try to delete the root of the cache.
+			if path == "/" {
+				return nil
+			}
+
+			des, err := b.fs.ReadDir(path)
+			if err != nil || len(des) > 0 {
+				return nil //nolint:nilerr // We'll try again next time.
+			}
+
+			// Cleanup empty directories. Best effort - we'll try
+			// again next time.
+			_ = b.fs.Remove(path)
+
+			return nil
+		}
+
+		info, err := d.Info()
+		if err != nil {
+			// The file was probably deleted after we read its
+			// directory.
+			return nil //nolint:nilerr // We just skip the file.
+		}
+
+		rel, err := filepath.Rel("/", path)
+		if err != nil {
+			return err
+		}
+
+		entries = append(entries, BackendEntry{Key: rel, Size: info.Size(), Modified: info.ModTime()})
+
+		return nil
+	})
+
+	return entries, err
+}
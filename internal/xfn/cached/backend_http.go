@@ -0,0 +1,384 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+this file except in compliance with the License. You may obtain a copy of the
+License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed
+under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+CONDITIONS OF ANY KIND, either express or implied. See the License for the
+specific language governing permissions and limitations under the License.
+*/
+
+package cached
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/subtle"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"os"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/errors"
+)
+
+// An HTTPBackend stores cached responses in a remote key/value store using a
+// simple HTTP protocol:
+//
+//   - GET /{key} returns the value stored at key, or 404 Not Found.
+//   - PUT /{key} stores the request body at key.
+//   - DELETE /{key} deletes the value stored at key, or returns 404 Not Found.
+//   - GET / returns a JSON array of BackendEntry.
+//
+// Use NewBackendHandler to serve this protocol.
+type HTTPBackend struct {
+	url     string
+	client  *http.Client
+	token   string
+	key     []byte
+	maxSize int64
+}
+
+// DefaultHTTPBackendTimeout is how long an HTTPBackend waits for the remote
+// store to respond by default. A cache that's slower than this is slower than
+// calling most functions.
+const DefaultHTTPBackendTimeout = 10 * time.Second
+
+// DefaultMaxEntrySize is the largest value, in bytes, an HTTPBackend reads
+// from the remote store, or a handler returned by NewBackendHandler accepts,
+// by default. It's much larger than a function response is likely to be.
+const DefaultMaxEntrySize = 16 << 20
+
+// An HTTPBackendOption configures an HTTPBackend.
+type HTTPBackendOption func(b *HTTPBackend)
+
+// WithHTTPClient configures the HTTP client an HTTPBackend uses.
+func WithHTTPClient(c *http.Client) HTTPBackendOption {
+	return func(b *HTTPBackend) {
+		b.client = c
+	}
+}
+
+// WithTimeout configures how long an HTTPBackend waits for the remote store to
+// respond. Zero means no timeout. It modifies the client supplied using
+// WithHTTPClient, if any.
+func WithTimeout(d time.Duration) HTTPBackendOption {
+	return func(b *HTTPBackend) {
+		b.client.Timeout = d
+	}
+}
+
+// WithTLSConfig configures the TLS config an HTTPBackend uses to connect to the
+// remote store, e.g. to trust a CA bundle or to present a client certificate.
+// It modifies the client supplied using WithHTTPClient, if any.
+func WithTLSConfig(cfg *tls.Config) HTTPBackendOption {
+	return func(b *HTTPBackend) {
+		t := http.DefaultTransport.(*http.Transport).Clone() //nolint:forcetypeassert // The default transport is always an *http.Transport.
+		t.TLSClientConfig = cfg
+		b.client.Transport = t
+	}
+}
+
+// WithBearerToken configures an HTTPBackend to authenticate to the remote store
+// using the supplied bearer token.
+func WithBearerToken(token string) HTTPBackendOption {
+	return func(b *HTTPBackend) {
+		b.token = token
+	}
+}
+
+// WithSigningKey configures an HTTPBackend to sign each value it writes using
+// HMAC-SHA256 with the supplied key, and to verify the signature of each value
+// it reads. This ensures Crossplane only serves responses that a replica with
+// the same key cached, even if the remote store is compromised.
+func WithSigningKey(key []byte) HTTPBackendOption {
+	return func(b *HTTPBackend) {
+		b.key = key
+	}
+}
+
+// WithMaxEntrySize configures the largest value, in bytes, an HTTPBackend
+// reads from the remote store. Larger values are treated as if they didn't
+// exist.
+func WithMaxEntrySize(n int64) HTTPBackendOption {
+	return func(b *HTTPBackend) {
+		b.maxSize = n
+	}
+}
+
+// NewHTTPBackend returns a Backend that stores cached responses in the remote
+// key/value store served at the supplied URL.
+func NewHTTPBackend(url string, o ...HTTPBackendOption) *HTTPBackend {
+	b := &HTTPBackend{
+		url:     strings.TrimSuffix(url, "/"),
+		client:  &http.Client{Timeout: DefaultHTTPBackendTimeout},
+		maxSize: DefaultMaxEntrySize,
+	}
+	for _, fn := range o {
+		fn(b)
+	}
+	return b
+}
+
+// LoadTLSConfig loads a TLS config for an HTTPBackend. The CA bundle is used
+// to verify the remote store's certificate. The certificate and key, if any,
+// are presented to the remote store. Any empty path is ignored.
+func LoadTLSConfig(caPath, certPath, keyPath string) (*tls.Config, error) {
+	cfg := &tls.Config{MinVersion: tls.VersionTLS12}
+
+	if caPath != "" {
+		ca, err := os.ReadFile(caPath) //nolint:gosec // The path is supplied by the administrator.
+		if err != nil {
+			return nil, errors.Wrapf(err, "cannot read CA bundle %s", caPath)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(ca) {
+			return nil, errors.Errorf("cannot parse CA bundle %s", caPath)
+		}
+		cfg.RootCAs = pool
+	}
+
+	if certPath != "" || keyPath != "" {
+		cert, err := tls.LoadX509KeyPair(certPath, keyPath)
+		if err != nil {
+			return nil, errors.Wrap(err, "cannot load client certificate")
+		}
+		cfg.Certificates = []tls.Certificate{cert}
+	}
+
+	return cfg, nil
+}
+
+// Read the value at the supplied key. Values that are too large, or that fail
+// signature verification, are deleted and treated as if they didn't exist.
+// Otherwise they'd fail every read until they expired.
+func (b *HTTPBackend) Read(ctx context.Context, key string) ([]byte, error) {
+	rsp, err := b.do(ctx, http.MethodGet, key, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer rsp.Body.Close() //nolint:errcheck // Only fails if the body was already closed.
+
+	v, err := io.ReadAll(io.LimitReader(rsp.Body, b.maxSize+1))
+	if err != nil {
+		return nil, errors.Wrapf(err, "cannot read value for key %s", key)
+	}
+	if int64(len(v)) > b.maxSize {
+		return nil, b.discard(ctx, key, fmt.Sprintf("value is larger than the maximum entry size of %d bytes", b.maxSize))
+	}
+	if b.key == nil {
+		return v, nil
+	}
+
+	if len(v) < sha256.Size {
+		return nil, b.discard(ctx, key, "value is too short to be signed")
+	}
+	sig, value := v[:sha256.Size], v[sha256.Size:]
+	if !hmac.Equal(sig, b.sign(key, value)) {
+		return nil, b.discard(ctx, key, "invalid signature")
+	}
+	return value, nil
+}
+
+// discard deletes the invalid value at the supplied key. It returns an error
+// that satisfies errors.Is(err, fs.ErrNotExist), so callers treat the value as
+// a cache miss.
+func (b *HTTPBackend) discard(ctx context.Context, key, reason string) error {
+	// Deleting the value is best effort. If we can't, we'll try again the
+	// next time we read it.
+	_ = b.Delete(ctx, key)
+	return errors.Wrapf(fs.ErrNotExist, "discarded invalid value for key %s: %s", key, reason)
+}
+
+// Write the value at the supplied key.
+func (b *HTTPBackend) Write(ctx context.Context, key string, value []byte) error {
+	if b.key != nil {
+		value = append(b.sign(key, value), value...)
+	}
+	rsp, err := b.do(ctx, http.MethodPut, key, value)
+	if err != nil {
+		return err
+	}
+	return rsp.Body.Close()
+}
+
+// Delete the value at the supplied key.
+func (b *HTTPBackend) Delete(ctx context.Context, key string) error {
+	rsp, err := b.do(ctx, http.MethodDelete, key, nil)
+	if err != nil {
+		return err
+	}
+	return rsp.Body.Close()
+}
+
+// List all values.
+func (b *HTTPBackend) List(ctx context.Context) ([]BackendEntry, error) {
+	rsp, err := b.do(ctx, http.MethodGet, "", nil)
+	if err != nil {
+		return nil, err
+	}
+	defer rsp.Body.Close() //nolint:errcheck // Only fails if the body was already closed.
+
+	entries := []BackendEntry{}
+	return entries, errors.Wrap(json.NewDecoder(rsp.Body).Decode(&entries), "cannot decode list of keys")
+}
+
+// sign returns the signature of the supplied value. The signature covers the
+// key too, so a value can't be replayed at another key.
+func (b *HTTPBackend) sign(key string, value []byte) []byte {
+	mac := hmac.New(sha256.New, b.key)
+	_, _ = mac.Write([]byte(key))
+	_, _ = mac.Write([]byte{0})
+	_, _ = mac.Write(value)
+	return mac.Sum(nil)
+}
+
+// do sends a request for the supplied key. It returns an error if the response
+// status isn't 2xx, which satisfies errors.Is(err, fs.ErrNotExist) if the
+// status is 404. Callers must close the response body.
+func (b *HTTPBackend) do(ctx context.Context, method, key string, body []byte) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, method, b.url+"/"+key, bytes.NewReader(body))
+	if err != nil {
+		return nil, errors.Wrapf(err, "cannot create %s request for key %q", method, key)
+	}
+	if b.token != "" {
+		req.Header.Set("Authorization", "Bearer "+b.token)
+	}
+
+	rsp, err := b.client.Do(req)
+	if err != nil {
+		return nil, errors.Wrapf(err, "cannot %s key %q", method, key)
+	}
+
+	switch {
+	case rsp.StatusCode == http.StatusNotFound:
+		_ = rsp.Body.Close()
+		return nil, errors.Wrapf(fs.ErrNotExist, "cannot %s key %q", method, key)
+	case rsp.StatusCode < 200 || rsp.StatusCode > 299:
+		_ = rsp.Body.Close()
+		return nil, errors.Errorf("cannot %s key %q: unexpected status %s", method, key, rsp.Status)
+	}
+
+	return rsp, nil
+}
+
+// A BackendHandlerOption configures a handler returned by NewBackendHandler.
+type BackendHandlerOption func(h *backendHandler)
+
+// WithRequiredBearerToken configures a handler to reject requests that don't
+// authenticate using the supplied bearer token.
+func WithRequiredBearerToken(token string) BackendHandlerOption {
+	return func(h *backendHandler) {
+		h.token = token
+	}
+}
+
+// WithHandlerMaxEntrySize configures the largest value, in bytes, a handler
+// accepts. Requests to write larger values are rejected.
+func WithHandlerMaxEntrySize(n int64) BackendHandlerOption {
+	return func(h *backendHandler) {
+		h.maxSize = n
+	}
+}
+
+type backendHandler struct {
+	token   string
+	maxSize int64
+}
+
+// NewBackendHandler returns an HTTP handler that serves the supplied Backend
+// using the protocol HTTPBackend expects. Serve a FilesystemBackend backed by
+// a persistent volume to share cached responses between Crossplane replicas.
+func NewBackendHandler(b Backend, o ...BackendHandlerOption) http.Handler {
+	h := &backendHandler{maxSize: DefaultMaxEntrySize}
+	for _, fn := range o {
+		fn(h)
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if h.token != "" && subtle.ConstantTimeCompare([]byte(r.Header.Get("Authorization")), []byte("Bearer "+h.token)) != 1 {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+
+		key := strings.TrimPrefix(r.URL.Path, "/")
+
+		if key == "" {
+			if r.Method != http.MethodGet {
+				http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+				return
+			}
+			entries, err := b.List(r.Context())
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusInternalServerError)
+				return
+			}
+			w.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(w).Encode(entries)
+			return
+		}
+
+		// Keys are like function-name/request-tag. Don't let a request
+		// escape the backend's root.
+		if path.Clean(key) != key || strings.Count(key, "/") != 1 || strings.Contains(key, "..") {
+			http.Error(w, "invalid key", http.StatusBadRequest)
+			return
+		}
+
+		switch r.Method {
+		case http.MethodGet:
+			v, err := b.Read(r.Context(), key)
+			if err != nil {
+				httpError(w, err)
+				return
+			}
+			w.Header().Set("Content-Type", "application/octet-stream")
+			_, _ = w.Write(v)
+		case http.MethodPut:
+			v, err := io.ReadAll(http.MaxBytesReader(w, r.Body, h.maxSize))
+			if mbe := (*http.MaxBytesError)(nil); errors.As(err, &mbe) {
+				http.Error(w, err.Error(), http.StatusRequestEntityTooLarge)
+				return
+			}
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			if err := b.Write(r.Context(), key, v); err != nil {
+				httpError(w, err)
+				return
+			}
+			w.WriteHeader(http.StatusNoContent)
+		case http.MethodDelete:
+			if err := b.Delete(r.Context(), key); err != nil {
+				httpError(w, err)
+				return
+			}
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		}
+	})
+}
+
+func httpError(w http.ResponseWriter, err error) {
+	if errors.Is(err, fs.ErrNotExist) {
+		http.Error(w, "not found", http.StatusNotFound)
+		return
+	}
+	http.Error(w, err.Error(), http.StatusInternalServerError)
+}
@@ -0,0 +1,219 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+this file except in compliance with the License. You may obtain a copy of the
+License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed
+under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+CONDITIONS OF ANY KIND, either express or implied. See the License for the
+specific language governing permissions and limitations under the License.
+*/
+
+package cached
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/spf13/afero"
+	"google.golang.org/protobuf/testing/protocmp"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	fnv1 "github.com/crossplane/crossplane/v2/proto/fn/v1"
+)
+
+func TestHTTPBackend(t *testing.T) {
+	srv := httptest.NewServer(NewBackendHandler(NewFilesystemBackend(afero.NewMemMapFs())))
+	defer srv.Close()
+
+	b := NewHTTPBackend(srv.URL)
+	ctx := context.Background()
+
+	if _, err := b.Read(ctx, "coolfn/req"); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("\nb.Read(...): want fs.ErrNotExist, got: %v", err)
+	}
+
+	if err := b.Write(ctx, "coolfn/req", []byte("cool")); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := b.Read(ctx, "coolfn/req")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff("cool", string(got)); diff != "" {
+		t.Errorf("\nb.Read(...): -want, +got:\n%s", diff)
+	}
+
+	entries, err := b.List(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keys := make([]string, 0, len(entries))
+	for _, e := range entries {
+		keys = append(keys, e.Key)
+	}
+	if diff := cmp.Diff([]string{"coolfn/req"}, keys); diff != "" {
+		t.Errorf("\nb.List(...): -want keys, +got keys:\n%s", diff)
+	}
+
+	if err := b.Delete(ctx, "coolfn/req"); err != nil {
+		t.Fatal(err)
+	}
+	if err := b.Delete(ctx, "coolfn/req"); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("\nb.Delete(...): want fs.ErrNotExist, got: %v", err)
+	}
+
+	if err := b.Write(ctx, "../escape", []byte("nope")); err == nil {
+		t.Errorf("\nb.Write(...): want error writing an invalid key, got nil")
+	}
+}
+
+func TestHTTPBackendAuthentication(t *testing.T) {
+	srv := httptest.NewServer(NewBackendHandler(NewFilesystemBackend(afero.NewMemMapFs()), WithRequiredBearerToken("secret")))
+	defer srv.Close()
+
+	ctx := context.Background()
+
+	if err := NewHTTPBackend(srv.URL).Write(ctx, "coolfn/req", []byte("cool")); err == nil {
+		t.Errorf("\nb.Write(...): want error writing without a bearer token, got nil")
+	}
+	if err := NewHTTPBackend(srv.URL, WithBearerToken("wrong")).Write(ctx, "coolfn/req", []byte("cool")); err == nil {
+		t.Errorf("\nb.Write(...): want error writing with the wrong bearer token, got nil")
+	}
+	if err := NewHTTPBackend(srv.URL, WithBearerToken("secret")).Write(ctx, "coolfn/req", []byte("cool")); err != nil {
+		t.Errorf("\nb.Write(...): want no error writing with the right bearer token, got: %v", err)
+	}
+}
+
+func TestHTTPBackendSigning(t *testing.T) {
+	srv := httptest.NewServer(NewBackendHandler(NewFilesystemBackend(afero.NewMemMapFs())))
+	defer srv.Close()
+
+	ctx := context.Background()
+	signed := NewHTTPBackend(srv.URL, WithSigningKey([]byte("key")))
+
+	if err := signed.Write(ctx, "coolfn/req", []byte("cool")); err != nil {
+		t.Fatal(err)
+	}
+	got, err := signed.Read(ctx, "coolfn/req")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff("cool", string(got)); diff != "" {
+		t.Errorf("\nb.Read(...): -want, +got:\n%s", diff)
+	}
+
+	// A signed value shouldn't be served at another key.
+	v, err := NewHTTPBackend(srv.URL).Read(ctx, "coolfn/req")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := NewHTTPBackend(srv.URL).Write(ctx, "coolfn/replayed", v); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := signed.Read(ctx, "coolfn/replayed"); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("\nb.Read(...): want fs.ErrNotExist reading a value signed for another key, got: %v", err)
+	}
+
+	// A value written without a signature, e.g. by a compromised remote
+	// store, shouldn't be served.
+	if err := NewHTTPBackend(srv.URL).Write(ctx, "coolfn/tampered", []byte("not cool, but long enough to look like it has a signature")); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := signed.Read(ctx, "coolfn/tampered"); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("\nb.Read(...): want fs.ErrNotExist reading an unsigned value, got: %v", err)
+	}
+
+	if _, err := NewHTTPBackend(srv.URL, WithSigningKey([]byte("other"))).Read(ctx, "coolfn/req"); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("\nb.Read(...): want fs.ErrNotExist reading a value signed with another key, got: %v", err)
+	}
+
+	// Values that fail verification should be deleted, so they don't fail
+	// every read until they're garbage collected.
+	for _, key := range []string{"coolfn/req", "coolfn/replayed", "coolfn/tampered"} {
+		if _, err := NewHTTPBackend(srv.URL).Read(ctx, key); !errors.Is(err, fs.ErrNotExist) {
+			t.Errorf("\nb.Read(%q): want fs.ErrNotExist reading a discarded value, got: %v", key, err)
+		}
+	}
+}
+
+func TestHTTPBackendMaxEntrySize(t *testing.T) {
+	srv := httptest.NewServer(NewBackendHandler(NewFilesystemBackend(afero.NewMemMapFs()), WithHandlerMaxEntrySize(8)))
+	defer srv.Close()
+
+	ctx := context.Background()
+
+	if err := NewHTTPBackend(srv.URL).Write(ctx, "coolfn/big", []byte("way too big")); err == nil {
+		t.Errorf("\nb.Write(...): want error writing a value larger than the handler's maximum entry size, got nil")
+	}
+	if err := NewHTTPBackend(srv.URL).Write(ctx, "coolfn/req", []byte("cool")); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := NewHTTPBackend(srv.URL, WithMaxEntrySize(2)).Read(ctx, "coolfn/req"); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("\nb.Read(...): want fs.ErrNotExist reading a value larger than the maximum entry size, got: %v", err)
+	}
+	if _, err := NewHTTPBackend(srv.URL).Read(ctx, "coolfn/req"); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("\nb.Read(...): want fs.ErrNotExist reading a discarded value, got: %v", err)
+	}
+}
+
+func TestSharedBackend(t *testing.T) {
+	srv := httptest.NewServer(NewBackendHandler(NewFilesystemBackend(afero.NewMemMapFs())))
+	defer srv.Close()
+
+	rsp := &fnv1.RunFunctionResponse{
+		Meta: &fnv1.ResponseMeta{
+			Tag: "wrapped",
+			Ttl: durationpb.New(1 * time.Minute),
+		},
+	}
+
+	wrapped := FunctionRunnerFn(func(_ context.Context, _ string, _ *fnv1.RunFunctionRequest) (*fnv1.RunFunctionResponse, error) {
+		return rsp, nil
+	})
+
+	// The first replica populates the shared cache.
+	leader := NewFileBackedRunner(wrapped, "/cache",
+		WithLogger(&TestLogger{t: t}),
+		WithBackend(NewHTTPBackend(srv.URL)))
+	if _, err := leader.CacheFunction(context.TODO(), "coolfn", &fnv1.RunFunctionRequest{Meta: &fnv1.RequestMeta{Tag: "req"}}); err != nil {
+		t.Fatal(err)
+	}
+
+	// The second replica has no wrapped runner, so it can only serve the
+	// response from the shared cache.
+	follower := NewFileBackedRunner(nil, "/cache",
+		WithLogger(&TestLogger{t: t}),
+		WithBackend(NewHTTPBackend(srv.URL)))
+
+	got, err := follower.RunFunction(context.TODO(), "coolfn", &fnv1.RunFunctionRequest{Meta: &fnv1.RequestMeta{Tag: "req"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(rsp, got, protocmp.Transform()); diff != "" {
+		t.Errorf("\nfollower.RunFunction(...): -want rsp, +got rsp:\n%s", diff)
+	}
+
+	// The second replica should track the shared response when it garbage
+	// collects, without deleting it.
+	collected, err := follower.GarbageCollectFilesNow(context.TODO())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if collected != 0 {
+		t.Errorf("\nfollower.GarbageCollectFilesNow(...): want 0 collected, got %d", collected)
+	}
+	if _, entries := follower.index.Size(); entries != 1 {
+		t.Errorf("\nfollower.index.Size(): want 1 entry, got %d", entries)
+	}
+}
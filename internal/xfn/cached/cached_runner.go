@@ -20,9 +20,11 @@ import (
 	"context"
 	"io/fs"
 	"path/filepath"
+	"sync/atomic"
 	"time"
 
 	"github.com/spf13/afero"
+	"golang.org/x/sync/singleflight"
 	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/timestamppb"
 
@@ -44,6 +46,10 @@ const (
 	ReasonError           CacheMissReason = "Error"
 )
 
+// evictTimeout bounds how long Evict waits for the backend to delete each
+// evicted response.
+const evictTimeout = 10 * time.Second
+
 // Metrics for the function response cache.
 type Metrics interface { //nolint:interfacebloat // Only a little bit bloated. :|
 	// Hit records a cache hit.
@@ -67,11 +73,29 @@ type Metrics interface { //nolint:interfacebloat // Only a little bit bloated. :
 	// DeletedBytes records bytes deleted from the cache.
 	DeletedBytes(name string, b int)
 
+	// Coalesce records a cache miss that was coalesced with an identical
+	// in-flight call to the function, instead of calling the function.
+	Coalesce(name string)
+
+	// MemoryHit records a cache hit served from the in-memory tier. Every
+	// memory hit is also recorded as a hit.
+	MemoryHit(name string)
+
 	// ReadDuration records the time taken by a cache hit.
 	ReadDuration(name string, d time.Duration)
 
 	// WriteDuration records the time taken to write to cache.
 	WriteDuration(name string, d time.Duration)
+
+	// Evict records a cache eviction, i.e. due to the cache exceeding its
+	// maximum size.
+	Evict(name string)
+
+	// EvictedBytes records bytes evicted from the cache.
+	EvictedBytes(name string, b int)
+
+	// Size records the total size of the cache, in bytes and entries.
+	Size(bytes int64, entries int)
 }
 
 // A FunctionRunner runs a composition function.
@@ -81,15 +105,27 @@ type FunctionRunner interface {
 }
 
 // A FileBackedRunner wraps another function runner. It caches responses
-// returned by that runner to the filesystem. It only caches responses that
-// specify a TTL. Requests are served from cache if there's a cached response
-// for an identical request with an unexpired TTL.
+// returned by that runner to the filesystem, or to another Backend. It only
+// caches responses that specify a TTL. Requests are served from cache if
+// there's a cached response for an identical request with an unexpired TTL. It
+// can optionally keep recently used responses in memory, in front of the
+// backend.
 type FileBackedRunner struct {
 	wrapped FunctionRunner
-	fs      afero.Afero
+	backend Backend
 	maxTTL  time.Duration
 	log     logging.Logger
 	metrics Metrics
+
+	maxBytes   int64
+	maxEntries int
+	policy     EvictionPolicy
+	index      *cacheIndex
+
+	memory *memoryTier
+
+	// inflight coalesces concurrent calls for the same cache key.
+	inflight singleflight.Group
 }
 
 // A FileBackedRunnerOption configures a FileBackedRunner.
@@ -118,13 +154,60 @@ func WithMaxTTL(ttl time.Duration) FileBackedRunnerOption {
 	}
 }
 
+// WithMaxSize limits the total size of cached responses, in bytes. When a write
+// would exceed the limit, cached responses are evicted according to the
+// eviction policy. Zero means unlimited.
+func WithMaxSize(bytes int64) FileBackedRunnerOption {
+	return func(r *FileBackedRunner) {
+		r.maxBytes = bytes
+	}
+}
+
+// WithMaxEntries limits the number of cached responses. When a write would
+// exceed the limit, cached responses are evicted according to the eviction
+// policy. Zero means unlimited.
+func WithMaxEntries(n int) FileBackedRunnerOption {
+	return func(r *FileBackedRunner) {
+		r.maxEntries = n
+	}
+}
+
+// WithEvictionPolicy specifies which cached responses are evicted first when
+// the cache exceeds its maximum size. The default is to evict the least
+// recently used responses first.
+func WithEvictionPolicy(p EvictionPolicy) FileBackedRunnerOption {
+	return func(r *FileBackedRunner) {
+		r.policy = p
+	}
+}
+
+// WithMemoryCache keeps up to the supplied number of recently used responses in
+// memory, in front of the backend. Responses are written through to the
+// backend, and expire from memory at the same deadline. Zero disables the
+// memory cache.
+func WithMemoryCache(maxEntries int) FileBackedRunnerOption {
+	return func(r *FileBackedRunner) {
+		r.memory = newMemoryTier(maxEntries)
+	}
+}
+
 // WithFilesystem specifies which filesystem implementation the FileBackedRunner
 // should use. The runner will ignore its path argument and cache files at the
 // root of this filesystem. Wrap your desired filesystem with afero.BasePathFS
 // to cache files under a specific path.
 func WithFilesystem(fs afero.Fs) FileBackedRunnerOption {
 	return func(r *FileBackedRunner) {
-		r.fs = afero.Afero{Fs: fs}
+		r.backend = NewFilesystemBackend(fs)
+	}
+}
+
+// WithBackend specifies which backend the FileBackedRunner should cache
+// responses in. The runner will ignore its path argument. Use a shared backend,
+// like an HTTPBackend, to share cached responses between Crossplane replicas
+// and across restarts.
+func WithBackend(b Backend) FileBackedRunnerOption {
+	return func(r *FileBackedRunner) {
+		r.backend = b
 	}
 }
 
@@ -133,9 +216,12 @@ func WithFilesystem(fs afero.Fs) FileBackedRunnerOption {
 func NewFileBackedRunner(wrap FunctionRunner, path string, o ...FileBackedRunnerOption) *FileBackedRunner {
 	r := &FileBackedRunner{
 		wrapped: wrap,
-		fs:      afero.Afero{Fs: afero.NewBasePathFs(afero.NewOsFs(), path)},
+		backend: NewFilesystemBackend(afero.NewBasePathFs(afero.NewOsFs(), path)),
 		log:     logging.NewNopLogger(),
 		metrics: &NopMetrics{},
+		policy:  EvictLeastRecentlyUsed,
+		index:   newCacheIndex(),
+		memory:  newMemoryTier(0),
 	}
 
 	for _, fn := range o {
@@ -163,10 +249,27 @@ func (r *FileBackedRunner) RunFunction(ctx context.Context, name string, req *fn
 	key := filepath.Join(name, req.GetMeta().GetTag())
 	log = log.WithValues("cache-key", key)
 
-	b, err := r.fs.ReadFile(key)
+	// Responses in memory expire at the same deadline as the backend, so
+	// an expired response in memory is also expired in the backend.
+	if crsp, ok := r.memory.Get(key); ok {
+		if time.Now().Before(crsp.GetDeadline().AsTime()) {
+			log.Debug("RunFunctionResponse cache hit", "tier", "memory")
+			r.metrics.Hit(name)
+			r.metrics.MemoryHit(name)
+			r.metrics.ReadDuration(name, time.Since(start))
+			r.index.Read(key, time.Now())
+
+			// Callers may modify the response.
+			return proto.CloneOf(crsp.GetResponse()), nil
+		}
+		r.memory.Remove(key)
+	}
+
+	b, err := r.backend.Read(ctx, key)
 	if errors.Is(err, fs.ErrNotExist) {
 		log.Debug("RunFunctionResponse cache miss", "reason", ReasonNotCached)
 		r.metrics.Miss(name)
+		r.index.Remove(key)
 
 		return r.CacheFunction(ctx, name, req)
 	}
@@ -201,12 +304,17 @@ func (r *FileBackedRunner) RunFunction(ctx context.Context, name string, req *fn
 
 	log.Debug("RunFunctionResponse cache hit")
 	r.metrics.Hit(name)
+	r.index.Read(key, time.Now())
+	r.memory.Put(key, proto.CloneOf(crsp))
 	r.metrics.ReadDuration(name, time.Since(start))
 
 	return crsp.GetResponse(), nil
 }
 
 // CacheFunction runs a function and caches its response if the TTL is non-zero.
+// Concurrent calls for identical requests are coalesced. Only the first calls
+// the function; the rest wait for and share its response until their own
+// context is done.
 func (r *FileBackedRunner) CacheFunction(ctx context.Context, name string, req *fnv1.RunFunctionRequest) (*fnv1.RunFunctionResponse, error) {
 	// If we don't have a cache key we can't cache the response. Just send
 	// it on. This should never happen.
@@ -215,6 +323,48 @@ func (r *FileBackedRunner) CacheFunction(ctx context.Context, name string, req *
 	}
 
 	key := filepath.Join(name, req.GetMeta().GetTag())
+
+	// The function runs in its own goroutine. It's bounded by the context
+	// of the caller that started it, and each caller stops waiting for it
+	// when its own context is done. Callers may have different deadlines.
+	var called atomic.Bool
+	ch := r.inflight.DoChan(key, func() (any, error) {
+		called.Store(true)
+		return r.cacheFunction(ctx, name, key, req)
+	})
+
+	var res singleflight.Result
+	select {
+	case res = <-ch:
+	case <-ctx.Done():
+		return nil, errors.Wrap(ctx.Err(), "cannot wait for function call")
+	}
+	v, err, shared := res.Val, res.Err, res.Shared
+
+	if !called.Load() {
+		r.log.Debug("RunFunctionResponse cache coalesced call", "name", name, "cache-key", key)
+		r.metrics.Coalesce(name)
+
+		// The call we joined used its caller's context. If that was
+		// cancelled but ours wasn't, call the function ourselves.
+		if (errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded)) && ctx.Err() == nil {
+			return r.cacheFunction(ctx, name, key, req)
+		}
+	}
+
+	rsp, _ := v.(*fnv1.RunFunctionResponse)
+
+	// Each caller may modify its response, so they can't share one.
+	if shared && rsp != nil {
+		rsp = proto.CloneOf(rsp)
+	}
+
+	return rsp, err
+}
+
+// cacheFunction runs a function and caches its response if the TTL is
+// non-zero.
+func (r *FileBackedRunner) cacheFunction(ctx context.Context, name, key string, req *fnv1.RunFunctionRequest) (*fnv1.RunFunctionResponse, error) {
 	log := r.log.WithValues("name", name, "cache-key", key)
 
 	rsp, err := r.wrapped.RunFunction(ctx, name, req)
@@ -242,7 +392,8 @@ func (r *FileBackedRunner) CacheFunction(ctx context.Context, name string, req *
 	// response.
 	deadline := time.Now().Add(ttl)
 
-	msg, err := proto.Marshal(&v1alpha1.CachedRunFunctionResponse{Deadline: timestamppb.New(deadline), Response: rsp})
+	crsp := &v1alpha1.CachedRunFunctionResponse{Deadline: timestamppb.New(deadline), Response: rsp}
+	msg, err := proto.Marshal(crsp)
 	if err != nil {
 		log.Info("RunFunctionResponse cache write error", "err", err)
 		r.metrics.Error(name)
@@ -250,52 +401,58 @@ func (r *FileBackedRunner) CacheFunction(ctx context.Context, name string, req *
 		return rsp, nil
 	}
 
-	if err := r.fs.MkdirAll(name, 0o700); err != nil {
+	if err := r.backend.Write(ctx, key, msg); err != nil {
 		log.Info("RunFunctionResponse cache write error", "err", err)
 		r.metrics.Error(name)
 
 		return rsp, nil
 	}
 
-	// Write and rename a temp file to make our write 'atomic'. This ensure
-	// we won't overwrite a cache file that we're currently reading.
-	tmp, err := r.fs.TempFile(name, "")
-	if err != nil {
-		log.Info("RunFunctionResponse cache write error", "err", err)
-		r.metrics.Error(name)
-
-		return rsp, nil
-	}
+	log.Debug("RunFunctionResponse cache write", "deadline", deadline, "bytes", len(msg))
+	r.metrics.Write(name)
+	r.metrics.WriteDuration(name, time.Since(start))
+	r.metrics.WroteBytes(name, len(msg))
 
-	if _, err := tmp.Write(msg); err != nil {
-		_ = tmp.Close()
+	// Write through to memory only once the response is cached in the
+	// backend, so every response in memory is also in the backend.
+	r.memory.Put(key, proto.CloneOf(crsp))
 
-		log.Info("RunFunctionResponse cache write error", "err", err)
-		r.metrics.Error(name)
+	r.index.Add(key, name, int64(len(msg)), time.Now())
+	r.Evict(ctx, key)
 
-		return rsp, nil
-	}
-
-	if err := tmp.Close(); err != nil {
-		log.Info("RunFunctionResponse cache write error", "err", err)
-		r.metrics.Error(name)
+	return rsp, nil
+}
 
-		return rsp, nil
-	}
+// Evict evicts cached responses until the cache fits within its maximum size,
+// according to the eviction policy. The response with the supplied key is
+// evicted last. Pass an empty key to treat all responses equally. Each
+// deletion is bounded by evictTimeout, so a slow backend can't block callers
+// indefinitely.
+func (r *FileBackedRunner) Evict(ctx context.Context, keep string) {
+	for _, e := range r.index.Victims(r.policy, r.maxBytes, r.maxEntries, keep) {
+		log := r.log.WithValues("name", e.name, "cache-key", e.key)
+		r.memory.Remove(e.key)
+
+		// There's a race here. CacheFunction could write a new response
+		// for this key after we stop tracking it but before we remove it.
+		// We're okay with this - it'll just mean we don't cache one
+		// response.
+		dctx, cancel := context.WithTimeout(ctx, evictTimeout)
+		err := r.backend.Delete(dctx, e.key)
+		cancel()
+		if err != nil && !errors.Is(err, fs.ErrNotExist) {
+			log.Info("RunFunctionResponse cache error", "error", err)
+			r.metrics.Error(e.name)
 
-	if err := r.fs.Rename(tmp.Name(), key); err != nil {
-		log.Info("RunFunctionResponse cache write error", "err", err)
-		r.metrics.Error(name)
+			continue
+		}
 
-		return rsp, nil
+		log.Debug("RunFunctionResponse cache evict", "policy", r.policy, "bytes", e.size)
+		r.metrics.Evict(e.name)
+		r.metrics.EvictedBytes(e.name, int(e.size))
 	}
 
-	log.Debug("RunFunctionResponse cache write", "deadline", deadline, "bytes", len(msg))
-	r.metrics.Write(name)
-	r.metrics.WriteDuration(name, time.Since(start))
-	r.metrics.WroteBytes(name, len(msg))
-
-	return rsp, nil
+	r.metrics.Size(r.index.Size())
 }
 
 // GarbageCollectFiles runs every interval until the supplied context is
@@ -318,58 +475,40 @@ func (r *FileBackedRunner) GarbageCollectFiles(ctx context.Context, interval tim
 }
 
 // GarbageCollectFilesNow immediately garbage collects any cached responses with
-// expired deadlines.
+// expired deadlines. It then evicts cached responses until the cache fits
+// within its maximum size, if any. Garbage collection also finds any responses
+// that were cached before the runner was created, so they count toward the
+// maximum size.
 func (r *FileBackedRunner) GarbageCollectFilesNow(ctx context.Context) (int, error) {
-	collected := 0
-	iofs := afero.NewIOFS(r.fs)
-	err := fs.WalkDir(iofs, "/", func(path string, d fs.DirEntry, err error) error {
-		if err != nil {
-			return err
-		}
-
-		// Stop walking if our context is cancelled.
-		select {
-		case <-ctx.Done():
-			return nil
-		default:
-		}
-
-		if d.IsDir() {
-			// Don't try to garbage collect the root of the cache.
-			if path == "/" {
-				return nil
-			}
-
-			entries, err := r.fs.ReadDir(path)
-			if err != nil {
-				r.log.Info("RunFunctionResponse cache error", "path", path, "error", err)
-				return nil
-			}
-
-			if len(entries) > 0 {
-				return nil
-			}
+	defer r.Evict(ctx, "")
 
-			// Cleanup empty directories. We don't count these as a
-			// garbage collected cache entry.
-			if err := r.fs.Remove(path); err != nil {
-				r.log.Info("RunFunctionResponse cache error", "path", path, "error", err)
-			}
+	entries, err := r.backend.List(ctx)
+	if err != nil {
+		return 0, errors.Wrap(err, "cannot list cached responses")
+	}
 
-			return nil
+	collected := 0
+	for _, e := range entries {
+		// Stop collecting if our context is cancelled.
+		if ctx.Err() != nil {
+			break
 		}
 
-		// The cache layout is like /cache/function-name/request-hash,
-		// so the directory name is our function name.
-		name := filepath.Base(filepath.Dir(path))
-		log := r.log.WithValues("name", name, "cache-key", path)
+		// The cache layout is like function-name/request-hash, so the
+		// directory name is our function name.
+		name := filepath.Base(filepath.Dir(e.Key))
+		log := r.log.WithValues("name", name, "cache-key", e.Key)
 
-		b, err := r.fs.ReadFile(path)
+		b, err := r.backend.Read(ctx, e.Key)
+		if errors.Is(err, fs.ErrNotExist) {
+			// Deleted since we listed it.
+			continue
+		}
 		if err != nil {
 			log.Info("RunFunctionResponse cache error", "error", err)
 			r.metrics.Error(name)
 
-			return nil
+			continue
 		}
 
 		crsp := &v1alpha1.CachedRunFunctionResponse{}
@@ -377,44 +516,43 @@ func (r *FileBackedRunner) GarbageCollectFilesNow(ctx context.Context) (int, err
 			log.Info("RunFunctionResponse cache error", "error", err)
 			r.metrics.Error(name)
 
-			return nil
+			continue
 		}
 
 		deadline := crsp.GetDeadline().AsTime()
 
 		// Cached response is still valid.
 		if time.Now().Before(deadline) {
-			return nil
-		}
+			// Responses cached before we started, or by another
+			// replica sharing our backend, aren't tracked yet.
+			// Consider them last used when they were written.
+			r.index.Track(e.Key, name, int64(len(b)), e.Modified)
 
-		info, err := d.Info()
-		if err != nil {
-			log.Info("RunFunctionResponse cache error", "error", err)
-			r.metrics.Error(name)
+			continue
 		}
 
 		// There's a race here. It's possible CacheFunction will write a
 		// new cache entry with a deadline in the future between where
-		// we read the file and here where we remove it. We're okay with
-		// this - it'll just mean we don't cache one response.
+		// we read the entry and here where we delete it. We're okay
+		// with this - it'll just mean we don't cache one response.
 		//
 		// There's no race with reading files. The file content won't
 		// actually be deleted until ReadFile closes the fd.
-		if err := r.fs.Remove(path); err != nil {
+		if err := r.backend.Delete(ctx, e.Key); err != nil && !errors.Is(err, fs.ErrNotExist) {
 			log.Info("RunFunctionResponse cache error", "error", err)
 			r.metrics.Error(name)
 
-			return nil
+			continue
 		}
 
 		collected++
+		r.index.Remove(e.Key)
+		r.memory.Remove(e.Key)
 
-		log.Debug("RunFunctionResponse cache delete", "deadline", deadline, "bytes", info.Size())
+		log.Debug("RunFunctionResponse cache delete", "deadline", deadline, "bytes", len(b))
 		r.metrics.Delete(name)
-		r.metrics.DeletedBytes(name, int(info.Size()))
-
-		return nil
-	})
+		r.metrics.DeletedBytes(name, len(b))
+	}
 
-	return collected, err
+	return collected, nil
 }
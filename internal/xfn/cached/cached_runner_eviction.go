@@ -0,0 +1,172 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+this file except in compliance with the License. You may obtain a copy of the
+License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed
+under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+CONDITIONS OF ANY KIND, either express or implied. See the License for the
+specific language governing permissions and limitations under the License.
+*/
+
+package cached
+
+import (
+	"cmp"
+	"slices"
+	"sync"
+	"time"
+)
+
+// An EvictionPolicy determines which cached responses are evicted first when
+// the cache exceeds its maximum size.
+type EvictionPolicy string
+
+// Eviction policies.
+const (
+	// EvictLeastRecentlyUsed evicts the cached responses that were least
+	// recently written or read first.
+	EvictLeastRecentlyUsed EvictionPolicy = "LRU"
+
+	// EvictLeastFrequentlyUsed evicts the cached responses that were read
+	// the fewest times first. Ties are broken by evicting the least recently
+	// used response first.
+	EvictLeastFrequentlyUsed EvictionPolicy = "LFU"
+)
+
+// A cacheEntry tracks the size and usage of a cached response.
+type cacheEntry struct {
+	key      string
+	name     string
+	size     int64
+	accessed time.Time
+	reads    int64
+}
+
+// A cacheIndex tracks the size and usage of all cached responses, across all
+// function subdirectories.
+type cacheIndex struct {
+	mu      sync.Mutex
+	entries map[string]*cacheEntry
+	bytes   int64
+}
+
+func newCacheIndex() *cacheIndex {
+	return &cacheIndex{entries: make(map[string]*cacheEntry)}
+}
+
+// Add tracks a cached response, replacing any existing entry for its key.
+func (i *cacheIndex) Add(key, name string, size int64, accessed time.Time) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	if e, ok := i.entries[key]; ok {
+		i.bytes -= e.size
+	}
+	i.entries[key] = &cacheEntry{key: key, name: name, size: size, accessed: accessed}
+	i.bytes += size
+}
+
+// Track tracks a cached response if it isn't already tracked. It's used to
+// track responses that were cached before the index was created.
+func (i *cacheIndex) Track(key, name string, size int64, accessed time.Time) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	if _, ok := i.entries[key]; ok {
+		return
+	}
+	i.entries[key] = &cacheEntry{key: key, name: name, size: size, accessed: accessed}
+	i.bytes += size
+}
+
+// Read records a read of a cached response.
+func (i *cacheIndex) Read(key string, accessed time.Time) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	e, ok := i.entries[key]
+	if !ok {
+		return
+	}
+	e.accessed = accessed
+	e.reads++
+}
+
+// Remove stops tracking a cached response.
+func (i *cacheIndex) Remove(key string) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	e, ok := i.entries[key]
+	if !ok {
+		return
+	}
+	i.bytes -= e.size
+	delete(i.entries, key)
+}
+
+// Size returns the total size in bytes and number of tracked responses.
+func (i *cacheIndex) Size() (int64, int) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	return i.bytes, len(i.entries)
+}
+
+// Victims stops tracking and returns the responses that must be evicted for
+// the cache to fit within the supplied maximum bytes and entries. A maximum of
+// zero is unlimited. The response with the supplied key is evicted last, so
+// that a newly cached response isn't evicted before older responses.
+func (i *cacheIndex) Victims(p EvictionPolicy, maxBytes int64, maxEntries int, keep string) []cacheEntry {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	over := func() bool {
+		return (maxBytes > 0 && i.bytes > maxBytes) || (maxEntries > 0 && len(i.entries) > maxEntries)
+	}
+	if !over() {
+		return nil
+	}
+
+	candidates := make([]*cacheEntry, 0, len(i.entries))
+	for _, e := range i.entries {
+		candidates = append(candidates, e)
+	}
+	slices.SortFunc(candidates, func(a, b *cacheEntry) int {
+		if c := cmp.Compare(boolInt(a.key == keep), boolInt(b.key == keep)); c != 0 {
+			return c
+		}
+		if p == EvictLeastFrequentlyUsed {
+			if c := cmp.Compare(a.reads, b.reads); c != 0 {
+				return c
+			}
+		}
+		if c := a.accessed.Compare(b.accessed); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.key, b.key)
+	})
+
+	var victims []cacheEntry
+	for _, e := range candidates {
+		if !over() {
+			break
+		}
+		victims = append(victims, *e)
+		i.bytes -= e.size
+		delete(i.entries, e.key)
+	}
+	return victims
+}
+
+func boolInt(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,106 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+this file except in compliance with the License. You may obtain a copy of the
+License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed
+under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+CONDITIONS OF ANY KIND, either express or implied. See the License for the
+specific language governing permissions and limitations under the License.
+*/
+
+package cached
+
+import (
+	"container/list"
+	"sync"
+
+	"github.com/crossplane/crossplane/v2/internal/proto/fn/v1alpha1"
+)
+
+// A memoryTier caches deserialized responses in memory, in front of the
+// backend. It holds at most maxEntries responses, evicting the least recently
+// used response first. Every response in the memory tier is also cached in the
+// backend, with the same deadline.
+type memoryTier struct {
+	mu         sync.Mutex
+	maxEntries int
+	lru        *list.List
+	elements   map[string]*list.Element
+}
+
+type memoryEntry struct {
+	key  string
+	crsp *v1alpha1.CachedRunFunctionResponse
+}
+
+func newMemoryTier(maxEntries int) *memoryTier {
+	return &memoryTier{
+		maxEntries: maxEntries,
+		lru:        list.New(),
+		elements:   make(map[string]*list.Element),
+	}
+}
+
+// Get returns the cached response for the supplied key, if any.
+func (m *memoryTier) Get(key string) (*v1alpha1.CachedRunFunctionResponse, bool) {
+	if m.maxEntries <= 0 {
+		return nil, false
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	e, ok := m.elements[key]
+	if !ok {
+		return nil, false
+	}
+	m.lru.MoveToFront(e)
+	return e.Value.(*memoryEntry).crsp, true //nolint:forcetypeassert // Will always be a *memoryEntry.
+}
+
+// Put caches the supplied response, evicting the least recently used response
+// if the memory tier is full.
+func (m *memoryTier) Put(key string, crsp *v1alpha1.CachedRunFunctionResponse) {
+	if m.maxEntries <= 0 {
+		return
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if e, ok := m.elements[key]; ok {
+		e.Value.(*memoryEntry).crsp = crsp //nolint:forcetypeassert // Will always be a *memoryEntry.
+		m.lru.MoveToFront(e)
+		return
+	}
+
+	m.elements[key] = m.lru.PushFront(&memoryEntry{key: key, crsp: crsp})
+
+	for m.lru.Len() > m.maxEntries {
+		e := m.lru.Back()
+		m.lru.Remove(e)
+		delete(m.elements, e.Value.(*memoryEntry).key) //nolint:forcetypeassert // Will always be a *memoryEntry.
+	}
+}
+
+// Remove removes the cached response for the supplied key, if any.
+func (m *memoryTier) Remove(key string) {
+	if m.maxEntries <= 0 {
+		return
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	e, ok := m.elements[key]
+	if !ok {
+		return
+	}
+	m.lru.Remove(e)
+	delete(m.elements, key)
+}
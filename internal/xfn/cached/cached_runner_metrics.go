@@ -37,6 +37,12 @@ type NopMetrics struct{}
 // Hit does nothing.
 func (m *NopMetrics) Hit(_ string) {}
 
+// Coalesce does nothing.
+func (m *NopMetrics) Coalesce(_ string) {}
+
+// MemoryHit does nothing.
+func (m *NopMetrics) MemoryHit(_ string) {}
+
 // Miss does nothing.
 func (m *NopMetrics) Miss(_ string) {}
 
@@ -61,17 +67,33 @@ func (m *NopMetrics) ReadDuration(_ string, _ time.Duration) {}
 // WriteDuration does nothing.
 func (m *NopMetrics) WriteDuration(_ string, _ time.Duration) {}
 
+// Evict does nothing.
+func (m *NopMetrics) Evict(_ string) {}
+
+// EvictedBytes does nothing.
+func (m *NopMetrics) EvictedBytes(_ string, _ int) {}
+
+// Size does nothing.
+func (m *NopMetrics) Size(_ int64, _ int) {}
+
 // PrometheusMetrics for the function response cache.
 type PrometheusMetrics struct {
-	hits   *prometheus.CounterVec
-	misses *prometheus.CounterVec
-	errors *prometheus.CounterVec
+	hits       *prometheus.CounterVec
+	memoryHits *prometheus.CounterVec
+	coalesced  *prometheus.CounterVec
+	misses     *prometheus.CounterVec
+	errors     *prometheus.CounterVec
 
-	writes  *prometheus.CounterVec
-	deletes *prometheus.CounterVec
+	writes    *prometheus.CounterVec
+	deletes   *prometheus.CounterVec
+	evictions *prometheus.CounterVec
 
 	bytesWritten *prometheus.CounterVec
 	bytesDeleted *prometheus.CounterVec
+	bytesEvicted *prometheus.CounterVec
+
+	bytes   prometheus.Gauge
+	entries prometheus.Gauge
 
 	readDuration  *prometheus.HistogramVec
 	writeDuration *prometheus.HistogramVec
@@ -86,6 +108,18 @@ func NewPrometheusMetrics() *PrometheusMetrics {
 			Help:      "Total number of RunFunctionResponse cache hits.",
 		}, []string{labelFunctionName}),
 
+		memoryHits: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Subsystem: subsystemFunction,
+			Name:      "run_function_response_cache_memory_hits_total",
+			Help:      "Total number of RunFunctionResponse cache hits served from memory.",
+		}, []string{labelFunctionName}),
+
+		coalesced: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Subsystem: subsystemFunction,
+			Name:      "run_function_response_cache_coalesced_total",
+			Help:      "Total number of RunFunctionResponse cache misses that waited for an identical in-flight function call instead of calling the function.",
+		}, []string{labelFunctionName}),
+
 		misses: prometheus.NewCounterVec(prometheus.CounterOpts{
 			Subsystem: subsystemFunction,
 			Name:      "run_function_response_cache_misses_total",
@@ -110,6 +144,12 @@ func NewPrometheusMetrics() *PrometheusMetrics {
 			Help:      "Total number of RunFunctionResponses cache deletes.",
 		}, []string{labelFunctionName}),
 
+		evictions: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Subsystem: subsystemFunction,
+			Name:      "run_function_response_cache_evictions_total",
+			Help:      "Total number of RunFunctionResponses evicted from the cache because it exceeded its maximum size.",
+		}, []string{labelFunctionName}),
+
 		bytesWritten: prometheus.NewCounterVec(prometheus.CounterOpts{
 			Subsystem: subsystemFunction,
 			Name:      "run_function_response_cache_bytes_written_total",
@@ -122,6 +162,24 @@ func NewPrometheusMetrics() *PrometheusMetrics {
 			Help:      "Total number of RunFunctionResponse bytes deleted.",
 		}, []string{labelFunctionName}),
 
+		bytesEvicted: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Subsystem: subsystemFunction,
+			Name:      "run_function_response_cache_bytes_evicted_total",
+			Help:      "Total number of RunFunctionResponse bytes evicted.",
+		}, []string{labelFunctionName}),
+
+		bytes: prometheus.NewGauge(prometheus.GaugeOpts{
+			Subsystem: subsystemFunction,
+			Name:      "run_function_response_cache_bytes",
+			Help:      "Current size of the RunFunctionResponse cache (bytes).",
+		}),
+
+		entries: prometheus.NewGauge(prometheus.GaugeOpts{
+			Subsystem: subsystemFunction,
+			Name:      "run_function_response_cache_entries",
+			Help:      "Current number of cached RunFunctionResponses.",
+		}),
+
 		readDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
 			Subsystem: subsystemFunction,
 			Name:      "run_function_response_cache_read_seconds",
@@ -143,6 +201,17 @@ func (m *PrometheusMetrics) Hit(name string) {
 	m.hits.With(prometheus.Labels{labelFunctionName: name}).Inc()
 }
 
+// Coalesce records a cache miss that was coalesced with an identical in-flight
+// call to the function.
+func (m *PrometheusMetrics) Coalesce(name string) {
+	m.coalesced.With(prometheus.Labels{labelFunctionName: name}).Inc()
+}
+
+// MemoryHit records a cache hit served from the in-memory tier.
+func (m *PrometheusMetrics) MemoryHit(name string) {
+	m.memoryHits.With(prometheus.Labels{labelFunctionName: name}).Inc()
+}
+
 // Miss records a cache miss.
 func (m *PrometheusMetrics) Miss(name string) {
 	m.misses.With(prometheus.Labels{labelFunctionName: name}).Inc()
@@ -183,17 +252,40 @@ func (m *PrometheusMetrics) WriteDuration(name string, d time.Duration) {
 	m.writeDuration.With(prometheus.Labels{labelFunctionName: name}).Observe(d.Seconds())
 }
 
+// Evict records a cache eviction, i.e. due to the cache exceeding its maximum
+// size.
+func (m *PrometheusMetrics) Evict(name string) {
+	m.evictions.With(prometheus.Labels{labelFunctionName: name}).Inc()
+}
+
+// EvictedBytes records bytes evicted from the cache.
+func (m *PrometheusMetrics) EvictedBytes(name string, b int) {
+	m.bytesEvicted.With(prometheus.Labels{labelFunctionName: name}).Add(float64(b))
+}
+
+// Size records the total size of the cache, in bytes and entries.
+func (m *PrometheusMetrics) Size(bytes int64, entries int) {
+	m.bytes.Set(float64(bytes))
+	m.entries.Set(float64(entries))
+}
+
 // Describe sends the super-set of all possible descriptors of metrics
 // collected by this Collector to the provided channel and returns once
 // the last descriptor has been sent.
 func (m *PrometheusMetrics) Describe(ch chan<- *prometheus.Desc) {
 	m.hits.Describe(ch)
+	m.memoryHits.Describe(ch)
+	m.coalesced.Describe(ch)
 	m.misses.Describe(ch)
 	m.errors.Describe(ch)
 	m.writes.Describe(ch)
 	m.deletes.Describe(ch)
+	m.evictions.Describe(ch)
 	m.bytesWritten.Describe(ch)
 	m.bytesDeleted.Describe(ch)
+	m.bytesEvicted.Describe(ch)
+	m.bytes.Describe(ch)
+	m.entries.Describe(ch)
 	m.readDuration.Describe(ch)
 	m.writeDuration.Describe(ch)
 }
@@ -203,12 +295,18 @@ func (m *PrometheusMetrics) Describe(ch chan<- *prometheus.Desc) {
 // provided channel and returns once the last metric has been sent.
 func (m *PrometheusMetrics) Collect(ch chan<- prometheus.Metric) {
 	m.hits.Collect(ch)
+	m.memoryHits.Collect(ch)
+	m.coalesced.Collect(ch)
 	m.misses.Collect(ch)
 	m.errors.Collect(ch)
 	m.writes.Collect(ch)
 	m.deletes.Collect(ch)
+	m.evictions.Collect(ch)
 	m.bytesWritten.Collect(ch)
 	m.bytesDeleted.Collect(ch)
+	m.bytesEvicted.Collect(ch)
+	m.bytes.Collect(ch)
+	m.entries.Collect(ch)
 	m.readDuration.Collect(ch)
 	m.writeDuration.Collect(ch)
 }
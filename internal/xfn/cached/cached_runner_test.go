@@ -19,6 +19,9 @@ import (
 	"bytes"
 	"context"
 	"errors"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -48,6 +51,10 @@ func MockFs(files map[string][]byte) afero.Fs {
 	fs := afero.NewMemMapFs()
 
 	for path, data := range files {
+		// Cached responses are always relative to the root of the
+		// filesystem.
+		path = filepath.Join("/", path)
+
 		// Special value for making a directory.
 		if bytes.Equal(data, MockDir) {
 			if err := fs.MkdirAll(path, 0o700); err != nil {
@@ -93,7 +100,6 @@ func TestRunFunction(t *testing.T) {
 	}
 
 	type args struct {
-		ctx  context.Context
 		name string
 		req  *fnv1.RunFunctionRequest
 	}
@@ -388,7 +394,7 @@ func TestRunFunction(t *testing.T) {
 	for name, tc := range cases {
 		t.Run(name, func(t *testing.T) {
 			r := NewFileBackedRunner(tc.params.wrap, tc.params.path, tc.params.o...)
-			rsp, err := r.RunFunction(tc.args.ctx, tc.args.name, tc.args.req)
+			rsp, err := r.RunFunction(context.Background(), tc.args.name, tc.args.req)
 
 			if diff := cmp.Diff(tc.want.rsp, rsp, protocmp.Transform()); diff != "" {
 				t.Errorf("\n%s\nr.RunFunction(...): -want rsp, +got rsp:\n%s", tc.reason, diff)
@@ -485,7 +491,7 @@ func TestCacheFunctionWithMaxTTL(t *testing.T) {
 	}
 
 	// Read the cached file directly to verify the deadline was clamped.
-	b, err := r.fs.ReadFile("coolfn/req")
+	b, err := afero.ReadFile(fs, "/coolfn/req")
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -536,3 +542,255 @@ func TestGarbageCollectFilesNow(t *testing.T) {
 		t.Errorf("\nr.GarbageCollectFilesNow(...): -want collected, +got collected:\n%s", diff)
 	}
 }
+
+func TestEvict(t *testing.T) {
+	wrapped := FunctionRunnerFn(func(_ context.Context, _ string, req *fnv1.RunFunctionRequest) (*fnv1.RunFunctionResponse, error) {
+		return &fnv1.RunFunctionResponse{
+			Meta: &fnv1.ResponseMeta{
+				Tag: req.GetMeta().GetTag(),
+				Ttl: durationpb.New(1 * time.Minute),
+			},
+		}, nil
+	})
+
+	req := func(tag string) *fnv1.RunFunctionRequest {
+		return &fnv1.RunFunctionRequest{Meta: &fnv1.RequestMeta{Tag: tag}}
+	}
+
+	type step struct {
+		name string
+		tag  string
+	}
+
+	cases := map[string]struct {
+		reason string
+		o      []FileBackedRunnerOption
+		steps  []step
+		want   []string
+	}{
+		"Unlimited": {
+			reason: "Nothing should be evicted if the cache has no maximum size.",
+			steps:  []step{{"coolfn", "a"}, {"coolfn", "b"}, {"otherfn", "c"}},
+			want:   []string{"coolfn/a", "coolfn/b", "otherfn/c"},
+		},
+		"LeastRecentlyUsed": {
+			reason: "The least recently used response should be evicted, across functions.",
+			o:      []FileBackedRunnerOption{WithMaxEntries(2)},
+			steps:  []step{{"coolfn", "a"}, {"coolfn", "a"}, {"coolfn", "b"}, {"otherfn", "c"}},
+			want:   []string{"coolfn/b", "otherfn/c"},
+		},
+		"LeastFrequentlyUsed": {
+			reason: "The least frequently used response should be evicted, across functions.",
+			o:      []FileBackedRunnerOption{WithMaxEntries(2), WithEvictionPolicy(EvictLeastFrequentlyUsed)},
+			steps:  []step{{"coolfn", "a"}, {"coolfn", "a"}, {"coolfn", "b"}, {"otherfn", "c"}},
+			want:   []string{"coolfn/a", "otherfn/c"},
+		},
+		"MaxSize": {
+			reason: "Responses should be evicted until the cache fits within its maximum size, including the newest response if it alone is too big.",
+			o:      []FileBackedRunnerOption{WithMaxSize(1)},
+			steps:  []step{{"coolfn", "a"}, {"coolfn", "b"}},
+			want:   []string{},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			fs := afero.NewMemMapFs()
+
+			o := append([]FileBackedRunnerOption{WithLogger(&TestLogger{t: t}), WithFilesystem(fs)}, tc.o...)
+			r := NewFileBackedRunner(wrapped, "/cache", o...)
+
+			for _, s := range tc.steps {
+				if _, err := r.RunFunction(context.TODO(), s.name, req(s.tag)); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			got := []string{}
+			for _, path := range []string{"coolfn/a", "coolfn/b", "otherfn/c"} {
+				if ok, _ := afero.Exists(fs, filepath.Join("/", path)); ok {
+					got = append(got, path)
+				}
+			}
+
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nr.RunFunction(...): -want cached, +got cached:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestGarbageCollectFilesNowEvicts(t *testing.T) {
+	future, _ := proto.Marshal(&v1alpha1.CachedRunFunctionResponse{Deadline: timestamppb.New(time.Now().Add(1 * time.Minute))})
+
+	fs := MockFs(map[string][]byte{
+		"/":              MockDir,
+		"/coolfn":        MockDir,
+		"/coolfn/valid":  future,
+		"/otherfn":       MockDir,
+		"/otherfn/valid": future,
+	})
+
+	// Responses cached before the runner was created should count toward
+	// its maximum size.
+	r := NewFileBackedRunner(nil, "/cache",
+		WithLogger(&TestLogger{t: t}),
+		WithFilesystem(fs),
+		WithMaxEntries(1))
+
+	if _, err := r.GarbageCollectFilesNow(context.TODO()); err != nil {
+		t.Fatal(err)
+	}
+
+	want := 1
+	_, got := r.index.Size()
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("\nr.GarbageCollectFilesNow(...): -want entries, +got entries:\n%s", diff)
+	}
+}
+
+func TestMemoryCache(t *testing.T) {
+	rsp := &fnv1.RunFunctionResponse{
+		Meta: &fnv1.ResponseMeta{
+			Tag: "wrapped",
+			Ttl: durationpb.New(1 * time.Minute),
+		},
+	}
+
+	wrapped := FunctionRunnerFn(func(_ context.Context, _ string, _ *fnv1.RunFunctionRequest) (*fnv1.RunFunctionResponse, error) {
+		return rsp, nil
+	})
+
+	fs := afero.NewMemMapFs()
+
+	r := NewFileBackedRunner(wrapped, "/cache",
+		WithLogger(&TestLogger{t: t}),
+		WithFilesystem(fs),
+		WithMemoryCache(1))
+
+	// Populate the cache. This should write through to memory.
+	if _, err := r.CacheFunction(context.TODO(), "coolfn", &fnv1.RunFunctionRequest{Meta: &fnv1.RequestMeta{Tag: "req"}}); err != nil {
+		t.Fatal(err)
+	}
+
+	// Remove the cached file, and the wrapped runner. We should only be able
+	// to serve the response from memory.
+	if err := fs.Remove("/coolfn/req"); err != nil {
+		t.Fatal(err)
+	}
+	r.wrapped = nil
+
+	got, err := r.RunFunction(context.TODO(), "coolfn", &fnv1.RunFunctionRequest{Meta: &fnv1.RequestMeta{Tag: "req"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if diff := cmp.Diff(rsp, got, protocmp.Transform()); diff != "" {
+		t.Errorf("\nr.RunFunction(...): -want rsp, +got rsp:\n%s", diff)
+	}
+}
+
+func TestMemoryTier(t *testing.T) {
+	m := newMemoryTier(2)
+
+	m.Put("a", &v1alpha1.CachedRunFunctionResponse{})
+	m.Put("b", &v1alpha1.CachedRunFunctionResponse{})
+
+	// Using a makes b the least recently used response.
+	m.Get("a")
+	m.Put("c", &v1alpha1.CachedRunFunctionResponse{})
+
+	got := map[string]bool{}
+	for _, key := range []string{"a", "b", "c"} {
+		_, got[key] = m.Get(key)
+	}
+
+	want := map[string]bool{"a": true, "b": false, "c": true}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("\nm.Get(...): -want cached, +got cached:\n%s", diff)
+	}
+}
+
+func TestCacheFunctionCoalesces(t *testing.T) {
+	rsp := &fnv1.RunFunctionResponse{
+		Meta: &fnv1.ResponseMeta{
+			Tag: "wrapped",
+			Ttl: durationpb.New(1 * time.Minute),
+		},
+	}
+
+	var calls atomic.Int32
+	release := make(chan struct{})
+	wrapped := FunctionRunnerFn(func(_ context.Context, _ string, _ *fnv1.RunFunctionRequest) (*fnv1.RunFunctionResponse, error) {
+		calls.Add(1)
+		<-release
+		return rsp, nil
+	})
+
+	r := NewFileBackedRunner(wrapped, "/cache",
+		WithLogger(&TestLogger{t: t}),
+		WithFilesystem(afero.NewMemMapFs()))
+
+	const concurrent = 10
+	got := make([]*fnv1.RunFunctionResponse, concurrent)
+
+	var wg sync.WaitGroup
+	for i := range concurrent {
+		wg.Go(func() {
+			var err error
+			got[i], err = r.CacheFunction(context.TODO(), "coolfn", &fnv1.RunFunctionRequest{Meta: &fnv1.RequestMeta{Tag: "req"}})
+			if err != nil {
+				t.Error(err)
+			}
+		})
+	}
+
+	// Give every call a chance to join the first before it returns.
+	time.Sleep(100 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	if diff := cmp.Diff(int32(1), calls.Load()); diff != "" {
+		t.Errorf("\nr.CacheFunction(...): -want calls, +got calls:\n%s", diff)
+	}
+
+	for i := range got {
+		if diff := cmp.Diff(rsp, got[i], protocmp.Transform()); diff != "" {
+			t.Errorf("\nr.CacheFunction(...): -want rsp, +got rsp:\n%s", diff)
+		}
+	}
+}
+
+func TestCacheFunctionCoalescedCallerContext(t *testing.T) {
+	release := make(chan struct{})
+	defer close(release)
+
+	started := make(chan struct{})
+	wrapped := FunctionRunnerFn(func(_ context.Context, _ string, _ *fnv1.RunFunctionRequest) (*fnv1.RunFunctionResponse, error) {
+		close(started)
+		<-release
+		return &fnv1.RunFunctionResponse{}, nil
+	})
+
+	r := NewFileBackedRunner(wrapped, "/cache",
+		WithLogger(&TestLogger{t: t}),
+		WithFilesystem(afero.NewMemMapFs()))
+
+	req := &fnv1.RunFunctionRequest{Meta: &fnv1.RequestMeta{Tag: "req"}}
+
+	// Start a call that won't return until the test ends.
+	go func() {
+		_, _ = r.CacheFunction(context.TODO(), "coolfn", req)
+	}()
+	<-started
+
+	// Join it with a call whose context is done long before the first
+	// call returns.
+	ctx, cancel := context.WithTimeout(context.TODO(), 10*time.Millisecond)
+	defer cancel()
+
+	_, err := r.CacheFunction(ctx, "coolfn", req)
+	if diff := cmp.Diff(context.DeadlineExceeded, err, cmpopts.EquateErrors()); diff != "" {
+		t.Errorf("\nr.CacheFunction(...): -want error, +got error:\n%s", diff)
+	}
+}
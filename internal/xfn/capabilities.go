@@ -101,10 +101,19 @@ func SupportedCapabilities() []fnv1.Capability {
 		fnv1.Capability_CAPABILITY_CREDENTIALS,
 		fnv1.Capability_CAPABILITY_CONDITIONS,
 		fnv1.Capability_CAPABILITY_REQUIRED_SCHEMAS,
+		fnv1.Capability_CAPABILITY_STREAMING,
 	}
 }
 
-// RenderCapabilities returns all capabilities supported by crank render.
+// RenderCapabilities returns all capabilities supported by crank render. Render
+// doesn't stream requests.
 func RenderCapabilities() []fnv1.Capability {
-	return SupportedCapabilities()
+	caps := make([]fnv1.Capability, 0)
+	for _, c := range SupportedCapabilities() {
+		if c == fnv1.Capability_CAPABILITY_STREAMING {
+			continue
+		}
+		caps = append(caps, c)
+	}
+	return caps
 }
@@ -19,6 +19,7 @@ package xfn
 import (
 	"context"
 	"crypto/tls"
+	"slices"
 	"sync"
 	"time"
 
@@ -33,6 +34,7 @@ import (
 	"github.com/crossplane/crossplane-runtime/v2/pkg/errors"
 	"github.com/crossplane/crossplane-runtime/v2/pkg/logging"
 
+	pkgmetav1 "github.com/crossplane/crossplane/apis/v2/pkg/meta/v1"
 	pkgv1 "github.com/crossplane/crossplane/apis/v2/pkg/v1"
 	fnv1 "github.com/crossplane/crossplane/v2/proto/fn/v1"
 	fnv1beta1 "github.com/crossplane/crossplane/v2/proto/fn/v1beta1"
@@ -46,33 +48,11 @@ const (
 
 	errFmtGetClientConn = "cannot get gRPC client connection for Function %q"
 	errFmtRunFunction   = "cannot run Function %q"
+	errFmtWaitFunction  = "cannot wait for a turn to run Function %q"
 	errFmtEmptyEndpoint = "cannot determine gRPC target: active FunctionRevision %q has an empty status.endpoint"
 	errFmtDialFunction  = "cannot gRPC dial target %q from status.endpoint of active FunctionRevision %q"
 )
 
-// This configures a gRPC client to use round robin load balancing. This means
-// that if the Function Deployment has more than one Pod, and the Function
-// Service is headless, requests will be spread across each Pod.
-// See https://github.com/grpc/grpc/blob/v1.58.0/doc/load-balancing.md#load-balancing-policies
-//
-// It also configures the gRPC client to wait for the server to be ready before
-// sending RPCs. Notably this gives Functions time to start before we make a
-// request. See https://grpc.io/docs/guides/wait-for-ready/
-const svcConfig = `
-{
-	"loadBalancingConfig": [
-		{
-			"round_robin":{}
-		}
-	],
-	"methodConfig": [
-		{
-			"name": [{}],
-			"waitForReady": true
-		}
-	]
-}`
-
 // A FunctionRunner runs a composition function.
 type FunctionRunner interface {
 	// RunFunction runs the named composition function.
@@ -90,15 +70,27 @@ func (fn FunctionRunnerFn) RunFunction(ctx context.Context, name string, req *fn
 // A PackagedFunctionRunner runs a Function by making a gRPC call to a Function
 // package's runtime. It creates a gRPC client connection for each Function. The
 // Function's endpoint is determined by reading the status.endpoint of the
-// active FunctionRevision. You must call GarbageCollectClientConnections in
-// order to ensure connections are properly closed.
+// active FunctionRevision. Each Function's CallPolicy can be configured using
+// its spec.callPolicy. You must call GarbageCollectClientConnections in order to
+// ensure connections are properly closed.
 type PackagedFunctionRunner struct {
 	client       client.Reader
 	creds        credentials.TransportCredentials
 	interceptors []InterceptorCreator
+	policy       CallPolicy
+	queue        *FairQueue
+
+	streamThreshold int
+	chunkSize       int
+	maxStreamSize   int
 
-	connsMx sync.RWMutex
-	conns   map[string]*grpc.ClientConn
+	connsMx  sync.RWMutex
+	conns    map[string]*grpc.ClientConn
+	policies map[string]CallPolicy
+
+	// Streamed calls don't pass through a connection's unary interceptors,
+	// so we keep each function's interceptors to run them explicitly.
+	streamInterceptors map[string][]grpc.UnaryClientInterceptor
 
 	log logging.Logger
 }
@@ -136,14 +128,63 @@ func WithInterceptorCreators(ics ...InterceptorCreator) PackagedFunctionRunnerOp
 	}
 }
 
+// WithCallPolicy configures the default CallPolicy the PackagedFunctionRunner
+// should use. Functions can override it using their spec.callPolicy.
+func WithCallPolicy(p CallPolicy) PackagedFunctionRunnerOption {
+	return func(r *PackagedFunctionRunner) {
+		r.policy = p
+	}
+}
+
+// WithFairQueue configures the FairQueue the PackagedFunctionRunner should use
+// to limit how many calls may be in flight to each function.
+func WithFairQueue(q *FairQueue) PackagedFunctionRunnerOption {
+	return func(r *PackagedFunctionRunner) {
+		r.queue = q
+	}
+}
+
+// WithStreamThreshold configures how large, in bytes, a RunFunctionRequest must
+// be before the PackagedFunctionRunner streams it to a Function that supports
+// streaming.
+func WithStreamThreshold(bytes int) PackagedFunctionRunnerOption {
+	return func(r *PackagedFunctionRunner) {
+		r.streamThreshold = bytes
+	}
+}
+
+// WithStreamChunkSize configures the size, in bytes, of the chunks the
+// PackagedFunctionRunner splits a streamed RunFunctionRequest into.
+func WithStreamChunkSize(bytes int) PackagedFunctionRunnerOption {
+	return func(r *PackagedFunctionRunner) {
+		r.chunkSize = bytes
+	}
+}
+
+// WithMaxStreamSize configures the size, in bytes, of the largest streamed
+// RunFunctionResponse the PackagedFunctionRunner will receive.
+func WithMaxStreamSize(bytes int) PackagedFunctionRunnerOption {
+	return func(r *PackagedFunctionRunner) {
+		r.maxStreamSize = bytes
+	}
+}
+
 // NewPackagedFunctionRunner returns a FunctionRunner that runs a Function by
 // making a gRPC call to a Function package's runtime.
 func NewPackagedFunctionRunner(c client.Reader, o ...PackagedFunctionRunnerOption) *PackagedFunctionRunner {
 	r := &PackagedFunctionRunner{
-		client: c,
-		creds:  insecure.NewCredentials(),
-		conns:  make(map[string]*grpc.ClientConn),
-		log:    logging.NewNopLogger(),
+		client:   c,
+		creds:    insecure.NewCredentials(),
+		conns:    make(map[string]*grpc.ClientConn),
+		policies: make(map[string]CallPolicy),
+
+		streamInterceptors: make(map[string][]grpc.UnaryClientInterceptor),
+		queue:              NewFairQueue(FairnessKeyComposition, NopQueueMetrics{}),
+		log:                logging.NewNopLogger(),
+
+		streamThreshold: DefaultStreamThreshold,
+		chunkSize:       DefaultStreamChunkSize,
+		maxStreamSize:   DefaultStreamMaxSize,
 	}
 
 	for _, fn := range o {
@@ -156,11 +197,33 @@ func NewPackagedFunctionRunner(c client.Reader, o ...PackagedFunctionRunnerOptio
 // RunFunction sends the supplied RunFunctionRequest to the named Function. The
 // function is expected to be an installed Function.pkg.crossplane.io package.
 func (r *PackagedFunctionRunner) RunFunction(ctx context.Context, name string, req *fnv1.RunFunctionRequest) (*fnv1.RunFunctionResponse, error) {
-	conn, err := r.getClientConn(ctx, name)
+	conn, policy, err := r.getClientConn(ctx, name)
 	if err != nil {
 		return nil, errors.Wrapf(err, errFmtGetClientConn, name)
 	}
 
+	// Wait our turn if too many calls are already in flight to the function.
+	release, err := r.queue.Acquire(ctx, name, policy.MaxInFlight, r.queue.Caller(ctx, req))
+	if err != nil {
+		return nil, errors.Wrapf(err, errFmtWaitFunction, name)
+	}
+	defer release()
+
+	// Stream requests that are too large to send in one message, if the
+	// function supports it. Fall back to a unary RPC if it turns out the
+	// function doesn't implement streaming after all.
+	if policy.Stream && proto.Size(req) > r.streamThreshold {
+		r.connsMx.RLock()
+		is := r.streamInterceptors[name]
+		r.connsMx.RUnlock()
+
+		rsp, err := NewStreamingFunctionRunnerServiceClient(conn, r.chunkSize, r.maxStreamSize, is...).RunFunction(ctx, req)
+		if status.Code(err) != codes.Unimplemented {
+			return rsp, errors.Wrapf(err, errFmtRunFunction, name)
+		}
+		r.log.Debug("Function advertises the streaming capability but doesn't implement RunFunctionStream", "function", name)
+	}
+
 	rsp, err := NewBetaFallBackFunctionRunnerServiceClient(conn).RunFunction(ctx, req)
 
 	return rsp, errors.Wrapf(err, errFmtRunFunction, name)
@@ -189,12 +252,16 @@ func (r *PackagedFunctionRunner) RunFunction(ctx context.Context, name string, r
 // cost of listing and iterating over FunctionRevisions from cache. The default
 // RevisionHistoryLimit is 1, so for most Functions we'd expect there to be two
 // revisions in the cache (one active, and one previously active).
-func (r *PackagedFunctionRunner) getClientConn(ctx context.Context, name string) (*grpc.ClientConn, error) {
+//
+// The connection's service config and interceptors depend on the Function's
+// CallPolicy, so we also verify that the connection has the correct CallPolicy
+// every time the Function is called.
+func (r *PackagedFunctionRunner) getClientConn(ctx context.Context, name string) (*grpc.ClientConn, CallPolicy, error) {
 	log := r.log.WithValues("function", name)
 
 	l := &pkgv1.FunctionRevisionList{}
 	if err := r.client.List(ctx, l, client.MatchingLabels{pkgv1.LabelParentPackage: name}); err != nil {
-		return nil, errors.Wrapf(err, errListFunctionRevisions)
+		return nil, CallPolicy{}, errors.Wrapf(err, errListFunctionRevisions)
 	}
 
 	var active *pkgv1.FunctionRevision
@@ -206,20 +273,23 @@ func (r *PackagedFunctionRunner) getClientConn(ctx context.Context, name string)
 	}
 
 	if active == nil {
-		return nil, errors.New(errNoActiveRevisions)
+		return nil, CallPolicy{}, errors.New(errNoActiveRevisions)
 	}
 
 	if active.Status.Endpoint == "" {
-		return nil, errors.Errorf(errFmtEmptyEndpoint, active.GetName())
+		return nil, CallPolicy{}, errors.Errorf(errFmtEmptyEndpoint, active.GetName())
 	}
 
+	policy := CallPolicyFromSpec(r.policy, active.Spec.CallPolicy)
+	policy.Stream = pkgmetav1.CapabilitiesContainFuzzyMatch(active.GetCapabilities(), pkgmetav1.FunctionCapabilityStreaming)
+
 	// If we have a connection for the up-to-date endpoint, return it.
 	r.connsMx.RLock()
 
 	conn, ok := r.conns[name]
-	if ok && conn.Target() == active.Status.Endpoint {
+	if ok && conn.Target() == active.Status.Endpoint && r.policies[name] == policy {
 		defer r.connsMx.RUnlock()
-		return conn, nil
+		return conn, policy, nil
 	}
 
 	r.connsMx.RUnlock()
@@ -233,37 +303,45 @@ func (r *PackagedFunctionRunner) getClientConn(ctx context.Context, name string)
 	conn, ok = r.conns[name]
 	if ok {
 		// We now have a connection for the up-to-date endpoint.
-		if conn.Target() == active.Status.Endpoint {
-			return conn, nil
+		if conn.Target() == active.Status.Endpoint && r.policies[name] == policy {
+			return conn, policy, nil
 		}
 
-		// This connection is to an old endpoint. We need to close it and create
-		// a new connection. Close only returns an error is if the connection is
-		// already closed or in the process of closing.
-		log.Debug("Closing gRPC client connection with stale target", "old-target", conn.Target(), "new-target", active.Status.Endpoint)
+		// This connection is to an old endpoint, or uses an old call
+		// policy. We need to close it and create a new connection. Close
+		// only returns an error is if the connection is already closed or
+		// in the process of closing.
+		log.Debug("Closing gRPC client connection with stale target or call policy", "old-target", conn.Target(), "new-target", active.Status.Endpoint)
 		_ = conn.Close()
 
 		delete(r.conns, name)
+		delete(r.policies, name)
+		delete(r.streamInterceptors, name)
 	}
 
-	is := make([]grpc.UnaryClientInterceptor, len(r.interceptors))
+	is := make([]grpc.UnaryClientInterceptor, 0, len(r.interceptors))
 	for i := range r.interceptors {
-		is[i] = r.interceptors[i].CreateInterceptor(name, active.Spec.Package)
+		is = append(is, r.interceptors[i].CreateInterceptor(name, active.Spec.Package))
 	}
 
+	// The call policy's interceptor runs last, so that other interceptors
+	// (e.g. metrics) see one call, not one call per hedged request. It only
+	// applies to unary calls. Streamed calls aren't hedged.
 	conn, err := grpc.NewClient(active.Status.Endpoint,
 		grpc.WithTransportCredentials(r.creds),
-		grpc.WithDefaultServiceConfig(svcConfig),
-		grpc.WithChainUnaryInterceptor(is...))
+		grpc.WithDefaultServiceConfig(policy.ServiceConfig()),
+		grpc.WithChainUnaryInterceptor(append(slices.Clip(is), policy.Interceptor())...))
 	if err != nil {
-		return nil, errors.Wrapf(err, errFmtDialFunction, active.Status.Endpoint, active.GetName())
+		return nil, CallPolicy{}, errors.Wrapf(err, errFmtDialFunction, active.Status.Endpoint, active.GetName())
 	}
 
 	r.conns[name] = conn
+	r.policies[name] = policy
+	r.streamInterceptors[name] = is
 
-	log.Debug("Created new gRPC client connection", "target", active.Status.Endpoint)
+	log.Debug("Created new gRPC client connection", "target", active.Status.Endpoint, "policy", policy)
 
-	return conn, nil
+	return conn, policy, nil
 }
 
 // GarbageCollectConnections runs every interval until the supplied context is
@@ -330,6 +408,8 @@ func (r *PackagedFunctionRunner) GarbageCollectConnectionsNow(ctx context.Contex
 		// closed or in the process of closing.
 		_ = r.conns[name].Close()
 		delete(r.conns, name)
+		delete(r.policies, name)
+		delete(r.streamInterceptors, name)
 
 		closed++
 
@@ -386,6 +466,12 @@ func (c *BetaFallBackFunctionRunnerServiceClient) RunFunction(ctx context.Contex
 	return rsp, err
 }
 
+// RunFunctionStream opens a v1 RunFunctionStream. There's no v1beta1 fallback;
+// functions that implement streaming implement v1.
+func (c *BetaFallBackFunctionRunnerServiceClient) RunFunctionStream(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[fnv1.RunFunctionChunk, fnv1.RunFunctionChunk], error) {
+	return fnv1.NewFunctionRunnerServiceClient(c.cc).RunFunctionStream(ctx, opts...)
+}
+
 func toBeta(req *fnv1.RunFunctionRequest) (*fnv1beta1.RunFunctionRequest, error) {
 	out := &fnv1beta1.RunFunctionRequest{}
 
@@ -26,12 +26,17 @@ import (
 	fnv1 "github.com/crossplane/crossplane/v2/proto/fn/v1"
 )
 
+var _ QueueMetrics = &PrometheusMetrics{}
+
 // PrometheusMetrics are requests, errors, and duration (RED) metrics for
-// function runs.
+// function runs. They also include metrics for calls waiting in a FairQueue.
 type PrometheusMetrics struct {
 	requests  *prometheus.CounterVec
 	responses *prometheus.CounterVec
 	duration  *prometheus.HistogramVec
+
+	queueDepth *prometheus.GaugeVec
+	queueWait  *prometheus.HistogramVec
 }
 
 // NewPrometheusMetrics creates metrics for function runs.
@@ -55,6 +60,19 @@ func NewPrometheusMetrics() *PrometheusMetrics {
 			Help:      "Histogram of RunFunctionResponse latency (seconds).",
 			Buckets:   prometheus.DefBuckets,
 		}, []string{"function_name", "function_package", "grpc_target", "grpc_method", "grpc_code", "result_severity"}),
+
+		queueDepth: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Subsystem: "function",
+			Name:      "run_function_queue_depth",
+			Help:      "Number of RunFunctionRequests waiting because too many requests are in flight to the function.",
+		}, []string{"function_name"}),
+
+		queueWait: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Subsystem: "function",
+			Name:      "run_function_queue_wait_seconds",
+			Help:      "Histogram of how long RunFunctionRequests waited before being sent (seconds).",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"function_name"}),
 	}
 }
 
@@ -65,6 +83,8 @@ func (m *PrometheusMetrics) Describe(ch chan<- *prometheus.Desc) {
 	m.requests.Describe(ch)
 	m.responses.Describe(ch)
 	m.duration.Describe(ch)
+	m.queueDepth.Describe(ch)
+	m.queueWait.Describe(ch)
 }
 
 // Collect is called by the Prometheus registry when collecting
@@ -74,6 +94,18 @@ func (m *PrometheusMetrics) Collect(ch chan<- prometheus.Metric) {
 	m.requests.Collect(ch)
 	m.responses.Collect(ch)
 	m.duration.Collect(ch)
+	m.queueDepth.Collect(ch)
+	m.queueWait.Collect(ch)
+}
+
+// QueueDepth records how many calls are waiting for the named function.
+func (m *PrometheusMetrics) QueueDepth(name string, depth int) {
+	m.queueDepth.With(prometheus.Labels{"function_name": name}).Set(float64(depth))
+}
+
+// QueueWait records how long a call waited for the named function.
+func (m *PrometheusMetrics) QueueWait(name string, wait time.Duration) {
+	m.queueWait.With(prometheus.Labels{"function_name": name}).Observe(wait.Seconds())
 }
 
 // CreateInterceptor returns a gRPC UnaryClientInterceptor for the named
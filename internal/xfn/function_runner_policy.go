@@ -0,0 +1,248 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+this file except in compliance with the License. You may obtain a copy of the
+License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed
+under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+CONDITIONS OF ANY KIND, either express or implied. See the License for the
+specific language governing permissions and limitations under the License.
+*/
+
+package xfn
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	pkgv1 "github.com/crossplane/crossplane/apis/v2/pkg/v1"
+)
+
+// MaxCallAttempts is the maximum number of requests a CallPolicy may send for
+// each call. gRPC silently caps attempts at 5.
+const MaxCallAttempts = 5
+
+// defaultRetryBackoff is how long to wait before the first retry if the
+// CallPolicy doesn't specify a backoff.
+const defaultRetryBackoff = 100 * time.Millisecond
+
+// A CallPolicy configures how a PackagedFunctionRunner calls a Function.
+type CallPolicy struct {
+	// Timeout is the maximum time to wait for the Function to respond,
+	// including retries and hedged requests. Zero means the call inherits
+	// the caller's deadline.
+	Timeout time.Duration
+
+	// MaxAttempts is the maximum number of requests to send for each call.
+	// Values less than two disable retries and hedging.
+	MaxAttempts int
+
+	// RetryBackoff is how long to wait before the first retry. Requests are
+	// only retried if the Function is UNAVAILABLE.
+	RetryBackoff time.Duration
+
+	// HedgingDelay enables hedging when non-zero. Hedging sends another
+	// request each time HedgingDelay passes without a response, up to
+	// MaxAttempts. Hedged requests aren't retried.
+	HedgingDelay time.Duration
+
+	// MaxInFlight is the maximum number of calls that may be in flight to the
+	// Function at once. Calls over the limit wait in a FairQueue. Zero means
+	// unlimited.
+	MaxInFlight int
+
+	// Stream requests that are larger than the PackagedFunctionRunner's
+	// stream threshold using the RunFunctionStream RPC. It's true if the
+	// Function's active revision advertises the streaming capability.
+	Stream bool
+}
+
+// CallPolicyFromSpec returns the supplied CallPolicy, overridden by any fields
+// set in the supplied Function call policy. The API server validates the
+// Function call policy, so this doesn't.
+func CallPolicyFromSpec(p CallPolicy, s *pkgv1.FunctionCallPolicy) CallPolicy {
+	if s == nil {
+		return p
+	}
+	if s.Timeout != nil {
+		p.Timeout = s.Timeout.Duration
+	}
+	if s.MaxAttempts != nil {
+		p.MaxAttempts = int(*s.MaxAttempts)
+	}
+	if s.RetryBackoff != nil {
+		p.RetryBackoff = s.RetryBackoff.Duration
+	}
+	if s.HedgingDelay != nil {
+		p.HedgingDelay = s.HedgingDelay.Duration
+	}
+	if s.MaxInFlight != nil {
+		p.MaxInFlight = int(*s.MaxInFlight)
+	}
+	return p
+}
+
+// Retries returns true if failed requests should be retried.
+func (p CallPolicy) Retries() bool {
+	return p.MaxAttempts > 1 && p.HedgingDelay == 0
+}
+
+// Hedges returns true if slow requests should be hedged.
+func (p CallPolicy) Hedges() bool {
+	return p.MaxAttempts > 1 && p.HedgingDelay > 0
+}
+
+// ServiceConfig returns the gRPC service config to use for the Function.
+//
+// This configures a gRPC client to use round robin load balancing. This means
+// that if the Function Deployment has more than one Pod, and the Function
+// Service is headless, requests will be spread across each Pod.
+// See https://github.com/grpc/grpc/blob/v1.58.0/doc/load-balancing.md#load-balancing-policies
+//
+// It also configures the gRPC client to wait for the server to be ready before
+// sending RPCs. Notably this gives Functions time to start before we make a
+// request. See https://grpc.io/docs/guides/wait-for-ready/
+//
+// Finally it configures the CallPolicy's timeout, and its retry policy if it
+// retries. See https://grpc.io/docs/guides/retry/
+func (p CallPolicy) ServiceConfig() string {
+	type retryPolicy struct {
+		MaxAttempts          int      `json:"maxAttempts"`
+		InitialBackoff       string   `json:"initialBackoff"`
+		MaxBackoff           string   `json:"maxBackoff"`
+		BackoffMultiplier    float64  `json:"backoffMultiplier"`
+		RetryableStatusCodes []string `json:"retryableStatusCodes"`
+	}
+	type methodConfig struct {
+		Name         []struct{}   `json:"name"`
+		WaitForReady bool         `json:"waitForReady"`
+		Timeout      string       `json:"timeout,omitempty"`
+		RetryPolicy  *retryPolicy `json:"retryPolicy,omitempty"`
+	}
+	type serviceConfig struct {
+		LoadBalancingConfig []map[string]struct{} `json:"loadBalancingConfig"`
+		MethodConfig        []methodConfig        `json:"methodConfig"`
+	}
+
+	mc := methodConfig{Name: []struct{}{{}}, WaitForReady: true}
+	if p.Timeout > 0 {
+		mc.Timeout = grpcDuration(p.Timeout)
+	}
+	if p.Retries() {
+		backoff := p.RetryBackoff
+		if backoff <= 0 {
+			backoff = defaultRetryBackoff
+		}
+		mc.RetryPolicy = &retryPolicy{
+			MaxAttempts:          p.MaxAttempts,
+			InitialBackoff:       grpcDuration(backoff),
+			MaxBackoff:           grpcDuration(10 * backoff),
+			BackoffMultiplier:    2,
+			RetryableStatusCodes: []string{"UNAVAILABLE"},
+		}
+	}
+
+	sc := serviceConfig{
+		LoadBalancingConfig: []map[string]struct{}{{"round_robin": {}}},
+		MethodConfig:        []methodConfig{mc},
+	}
+
+	// Marshalling these types can't fail.
+	b, _ := json.Marshal(sc) //nolint:errchkjson // See above.
+	return string(b)
+}
+
+// Interceptor returns a gRPC UnaryClientInterceptor that enforces the
+// CallPolicy's timeout across all hedged requests, and hedges slow requests.
+// gRPC doesn't support hedging natively.
+func (p CallPolicy) Interceptor() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		if !p.Hedges() {
+			return invoker(ctx, method, req, reply, cc, opts...)
+		}
+
+		if p.Timeout > 0 {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithTimeout(ctx, p.Timeout)
+			defer cancel()
+		}
+
+		// Cancel any outstanding hedged requests once we return.
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+
+		rm, ok := reply.(proto.Message)
+		if !ok {
+			return invoker(ctx, method, req, reply, cc, opts...)
+		}
+
+		type result struct {
+			reply proto.Message
+			err   error
+		}
+		results := make(chan result, p.MaxAttempts)
+
+		send := func() {
+			r := rm.ProtoReflect().New().Interface()
+			results <- result{reply: r, err: invoker(ctx, method, req, r, cc, opts...)}
+		}
+
+		t := time.NewTicker(p.HedgingDelay)
+		defer t.Stop()
+
+		go send()
+		sent, failed := 1, 0
+
+		var err error
+
+		for {
+			select {
+			case <-t.C:
+				if sent < p.MaxAttempts {
+					go send()
+					sent++
+				}
+			case r := <-results:
+				if r.err == nil {
+					proto.Merge(rm, r.reply)
+					return nil
+				}
+				// Only hedge requests that fail because the
+				// Function is unavailable.
+				if status.Code(r.err) != codes.Unavailable {
+					return r.err
+				}
+				err = r.err
+				failed++
+
+				// Every request we sent failed. Send another if we
+				// can, rather than waiting for the next tick.
+				if failed < sent {
+					continue
+				}
+				if sent == p.MaxAttempts {
+					return err
+				}
+				go send()
+				sent++
+				t.Reset(p.HedgingDelay)
+			}
+		}
+	}
+}
+
+// grpcDuration formats the supplied duration as a protobuf JSON duration.
+func grpcDuration(d time.Duration) string {
+	return strconv.FormatFloat(d.Seconds(), 'f', -1, 64) + "s"
+}
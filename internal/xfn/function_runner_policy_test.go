@@ -0,0 +1,236 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+this file except in compliance with the License. You may obtain a copy of the
+License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed
+under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+CONDITIONS OF ANY KIND, either express or implied. See the License for the
+specific language governing permissions and limitations under the License.
+*/
+
+package xfn
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/testing/protocmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	pkgv1 "github.com/crossplane/crossplane/apis/v2/pkg/v1"
+	fnv1 "github.com/crossplane/crossplane/v2/proto/fn/v1"
+)
+
+func TestCallPolicyFromSpec(t *testing.T) {
+	type args struct {
+		p CallPolicy
+		s *pkgv1.FunctionCallPolicy
+	}
+
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   CallPolicy
+	}{
+		"NoCallPolicy": {
+			reason: "The default policy should be returned if the Function has no call policy.",
+			args: args{
+				p: CallPolicy{Timeout: time.Minute},
+			},
+			want: CallPolicy{Timeout: time.Minute},
+		},
+		"OverrideDefaults": {
+			reason: "Fields set in the Function's call policy should override the default policy.",
+			args: args{
+				p: CallPolicy{Timeout: time.Minute, MaxAttempts: 2, RetryBackoff: time.Second},
+				s: &pkgv1.FunctionCallPolicy{
+					Timeout:      &metav1.Duration{Duration: 10 * time.Second},
+					MaxAttempts:  ptr.To[int32](3),
+					HedgingDelay: &metav1.Duration{Duration: 500 * time.Millisecond},
+					MaxInFlight:  ptr.To[int32](10),
+				},
+			},
+			want: CallPolicy{Timeout: 10 * time.Second, MaxAttempts: 3, RetryBackoff: time.Second, HedgingDelay: 500 * time.Millisecond, MaxInFlight: 10},
+		},
+		"OverrideWithZero": {
+			reason: "Fields explicitly set to zero should override the default policy.",
+			args: args{
+				p: CallPolicy{Timeout: time.Minute, MaxInFlight: 5},
+				s: &pkgv1.FunctionCallPolicy{
+					Timeout:     &metav1.Duration{},
+					MaxInFlight: ptr.To[int32](0),
+				},
+			},
+			want: CallPolicy{},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			p := CallPolicyFromSpec(tc.args.p, tc.args.s)
+
+			if diff := cmp.Diff(tc.want, p); diff != "" {
+				t.Errorf("\n%s\nCallPolicyFromSpec(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestCallPolicyServiceConfig(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		p      CallPolicy
+	}{
+		"Default": {
+			reason: "The default policy should produce a valid service config.",
+		},
+		"TimeoutAndRetries": {
+			reason: "A policy with a timeout and retries should produce a valid service config.",
+			p:      CallPolicy{Timeout: 1500 * time.Millisecond, MaxAttempts: 3, RetryBackoff: 50 * time.Millisecond},
+		},
+		"Hedging": {
+			reason: "A policy that hedges should produce a valid service config.",
+			p:      CallPolicy{MaxAttempts: 3, HedgingDelay: time.Second},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			// NewClient validates the default service config.
+			conn, err := grpc.NewClient("dns:///localhost:9443",
+				grpc.WithTransportCredentials(insecure.NewCredentials()),
+				grpc.WithDefaultServiceConfig(tc.p.ServiceConfig()))
+			if err != nil {
+				t.Fatalf("\n%s\ngrpc.NewClient(...): %s\n%s", tc.reason, err, tc.p.ServiceConfig())
+			}
+			_ = conn.Close()
+		})
+	}
+}
+
+func TestCallPolicyInterceptor(t *testing.T) {
+	rsp := &fnv1.RunFunctionResponse{Meta: &fnv1.ResponseMeta{Tag: "hi!"}}
+
+	type want struct {
+		rsp   *fnv1.RunFunctionResponse
+		calls int32
+		err   error
+	}
+
+	cases := map[string]struct {
+		reason string
+		p      CallPolicy
+		// Returns the error for the supplied (1-indexed) call.
+		invoke func(ctx context.Context, call int32) error
+		want   want
+	}{
+		"NoHedging": {
+			reason: "A policy that doesn't hedge should call the invoker once.",
+			p:      CallPolicy{MaxAttempts: 3},
+			invoke: func(_ context.Context, _ int32) error {
+				return status.Error(codes.Unavailable, "boom")
+			},
+			want: want{
+				rsp:   &fnv1.RunFunctionResponse{},
+				calls: 1,
+				err:   cmpopts.AnyError,
+			},
+		},
+		"HedgeSlowRequest": {
+			reason: "A slow request should be hedged, and the first response should win.",
+			p:      CallPolicy{MaxAttempts: 2, HedgingDelay: 10 * time.Millisecond},
+			invoke: func(ctx context.Context, call int32) error {
+				if call == 1 {
+					// The first request never responds.
+					<-ctx.Done()
+					return ctx.Err()
+				}
+				return nil
+			},
+			want: want{
+				rsp:   rsp,
+				calls: 2,
+			},
+		},
+		"HedgeUnavailableRequest": {
+			reason: "A request that fails because the Function is unavailable should be hedged immediately.",
+			p:      CallPolicy{MaxAttempts: 3, HedgingDelay: time.Hour},
+			invoke: func(_ context.Context, call int32) error {
+				if call < 3 {
+					return status.Error(codes.Unavailable, "boom")
+				}
+				return nil
+			},
+			want: want{
+				rsp:   rsp,
+				calls: 3,
+			},
+		},
+		"FatalError": {
+			reason: "A request that fails for any other reason shouldn't be hedged.",
+			p:      CallPolicy{MaxAttempts: 3, HedgingDelay: time.Hour},
+			invoke: func(_ context.Context, _ int32) error {
+				return status.Error(codes.InvalidArgument, "boom")
+			},
+			want: want{
+				rsp:   &fnv1.RunFunctionResponse{},
+				calls: 1,
+				err:   cmpopts.AnyError,
+			},
+		},
+		"AllAttemptsFail": {
+			reason: "We should return an error if every hedged request fails.",
+			p:      CallPolicy{MaxAttempts: 2, HedgingDelay: time.Hour},
+			invoke: func(_ context.Context, _ int32) error {
+				return status.Error(codes.Unavailable, "boom")
+			},
+			want: want{
+				rsp:   &fnv1.RunFunctionResponse{},
+				calls: 2,
+				err:   cmpopts.AnyError,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			calls := &atomic.Int32{}
+			invoker := func(ctx context.Context, _ string, _, reply any, _ *grpc.ClientConn, _ ...grpc.CallOption) error {
+				err := tc.invoke(ctx, calls.Add(1))
+				if err == nil {
+					reply.(*fnv1.RunFunctionResponse).Meta = &fnv1.ResponseMeta{Tag: "hi!"} //nolint:forcetypeassert // Will always be a response.
+				}
+				return err
+			}
+
+			got := &fnv1.RunFunctionResponse{}
+			err := tc.p.Interceptor()(context.Background(), "/RunFunction", &fnv1.RunFunctionRequest{}, got, nil, invoker)
+
+			if diff := cmp.Diff(tc.want.rsp, got, protocmp.Transform()); diff != "" {
+				t.Errorf("\n%s\nInterceptor(...): -want, +got:\n%s", tc.reason, diff)
+			}
+
+			if diff := cmp.Diff(tc.want.calls, calls.Load()); diff != "" {
+				t.Errorf("\n%s\nInterceptor(...): -want calls, +got calls:\n%s", tc.reason, diff)
+			}
+
+			if diff := cmp.Diff(tc.want.err, err, cmpopts.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nInterceptor(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,239 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+this file except in compliance with the License. You may obtain a copy of the
+License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed
+under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+CONDITIONS OF ANY KIND, either express or implied. See the License for the
+specific language governing permissions and limitations under the License.
+*/
+
+package xfn
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/crossplane/crossplane/v2/internal/controller/apiextensions/composite/step"
+	fnv1 "github.com/crossplane/crossplane/v2/proto/fn/v1"
+)
+
+// A FairnessKey determines which callers share a fair share of a function's
+// in-flight calls.
+type FairnessKey string
+
+// Fairness keys.
+const (
+	// FairnessKeyComposition gives each Composition a fair share.
+	FairnessKeyComposition FairnessKey = "Composition"
+
+	// FairnessKeyCompositeType gives each type of composite resource (i.e.
+	// each XRD) a fair share.
+	FairnessKeyCompositeType FairnessKey = "XRD"
+)
+
+// QueueMetrics records how long calls wait for a function.
+type QueueMetrics interface {
+	// QueueDepth records how many calls are waiting for the named function.
+	QueueDepth(name string, depth int)
+
+	// QueueWait records how long a call waited for the named function.
+	QueueWait(name string, wait time.Duration)
+}
+
+// NopQueueMetrics does nothing.
+type NopQueueMetrics struct{}
+
+// QueueDepth does nothing.
+func (NopQueueMetrics) QueueDepth(_ string, _ int) {}
+
+// QueueWait does nothing.
+func (NopQueueMetrics) QueueWait(_ string, _ time.Duration) {}
+
+// A FairQueue limits how many calls may be in flight to each function. Calls
+// over the limit wait in a queue. Waiting calls are grouped by caller, and
+// each caller with waiting calls takes turns to call the function. A caller
+// that makes many calls therefore can't starve other callers of the same
+// function.
+type FairQueue struct {
+	key     FairnessKey
+	metrics QueueMetrics
+
+	mx  sync.Mutex
+	fns map[string]*functionQueue
+}
+
+// A functionQueue tracks calls to one function.
+type functionQueue struct {
+	inFlight int
+	depth    int
+
+	// Waiting calls for each caller, in the order they arrived.
+	waiting map[string][]*waiter
+
+	// Callers with waiting calls, in the order they take turns.
+	callers []string
+}
+
+type waiter struct {
+	ready   chan struct{}
+	granted bool
+}
+
+// NewFairQueue returns a FairQueue that gives each caller identified by the
+// supplied key a fair share of calls to each function.
+func NewFairQueue(key FairnessKey, m QueueMetrics) *FairQueue {
+	return &FairQueue{
+		key:     key,
+		metrics: m,
+		fns:     make(map[string]*functionQueue),
+	}
+}
+
+// Caller returns the caller of a function, according to the queue's fairness
+// key. Operations are always identified by name.
+func (q *FairQueue) Caller(ctx context.Context, req *fnv1.RunFunctionRequest) string {
+	if name, ok := ctx.Value(step.ContextKeyOperationName).(string); ok {
+		return "Operation/" + name
+	}
+
+	if q.key == FairnessKeyCompositeType {
+		xr := req.GetObserved().GetComposite().GetResource().GetFields()
+		group, _, _ := strings.Cut(xr["apiVersion"].GetStringValue(), "/")
+		return xr["kind"].GetStringValue() + "." + group
+	}
+
+	name, _ := ctx.Value(step.ContextKeyCompositionName).(string)
+	return "Composition/" + name
+}
+
+// Acquire blocks until the supplied caller may call the named function without
+// exceeding the supplied maximum number of in-flight calls, or until the
+// supplied context is done. A maximum of zero is unlimited. Callers must call
+// the returned function when their call completes.
+func (q *FairQueue) Acquire(ctx context.Context, name string, maxInFlight int, caller string) (func(), error) {
+	if maxInFlight <= 0 {
+		return func() {}, nil
+	}
+
+	q.mx.Lock()
+
+	fq, ok := q.fns[name]
+	if !ok {
+		fq = &functionQueue{waiting: make(map[string][]*waiter)}
+		q.fns[name] = fq
+	}
+
+	release := func() {
+		q.mx.Lock()
+		defer q.mx.Unlock()
+
+		fq.inFlight--
+		q.dispatch(name, fq, maxInFlight)
+	}
+
+	if fq.depth == 0 && fq.inFlight < maxInFlight {
+		fq.inFlight++
+		q.mx.Unlock()
+		q.metrics.QueueWait(name, 0)
+		return release, nil
+	}
+
+	w := &waiter{ready: make(chan struct{})}
+	if len(fq.waiting[caller]) == 0 {
+		fq.callers = append(fq.callers, caller)
+	}
+	fq.waiting[caller] = append(fq.waiting[caller], w)
+	fq.depth++
+	q.metrics.QueueDepth(name, fq.depth)
+
+	q.mx.Unlock()
+
+	start := time.Now()
+	select {
+	case <-w.ready:
+		q.metrics.QueueWait(name, time.Since(start))
+		return release, nil
+	case <-ctx.Done():
+	}
+
+	q.mx.Lock()
+	defer q.mx.Unlock()
+
+	// We were granted a slot just as our context was done. Give it to the
+	// next waiting call.
+	if w.granted {
+		fq.inFlight--
+		q.dispatch(name, fq, maxInFlight)
+		return nil, ctx.Err()
+	}
+
+	q.remove(fq, caller, w)
+	q.metrics.QueueDepth(name, fq.depth)
+
+	return nil, ctx.Err()
+}
+
+// dispatch grants slots to waiting calls, taking turns between callers. It
+// must be called with the queue's lock held.
+func (q *FairQueue) dispatch(name string, fq *functionQueue, maxInFlight int) {
+	for fq.inFlight < maxInFlight && len(fq.callers) > 0 {
+		caller := fq.callers[0]
+		fq.callers = fq.callers[1:]
+
+		w := fq.waiting[caller][0]
+		fq.waiting[caller] = fq.waiting[caller][1:]
+
+		// This caller has more waiting calls. It takes its next turn
+		// after every other waiting caller.
+		if len(fq.waiting[caller]) > 0 {
+			fq.callers = append(fq.callers, caller)
+		} else {
+			delete(fq.waiting, caller)
+		}
+
+		fq.depth--
+		fq.inFlight++
+		w.granted = true
+		close(w.ready)
+	}
+
+	q.metrics.QueueDepth(name, fq.depth)
+
+	// Forget about idle functions.
+	if fq.inFlight == 0 && fq.depth == 0 {
+		delete(q.fns, name)
+	}
+}
+
+// remove removes a waiting call whose context was done. It must be called with
+// the queue's lock held.
+func (q *FairQueue) remove(fq *functionQueue, caller string, w *waiter) {
+	ws := fq.waiting[caller]
+	for i := range ws {
+		if ws[i] == w {
+			fq.waiting[caller] = append(ws[:i], ws[i+1:]...)
+			fq.depth--
+			break
+		}
+	}
+
+	if len(fq.waiting[caller]) > 0 {
+		return
+	}
+
+	delete(fq.waiting, caller)
+	for i := range fq.callers {
+		if fq.callers[i] == caller {
+			fq.callers = append(fq.callers[:i], fq.callers[i+1:]...)
+			break
+		}
+	}
+}
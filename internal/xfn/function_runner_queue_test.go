@@ -0,0 +1,248 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+this file except in compliance with the License. You may obtain a copy of the
+License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed
+under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+CONDITIONS OF ANY KIND, either express or implied. See the License for the
+specific language governing permissions and limitations under the License.
+*/
+
+package xfn
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/protobuf/types/known/structpb"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/test"
+
+	"github.com/crossplane/crossplane/v2/internal/controller/apiextensions/composite/step"
+	fnv1 "github.com/crossplane/crossplane/v2/proto/fn/v1"
+)
+
+// MockQueueMetrics records the queue depth of each function.
+type MockQueueMetrics struct {
+	mx    sync.Mutex
+	depth map[string]int
+}
+
+func (m *MockQueueMetrics) QueueDepth(name string, depth int) {
+	m.mx.Lock()
+	defer m.mx.Unlock()
+
+	m.depth[name] = depth
+}
+
+func (m *MockQueueMetrics) QueueWait(_ string, _ time.Duration) {}
+
+// WaitForDepth blocks until the named function's queue depth is the supplied
+// depth.
+func (m *MockQueueMetrics) WaitForDepth(t *testing.T, name string, depth int) {
+	t.Helper()
+
+	for range 1000 {
+		m.mx.Lock()
+		d := m.depth[name]
+		m.mx.Unlock()
+
+		if d == depth {
+			return
+		}
+
+		time.Sleep(time.Millisecond)
+	}
+
+	t.Fatalf("timed out waiting for queue depth %d", depth)
+}
+
+func TestFairQueueAcquire(t *testing.T) {
+	type want struct {
+		order []string
+	}
+
+	cases := map[string]struct {
+		reason      string
+		maxInFlight int
+		callers     []string
+		want        want
+	}{
+		"TakeTurns": {
+			reason:      "Callers with waiting calls should take turns, regardless of the order their calls arrived.",
+			maxInFlight: 1,
+			callers:     []string{"a", "a", "a", "b", "c"},
+			want: want{
+				order: []string{"a", "b", "c", "a", "a"},
+			},
+		},
+		"FirstInFirstOut": {
+			reason:      "A single caller's calls should be granted in the order they arrived.",
+			maxInFlight: 1,
+			callers:     []string{"a", "a"},
+			want: want{
+				order: []string{"a", "a"},
+			},
+		},
+		"Unlimited": {
+			reason:      "No calls should wait if the maximum in flight is zero.",
+			maxInFlight: 0,
+			callers:     []string{},
+			want: want{
+				order: []string{},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			m := &MockQueueMetrics{depth: map[string]int{}}
+			q := NewFairQueue(FairnessKeyComposition, m)
+
+			// Fill all the function's slots.
+			held := make([]func(), 0, tc.maxInFlight)
+			for range tc.maxInFlight {
+				release, err := q.Acquire(context.Background(), "cool-fn", tc.maxInFlight, "holder")
+				if err != nil {
+					t.Fatalf("q.Acquire(...): %v", err)
+				}
+				held = append(held, release)
+			}
+
+			mx := sync.Mutex{}
+			order := []string{}
+			wg := sync.WaitGroup{}
+
+			for i, caller := range tc.callers {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+
+					release, err := q.Acquire(context.Background(), "cool-fn", tc.maxInFlight, caller)
+					if err != nil {
+						t.Errorf("q.Acquire(...): %v", err)
+						return
+					}
+
+					mx.Lock()
+					order = append(order, caller)
+					mx.Unlock()
+
+					release()
+				}()
+
+				// Wait for each call to be queued, so they arrive in order.
+				m.WaitForDepth(t, "cool-fn", i+1)
+			}
+
+			for _, release := range held {
+				release()
+			}
+			wg.Wait()
+
+			if diff := cmp.Diff(tc.want.order, order); diff != "" {
+				t.Errorf("\n%s\nq.Acquire(...): -want order, +got order:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestFairQueueAcquireContextDone(t *testing.T) {
+	m := &MockQueueMetrics{depth: map[string]int{}}
+	q := NewFairQueue(FairnessKeyComposition, m)
+
+	release, err := q.Acquire(context.Background(), "cool-fn", 1, "a")
+	if err != nil {
+		t.Fatalf("q.Acquire(...): %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	// A call that gives up waiting should return its context's error.
+	if _, err := q.Acquire(ctx, "cool-fn", 1, "b"); err == nil {
+		t.Errorf("q.Acquire(...): want error, got nil")
+	}
+	m.WaitForDepth(t, "cool-fn", 0)
+
+	// The call that gave up shouldn't hold a slot.
+	release()
+
+	release, err = q.Acquire(context.Background(), "cool-fn", 1, "c")
+	if diff := cmp.Diff(nil, err, test.EquateErrors()); diff != "" {
+		t.Errorf("\nq.Acquire(...): -want error, +got error:\n%s", diff)
+	}
+	release()
+}
+
+func TestFairQueueCaller(t *testing.T) {
+	xr := &fnv1.RunFunctionRequest{
+		Observed: &fnv1.State{
+			Composite: &fnv1.Resource{
+				Resource: &structpb.Struct{Fields: map[string]*structpb.Value{
+					"apiVersion": structpb.NewStringValue("example.org/v1"),
+					"kind":       structpb.NewStringValue("XCoolResource"),
+				}},
+			},
+		},
+	}
+
+	type args struct {
+		ctx context.Context
+		req *fnv1.RunFunctionRequest
+	}
+
+	cases := map[string]struct {
+		reason string
+		key    FairnessKey
+		args   args
+		want   string
+	}{
+		"Composition": {
+			reason: "Composition callers should be identified by their Composition's name.",
+			key:    FairnessKeyComposition,
+			args: args{
+				ctx: context.WithValue(context.Background(), step.ContextKeyCompositionName, "cool-comp"),
+				req: xr,
+			},
+			want: "Composition/cool-comp",
+		},
+		"CompositeType": {
+			reason: "Composition callers should be identified by their XR's kind and group when keyed by XRD.",
+			key:    FairnessKeyCompositeType,
+			args: args{
+				ctx: context.WithValue(context.Background(), step.ContextKeyCompositionName, "cool-comp"),
+				req: xr,
+			},
+			want: "XCoolResource.example.org",
+		},
+		"Operation": {
+			reason: "Operation callers should be identified by their Operation's name.",
+			key:    FairnessKeyCompositeType,
+			args: args{
+				ctx: context.WithValue(context.Background(), step.ContextKeyOperationName, "cool-op"),
+				req: &fnv1.RunFunctionRequest{},
+			},
+			want: "Operation/cool-op",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			q := NewFairQueue(tc.key, NopQueueMetrics{})
+			got := q.Caller(tc.args.ctx, tc.args.req)
+
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nq.Caller(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,184 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+this file except in compliance with the License. You may obtain a copy of the
+License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed
+under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+CONDITIONS OF ANY KIND, either express or implied. See the License for the
+specific language governing permissions and limitations under the License.
+*/
+
+package xfn
+
+import (
+	"context"
+	"io"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/errors"
+
+	fnv1 "github.com/crossplane/crossplane/v2/proto/fn/v1"
+)
+
+// Streaming defaults.
+const (
+	// DefaultStreamThreshold is the size of the largest RunFunctionRequest
+	// that's sent in one message. It leaves headroom below gRPC's default
+	// 4 MiB maximum message size.
+	DefaultStreamThreshold = 3 << 20
+
+	// DefaultStreamChunkSize is the size of each chunk of a streamed
+	// RunFunctionRequest or RunFunctionResponse.
+	DefaultStreamChunkSize = 1 << 20
+
+	// DefaultStreamMaxSize is the size of the largest streamed
+	// RunFunctionRequest or RunFunctionResponse that will be received.
+	DefaultStreamMaxSize = 64 << 20
+)
+
+// A StreamingFunctionRunnerServiceClient sends a RunFunctionRequest using the
+// RunFunctionStream RPC. It encodes the request and sends it in chunks, then
+// decodes the chunked response.
+type StreamingFunctionRunnerServiceClient struct {
+	cc           *grpc.ClientConn
+	chunkSize    int
+	maxSize      int
+	interceptors []grpc.UnaryClientInterceptor
+}
+
+// NewStreamingFunctionRunnerServiceClient returns a client that streams
+// requests in chunks of the supplied size, and receives responses up to the
+// supplied maximum size.
+//
+// The ClientConn's unary interceptors don't see streamed calls, so the client
+// runs each call through the supplied interceptors instead, as if it were a
+// unary call to the RunFunctionStream method. This lets interceptors such as
+// metrics see the whole request and response.
+func NewStreamingFunctionRunnerServiceClient(cc *grpc.ClientConn, chunkSize, maxSize int, is ...grpc.UnaryClientInterceptor) *StreamingFunctionRunnerServiceClient {
+	return &StreamingFunctionRunnerServiceClient{cc: cc, chunkSize: chunkSize, maxSize: maxSize, interceptors: is}
+}
+
+// RunFunction streams the supplied RunFunctionRequest to the function, and
+// returns its streamed RunFunctionResponse.
+func (c *StreamingFunctionRunnerServiceClient) RunFunction(ctx context.Context, req *fnv1.RunFunctionRequest, opts ...grpc.CallOption) (*fnv1.RunFunctionResponse, error) {
+	invoke := func(ctx context.Context, _ string, req, reply any, _ *grpc.ClientConn, opts ...grpc.CallOption) error {
+		return c.runFunctionStream(ctx, req.(*fnv1.RunFunctionRequest), reply.(*fnv1.RunFunctionResponse), opts...) //nolint:forcetypeassert // We always pass these types.
+	}
+
+	// Wrap invoke with each interceptor, so the first runs outermost.
+	for i := len(c.interceptors) - 1; i >= 0; i-- {
+		ic, next := c.interceptors[i], invoke
+		invoke = func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+			return ic(ctx, method, req, reply, cc, next, opts...)
+		}
+	}
+
+	rsp := &fnv1.RunFunctionResponse{}
+	if err := invoke(ctx, fnv1.FunctionRunnerService_RunFunctionStream_FullMethodName, req, rsp, c.cc, opts...); err != nil {
+		return nil, err
+	}
+	return rsp, nil
+}
+
+func (c *StreamingFunctionRunnerServiceClient) runFunctionStream(ctx context.Context, req *fnv1.RunFunctionRequest, rsp *fnv1.RunFunctionResponse, opts ...grpc.CallOption) error {
+	b, err := proto.Marshal(req)
+	if err != nil {
+		return errors.Wrap(err, "cannot encode RunFunctionRequest")
+	}
+
+	// Cancelling the context tells the server we're done with the stream, if
+	// we return before we've received the whole response.
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	s, err := fnv1.NewFunctionRunnerServiceClient(c.cc).RunFunctionStream(ctx, opts...)
+	if err != nil {
+		return err
+	}
+
+	// Send returns io.EOF if the server ended the stream. Recv returns the
+	// reason it did.
+	if err := SendChunks(s.Send, b, c.chunkSize); err != nil && !errors.Is(err, io.EOF) {
+		return err
+	}
+	if err := s.CloseSend(); err != nil {
+		return err
+	}
+
+	b, err = ReceiveChunks(s.Recv, c.maxSize)
+	if err != nil {
+		return err
+	}
+
+	return errors.Wrap(proto.Unmarshal(b, rsp), "cannot decode RunFunctionResponse")
+}
+
+// SendChunks sends the supplied data in chunks of the supplied size.
+func SendChunks(send func(*fnv1.RunFunctionChunk) error, data []byte, size int) error {
+	for len(data) > 0 {
+		n := min(size, len(data))
+		if err := send(&fnv1.RunFunctionChunk{Data: data[:n]}); err != nil {
+			return err
+		}
+		data = data[n:]
+	}
+	return nil
+}
+
+// ReceiveChunks receives chunks until the sender is done, and returns their
+// concatenated data. It returns a ResourceExhausted error if the concatenated
+// data would be larger than the supplied maximum size.
+func ReceiveChunks(recv func() (*fnv1.RunFunctionChunk, error), maxSize int) ([]byte, error) {
+	var data []byte
+	for {
+		c, err := recv()
+		if errors.Is(err, io.EOF) {
+			return data, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		if len(data)+len(c.GetData()) > maxSize {
+			return nil, status.Errorf(codes.ResourceExhausted, "streamed message is larger than the maximum size of %d bytes", maxSize)
+		}
+		data = append(data, c.GetData()...)
+	}
+}
+
+// ServeRunFunctionStream serves a RunFunctionStream RPC by receiving a chunked
+// RunFunctionRequest up to the supplied maximum size, passing it to the
+// supplied FunctionRunnerServiceServer's RunFunction method, and sending its
+// RunFunctionResponse in chunks of the supplied size. Function servers can use
+// it to implement RunFunctionStream.
+func ServeRunFunctionStream(srv fnv1.FunctionRunnerServiceServer, s grpc.BidiStreamingServer[fnv1.RunFunctionChunk, fnv1.RunFunctionChunk], chunkSize, maxSize int) error {
+	b, err := ReceiveChunks(s.Recv, maxSize)
+	if err != nil {
+		return err
+	}
+
+	req := &fnv1.RunFunctionRequest{}
+	if err := proto.Unmarshal(b, req); err != nil {
+		return errors.Wrap(err, "cannot decode RunFunctionRequest")
+	}
+
+	rsp, err := srv.RunFunction(s.Context(), req)
+	if err != nil {
+		return err
+	}
+
+	b, err = proto.Marshal(rsp)
+	if err != nil {
+		return errors.Wrap(err, "cannot encode RunFunctionResponse")
+	}
+
+	return SendChunks(s.Send, b, chunkSize)
+}
@@ -19,11 +19,15 @@ import (
 	"context"
 	"net"
 	"strings"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/testing/protocmp"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -285,7 +289,7 @@ func TestGetClientConn(t *testing.T) {
 
 	// We should be able to create a new connection.
 	t.Run("CreateNewConnection", func(t *testing.T) {
-		conn, err := r.getClientConn(context.Background(), "cool-fn")
+		conn, _, err := r.getClientConn(context.Background(), "cool-fn")
 
 		if diff := cmp.Diff(target, conn.Target()); diff != "" {
 			t.Errorf("\nr.getClientConn(...): -want, +got:\n%s", diff)
@@ -299,7 +303,7 @@ func TestGetClientConn(t *testing.T) {
 	// If we're called again and our FunctionRevision's endpoint hasn't changed,
 	// we should return our cached connection.
 	t.Run("ReuseExistingConnection", func(t *testing.T) {
-		conn, err := r.getClientConn(context.Background(), "cool-fn")
+		conn, _, err := r.getClientConn(context.Background(), "cool-fn")
 
 		if diff := cmp.Diff(target, conn.Target()); diff != "" {
 			t.Errorf("\nr.getClientConn(...): -want, +got:\n%s", diff)
@@ -322,7 +326,7 @@ func TestGetClientConn(t *testing.T) {
 	// If we're called again and our FunctionRevision's endpoint _has_ changed,
 	// we should close our cached connection and create a new one.
 	t.Run("ReplaceExistingConnection", func(t *testing.T) {
-		conn, err := r.getClientConn(context.Background(), "cool-fn")
+		conn, _, err := r.getClientConn(context.Background(), "cool-fn")
 
 		if diff := cmp.Diff(target, conn.Target()); diff != "" {
 			t.Errorf("\nr.getClientConn(...): -want, +got:\n%s", diff)
@@ -333,12 +337,186 @@ func TestGetClientConn(t *testing.T) {
 		}
 	})
 
+	var replaced *grpc.ClientConn
+
+	list := NewListFn(target)
+	c.MockList = func(ctx context.Context, obj client.ObjectList, opts ...client.ListOption) error {
+		if err := list(ctx, obj, opts...); err != nil {
+			return err
+		}
+		if l, ok := obj.(*pkgv1.FunctionRevisionList); ok {
+			l.Items[0].Spec.CallPolicy = &pkgv1.FunctionCallPolicy{Timeout: &metav1.Duration{Duration: 30 * time.Second}}
+		}
+		return nil
+	}
+
+	// If we're called again and our FunctionRevision's call policy has
+	// changed, we should close our cached connection and create a new one.
+	t.Run("ReplaceConnectionWithNewCallPolicy", func(t *testing.T) {
+		r.connsMx.RLock()
+		replaced = r.conns["cool-fn"]
+		r.connsMx.RUnlock()
+
+		conn, _, err := r.getClientConn(context.Background(), "cool-fn")
+
+		if conn == replaced {
+			t.Errorf("\nr.getClientConn(...): want a new connection, got the existing connection")
+		}
+
+		if diff := cmp.Diff(CallPolicy{Timeout: 30 * time.Second}, r.policies["cool-fn"]); diff != "" {
+			t.Errorf("\nr.getClientConn(...): -want policy, +got policy:\n%s", diff)
+		}
+
+		if diff := cmp.Diff(nil, err, test.EquateErrors()); diff != "" {
+			t.Errorf("\nr.getClientConn(...): -want error, +got error:\n%s", diff)
+		}
+	})
+
 	// Close any gRPC clients.
 	if _, err := r.GarbageCollectConnectionsNow(context.Background()); err != nil {
 		t.Logf("Error closing client connections: %s", err)
 	}
 }
 
+func TestRunFunctionStream(t *testing.T) {
+	unary := &fnv1.RunFunctionResponse{Meta: &fnv1.ResponseMeta{Tag: "unary"}}
+	streamed := &fnv1.RunFunctionResponse{
+		Meta: &fnv1.ResponseMeta{Tag: "streamed"},
+		Results: []*fnv1.Result{
+			{Message: "This response is larger than one chunk."},
+		},
+	}
+
+	type params struct {
+		ss   fnv1.FunctionRunnerServiceServer
+		caps []string
+		o    []PackagedFunctionRunnerOption
+	}
+
+	type want struct {
+		rsp *fnv1.RunFunctionResponse
+
+		// The methods our interceptor saw, and the responses it saw.
+		methods []string
+		seen    []*fnv1.RunFunctionResponse
+
+		code codes.Code
+	}
+
+	cases := map[string]struct {
+		reason string
+		params params
+		want   want
+	}{
+		"Streamed": {
+			reason: "We should stream requests larger than the threshold to a function that advertises the streaming capability, and run the streamed call through our interceptors.",
+			params: params{
+				ss:   &MockStreamingFunctionServer{unary: unary, streamed: streamed},
+				caps: []string{"composition", "streaming"},
+				o:    []PackagedFunctionRunnerOption{WithStreamThreshold(0), WithStreamChunkSize(8)},
+			},
+			want: want{
+				rsp:     streamed,
+				methods: []string{fnv1.FunctionRunnerService_RunFunctionStream_FullMethodName},
+				seen:    []*fnv1.RunFunctionResponse{streamed},
+			},
+		},
+		"StreamedResponseTooLarge": {
+			reason: "We should return a ResourceExhausted error if a streamed response is larger than the maximum stream size.",
+			params: params{
+				ss:   &MockStreamingFunctionServer{unary: unary, streamed: streamed},
+				caps: []string{"composition", "streaming"},
+				o:    []PackagedFunctionRunnerOption{WithStreamThreshold(0), WithMaxStreamSize(8)},
+			},
+			want: want{
+				methods: []string{fnv1.FunctionRunnerService_RunFunctionStream_FullMethodName},
+				seen:    []*fnv1.RunFunctionResponse{{}},
+				code:    codes.ResourceExhausted,
+			},
+		},
+		"BelowThreshold": {
+			reason: "We should send requests smaller than the threshold in one message.",
+			params: params{
+				ss:   &MockStreamingFunctionServer{unary: unary, streamed: streamed},
+				caps: []string{"composition", "streaming"},
+			},
+			want: want{
+				rsp:     unary,
+				methods: []string{fnv1.FunctionRunnerService_RunFunction_FullMethodName},
+				seen:    []*fnv1.RunFunctionResponse{unary},
+			},
+		},
+		"NotAdvertised": {
+			reason: "We should send requests in one message to a function that doesn't advertise the streaming capability.",
+			params: params{
+				ss:   &MockStreamingFunctionServer{unary: unary, streamed: streamed},
+				caps: []string{"composition"},
+				o:    []PackagedFunctionRunnerOption{WithStreamThreshold(0)},
+			},
+			want: want{
+				rsp:     unary,
+				methods: []string{fnv1.FunctionRunnerService_RunFunction_FullMethodName},
+				seen:    []*fnv1.RunFunctionResponse{unary},
+			},
+		},
+		"Unimplemented": {
+			reason: "We should fall back to sending requests in one message if the function doesn't implement streaming.",
+			params: params{
+				ss:   &MockFunctionServer{rsp: unary},
+				caps: []string{"composition", "streaming"},
+				o:    []PackagedFunctionRunnerOption{WithStreamThreshold(0)},
+			},
+			want: want{
+				rsp: unary,
+				methods: []string{
+					fnv1.FunctionRunnerService_RunFunctionStream_FullMethodName,
+					fnv1.FunctionRunnerService_RunFunction_FullMethodName,
+				},
+				seen: []*fnv1.RunFunctionResponse{{}, unary},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			lis := NewGRPCServer(t, tc.params.ss)
+			defer lis.Close()
+
+			target := strings.Replace(lis.Addr().String(), "127.0.0.1", "dns:///localhost", 1)
+			c := &test.MockClient{
+				MockList: NewListFn(target, tc.params.caps...),
+			}
+
+			ic := &MockInterceptorCreator{}
+			r := NewPackagedFunctionRunner(c, append(tc.params.o, WithInterceptorCreators(ic))...)
+			req := &fnv1.RunFunctionRequest{Meta: &fnv1.RequestMeta{Tag: "this request is larger than one chunk"}}
+
+			rsp, err := r.RunFunction(context.Background(), "cool-fn", req)
+
+			if diff := cmp.Diff(tc.want.code, status.Code(err)); diff != "" {
+				t.Errorf("\n%s\nr.RunFunction(...): -want code, +got code:\n%s", tc.reason, diff)
+			}
+
+			if diff := cmp.Diff(tc.want.rsp, rsp, protocmp.Transform()); diff != "" {
+				t.Errorf("\n%s\nr.RunFunction(...): -want, +got:\n%s", tc.reason, diff)
+			}
+
+			if diff := cmp.Diff(tc.want.methods, ic.methods); diff != "" {
+				t.Errorf("\n%s\nr.RunFunction(...): -want intercepted methods, +got:\n%s", tc.reason, diff)
+			}
+
+			if diff := cmp.Diff(tc.want.seen, ic.seen, protocmp.Transform()); diff != "" {
+				t.Errorf("\n%s\nr.RunFunction(...): -want intercepted responses, +got:\n%s", tc.reason, diff)
+			}
+
+			// Close any gRPC clients.
+			if _, err := r.GarbageCollectConnectionsNow(context.Background()); err != nil {
+				t.Logf("Error closing client connections: %s", err)
+			}
+		})
+	}
+}
+
 func TestGarbageCollectConnectionsNow(t *testing.T) {
 	// TestRunFunction exercises most of the GarbageCollectConnectionsNow code.
 	// Here we just test some cases that don't fit well in our usual
@@ -406,7 +584,7 @@ func TestGarbageCollectConnectionsNow(t *testing.T) {
 	})
 }
 
-func NewListFn(target string) test.MockListFn {
+func NewListFn(target string, caps ...string) test.MockListFn {
 	return test.NewMockListFn(nil, func(obj client.ObjectList) error {
 		l, ok := obj.(*pkgv1.FunctionRevisionList)
 		if !ok {
@@ -427,6 +605,9 @@ func NewListFn(target string) test.MockListFn {
 				},
 				Status: pkgv1.FunctionRevisionStatus{
 					Endpoint: target,
+					PackageRevisionStatus: pkgv1.PackageRevisionStatus{
+						Capabilities: caps,
+					},
 				},
 			},
 		}
@@ -490,6 +671,44 @@ func (s *MockFunctionServer) RunFunction(context.Context, *fnv1.RunFunctionReque
 	return s.rsp, s.err
 }
 
+// A MockStreamingFunctionServer returns its unary response from RunFunction,
+// and its streamed response from RunFunctionStream.
+type MockStreamingFunctionServer struct {
+	fnv1.UnimplementedFunctionRunnerServiceServer
+
+	unary    *fnv1.RunFunctionResponse
+	streamed *fnv1.RunFunctionResponse
+}
+
+func (s *MockStreamingFunctionServer) RunFunction(context.Context, *fnv1.RunFunctionRequest) (*fnv1.RunFunctionResponse, error) {
+	return s.unary, nil
+}
+
+func (s *MockStreamingFunctionServer) RunFunctionStream(ss grpc.BidiStreamingServer[fnv1.RunFunctionChunk, fnv1.RunFunctionChunk]) error {
+	return ServeRunFunctionStream(&MockFunctionServer{rsp: s.streamed}, ss, 8, DefaultStreamMaxSize)
+}
+
+// A MockInterceptorCreator creates interceptors that record the methods they
+// intercept, and the responses they see.
+type MockInterceptorCreator struct {
+	mx      sync.Mutex
+	methods []string
+	seen    []*fnv1.RunFunctionResponse
+}
+
+func (c *MockInterceptorCreator) CreateInterceptor(_, _ string) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		err := invoker(ctx, method, req, reply, cc, opts...)
+
+		c.mx.Lock()
+		defer c.mx.Unlock()
+		c.methods = append(c.methods, method)
+		c.seen = append(c.seen, reply.(*fnv1.RunFunctionResponse)) //nolint:forcetypeassert // Will always be a response.
+
+		return err
+	}
+}
+
 type MockBetaFunctionServer struct {
 	fnv1beta1.UnimplementedFunctionRunnerServiceServer
 
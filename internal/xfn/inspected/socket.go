@@ -89,13 +89,7 @@ func (e *SocketPipelineInspector) EmitRequest(ctx context.Context, req *fnv1.Run
 	}
 
 	// Create a copy with sensitive data redacted for security.
-	sanitizedReq := proto.CloneOf[*fnv1.RunFunctionRequest](req)
-
-	redactCredentials(sanitizedReq.GetCredentials())
-	sanitizeState(sanitizedReq.GetObserved())
-	sanitizeState(sanitizedReq.GetDesired())
-	sanitizeRequiredResources(sanitizedReq.GetRequiredResources())
-	sanitizeRequiredResources(sanitizedReq.GetExtraResources()) //nolint:staticcheck // Supporting deprecated field for backward compatibility
+	sanitizedReq := SanitizeRequest(req)
 
 	// Serialize the request to JSON bytes.
 	reqBytes, err := protojson.Marshal(sanitizedReq)
@@ -118,9 +112,7 @@ func (e *SocketPipelineInspector) EmitResponse(ctx context.Context, rsp *fnv1.Ru
 	if meta == nil {
 		return errors.New("step metadata is required to emit pipeline response")
 	}
-	sanitizedRsp := proto.CloneOf[*fnv1.RunFunctionResponse](rsp)
-
-	sanitizeState(sanitizedRsp.GetDesired())
+	sanitizedRsp := SanitizeResponse(rsp)
 
 	errMsg := ""
 	if fnErr != nil {
@@ -156,6 +148,30 @@ func (e *SocketPipelineInspector) Close() error {
 	return nil
 }
 
+// SanitizeRequest returns a copy of the supplied request with sensitive data
+// redacted. It redacts credentials, connection details, and the data of any
+// Secrets, preserving their keys.
+func SanitizeRequest(req *fnv1.RunFunctionRequest) *fnv1.RunFunctionRequest {
+	sanitized := proto.CloneOf(req)
+
+	redactCredentials(sanitized.GetCredentials())
+	sanitizeState(sanitized.GetObserved())
+	sanitizeState(sanitized.GetDesired())
+	sanitizeRequiredResources(sanitized.GetRequiredResources())
+	sanitizeRequiredResources(sanitized.GetExtraResources()) //nolint:staticcheck // Supporting deprecated field for backward compatibility
+
+	return sanitized
+}
+
+// SanitizeResponse returns a copy of the supplied response with sensitive data
+// redacted. It redacts connection details and the data of any desired Secrets,
+// preserving their keys.
+func SanitizeResponse(rsp *fnv1.RunFunctionResponse) *fnv1.RunFunctionResponse {
+	sanitized := proto.CloneOf(rsp)
+	sanitizeState(sanitized.GetDesired())
+	return sanitized
+}
+
 // redactCredentials redacts credential data values while preserving the keys.
 // Values are replaced with []byte(redactedValue).
 func redactCredentials(credentials map[string]*fnv1.Credentials) {
@@ -0,0 +1,283 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+this file except in compliance with the License. You may obtain a copy of the
+License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed
+under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+CONDITIONS OF ANY KIND, either express or implied. See the License for the
+specific language governing permissions and limitations under the License.
+*/
+
+package xfn
+
+import (
+	"container/list"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"slices"
+	"sync"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/logging"
+
+	pkgmetav1 "github.com/crossplane/crossplane/apis/v2/pkg/meta/v1"
+	fnv1 "github.com/crossplane/crossplane/v2/proto/fn/v1"
+)
+
+// DefaultMaxObservedStates is the default number of acknowledged observed
+// states an ObservedDeltaFunctionRunner keeps.
+const DefaultMaxObservedStates = 1000
+
+// An ObservedDeltaFunctionRunner wraps an underlying FunctionRunner. It sends
+// a function only the changes to the observed state since the last observed
+// state the function acknowledged. Functions acknowledge an observed state by
+// returning its hash in their response's meta.acknowledged_observed_hash.
+// Functions that don't acknowledge observed states always get the full
+// observed state.
+//
+// Only functions whose active revision advertises the observed-delta
+// capability are sent deltas, or told that Crossplane supports them. Requests
+// to other functions are passed to the wrapped FunctionRunner unchanged,
+// without hashing their observed state.
+//
+// Observed states are tracked per function and composite resource. Requests
+// without an observed composite resource (e.g. from an Operation) are passed
+// to the wrapped FunctionRunner unchanged.
+type ObservedDeltaFunctionRunner struct {
+	wrapped FunctionRunner
+	caps    CapabilityChecker
+	max     int
+	log     logging.Logger
+
+	mx     sync.Mutex
+	lru    *list.List
+	states map[string]*list.Element
+}
+
+// An acknowledged observed state.
+type observedState struct {
+	key   string
+	hash  string
+	state *fnv1.State
+}
+
+// An ObservedDeltaFunctionRunnerOption configures an
+// ObservedDeltaFunctionRunner.
+type ObservedDeltaFunctionRunnerOption func(r *ObservedDeltaFunctionRunner)
+
+// WithMaxObservedStates configures how many acknowledged observed states the
+// ObservedDeltaFunctionRunner keeps. It forgets the least recently used state
+// when it has too many. Functions get the full observed state for a composite
+// resource whose state was forgotten.
+func WithMaxObservedStates(n int) ObservedDeltaFunctionRunnerOption {
+	return func(r *ObservedDeltaFunctionRunner) {
+		r.max = n
+	}
+}
+
+// WithObservedDeltaLogger configures the logger the
+// ObservedDeltaFunctionRunner should use.
+func WithObservedDeltaLogger(l logging.Logger) ObservedDeltaFunctionRunnerOption {
+	return func(r *ObservedDeltaFunctionRunner) {
+		r.log = l
+	}
+}
+
+// NewObservedDeltaFunctionRunner returns a FunctionRunner that sends functions
+// observed state deltas. It uses the supplied CapabilityChecker to determine
+// which functions support observed state deltas.
+func NewObservedDeltaFunctionRunner(wrapped FunctionRunner, c CapabilityChecker, o ...ObservedDeltaFunctionRunnerOption) *ObservedDeltaFunctionRunner {
+	r := &ObservedDeltaFunctionRunner{
+		wrapped: wrapped,
+		caps:    c,
+		max:     DefaultMaxObservedStates,
+		log:     logging.NewNopLogger(),
+		lru:     list.New(),
+		states:  make(map[string]*list.Element),
+	}
+
+	for _, fn := range o {
+		fn(r)
+	}
+
+	return r
+}
+
+// RunFunction runs the named function. It sends the function the changes to
+// the observed state if the function acknowledged a previous observed state.
+// The supplied request is restored before RunFunction returns.
+func (r *ObservedDeltaFunctionRunner) RunFunction(ctx context.Context, name string, req *fnv1.RunFunctionRequest) (*fnv1.RunFunctionResponse, error) {
+	uid := req.GetObserved().GetComposite().GetResource().GetFields()["metadata"].GetStructValue().GetFields()["uid"].GetStringValue()
+	if uid == "" {
+		return r.wrapped.RunFunction(ctx, name, req)
+	}
+
+	if err := r.caps.CheckCapabilities(ctx, []string{pkgmetav1.FunctionCapabilityObservedDelta}, name); err != nil {
+		return r.wrapped.RunFunction(ctx, name, req)
+	}
+
+	key := name + "/" + uid
+	observed := req.GetObserved()
+
+	base := r.get(key)
+
+	// Callers usually send the same observed state to several functions, and
+	// to the same function several times while satisfying its requirements.
+	// Don't hash it again if we already did.
+	hash := ""
+	if base != nil && base.state == observed {
+		hash = base.hash
+	}
+	if hash == "" {
+		h, err := hashState(observed)
+		if err != nil {
+			r.log.Debug("Cannot hash observed state; sending the full observed state", "function", name, "error", err)
+			return r.wrapped.RunFunction(ctx, name, req)
+		}
+		hash = h
+	}
+
+	meta := req.GetMeta()
+	if meta == nil {
+		req.Meta = &fnv1.RequestMeta{}
+	}
+	caps := req.Meta.GetCapabilities()
+	req.Meta.Capabilities = append(slices.Clip(caps), fnv1.Capability_CAPABILITY_OBSERVED_DELTA)
+	req.Meta.ObservedHash = hash
+
+	defer func() {
+		req.Meta.Capabilities = caps
+		req.Meta.ObservedHash = ""
+		if meta == nil {
+			req.Meta = nil
+		}
+		req.Observed = observed
+		req.ObservedDelta = nil
+	}()
+
+	if base != nil {
+		req.Observed = nil
+		req.ObservedDelta = diffState(base.hash, base.state, observed)
+	}
+
+	rsp, err := r.wrapped.RunFunction(ctx, name, req)
+
+	// The function doesn't have the base observed state anymore, for example
+	// because it restarted. Send it the full observed state.
+	if base != nil && status.Code(err) == codes.FailedPrecondition {
+		r.log.Debug("Function doesn't have the base of an observed state delta; sending the full observed state", "function", name, "error", err)
+		req.Observed = observed
+		req.ObservedDelta = nil
+		rsp, err = r.wrapped.RunFunction(ctx, name, req)
+	}
+
+	if err != nil {
+		r.forget(key)
+		return rsp, err
+	}
+
+	if rsp.GetMeta().GetAcknowledgedObservedHash() != hash {
+		r.forget(key)
+		return rsp, nil
+	}
+
+	r.put(&observedState{key: key, hash: hash, state: observed})
+
+	return rsp, nil
+}
+
+func (r *ObservedDeltaFunctionRunner) get(key string) *observedState {
+	r.mx.Lock()
+	defer r.mx.Unlock()
+
+	e, ok := r.states[key]
+	if !ok {
+		return nil
+	}
+	r.lru.MoveToFront(e)
+
+	return e.Value.(*observedState) //nolint:forcetypeassert // We only put *observedState in the list.
+}
+
+func (r *ObservedDeltaFunctionRunner) put(s *observedState) {
+	r.mx.Lock()
+	defer r.mx.Unlock()
+
+	if e, ok := r.states[s.key]; ok {
+		e.Value = s
+		r.lru.MoveToFront(e)
+		return
+	}
+
+	r.states[s.key] = r.lru.PushFront(s)
+
+	for r.lru.Len() > r.max {
+		e := r.lru.Back()
+		r.lru.Remove(e)
+		delete(r.states, e.Value.(*observedState).key) //nolint:forcetypeassert // We only put *observedState in the list.
+	}
+}
+
+func (r *ObservedDeltaFunctionRunner) forget(key string) {
+	r.mx.Lock()
+	defer r.mx.Unlock()
+
+	if e, ok := r.states[key]; ok {
+		r.lru.Remove(e)
+		delete(r.states, key)
+	}
+}
+
+// hashState returns an opaque string identifying the supplied State.
+func hashState(s *fnv1.State) (string, error) {
+	b, err := proto.MarshalOptions{Deterministic: true}.Marshal(s)
+	if err != nil {
+		return "", err
+	}
+
+	h := sha256.Sum256(b)
+
+	return hex.EncodeToString(h[:]), nil
+}
+
+// diffState returns the changes from the supplied base State to the supplied
+// State.
+func diffState(baseHash string, base, s *fnv1.State) *fnv1.StateDelta {
+	d := &fnv1.StateDelta{
+		BaseHash:  baseHash,
+		Resources: make(map[string]*fnv1.Resource),
+	}
+
+	if base == s {
+		return d
+	}
+
+	if !proto.Equal(base.GetComposite(), s.GetComposite()) {
+		d.Composite = s.GetComposite()
+	}
+
+	for name, cd := range s.GetResources() {
+		if !proto.Equal(base.GetResources()[name], cd) {
+			d.Resources[name] = cd
+		}
+	}
+
+	for name := range base.GetResources() {
+		if _, ok := s.GetResources()[name]; !ok {
+			d.RemovedResources = append(d.RemovedResources, name)
+		}
+	}
+
+	slices.Sort(d.RemovedResources)
+
+	return d
+}
@@ -0,0 +1,239 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+this file except in compliance with the License. You may obtain a copy of the
+License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed
+under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+CONDITIONS OF ANY KIND, either express or implied. See the License for the
+specific language governing permissions and limitations under the License.
+*/
+
+package xfn
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/testing/protocmp"
+	"google.golang.org/protobuf/types/known/structpb"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/errors"
+
+	fnv1 "github.com/crossplane/crossplane/v2/proto/fn/v1"
+)
+
+// The kind of observed state a function received.
+type received string
+
+const (
+	receivedFull  received = "Full"
+	receivedDelta received = "Delta"
+)
+
+// A MockDeltaFunction keeps the observed states it acknowledges, and applies
+// observed state deltas to them.
+type MockDeltaFunction struct {
+	// Whether to acknowledge observed states.
+	ack bool
+
+	// Whether to forget observed states before each call.
+	forget bool
+
+	states   map[string]*fnv1.State
+	received []received
+	observed []*fnv1.State
+
+	// Whether each request told the function Crossplane supports observed
+	// state deltas, and identified its observed state.
+	advertised []bool
+}
+
+func (f *MockDeltaFunction) RunFunction(_ context.Context, _ string, req *fnv1.RunFunctionRequest) (*fnv1.RunFunctionResponse, error) {
+	if f.forget {
+		f.states = map[string]*fnv1.State{}
+	}
+
+	observed := req.GetObserved()
+
+	advertised := slices.Contains(req.GetMeta().GetCapabilities(), fnv1.Capability_CAPABILITY_OBSERVED_DELTA) && req.GetMeta().GetObservedHash() != ""
+	f.advertised = append(f.advertised, advertised)
+
+	if d := req.GetObservedDelta(); d != nil {
+		f.received = append(f.received, receivedDelta)
+
+		base, ok := f.states[d.GetBaseHash()]
+		if !ok {
+			return nil, status.Error(codes.FailedPrecondition, "unknown base observed state")
+		}
+
+		observed = proto.Clone(base).(*fnv1.State) //nolint:forcetypeassert // Clone returns the supplied type.
+		if d.GetComposite() != nil {
+			observed.Composite = d.GetComposite()
+		}
+		if observed.Resources == nil {
+			observed.Resources = map[string]*fnv1.Resource{}
+		}
+		for name, r := range d.GetResources() {
+			observed.Resources[name] = r
+		}
+		for _, name := range d.GetRemovedResources() {
+			delete(observed.Resources, name)
+		}
+	} else {
+		f.received = append(f.received, receivedFull)
+	}
+
+	f.observed = append(f.observed, observed)
+
+	if !f.ack {
+		return &fnv1.RunFunctionResponse{}, nil
+	}
+
+	f.states[req.GetMeta().GetObservedHash()] = observed
+
+	return &fnv1.RunFunctionResponse{Meta: &fnv1.ResponseMeta{AcknowledgedObservedHash: req.GetMeta().GetObservedHash()}}, nil
+}
+
+func TestObservedDeltaFunctionRunner(t *testing.T) {
+	xr := func(uid, value string) *fnv1.Resource {
+		return &fnv1.Resource{Resource: &structpb.Struct{Fields: map[string]*structpb.Value{
+			"metadata": structpb.NewStructValue(&structpb.Struct{Fields: map[string]*structpb.Value{
+				"uid": structpb.NewStringValue(uid),
+			}}),
+			"spec": structpb.NewStringValue(value),
+		}}}
+	}
+	cd := func(value string) *fnv1.Resource {
+		return &fnv1.Resource{Resource: &structpb.Struct{Fields: map[string]*structpb.Value{
+			"spec": structpb.NewStringValue(value),
+		}}}
+	}
+
+	s1 := &fnv1.State{
+		Composite: xr("cool-uid", "a"),
+		Resources: map[string]*fnv1.Resource{
+			"unchanged": cd("a"),
+			"changed":   cd("a"),
+			"removed":   cd("a"),
+		},
+	}
+	s2 := &fnv1.State{
+		Composite: xr("cool-uid", "b"),
+		Resources: map[string]*fnv1.Resource{
+			"unchanged": cd("a"),
+			"changed":   cd("b"),
+			"added":     cd("a"),
+		},
+	}
+
+	advertises := CapabilityCheckerFn(func(_ context.Context, _ []string, _ ...string) error { return nil })
+
+	type want struct {
+		received   []received
+		advertised []bool
+	}
+
+	cases := map[string]struct {
+		reason   string
+		fn       *MockDeltaFunction
+		caps     CapabilityChecker
+		observed []*fnv1.State
+		want     want
+	}{
+		"Acknowledged": {
+			reason:   "A function that acknowledges an observed state should receive only changes to it.",
+			fn:       &MockDeltaFunction{ack: true},
+			caps:     advertises,
+			observed: []*fnv1.State{s1, s2, s2},
+			want: want{
+				received:   []received{receivedFull, receivedDelta, receivedDelta},
+				advertised: []bool{true, true, true},
+			},
+		},
+		"NotAcknowledged": {
+			reason:   "A function that doesn't acknowledge observed states should always receive the full observed state.",
+			fn:       &MockDeltaFunction{},
+			caps:     advertises,
+			observed: []*fnv1.State{s1, s2},
+			want: want{
+				received:   []received{receivedFull, receivedFull},
+				advertised: []bool{true, true},
+			},
+		},
+		"BaseForgotten": {
+			reason:   "A function that forgot the base of a delta should receive the full observed state.",
+			fn:       &MockDeltaFunction{ack: true, forget: true},
+			caps:     advertises,
+			observed: []*fnv1.State{s1, s2},
+			want: want{
+				received:   []received{receivedFull, receivedDelta, receivedFull},
+				advertised: []bool{true, true, true},
+			},
+		},
+		"NoComposite": {
+			reason:   "A function should receive the full observed state if there's no observed composite resource.",
+			fn:       &MockDeltaFunction{ack: true},
+			caps:     advertises,
+			observed: []*fnv1.State{{}, {}},
+			want: want{
+				received:   []received{receivedFull, receivedFull},
+				advertised: []bool{false, false},
+			},
+		},
+		"CapabilityNotAdvertised": {
+			reason: "A function that doesn't advertise the observed-delta capability should always receive the full, unhashed observed state.",
+			fn:     &MockDeltaFunction{ack: true},
+			caps: CapabilityCheckerFn(func(_ context.Context, _ []string, _ ...string) error {
+				return errors.New("missing capability")
+			}),
+			observed: []*fnv1.State{s1, s2},
+			want: want{
+				received:   []received{receivedFull, receivedFull},
+				advertised: []bool{false, false},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			tc.fn.states = map[string]*fnv1.State{}
+			r := NewObservedDeltaFunctionRunner(tc.fn, tc.caps)
+
+			for _, o := range tc.observed {
+				req := &fnv1.RunFunctionRequest{Observed: o}
+				if _, err := r.RunFunction(context.Background(), "cool-fn", req); err != nil {
+					t.Fatalf("\n%s\nr.RunFunction(...): %v", tc.reason, err)
+				}
+
+				// The function should be able to rebuild the observed state.
+				got := tc.fn.observed[len(tc.fn.observed)-1]
+				if diff := cmp.Diff(o, got, protocmp.Transform()); diff != "" {
+					t.Errorf("\n%s\nr.RunFunction(...): -want observed, +got observed:\n%s", tc.reason, diff)
+				}
+
+				// The request should be restored.
+				if diff := cmp.Diff(&fnv1.RunFunctionRequest{Observed: o}, req, protocmp.Transform()); diff != "" {
+					t.Errorf("\n%s\nr.RunFunction(...): -want request, +got request:\n%s", tc.reason, diff)
+				}
+			}
+
+			if diff := cmp.Diff(tc.want.received, tc.fn.received); diff != "" {
+				t.Errorf("\n%s\nr.RunFunction(...): -want received, +got received:\n%s", tc.reason, diff)
+			}
+
+			if diff := cmp.Diff(tc.want.advertised, tc.fn.advertised); diff != "" {
+				t.Errorf("\n%s\nr.RunFunction(...): -want advertised, +got advertised:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
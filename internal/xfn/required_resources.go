@@ -32,9 +32,10 @@ import (
 	fnv1 "github.com/crossplane/crossplane/v2/proto/fn/v1"
 )
 
-// MaxRequirementsIterations is the maximum number of times a Function should be
-// called, limiting the number of times it can request for required resources,
-// capped for safety.
+// MaxRequirementsIterations is the default maximum number of times a Function
+// should be called, limiting the number of times it can request for required
+// resources, capped for safety. Pipeline steps can override it using
+// ContextWithMaxRequirementsIterations.
 const MaxRequirementsIterations = 5
 
 // An RequiredResourcesFetcher gets required resources matching a selector.
@@ -65,18 +66,24 @@ func NewFetchingFunctionRunner(r FunctionRunner, rf RequiredResourcesFetcher, sf
 }
 
 // RunFunction runs a function, repeatedly fetching any required resources it asks
-// for. The function may be run up to MaxRequirementsIterations times.
+// for. The function may be run up to MaxRequirementsIterations times, unless the
+// supplied context configures a different limit.
 func (c *FetchingFunctionRunner) RunFunction(ctx context.Context, name string, req *fnv1.RunFunctionRequest) (*fnv1.RunFunctionResponse, error) { //nolint:gocognit // It's essentially one loop with a series of flat conditionals; it doesn't read as complex.
 	// The requirements returned at the previous iteration.
 	var requirements *fnv1.Requirements
 
+	// The requirements returned at each iteration, to explain what happened
+	// if they don't stabilize.
+	limit := maxRequirementsIterations(ctx)
+	iterations := make([]*fnv1.Requirements, 0, limit+1)
+
 	// The resources and schemas the function was given up front - e.g. a
 	// Composition's bootstrap requirements, or resources seeded from a previous
 	// reconcile. We preserve them across iterations.
 	bootstrapResources := maps.Clone(req.GetRequiredResources())
 	bootstrapSchemas := maps.Clone(req.GetRequiredSchemas())
 
-	for i := int32(0); i <= MaxRequirementsIterations; i++ {
+	for i := int32(0); i <= limit; i++ {
 		// Update the iteration counter in the context for downstream components.
 		iterCtx := step.ContextWithStepIteration(ctx, i)
 
@@ -94,6 +101,7 @@ func (c *FetchingFunctionRunner) RunFunction(ctx context.Context, name string, r
 		}
 
 		reqs := rsp.GetRequirements()
+		iterations = append(iterations, reqs)
 		if proto.Equal(reqs, requirements) {
 			// The requirements stabilized, the function is done.
 			return rsp, nil
@@ -176,7 +184,7 @@ func (c *FetchingFunctionRunner) RunFunction(ctx context.Context, name string, r
 		req.Context = rsp.GetContext()
 	}
 	// The requirements didn't stabilize after the maximum number of iterations.
-	return nil, errors.Errorf("requirements didn't stabilize after the maximum number of iterations (%d)", MaxRequirementsIterations)
+	return nil, &RequirementsUnstableError{MaxIterations: limit, Iterations: iterations}
 }
 
 // required is the resources and schemas a function's requirements resolve to.
@@ -0,0 +1,204 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+this file except in compliance with the License. You may obtain a copy of the
+License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed
+under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+CONDITIONS OF ANY KIND, either express or implied. See the License for the
+specific language governing permissions and limitations under the License.
+*/
+
+package xfn
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/errors"
+
+	xpv2 "github.com/crossplane/crossplane/apis/v2/core/v2"
+	"github.com/crossplane/crossplane/v2/internal/xfn/breaker"
+	fnv1 "github.com/crossplane/crossplane/v2/proto/fn/v1"
+)
+
+// ReasonRequirementsUnstable indicates that a resource couldn't be reconciled
+// because a function's requirements didn't stabilize.
+const ReasonRequirementsUnstable xpv2.ConditionReason = "RequirementsUnstable"
+
+type contextKey string
+
+const contextKeyMaxRequirementsIterations contextKey = "max-requirements-iterations"
+
+// ContextWithMaxRequirementsIterations returns a context that limits how many
+// times a FetchingFunctionRunner calls a function to satisfy its requirements.
+func ContextWithMaxRequirementsIterations(ctx context.Context, n int32) context.Context {
+	return context.WithValue(ctx, contextKeyMaxRequirementsIterations, n)
+}
+
+// maxRequirementsIterations returns the maximum number of requirements
+// iterations configured by the supplied context, or MaxRequirementsIterations.
+func maxRequirementsIterations(ctx context.Context) int32 {
+	if ctx == nil {
+		return MaxRequirementsIterations
+	}
+	if n, ok := ctx.Value(contextKeyMaxRequirementsIterations).(int32); ok {
+		return n
+	}
+	return MaxRequirementsIterations
+}
+
+// A RequirementsUnstableError is returned when a function's requirements don't
+// stabilize within the maximum number of iterations.
+type RequirementsUnstableError struct {
+	// MaxIterations is the maximum number of iterations.
+	MaxIterations int32
+
+	// Iterations are the requirements the function returned at each
+	// iteration.
+	Iterations []*fnv1.Requirements
+}
+
+// maxUnstableRequirements is the maximum number of requirements a
+// RequirementsUnstableError's message describes.
+const maxUnstableRequirements = 10
+
+// Error returns the error message. It lists the requirements that kept
+// changing, and how they changed between the last two iterations. It doesn't
+// describe every iteration, so the message doesn't grow with the maximum
+// number of iterations.
+func (e *RequirementsUnstableError) Error() string {
+	b := &strings.Builder{}
+	fmt.Fprintf(b, "requirements didn't stabilize after the maximum number of iterations (%d)", e.MaxIterations)
+
+	if changing := e.Changing(); len(changing) > 0 {
+		fmt.Fprintf(b, "; requirements that kept changing: %s", strings.Join(truncate(changing), ", "))
+	}
+
+	if n := len(e.Iterations); n > 1 {
+		prev := requirementSelectors(e.Iterations[n-2])
+		cur := requirementSelectors(e.Iterations[n-1])
+		keys := slices.Collect(maps.Keys(cur))
+		for k := range prev {
+			if _, ok := cur[k]; !ok {
+				keys = append(keys, k)
+			}
+		}
+		slices.Sort(keys)
+
+		changes := make([]string, 0, len(keys))
+		for _, k := range keys {
+			if prev[k] == cur[k] {
+				continue
+			}
+			changes = append(changes, fmt.Sprintf("%s from %s to %s", k, describeOrNothing(prev, k), describeOrNothing(cur, k)))
+		}
+		if len(changes) > 0 {
+			fmt.Fprintf(b, "; last iteration changed %s", strings.Join(truncate(changes), ", "))
+		}
+	}
+
+	return b.String()
+}
+
+// Changing returns the requirements that changed between any two consecutive
+// iterations, e.g. resources[cool-resource].
+func (e *RequirementsUnstableError) Changing() []string {
+	changing := map[string]bool{}
+	for i := 1; i < len(e.Iterations); i++ {
+		prev := requirementSelectors(e.Iterations[i-1])
+		cur := requirementSelectors(e.Iterations[i])
+		for k, v := range cur {
+			if prev[k] != v {
+				changing[k] = true
+			}
+		}
+		for k := range prev {
+			if _, ok := cur[k]; !ok {
+				changing[k] = true
+			}
+		}
+	}
+	return slices.Sorted(maps.Keys(changing))
+}
+
+// IsRequirementsUnstable returns true if the supplied error was caused by a
+// function's requirements not stabilizing.
+func IsRequirementsUnstable(err error) bool {
+	e := &RequirementsUnstableError{}
+	return errors.As(err, &e)
+}
+
+// ReconcileError returns a ReconcileError condition for the supplied error. Its
+// reason is ReasonRequirementsUnstable if the error was caused by a function's
+// requirements not stabilizing, or breaker.ReasonCircuitOpen if it was caused
+// by a function's circuit being open.
+func ReconcileError(err error) xpv2.Condition {
+	c := breaker.ReconcileError(err)
+	if IsRequirementsUnstable(err) {
+		c.Reason = ReasonRequirementsUnstable
+	}
+	return c
+}
+
+// truncate returns the first maxUnstableRequirements of the supplied strings,
+// noting how many it omitted.
+func truncate(s []string) []string {
+	if len(s) <= maxUnstableRequirements {
+		return s
+	}
+	return append(slices.Clone(s[:maxUnstableRequirements]), fmt.Sprintf("and %d more", len(s)-maxUnstableRequirements))
+}
+
+// describeOrNothing returns the supplied requirement's selector, or "nothing"
+// if it wasn't required.
+func describeOrNothing(sels map[string]string, k string) string {
+	if d, ok := sels[k]; ok {
+		return d
+	}
+	return "nothing"
+}
+
+// requirementSelectors returns a description of each of the supplied
+// requirements' selectors, keyed by requirement.
+func requirementSelectors(r *fnv1.Requirements) map[string]string {
+	sels := map[string]string{}
+	for name, sel := range r.GetResources() {
+		sels[fmt.Sprintf("resources[%s]", name)] = describeResourceSelector(sel)
+	}
+	for name, sel := range r.GetExtraResources() { //nolint:staticcheck // Supporting deprecated field for backward compatibility.
+		sels[fmt.Sprintf("extraResources[%s]", name)] = describeResourceSelector(sel)
+	}
+	for name, sel := range r.GetSchemas() {
+		sels[fmt.Sprintf("schemas[%s]", name)] = fmt.Sprintf("{%s, Kind=%s}", sel.GetApiVersion(), sel.GetKind())
+	}
+	return sels
+}
+
+func describeResourceSelector(sel *fnv1.ResourceSelector) string {
+	b := &strings.Builder{}
+	fmt.Fprintf(b, "{%s, Kind=%s", sel.GetApiVersion(), sel.GetKind())
+	if sel.Namespace != nil {
+		fmt.Fprintf(b, ", namespace=%s", sel.GetNamespace())
+	}
+	switch m := sel.GetMatch().(type) {
+	case *fnv1.ResourceSelector_MatchName:
+		fmt.Fprintf(b, ", name=%s", m.MatchName)
+	case *fnv1.ResourceSelector_MatchLabels:
+		labels := m.MatchLabels.GetLabels()
+		pairs := make([]string, 0, len(labels))
+		for _, k := range slices.Sorted(maps.Keys(labels)) {
+			pairs = append(pairs, k+"="+labels[k])
+		}
+		fmt.Fprintf(b, ", labels=%s", strings.Join(pairs, ","))
+	}
+	b.WriteString("}")
+	return b.String()
+}
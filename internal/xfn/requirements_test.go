@@ -0,0 +1,232 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+this file except in compliance with the License. You may obtain a copy of the
+License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed
+under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+CONDITIONS OF ANY KIND, either express or implied. See the License for the
+specific language governing permissions and limitations under the License.
+*/
+
+package xfn
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/protobuf/testing/protocmp"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/errors"
+
+	xpv2 "github.com/crossplane/crossplane/apis/v2/core/v2"
+	fnv1 "github.com/crossplane/crossplane/v2/proto/fn/v1"
+)
+
+func TestFetchingFunctionRunnerMaxIterations(t *testing.T) {
+	// A function that requires a different resource each time it's called.
+	requirements := func(i int) *fnv1.Requirements {
+		return &fnv1.Requirements{
+			Resources: map[string]*fnv1.ResourceSelector{
+				"cool": {
+					ApiVersion: "test.crossplane.io/v1",
+					Kind:       "CoolResource",
+					Match:      &fnv1.ResourceSelector_MatchName{MatchName: fmt.Sprintf("cool-%d", i)},
+				},
+			},
+		}
+	}
+
+	type want struct {
+		calls int
+		err   *RequirementsUnstableError
+	}
+
+	cases := map[string]struct {
+		reason string
+		ctx    context.Context
+		want   want
+	}{
+		"DefaultLimit": {
+			reason: "We should call the function MaxRequirementsIterations times after the first call if the context doesn't configure a limit.",
+			ctx:    context.Background(),
+			want: want{
+				calls: MaxRequirementsIterations + 1,
+				err: &RequirementsUnstableError{
+					MaxIterations: MaxRequirementsIterations,
+					Iterations:    []*fnv1.Requirements{requirements(0), requirements(1), requirements(2), requirements(3), requirements(4), requirements(5)},
+				},
+			},
+		},
+		"ConfiguredLimit": {
+			reason: "We should call the function the configured number of times after the first call if the context configures a limit.",
+			ctx:    ContextWithMaxRequirementsIterations(context.Background(), 2),
+			want: want{
+				calls: 3,
+				err: &RequirementsUnstableError{
+					MaxIterations: 2,
+					Iterations:    []*fnv1.Requirements{requirements(0), requirements(1), requirements(2)},
+				},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			calls := 0
+			wrapped := FunctionRunnerFn(func(_ context.Context, _ string, _ *fnv1.RunFunctionRequest) (*fnv1.RunFunctionResponse, error) {
+				rsp := &fnv1.RunFunctionResponse{Requirements: requirements(calls)}
+				calls++
+				return rsp, nil
+			})
+			resources := RequiredResourcesFetcherFn(func(_ context.Context, _ *fnv1.ResourceSelector) (*fnv1.Resources, error) {
+				return &fnv1.Resources{}, nil
+			})
+
+			r := NewFetchingFunctionRunner(wrapped, resources, NopRequiredSchemasFetcher{})
+			_, err := r.RunFunction(tc.ctx, "cool-fn", &fnv1.RunFunctionRequest{})
+
+			if diff := cmp.Diff(tc.want.calls, calls); diff != "" {
+				t.Errorf("\n%s\nr.RunFunction(...): -want calls, +got calls:\n%s", tc.reason, diff)
+			}
+
+			got := &RequirementsUnstableError{}
+			if !errors.As(err, &got) {
+				t.Fatalf("\n%s\nr.RunFunction(...): want *RequirementsUnstableError, got %v", tc.reason, err)
+			}
+			if diff := cmp.Diff(tc.want.err, got, protocmp.Transform()); diff != "" {
+				t.Errorf("\n%s\nr.RunFunction(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestRequirementsUnstableError(t *testing.T) {
+	sel := func(kind string) *fnv1.ResourceSelector {
+		return &fnv1.ResourceSelector{
+			ApiVersion: "test.crossplane.io/v1",
+			Kind:       kind,
+			Match:      &fnv1.ResourceSelector_MatchName{MatchName: "cool"},
+		}
+	}
+
+	type want struct {
+		changing []string
+		err      string
+	}
+
+	cases := map[string]struct {
+		reason string
+		err    *RequirementsUnstableError
+		want   want
+	}{
+		"ChangingResource": {
+			reason: "We should report a required resource whose selector changes between iterations.",
+			err: &RequirementsUnstableError{
+				MaxIterations: 1,
+				Iterations: []*fnv1.Requirements{
+					{Resources: map[string]*fnv1.ResourceSelector{"stable": sel("A"), "unstable": sel("A")}},
+					{Resources: map[string]*fnv1.ResourceSelector{"stable": sel("A"), "unstable": sel("B")}},
+				},
+			},
+			want: want{
+				changing: []string{"resources[unstable]"},
+				err: "requirements didn't stabilize after the maximum number of iterations (1); requirements that kept changing: resources[unstable]" +
+					"; last iteration changed resources[unstable] from {test.crossplane.io/v1, Kind=A, name=cool} to {test.crossplane.io/v1, Kind=B, name=cool}",
+			},
+		},
+		"AppearingAndDisappearing": {
+			reason: "We should report requirements that appear or disappear between iterations.",
+			err: &RequirementsUnstableError{
+				MaxIterations: 1,
+				Iterations: []*fnv1.Requirements{
+					{Schemas: map[string]*fnv1.SchemaSelector{"schema": {ApiVersion: "test.crossplane.io/v1", Kind: "A"}}},
+					{},
+				},
+			},
+			want: want{
+				changing: []string{"schemas[schema]"},
+				err: "requirements didn't stabilize after the maximum number of iterations (1); requirements that kept changing: schemas[schema]" +
+					"; last iteration changed schemas[schema] from {test.crossplane.io/v1, Kind=A} to nothing",
+			},
+		},
+		"OnlyLastIteration": {
+			reason: "We should only describe how the requirements changed in the last iteration, so the message doesn't grow with the number of iterations.",
+			err: &RequirementsUnstableError{
+				MaxIterations: 2,
+				Iterations: []*fnv1.Requirements{
+					{Resources: map[string]*fnv1.ResourceSelector{"a": sel("A")}},
+					{Resources: map[string]*fnv1.ResourceSelector{"a": sel("B")}},
+					{Resources: map[string]*fnv1.ResourceSelector{"a": sel("B"), "b": sel("A")}},
+				},
+			},
+			want: want{
+				changing: []string{"resources[a]", "resources[b]"},
+				err: "requirements didn't stabilize after the maximum number of iterations (2); requirements that kept changing: resources[a], resources[b]" +
+					"; last iteration changed resources[b] from nothing to {test.crossplane.io/v1, Kind=A, name=cool}",
+			},
+		},
+		"ManyChangingRequirements": {
+			reason: "We should truncate the list of requirements that kept changing.",
+			err: func() *RequirementsUnstableError {
+				prev := &fnv1.Requirements{Resources: map[string]*fnv1.ResourceSelector{}}
+				cur := &fnv1.Requirements{Resources: map[string]*fnv1.ResourceSelector{}}
+				for i := range maxUnstableRequirements + 2 {
+					cur.Resources[fmt.Sprintf("r%02d", i)] = sel("A")
+				}
+				return &RequirementsUnstableError{MaxIterations: 1, Iterations: []*fnv1.Requirements{prev, cur}}
+			}(),
+			want: want{
+				changing: []string{"resources[r00]", "resources[r01]", "resources[r02]", "resources[r03]", "resources[r04]", "resources[r05]", "resources[r06]", "resources[r07]", "resources[r08]", "resources[r09]", "resources[r10]", "resources[r11]"},
+				err: "requirements didn't stabilize after the maximum number of iterations (1)" +
+					"; requirements that kept changing: resources[r00], resources[r01], resources[r02], resources[r03], resources[r04], resources[r05], resources[r06], resources[r07], resources[r08], resources[r09], and 2 more" +
+					"; last iteration changed resources[r00] from nothing to {test.crossplane.io/v1, Kind=A, name=cool}, resources[r01] from nothing to {test.crossplane.io/v1, Kind=A, name=cool}, resources[r02] from nothing to {test.crossplane.io/v1, Kind=A, name=cool}, resources[r03] from nothing to {test.crossplane.io/v1, Kind=A, name=cool}, resources[r04] from nothing to {test.crossplane.io/v1, Kind=A, name=cool}, resources[r05] from nothing to {test.crossplane.io/v1, Kind=A, name=cool}, resources[r06] from nothing to {test.crossplane.io/v1, Kind=A, name=cool}, resources[r07] from nothing to {test.crossplane.io/v1, Kind=A, name=cool}, resources[r08] from nothing to {test.crossplane.io/v1, Kind=A, name=cool}, resources[r09] from nothing to {test.crossplane.io/v1, Kind=A, name=cool}, and 2 more",
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if diff := cmp.Diff(tc.want.changing, tc.err.Changing()); diff != "" {
+				t.Errorf("\n%s\ne.Changing(): -want, +got:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.err, tc.err.Error()); diff != "" {
+				t.Errorf("\n%s\ne.Error(): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestReconcileError(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		err    error
+		want   xpv2.ConditionReason
+	}{
+		"RequirementsUnstable": {
+			reason: "Errors caused by unstable requirements should have the RequirementsUnstable reason.",
+			err:    errors.Wrap(&RequirementsUnstableError{MaxIterations: 5}, "cannot run pipeline step"),
+			want:   ReasonRequirementsUnstable,
+		},
+		"OtherError": {
+			reason: "Other errors should have the ReconcileError reason.",
+			err:    errors.New("boom"),
+			want:   xpv2.ReasonReconcileError,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := ReconcileError(tc.err)
+			if diff := cmp.Diff(tc.want, got.Reason); diff != "" {
+				t.Errorf("\n%s\nReconcileError(...): -want reason, +got reason:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -73,6 +73,17 @@ const (
 	// OpenAPI schemas and Crossplane will return them in required_schemas. Added
 	// in Crossplane v2.2.
 	Capability_CAPABILITY_REQUIRED_SCHEMAS Capability = 5
+	// Crossplane supports the RunFunctionStream RPC. Functions that implement
+	// it can advertise the streaming capability in their package metadata, and
+	// Crossplane will stream requests that are too large to send in one message.
+	// Added in Crossplane v2.3.
+	Capability_CAPABILITY_STREAMING Capability = 6
+	// Crossplane supports sending observed state as a delta. Functions that
+	// acknowledge an observed state using meta.acknowledged_observed_hash will
+	// receive only changes to it in observed_delta. Crossplane only advertises
+	// this capability to functions whose package declares the observed-delta
+	// capability. Added in Crossplane v2.3.
+	Capability_CAPABILITY_OBSERVED_DELTA Capability = 7
 )
 
 // Enum value maps for Capability.
@@ -84,6 +95,8 @@ var (
 		3: "CAPABILITY_CREDENTIALS",
 		4: "CAPABILITY_CONDITIONS",
 		5: "CAPABILITY_REQUIRED_SCHEMAS",
+		6: "CAPABILITY_STREAMING",
+		7: "CAPABILITY_OBSERVED_DELTA",
 	}
 	Capability_value = map[string]int32{
 		"CAPABILITY_UNSPECIFIED":        0,
@@ -92,6 +105,8 @@ var (
 		"CAPABILITY_CREDENTIALS":        3,
 		"CAPABILITY_CONDITIONS":         4,
 		"CAPABILITY_REQUIRED_SCHEMAS":   5,
+		"CAPABILITY_STREAMING":          6,
+		"CAPABILITY_OBSERVED_DELTA":     7,
 	}
 )
 
@@ -342,6 +357,56 @@ func (Status) EnumDescriptor() ([]byte, []int) {
 	return file_proto_fn_v1_run_function_proto_rawDescGZIP(), []int{4}
 }
 
+// A RunFunctionChunk is part of a protobuf encoded RunFunctionRequest or
+// RunFunctionResponse. The sender encodes the message, splits it into chunks,
+// and sends the chunks in order. The client closes its side of the stream
+// after it sends its last chunk. The server returns after it sends its last
+// chunk. The receiver concatenates the chunks and decodes the message.
+type RunFunctionChunk struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Data is the next part of the encoded message.
+	Data          []byte `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RunFunctionChunk) Reset() {
+	*x = RunFunctionChunk{}
+	mi := &file_proto_fn_v1_run_function_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RunFunctionChunk) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RunFunctionChunk) ProtoMessage() {}
+
+func (x *RunFunctionChunk) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_fn_v1_run_function_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RunFunctionChunk.ProtoReflect.Descriptor instead.
+func (*RunFunctionChunk) Descriptor() ([]byte, []int) {
+	return file_proto_fn_v1_run_function_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *RunFunctionChunk) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
 // A RunFunctionRequest requests that the function be run.
 type RunFunctionRequest struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
@@ -399,13 +464,21 @@ type RunFunctionRequest struct {
 	// sets the map key to an empty Schema message to indicate that it attempted
 	// to satisfy the request.
 	RequiredSchemas map[string]*Schema `protobuf:"bytes,9,rep,name=required_schemas,json=requiredSchemas,proto3" json:"required_schemas,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
-	unknownFields   protoimpl.UnknownFields
-	sizeCache       protoimpl.SizeCache
+	// Optional changes to the observed state, relative to an observed state the
+	// function previously acknowledged. Crossplane only sets observed_delta if
+	// the function acknowledged an observed state by returning its hash in
+	// meta.acknowledged_observed_hash. When observed_delta is set the observed
+	// field is unset. A function that no longer has the base observed state
+	// should return a FAILED_PRECONDITION error, and Crossplane will send the
+	// full observed state.
+	ObservedDelta *StateDelta `protobuf:"bytes,10,opt,name=observed_delta,json=observedDelta,proto3,oneof" json:"observed_delta,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
 func (x *RunFunctionRequest) Reset() {
 	*x = RunFunctionRequest{}
-	mi := &file_proto_fn_v1_run_function_proto_msgTypes[0]
+	mi := &file_proto_fn_v1_run_function_proto_msgTypes[1]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -417,7 +490,7 @@ func (x *RunFunctionRequest) String() string {
 func (*RunFunctionRequest) ProtoMessage() {}
 
 func (x *RunFunctionRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_fn_v1_run_function_proto_msgTypes[0]
+	mi := &file_proto_fn_v1_run_function_proto_msgTypes[1]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -430,7 +503,7 @@ func (x *RunFunctionRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RunFunctionRequest.ProtoReflect.Descriptor instead.
 func (*RunFunctionRequest) Descriptor() ([]byte, []int) {
-	return file_proto_fn_v1_run_function_proto_rawDescGZIP(), []int{0}
+	return file_proto_fn_v1_run_function_proto_rawDescGZIP(), []int{1}
 }
 
 func (x *RunFunctionRequest) GetMeta() *RequestMeta {
@@ -497,6 +570,87 @@ func (x *RunFunctionRequest) GetRequiredSchemas() map[string]*Schema {
 	return nil
 }
 
+func (x *RunFunctionRequest) GetObservedDelta() *StateDelta {
+	if x != nil {
+		return x.ObservedDelta
+	}
+	return nil
+}
+
+// StateDelta represents the changes to a State, relative to a base State.
+type StateDelta struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The hash of the base State these changes apply to. It matches the
+	// meta.observed_hash of the request that sent the base State.
+	BaseHash string `protobuf:"bytes,1,opt,name=base_hash,json=baseHash,proto3" json:"base_hash,omitempty"`
+	// The composite resource, if it changed.
+	Composite *Resource `protobuf:"bytes,2,opt,name=composite,proto3,oneof" json:"composite,omitempty"`
+	// Composed resources that were added or changed, keyed by name.
+	Resources map[string]*Resource `protobuf:"bytes,3,rep,name=resources,proto3" json:"resources,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// The names of composed resources that were removed.
+	RemovedResources []string `protobuf:"bytes,4,rep,name=removed_resources,json=removedResources,proto3" json:"removed_resources,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *StateDelta) Reset() {
+	*x = StateDelta{}
+	mi := &file_proto_fn_v1_run_function_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StateDelta) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StateDelta) ProtoMessage() {}
+
+func (x *StateDelta) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_fn_v1_run_function_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StateDelta.ProtoReflect.Descriptor instead.
+func (*StateDelta) Descriptor() ([]byte, []int) {
+	return file_proto_fn_v1_run_function_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *StateDelta) GetBaseHash() string {
+	if x != nil {
+		return x.BaseHash
+	}
+	return ""
+}
+
+func (x *StateDelta) GetComposite() *Resource {
+	if x != nil {
+		return x.Composite
+	}
+	return nil
+}
+
+func (x *StateDelta) GetResources() map[string]*Resource {
+	if x != nil {
+		return x.Resources
+	}
+	return nil
+}
+
+func (x *StateDelta) GetRemovedResources() []string {
+	if x != nil {
+		return x.RemovedResources
+	}
+	return nil
+}
+
 // Credentials that a function may use to communicate with an external system.
 type Credentials struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
@@ -512,7 +666,7 @@ type Credentials struct {
 
 func (x *Credentials) Reset() {
 	*x = Credentials{}
-	mi := &file_proto_fn_v1_run_function_proto_msgTypes[1]
+	mi := &file_proto_fn_v1_run_function_proto_msgTypes[3]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -524,7 +678,7 @@ func (x *Credentials) String() string {
 func (*Credentials) ProtoMessage() {}
 
 func (x *Credentials) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_fn_v1_run_function_proto_msgTypes[1]
+	mi := &file_proto_fn_v1_run_function_proto_msgTypes[3]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -537,7 +691,7 @@ func (x *Credentials) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Credentials.ProtoReflect.Descriptor instead.
 func (*Credentials) Descriptor() ([]byte, []int) {
-	return file_proto_fn_v1_run_function_proto_rawDescGZIP(), []int{1}
+	return file_proto_fn_v1_run_function_proto_rawDescGZIP(), []int{3}
 }
 
 func (x *Credentials) GetSource() isCredentials_Source {
@@ -577,7 +731,7 @@ type CredentialData struct {
 
 func (x *CredentialData) Reset() {
 	*x = CredentialData{}
-	mi := &file_proto_fn_v1_run_function_proto_msgTypes[2]
+	mi := &file_proto_fn_v1_run_function_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -589,7 +743,7 @@ func (x *CredentialData) String() string {
 func (*CredentialData) ProtoMessage() {}
 
 func (x *CredentialData) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_fn_v1_run_function_proto_msgTypes[2]
+	mi := &file_proto_fn_v1_run_function_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -602,7 +756,7 @@ func (x *CredentialData) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CredentialData.ProtoReflect.Descriptor instead.
 func (*CredentialData) Descriptor() ([]byte, []int) {
-	return file_proto_fn_v1_run_function_proto_rawDescGZIP(), []int{2}
+	return file_proto_fn_v1_run_function_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *CredentialData) GetData() map[string][]byte {
@@ -622,7 +776,7 @@ type Resources struct {
 
 func (x *Resources) Reset() {
 	*x = Resources{}
-	mi := &file_proto_fn_v1_run_function_proto_msgTypes[3]
+	mi := &file_proto_fn_v1_run_function_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -634,7 +788,7 @@ func (x *Resources) String() string {
 func (*Resources) ProtoMessage() {}
 
 func (x *Resources) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_fn_v1_run_function_proto_msgTypes[3]
+	mi := &file_proto_fn_v1_run_function_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -647,7 +801,7 @@ func (x *Resources) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Resources.ProtoReflect.Descriptor instead.
 func (*Resources) Descriptor() ([]byte, []int) {
-	return file_proto_fn_v1_run_function_proto_rawDescGZIP(), []int{3}
+	return file_proto_fn_v1_run_function_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *Resources) GetItems() []*Resource {
@@ -696,7 +850,7 @@ type RunFunctionResponse struct {
 
 func (x *RunFunctionResponse) Reset() {
 	*x = RunFunctionResponse{}
-	mi := &file_proto_fn_v1_run_function_proto_msgTypes[4]
+	mi := &file_proto_fn_v1_run_function_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -708,7 +862,7 @@ func (x *RunFunctionResponse) String() string {
 func (*RunFunctionResponse) ProtoMessage() {}
 
 func (x *RunFunctionResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_fn_v1_run_function_proto_msgTypes[4]
+	mi := &file_proto_fn_v1_run_function_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -721,7 +875,7 @@ func (x *RunFunctionResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RunFunctionResponse.ProtoReflect.Descriptor instead.
 func (*RunFunctionResponse) Descriptor() ([]byte, []int) {
-	return file_proto_fn_v1_run_function_proto_rawDescGZIP(), []int{4}
+	return file_proto_fn_v1_run_function_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *RunFunctionResponse) GetMeta() *ResponseMeta {
@@ -782,14 +936,19 @@ type RequestMeta struct {
 	// Capabilities supported by this version of Crossplane. Functions may use
 	// this to determine whether Crossplane will honor certain fields in their
 	// response, or populate certain fields in their request.
-	Capabilities  []Capability `protobuf:"varint,2,rep,packed,name=capabilities,proto3,enum=apiextensions.fn.proto.v1.Capability" json:"capabilities,omitempty"`
+	Capabilities []Capability `protobuf:"varint,2,rep,packed,name=capabilities,proto3,enum=apiextensions.fn.proto.v1.Capability" json:"capabilities,omitempty"`
+	// An opaque string identifying the observed state. If the request has an
+	// observed_delta it identifies the observed state that results from applying
+	// the delta to its base. Functions that support observed deltas return it in
+	// their response's meta.acknowledged_observed_hash.
+	ObservedHash  string `protobuf:"bytes,3,opt,name=observed_hash,json=observedHash,proto3" json:"observed_hash,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *RequestMeta) Reset() {
 	*x = RequestMeta{}
-	mi := &file_proto_fn_v1_run_function_proto_msgTypes[5]
+	mi := &file_proto_fn_v1_run_function_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -801,7 +960,7 @@ func (x *RequestMeta) String() string {
 func (*RequestMeta) ProtoMessage() {}
 
 func (x *RequestMeta) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_fn_v1_run_function_proto_msgTypes[5]
+	mi := &file_proto_fn_v1_run_function_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -814,7 +973,7 @@ func (x *RequestMeta) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RequestMeta.ProtoReflect.Descriptor instead.
 func (*RequestMeta) Descriptor() ([]byte, []int) {
-	return file_proto_fn_v1_run_function_proto_rawDescGZIP(), []int{5}
+	return file_proto_fn_v1_run_function_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *RequestMeta) GetTag() string {
@@ -831,6 +990,13 @@ func (x *RequestMeta) GetCapabilities() []Capability {
 	return nil
 }
 
+func (x *RequestMeta) GetObservedHash() string {
+	if x != nil {
+		return x.ObservedHash
+	}
+	return ""
+}
+
 // Requirements that must be satisfied for a function to run successfully.
 type Requirements struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
@@ -853,7 +1019,7 @@ type Requirements struct {
 
 func (x *Requirements) Reset() {
 	*x = Requirements{}
-	mi := &file_proto_fn_v1_run_function_proto_msgTypes[6]
+	mi := &file_proto_fn_v1_run_function_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -865,7 +1031,7 @@ func (x *Requirements) String() string {
 func (*Requirements) ProtoMessage() {}
 
 func (x *Requirements) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_fn_v1_run_function_proto_msgTypes[6]
+	mi := &file_proto_fn_v1_run_function_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -878,7 +1044,7 @@ func (x *Requirements) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Requirements.ProtoReflect.Descriptor instead.
 func (*Requirements) Descriptor() ([]byte, []int) {
-	return file_proto_fn_v1_run_function_proto_rawDescGZIP(), []int{6}
+	return file_proto_fn_v1_run_function_proto_rawDescGZIP(), []int{8}
 }
 
 // Deprecated: Marked as deprecated in proto/fn/v1/run_function.proto.
@@ -916,7 +1082,7 @@ type SchemaSelector struct {
 
 func (x *SchemaSelector) Reset() {
 	*x = SchemaSelector{}
-	mi := &file_proto_fn_v1_run_function_proto_msgTypes[7]
+	mi := &file_proto_fn_v1_run_function_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -928,7 +1094,7 @@ func (x *SchemaSelector) String() string {
 func (*SchemaSelector) ProtoMessage() {}
 
 func (x *SchemaSelector) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_fn_v1_run_function_proto_msgTypes[7]
+	mi := &file_proto_fn_v1_run_function_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -941,7 +1107,7 @@ func (x *SchemaSelector) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SchemaSelector.ProtoReflect.Descriptor instead.
 func (*SchemaSelector) Descriptor() ([]byte, []int) {
-	return file_proto_fn_v1_run_function_proto_rawDescGZIP(), []int{7}
+	return file_proto_fn_v1_run_function_proto_rawDescGZIP(), []int{9}
 }
 
 func (x *SchemaSelector) GetApiVersion() string {
@@ -971,7 +1137,7 @@ type Schema struct {
 
 func (x *Schema) Reset() {
 	*x = Schema{}
-	mi := &file_proto_fn_v1_run_function_proto_msgTypes[8]
+	mi := &file_proto_fn_v1_run_function_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -983,7 +1149,7 @@ func (x *Schema) String() string {
 func (*Schema) ProtoMessage() {}
 
 func (x *Schema) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_fn_v1_run_function_proto_msgTypes[8]
+	mi := &file_proto_fn_v1_run_function_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -996,7 +1162,7 @@ func (x *Schema) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Schema.ProtoReflect.Descriptor instead.
 func (*Schema) Descriptor() ([]byte, []int) {
-	return file_proto_fn_v1_run_function_proto_rawDescGZIP(), []int{8}
+	return file_proto_fn_v1_run_function_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *Schema) GetOpenapiV3() *structpb.Struct {
@@ -1030,7 +1196,7 @@ type ResourceSelector struct {
 
 func (x *ResourceSelector) Reset() {
 	*x = ResourceSelector{}
-	mi := &file_proto_fn_v1_run_function_proto_msgTypes[9]
+	mi := &file_proto_fn_v1_run_function_proto_msgTypes[11]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1042,7 +1208,7 @@ func (x *ResourceSelector) String() string {
 func (*ResourceSelector) ProtoMessage() {}
 
 func (x *ResourceSelector) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_fn_v1_run_function_proto_msgTypes[9]
+	mi := &file_proto_fn_v1_run_function_proto_msgTypes[11]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1055,7 +1221,7 @@ func (x *ResourceSelector) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ResourceSelector.ProtoReflect.Descriptor instead.
 func (*ResourceSelector) Descriptor() ([]byte, []int) {
-	return file_proto_fn_v1_run_function_proto_rawDescGZIP(), []int{9}
+	return file_proto_fn_v1_run_function_proto_rawDescGZIP(), []int{11}
 }
 
 func (x *ResourceSelector) GetApiVersion() string {
@@ -1132,7 +1298,7 @@ type MatchLabels struct {
 
 func (x *MatchLabels) Reset() {
 	*x = MatchLabels{}
-	mi := &file_proto_fn_v1_run_function_proto_msgTypes[10]
+	mi := &file_proto_fn_v1_run_function_proto_msgTypes[12]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1144,7 +1310,7 @@ func (x *MatchLabels) String() string {
 func (*MatchLabels) ProtoMessage() {}
 
 func (x *MatchLabels) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_fn_v1_run_function_proto_msgTypes[10]
+	mi := &file_proto_fn_v1_run_function_proto_msgTypes[12]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1157,7 +1323,7 @@ func (x *MatchLabels) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use MatchLabels.ProtoReflect.Descriptor instead.
 func (*MatchLabels) Descriptor() ([]byte, []int) {
-	return file_proto_fn_v1_run_function_proto_rawDescGZIP(), []int{10}
+	return file_proto_fn_v1_run_function_proto_rawDescGZIP(), []int{12}
 }
 
 func (x *MatchLabels) GetLabels() map[string]string {
@@ -1176,14 +1342,18 @@ type ResponseMeta struct {
 	// Time-to-live of this response. Crossplane will call the function again when
 	// the TTL expires. Crossplane may cache the response to avoid calling the
 	// function again until the TTL expires.
-	Ttl           *durationpb.Duration `protobuf:"bytes,2,opt,name=ttl,proto3,oneof" json:"ttl,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	Ttl *durationpb.Duration `protobuf:"bytes,2,opt,name=ttl,proto3,oneof" json:"ttl,omitempty"`
+	// The meta.observed_hash of the corresponding RunFunctionRequest. A function
+	// sets it to tell Crossplane that it keeps the request's observed state, and
+	// that Crossplane may send the next request's observed state as a delta.
+	AcknowledgedObservedHash string `protobuf:"bytes,3,opt,name=acknowledged_observed_hash,json=acknowledgedObservedHash,proto3" json:"acknowledged_observed_hash,omitempty"`
+	unknownFields            protoimpl.UnknownFields
+	sizeCache                protoimpl.SizeCache
 }
 
 func (x *ResponseMeta) Reset() {
 	*x = ResponseMeta{}
-	mi := &file_proto_fn_v1_run_function_proto_msgTypes[11]
+	mi := &file_proto_fn_v1_run_function_proto_msgTypes[13]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1195,7 +1365,7 @@ func (x *ResponseMeta) String() string {
 func (*ResponseMeta) ProtoMessage() {}
 
 func (x *ResponseMeta) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_fn_v1_run_function_proto_msgTypes[11]
+	mi := &file_proto_fn_v1_run_function_proto_msgTypes[13]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1208,7 +1378,7 @@ func (x *ResponseMeta) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ResponseMeta.ProtoReflect.Descriptor instead.
 func (*ResponseMeta) Descriptor() ([]byte, []int) {
-	return file_proto_fn_v1_run_function_proto_rawDescGZIP(), []int{11}
+	return file_proto_fn_v1_run_function_proto_rawDescGZIP(), []int{13}
 }
 
 func (x *ResponseMeta) GetTag() string {
@@ -1225,6 +1395,13 @@ func (x *ResponseMeta) GetTtl() *durationpb.Duration {
 	return nil
 }
 
+func (x *ResponseMeta) GetAcknowledgedObservedHash() string {
+	if x != nil {
+		return x.AcknowledgedObservedHash
+	}
+	return ""
+}
+
 // State of the XR (XR) and any resources.
 type State struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
@@ -1240,7 +1417,7 @@ type State struct {
 
 func (x *State) Reset() {
 	*x = State{}
-	mi := &file_proto_fn_v1_run_function_proto_msgTypes[12]
+	mi := &file_proto_fn_v1_run_function_proto_msgTypes[14]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1252,7 +1429,7 @@ func (x *State) String() string {
 func (*State) ProtoMessage() {}
 
 func (x *State) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_fn_v1_run_function_proto_msgTypes[12]
+	mi := &file_proto_fn_v1_run_function_proto_msgTypes[14]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1265,7 +1442,7 @@ func (x *State) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use State.ProtoReflect.Descriptor instead.
 func (*State) Descriptor() ([]byte, []int) {
-	return file_proto_fn_v1_run_function_proto_rawDescGZIP(), []int{12}
+	return file_proto_fn_v1_run_function_proto_rawDescGZIP(), []int{14}
 }
 
 func (x *State) GetComposite() *Resource {
@@ -1336,7 +1513,7 @@ type Resource struct {
 
 func (x *Resource) Reset() {
 	*x = Resource{}
-	mi := &file_proto_fn_v1_run_function_proto_msgTypes[13]
+	mi := &file_proto_fn_v1_run_function_proto_msgTypes[15]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1348,7 +1525,7 @@ func (x *Resource) String() string {
 func (*Resource) ProtoMessage() {}
 
 func (x *Resource) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_fn_v1_run_function_proto_msgTypes[13]
+	mi := &file_proto_fn_v1_run_function_proto_msgTypes[15]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1361,7 +1538,7 @@ func (x *Resource) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Resource.ProtoReflect.Descriptor instead.
 func (*Resource) Descriptor() ([]byte, []int) {
-	return file_proto_fn_v1_run_function_proto_rawDescGZIP(), []int{13}
+	return file_proto_fn_v1_run_function_proto_rawDescGZIP(), []int{15}
 }
 
 func (x *Resource) GetResource() *structpb.Struct {
@@ -1403,7 +1580,7 @@ type Result struct {
 
 func (x *Result) Reset() {
 	*x = Result{}
-	mi := &file_proto_fn_v1_run_function_proto_msgTypes[14]
+	mi := &file_proto_fn_v1_run_function_proto_msgTypes[16]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1415,7 +1592,7 @@ func (x *Result) String() string {
 func (*Result) ProtoMessage() {}
 
 func (x *Result) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_fn_v1_run_function_proto_msgTypes[14]
+	mi := &file_proto_fn_v1_run_function_proto_msgTypes[16]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1428,7 +1605,7 @@ func (x *Result) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Result.ProtoReflect.Descriptor instead.
 func (*Result) Descriptor() ([]byte, []int) {
-	return file_proto_fn_v1_run_function_proto_rawDescGZIP(), []int{14}
+	return file_proto_fn_v1_run_function_proto_rawDescGZIP(), []int{16}
 }
 
 func (x *Result) GetSeverity() Severity {
@@ -1486,7 +1663,7 @@ type Condition struct {
 
 func (x *Condition) Reset() {
 	*x = Condition{}
-	mi := &file_proto_fn_v1_run_function_proto_msgTypes[15]
+	mi := &file_proto_fn_v1_run_function_proto_msgTypes[17]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1498,7 +1675,7 @@ func (x *Condition) String() string {
 func (*Condition) ProtoMessage() {}
 
 func (x *Condition) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_fn_v1_run_function_proto_msgTypes[15]
+	mi := &file_proto_fn_v1_run_function_proto_msgTypes[17]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1511,7 +1688,7 @@ func (x *Condition) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Condition.ProtoReflect.Descriptor instead.
 func (*Condition) Descriptor() ([]byte, []int) {
-	return file_proto_fn_v1_run_function_proto_rawDescGZIP(), []int{15}
+	return file_proto_fn_v1_run_function_proto_rawDescGZIP(), []int{17}
 }
 
 func (x *Condition) GetType() string {
@@ -1553,7 +1730,10 @@ var File_proto_fn_v1_run_function_proto protoreflect.FileDescriptor
 
 const file_proto_fn_v1_run_function_proto_rawDesc = "" +
 	"\n" +
-	"\x1eproto/fn/v1/run_function.proto\x12\x19apiextensions.fn.proto.v1\x1a\x1egoogle/protobuf/duration.proto\x1a\x1cgoogle/protobuf/struct.proto\"\xa6\t\n" +
+	"\x1eproto/fn/v1/run_function.proto\x12\x19apiextensions.fn.proto.v1\x1a\x1egoogle/protobuf/duration.proto\x1a\x1cgoogle/protobuf/struct.proto\"&\n" +
+	"\x10RunFunctionChunk\x12\x12\n" +
+	"\x04data\x18\x01 \x01(\fR\x04data\"\x8c\n" +
+	"\n" +
 	"\x12RunFunctionRequest\x12:\n" +
 	"\x04meta\x18\x01 \x01(\v2&.apiextensions.fn.proto.v1.RequestMetaR\x04meta\x12<\n" +
 	"\bobserved\x18\x02 \x01(\v2 .apiextensions.fn.proto.v1.StateR\bobserved\x12:\n" +
@@ -1563,7 +1743,9 @@ const file_proto_fn_v1_run_function_proto_rawDesc = "" +
 	"\x0fextra_resources\x18\x06 \x03(\v2A.apiextensions.fn.proto.v1.RunFunctionRequest.ExtraResourcesEntryB\x02\x18\x01R\x0eextraResources\x12`\n" +
 	"\vcredentials\x18\a \x03(\v2>.apiextensions.fn.proto.v1.RunFunctionRequest.CredentialsEntryR\vcredentials\x12s\n" +
 	"\x12required_resources\x18\b \x03(\v2D.apiextensions.fn.proto.v1.RunFunctionRequest.RequiredResourcesEntryR\x11requiredResources\x12m\n" +
-	"\x10required_schemas\x18\t \x03(\v2B.apiextensions.fn.proto.v1.RunFunctionRequest.RequiredSchemasEntryR\x0frequiredSchemas\x1ag\n" +
+	"\x10required_schemas\x18\t \x03(\v2B.apiextensions.fn.proto.v1.RunFunctionRequest.RequiredSchemasEntryR\x0frequiredSchemas\x12Q\n" +
+	"\x0eobserved_delta\x18\n" +
+	" \x01(\v2%.apiextensions.fn.proto.v1.StateDeltaH\x02R\robservedDelta\x88\x01\x01\x1ag\n" +
 	"\x13ExtraResourcesEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12:\n" +
 	"\x05value\x18\x02 \x01(\v2$.apiextensions.fn.proto.v1.ResourcesR\x05value:\x028\x01\x1af\n" +
@@ -1578,7 +1760,19 @@ const file_proto_fn_v1_run_function_proto_rawDesc = "" +
 	"\x05value\x18\x02 \x01(\v2!.apiextensions.fn.proto.v1.SchemaR\x05value:\x028\x01B\b\n" +
 	"\x06_inputB\n" +
 	"\n" +
-	"\b_context\"m\n" +
+	"\b_contextB\x11\n" +
+	"\x0f_observed_delta\"\xe3\x02\n" +
+	"\n" +
+	"StateDelta\x12\x1b\n" +
+	"\tbase_hash\x18\x01 \x01(\tR\bbaseHash\x12F\n" +
+	"\tcomposite\x18\x02 \x01(\v2#.apiextensions.fn.proto.v1.ResourceH\x00R\tcomposite\x88\x01\x01\x12R\n" +
+	"\tresources\x18\x03 \x03(\v24.apiextensions.fn.proto.v1.StateDelta.ResourcesEntryR\tresources\x12+\n" +
+	"\x11removed_resources\x18\x04 \x03(\tR\x10removedResources\x1aa\n" +
+	"\x0eResourcesEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x129\n" +
+	"\x05value\x18\x02 \x01(\v2#.apiextensions.fn.proto.v1.ResourceR\x05value:\x028\x01B\f\n" +
+	"\n" +
+	"_composite\"m\n" +
 	"\vCredentials\x12T\n" +
 	"\x0fcredential_data\x18\x01 \x01(\v2).apiextensions.fn.proto.v1.CredentialDataH\x00R\x0ecredentialDataB\b\n" +
 	"\x06source\"\x92\x01\n" +
@@ -1601,10 +1795,11 @@ const file_proto_fn_v1_run_function_proto_rawDesc = "" +
 	"\x06output\x18\a \x01(\v2\x17.google.protobuf.StructH\x01R\x06output\x88\x01\x01B\n" +
 	"\n" +
 	"\b_contextB\t\n" +
-	"\a_output\"j\n" +
+	"\a_output\"\x8f\x01\n" +
 	"\vRequestMeta\x12\x10\n" +
 	"\x03tag\x18\x01 \x01(\tR\x03tag\x12I\n" +
-	"\fcapabilities\x18\x02 \x03(\x0e2%.apiextensions.fn.proto.v1.CapabilityR\fcapabilities\"\xe0\x04\n" +
+	"\fcapabilities\x18\x02 \x03(\x0e2%.apiextensions.fn.proto.v1.CapabilityR\fcapabilities\x12#\n" +
+	"\robserved_hash\x18\x03 \x01(\tR\fobservedHash\"\xe0\x04\n" +
 	"\fRequirements\x12h\n" +
 	"\x0fextra_resources\x18\x01 \x03(\v2;.apiextensions.fn.proto.v1.Requirements.ExtraResourcesEntryB\x02\x18\x01R\x0eextraResources\x12T\n" +
 	"\tresources\x18\x02 \x03(\v26.apiextensions.fn.proto.v1.Requirements.ResourcesEntryR\tresources\x12N\n" +
@@ -1641,10 +1836,11 @@ const file_proto_fn_v1_run_function_proto_rawDesc = "" +
 	"\x06labels\x18\x01 \x03(\v22.apiextensions.fn.proto.v1.MatchLabels.LabelsEntryR\x06labels\x1a9\n" +
 	"\vLabelsEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
-	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"Z\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\x98\x01\n" +
 	"\fResponseMeta\x12\x10\n" +
 	"\x03tag\x18\x01 \x01(\tR\x03tag\x120\n" +
-	"\x03ttl\x18\x02 \x01(\v2\x19.google.protobuf.DurationH\x00R\x03ttl\x88\x01\x01B\x06\n" +
+	"\x03ttl\x18\x02 \x01(\v2\x19.google.protobuf.DurationH\x00R\x03ttl\x88\x01\x01\x12<\n" +
+	"\x1aacknowledged_observed_hash\x18\x03 \x01(\tR\x18acknowledgedObservedHashB\x06\n" +
 	"\x04_ttl\"\xfc\x01\n" +
 	"\x05State\x12A\n" +
 	"\tcomposite\x18\x01 \x01(\v2#.apiextensions.fn.proto.v1.ResourceR\tcomposite\x12M\n" +
@@ -1674,7 +1870,7 @@ const file_proto_fn_v1_run_function_proto_rawDesc = "" +
 	"\x06target\x18\x05 \x01(\x0e2!.apiextensions.fn.proto.v1.TargetH\x01R\x06target\x88\x01\x01B\n" +
 	"\n" +
 	"\b_messageB\t\n" +
-	"\a_target*\xc0\x01\n" +
+	"\a_target*\xf9\x01\n" +
 	"\n" +
 	"Capability\x12\x1a\n" +
 	"\x16CAPABILITY_UNSPECIFIED\x10\x00\x12\x1b\n" +
@@ -1682,7 +1878,9 @@ const file_proto_fn_v1_run_function_proto_rawDesc = "" +
 	"\x1dCAPABILITY_REQUIRED_RESOURCES\x10\x02\x12\x1a\n" +
 	"\x16CAPABILITY_CREDENTIALS\x10\x03\x12\x19\n" +
 	"\x15CAPABILITY_CONDITIONS\x10\x04\x12\x1f\n" +
-	"\x1bCAPABILITY_REQUIRED_SCHEMAS\x10\x05*?\n" +
+	"\x1bCAPABILITY_REQUIRED_SCHEMAS\x10\x05\x12\x18\n" +
+	"\x14CAPABILITY_STREAMING\x10\x06\x12\x1d\n" +
+	"\x19CAPABILITY_OBSERVED_DELTA\x10\a*?\n" +
 	"\x05Ready\x12\x15\n" +
 	"\x11READY_UNSPECIFIED\x10\x00\x12\x0e\n" +
 	"\n" +
@@ -1701,9 +1899,10 @@ const file_proto_fn_v1_run_function_proto_rawDesc = "" +
 	"\x1cSTATUS_CONDITION_UNSPECIFIED\x10\x00\x12\x1c\n" +
 	"\x18STATUS_CONDITION_UNKNOWN\x10\x01\x12\x19\n" +
 	"\x15STATUS_CONDITION_TRUE\x10\x02\x12\x1a\n" +
-	"\x16STATUS_CONDITION_FALSE\x10\x032\x87\x01\n" +
+	"\x16STATUS_CONDITION_FALSE\x10\x032\xfc\x01\n" +
 	"\x15FunctionRunnerService\x12n\n" +
-	"\vRunFunction\x12-.apiextensions.fn.proto.v1.RunFunctionRequest\x1a..apiextensions.fn.proto.v1.RunFunctionResponse\"\x00B1Z/github.com/crossplane/crossplane/v2/proto/fn/v1b\x06proto3"
+	"\vRunFunction\x12-.apiextensions.fn.proto.v1.RunFunctionRequest\x1a..apiextensions.fn.proto.v1.RunFunctionResponse\"\x00\x12s\n" +
+	"\x11RunFunctionStream\x12+.apiextensions.fn.proto.v1.RunFunctionChunk\x1a+.apiextensions.fn.proto.v1.RunFunctionChunk\"\x00(\x010\x01B1Z/github.com/crossplane/crossplane/v2/proto/fn/v1b\x06proto3"
 
 var (
 	file_proto_fn_v1_run_function_proto_rawDescOnce sync.Once
@@ -1718,95 +1917,104 @@ func file_proto_fn_v1_run_function_proto_rawDescGZIP() []byte {
 }
 
 var file_proto_fn_v1_run_function_proto_enumTypes = make([]protoimpl.EnumInfo, 5)
-var file_proto_fn_v1_run_function_proto_msgTypes = make([]protoimpl.MessageInfo, 27)
+var file_proto_fn_v1_run_function_proto_msgTypes = make([]protoimpl.MessageInfo, 30)
 var file_proto_fn_v1_run_function_proto_goTypes = []any{
 	(Capability)(0),             // 0: apiextensions.fn.proto.v1.Capability
 	(Ready)(0),                  // 1: apiextensions.fn.proto.v1.Ready
 	(Severity)(0),               // 2: apiextensions.fn.proto.v1.Severity
 	(Target)(0),                 // 3: apiextensions.fn.proto.v1.Target
 	(Status)(0),                 // 4: apiextensions.fn.proto.v1.Status
-	(*RunFunctionRequest)(nil),  // 5: apiextensions.fn.proto.v1.RunFunctionRequest
-	(*Credentials)(nil),         // 6: apiextensions.fn.proto.v1.Credentials
-	(*CredentialData)(nil),      // 7: apiextensions.fn.proto.v1.CredentialData
-	(*Resources)(nil),           // 8: apiextensions.fn.proto.v1.Resources
-	(*RunFunctionResponse)(nil), // 9: apiextensions.fn.proto.v1.RunFunctionResponse
-	(*RequestMeta)(nil),         // 10: apiextensions.fn.proto.v1.RequestMeta
-	(*Requirements)(nil),        // 11: apiextensions.fn.proto.v1.Requirements
-	(*SchemaSelector)(nil),      // 12: apiextensions.fn.proto.v1.SchemaSelector
-	(*Schema)(nil),              // 13: apiextensions.fn.proto.v1.Schema
-	(*ResourceSelector)(nil),    // 14: apiextensions.fn.proto.v1.ResourceSelector
-	(*MatchLabels)(nil),         // 15: apiextensions.fn.proto.v1.MatchLabels
-	(*ResponseMeta)(nil),        // 16: apiextensions.fn.proto.v1.ResponseMeta
-	(*State)(nil),               // 17: apiextensions.fn.proto.v1.State
-	(*Resource)(nil),            // 18: apiextensions.fn.proto.v1.Resource
-	(*Result)(nil),              // 19: apiextensions.fn.proto.v1.Result
-	(*Condition)(nil),           // 20: apiextensions.fn.proto.v1.Condition
-	nil,                         // 21: apiextensions.fn.proto.v1.RunFunctionRequest.ExtraResourcesEntry
-	nil,                         // 22: apiextensions.fn.proto.v1.RunFunctionRequest.CredentialsEntry
-	nil,                         // 23: apiextensions.fn.proto.v1.RunFunctionRequest.RequiredResourcesEntry
-	nil,                         // 24: apiextensions.fn.proto.v1.RunFunctionRequest.RequiredSchemasEntry
-	nil,                         // 25: apiextensions.fn.proto.v1.CredentialData.DataEntry
-	nil,                         // 26: apiextensions.fn.proto.v1.Requirements.ExtraResourcesEntry
-	nil,                         // 27: apiextensions.fn.proto.v1.Requirements.ResourcesEntry
-	nil,                         // 28: apiextensions.fn.proto.v1.Requirements.SchemasEntry
-	nil,                         // 29: apiextensions.fn.proto.v1.MatchLabels.LabelsEntry
-	nil,                         // 30: apiextensions.fn.proto.v1.State.ResourcesEntry
-	nil,                         // 31: apiextensions.fn.proto.v1.Resource.ConnectionDetailsEntry
-	(*structpb.Struct)(nil),     // 32: google.protobuf.Struct
-	(*durationpb.Duration)(nil), // 33: google.protobuf.Duration
+	(*RunFunctionChunk)(nil),    // 5: apiextensions.fn.proto.v1.RunFunctionChunk
+	(*RunFunctionRequest)(nil),  // 6: apiextensions.fn.proto.v1.RunFunctionRequest
+	(*StateDelta)(nil),          // 7: apiextensions.fn.proto.v1.StateDelta
+	(*Credentials)(nil),         // 8: apiextensions.fn.proto.v1.Credentials
+	(*CredentialData)(nil),      // 9: apiextensions.fn.proto.v1.CredentialData
+	(*Resources)(nil),           // 10: apiextensions.fn.proto.v1.Resources
+	(*RunFunctionResponse)(nil), // 11: apiextensions.fn.proto.v1.RunFunctionResponse
+	(*RequestMeta)(nil),         // 12: apiextensions.fn.proto.v1.RequestMeta
+	(*Requirements)(nil),        // 13: apiextensions.fn.proto.v1.Requirements
+	(*SchemaSelector)(nil),      // 14: apiextensions.fn.proto.v1.SchemaSelector
+	(*Schema)(nil),              // 15: apiextensions.fn.proto.v1.Schema
+	(*ResourceSelector)(nil),    // 16: apiextensions.fn.proto.v1.ResourceSelector
+	(*MatchLabels)(nil),         // 17: apiextensions.fn.proto.v1.MatchLabels
+	(*ResponseMeta)(nil),        // 18: apiextensions.fn.proto.v1.ResponseMeta
+	(*State)(nil),               // 19: apiextensions.fn.proto.v1.State
+	(*Resource)(nil),            // 20: apiextensions.fn.proto.v1.Resource
+	(*Result)(nil),              // 21: apiextensions.fn.proto.v1.Result
+	(*Condition)(nil),           // 22: apiextensions.fn.proto.v1.Condition
+	nil,                         // 23: apiextensions.fn.proto.v1.RunFunctionRequest.ExtraResourcesEntry
+	nil,                         // 24: apiextensions.fn.proto.v1.RunFunctionRequest.CredentialsEntry
+	nil,                         // 25: apiextensions.fn.proto.v1.RunFunctionRequest.RequiredResourcesEntry
+	nil,                         // 26: apiextensions.fn.proto.v1.RunFunctionRequest.RequiredSchemasEntry
+	nil,                         // 27: apiextensions.fn.proto.v1.StateDelta.ResourcesEntry
+	nil,                         // 28: apiextensions.fn.proto.v1.CredentialData.DataEntry
+	nil,                         // 29: apiextensions.fn.proto.v1.Requirements.ExtraResourcesEntry
+	nil,                         // 30: apiextensions.fn.proto.v1.Requirements.ResourcesEntry
+	nil,                         // 31: apiextensions.fn.proto.v1.Requirements.SchemasEntry
+	nil,                         // 32: apiextensions.fn.proto.v1.MatchLabels.LabelsEntry
+	nil,                         // 33: apiextensions.fn.proto.v1.State.ResourcesEntry
+	nil,                         // 34: apiextensions.fn.proto.v1.Resource.ConnectionDetailsEntry
+	(*structpb.Struct)(nil),     // 35: google.protobuf.Struct
+	(*durationpb.Duration)(nil), // 36: google.protobuf.Duration
 }
 var file_proto_fn_v1_run_function_proto_depIdxs = []int32{
-	10, // 0: apiextensions.fn.proto.v1.RunFunctionRequest.meta:type_name -> apiextensions.fn.proto.v1.RequestMeta
-	17, // 1: apiextensions.fn.proto.v1.RunFunctionRequest.observed:type_name -> apiextensions.fn.proto.v1.State
-	17, // 2: apiextensions.fn.proto.v1.RunFunctionRequest.desired:type_name -> apiextensions.fn.proto.v1.State
-	32, // 3: apiextensions.fn.proto.v1.RunFunctionRequest.input:type_name -> google.protobuf.Struct
-	32, // 4: apiextensions.fn.proto.v1.RunFunctionRequest.context:type_name -> google.protobuf.Struct
-	21, // 5: apiextensions.fn.proto.v1.RunFunctionRequest.extra_resources:type_name -> apiextensions.fn.proto.v1.RunFunctionRequest.ExtraResourcesEntry
-	22, // 6: apiextensions.fn.proto.v1.RunFunctionRequest.credentials:type_name -> apiextensions.fn.proto.v1.RunFunctionRequest.CredentialsEntry
-	23, // 7: apiextensions.fn.proto.v1.RunFunctionRequest.required_resources:type_name -> apiextensions.fn.proto.v1.RunFunctionRequest.RequiredResourcesEntry
-	24, // 8: apiextensions.fn.proto.v1.RunFunctionRequest.required_schemas:type_name -> apiextensions.fn.proto.v1.RunFunctionRequest.RequiredSchemasEntry
-	7,  // 9: apiextensions.fn.proto.v1.Credentials.credential_data:type_name -> apiextensions.fn.proto.v1.CredentialData
-	25, // 10: apiextensions.fn.proto.v1.CredentialData.data:type_name -> apiextensions.fn.proto.v1.CredentialData.DataEntry
-	18, // 11: apiextensions.fn.proto.v1.Resources.items:type_name -> apiextensions.fn.proto.v1.Resource
-	16, // 12: apiextensions.fn.proto.v1.RunFunctionResponse.meta:type_name -> apiextensions.fn.proto.v1.ResponseMeta
-	17, // 13: apiextensions.fn.proto.v1.RunFunctionResponse.desired:type_name -> apiextensions.fn.proto.v1.State
-	19, // 14: apiextensions.fn.proto.v1.RunFunctionResponse.results:type_name -> apiextensions.fn.proto.v1.Result
-	32, // 15: apiextensions.fn.proto.v1.RunFunctionResponse.context:type_name -> google.protobuf.Struct
-	11, // 16: apiextensions.fn.proto.v1.RunFunctionResponse.requirements:type_name -> apiextensions.fn.proto.v1.Requirements
-	20, // 17: apiextensions.fn.proto.v1.RunFunctionResponse.conditions:type_name -> apiextensions.fn.proto.v1.Condition
-	32, // 18: apiextensions.fn.proto.v1.RunFunctionResponse.output:type_name -> google.protobuf.Struct
-	0,  // 19: apiextensions.fn.proto.v1.RequestMeta.capabilities:type_name -> apiextensions.fn.proto.v1.Capability
-	26, // 20: apiextensions.fn.proto.v1.Requirements.extra_resources:type_name -> apiextensions.fn.proto.v1.Requirements.ExtraResourcesEntry
-	27, // 21: apiextensions.fn.proto.v1.Requirements.resources:type_name -> apiextensions.fn.proto.v1.Requirements.ResourcesEntry
-	28, // 22: apiextensions.fn.proto.v1.Requirements.schemas:type_name -> apiextensions.fn.proto.v1.Requirements.SchemasEntry
-	32, // 23: apiextensions.fn.proto.v1.Schema.openapi_v3:type_name -> google.protobuf.Struct
-	15, // 24: apiextensions.fn.proto.v1.ResourceSelector.match_labels:type_name -> apiextensions.fn.proto.v1.MatchLabels
-	29, // 25: apiextensions.fn.proto.v1.MatchLabels.labels:type_name -> apiextensions.fn.proto.v1.MatchLabels.LabelsEntry
-	33, // 26: apiextensions.fn.proto.v1.ResponseMeta.ttl:type_name -> google.protobuf.Duration
-	18, // 27: apiextensions.fn.proto.v1.State.composite:type_name -> apiextensions.fn.proto.v1.Resource
-	30, // 28: apiextensions.fn.proto.v1.State.resources:type_name -> apiextensions.fn.proto.v1.State.ResourcesEntry
-	32, // 29: apiextensions.fn.proto.v1.Resource.resource:type_name -> google.protobuf.Struct
-	31, // 30: apiextensions.fn.proto.v1.Resource.connection_details:type_name -> apiextensions.fn.proto.v1.Resource.ConnectionDetailsEntry
-	1,  // 31: apiextensions.fn.proto.v1.Resource.ready:type_name -> apiextensions.fn.proto.v1.Ready
-	2,  // 32: apiextensions.fn.proto.v1.Result.severity:type_name -> apiextensions.fn.proto.v1.Severity
-	3,  // 33: apiextensions.fn.proto.v1.Result.target:type_name -> apiextensions.fn.proto.v1.Target
-	4,  // 34: apiextensions.fn.proto.v1.Condition.status:type_name -> apiextensions.fn.proto.v1.Status
-	3,  // 35: apiextensions.fn.proto.v1.Condition.target:type_name -> apiextensions.fn.proto.v1.Target
-	8,  // 36: apiextensions.fn.proto.v1.RunFunctionRequest.ExtraResourcesEntry.value:type_name -> apiextensions.fn.proto.v1.Resources
-	6,  // 37: apiextensions.fn.proto.v1.RunFunctionRequest.CredentialsEntry.value:type_name -> apiextensions.fn.proto.v1.Credentials
-	8,  // 38: apiextensions.fn.proto.v1.RunFunctionRequest.RequiredResourcesEntry.value:type_name -> apiextensions.fn.proto.v1.Resources
-	13, // 39: apiextensions.fn.proto.v1.RunFunctionRequest.RequiredSchemasEntry.value:type_name -> apiextensions.fn.proto.v1.Schema
-	14, // 40: apiextensions.fn.proto.v1.Requirements.ExtraResourcesEntry.value:type_name -> apiextensions.fn.proto.v1.ResourceSelector
-	14, // 41: apiextensions.fn.proto.v1.Requirements.ResourcesEntry.value:type_name -> apiextensions.fn.proto.v1.ResourceSelector
-	12, // 42: apiextensions.fn.proto.v1.Requirements.SchemasEntry.value:type_name -> apiextensions.fn.proto.v1.SchemaSelector
-	18, // 43: apiextensions.fn.proto.v1.State.ResourcesEntry.value:type_name -> apiextensions.fn.proto.v1.Resource
-	5,  // 44: apiextensions.fn.proto.v1.FunctionRunnerService.RunFunction:input_type -> apiextensions.fn.proto.v1.RunFunctionRequest
-	9,  // 45: apiextensions.fn.proto.v1.FunctionRunnerService.RunFunction:output_type -> apiextensions.fn.proto.v1.RunFunctionResponse
-	45, // [45:46] is the sub-list for method output_type
-	44, // [44:45] is the sub-list for method input_type
-	44, // [44:44] is the sub-list for extension type_name
-	44, // [44:44] is the sub-list for extension extendee
-	0,  // [0:44] is the sub-list for field type_name
+	12, // 0: apiextensions.fn.proto.v1.RunFunctionRequest.meta:type_name -> apiextensions.fn.proto.v1.RequestMeta
+	19, // 1: apiextensions.fn.proto.v1.RunFunctionRequest.observed:type_name -> apiextensions.fn.proto.v1.State
+	19, // 2: apiextensions.fn.proto.v1.RunFunctionRequest.desired:type_name -> apiextensions.fn.proto.v1.State
+	35, // 3: apiextensions.fn.proto.v1.RunFunctionRequest.input:type_name -> google.protobuf.Struct
+	35, // 4: apiextensions.fn.proto.v1.RunFunctionRequest.context:type_name -> google.protobuf.Struct
+	23, // 5: apiextensions.fn.proto.v1.RunFunctionRequest.extra_resources:type_name -> apiextensions.fn.proto.v1.RunFunctionRequest.ExtraResourcesEntry
+	24, // 6: apiextensions.fn.proto.v1.RunFunctionRequest.credentials:type_name -> apiextensions.fn.proto.v1.RunFunctionRequest.CredentialsEntry
+	25, // 7: apiextensions.fn.proto.v1.RunFunctionRequest.required_resources:type_name -> apiextensions.fn.proto.v1.RunFunctionRequest.RequiredResourcesEntry
+	26, // 8: apiextensions.fn.proto.v1.RunFunctionRequest.required_schemas:type_name -> apiextensions.fn.proto.v1.RunFunctionRequest.RequiredSchemasEntry
+	7,  // 9: apiextensions.fn.proto.v1.RunFunctionRequest.observed_delta:type_name -> apiextensions.fn.proto.v1.StateDelta
+	20, // 10: apiextensions.fn.proto.v1.StateDelta.composite:type_name -> apiextensions.fn.proto.v1.Resource
+	27, // 11: apiextensions.fn.proto.v1.StateDelta.resources:type_name -> apiextensions.fn.proto.v1.StateDelta.ResourcesEntry
+	9,  // 12: apiextensions.fn.proto.v1.Credentials.credential_data:type_name -> apiextensions.fn.proto.v1.CredentialData
+	28, // 13: apiextensions.fn.proto.v1.CredentialData.data:type_name -> apiextensions.fn.proto.v1.CredentialData.DataEntry
+	20, // 14: apiextensions.fn.proto.v1.Resources.items:type_name -> apiextensions.fn.proto.v1.Resource
+	18, // 15: apiextensions.fn.proto.v1.RunFunctionResponse.meta:type_name -> apiextensions.fn.proto.v1.ResponseMeta
+	19, // 16: apiextensions.fn.proto.v1.RunFunctionResponse.desired:type_name -> apiextensions.fn.proto.v1.State
+	21, // 17: apiextensions.fn.proto.v1.RunFunctionResponse.results:type_name -> apiextensions.fn.proto.v1.Result
+	35, // 18: apiextensions.fn.proto.v1.RunFunctionResponse.context:type_name -> google.protobuf.Struct
+	13, // 19: apiextensions.fn.proto.v1.RunFunctionResponse.requirements:type_name -> apiextensions.fn.proto.v1.Requirements
+	22, // 20: apiextensions.fn.proto.v1.RunFunctionResponse.conditions:type_name -> apiextensions.fn.proto.v1.Condition
+	35, // 21: apiextensions.fn.proto.v1.RunFunctionResponse.output:type_name -> google.protobuf.Struct
+	0,  // 22: apiextensions.fn.proto.v1.RequestMeta.capabilities:type_name -> apiextensions.fn.proto.v1.Capability
+	29, // 23: apiextensions.fn.proto.v1.Requirements.extra_resources:type_name -> apiextensions.fn.proto.v1.Requirements.ExtraResourcesEntry
+	30, // 24: apiextensions.fn.proto.v1.Requirements.resources:type_name -> apiextensions.fn.proto.v1.Requirements.ResourcesEntry
+	31, // 25: apiextensions.fn.proto.v1.Requirements.schemas:type_name -> apiextensions.fn.proto.v1.Requirements.SchemasEntry
+	35, // 26: apiextensions.fn.proto.v1.Schema.openapi_v3:type_name -> google.protobuf.Struct
+	17, // 27: apiextensions.fn.proto.v1.ResourceSelector.match_labels:type_name -> apiextensions.fn.proto.v1.MatchLabels
+	32, // 28: apiextensions.fn.proto.v1.MatchLabels.labels:type_name -> apiextensions.fn.proto.v1.MatchLabels.LabelsEntry
+	36, // 29: apiextensions.fn.proto.v1.ResponseMeta.ttl:type_name -> google.protobuf.Duration
+	20, // 30: apiextensions.fn.proto.v1.State.composite:type_name -> apiextensions.fn.proto.v1.Resource
+	33, // 31: apiextensions.fn.proto.v1.State.resources:type_name -> apiextensions.fn.proto.v1.State.ResourcesEntry
+	35, // 32: apiextensions.fn.proto.v1.Resource.resource:type_name -> google.protobuf.Struct
+	34, // 33: apiextensions.fn.proto.v1.Resource.connection_details:type_name -> apiextensions.fn.proto.v1.Resource.ConnectionDetailsEntry
+	1,  // 34: apiextensions.fn.proto.v1.Resource.ready:type_name -> apiextensions.fn.proto.v1.Ready
+	2,  // 35: apiextensions.fn.proto.v1.Result.severity:type_name -> apiextensions.fn.proto.v1.Severity
+	3,  // 36: apiextensions.fn.proto.v1.Result.target:type_name -> apiextensions.fn.proto.v1.Target
+	4,  // 37: apiextensions.fn.proto.v1.Condition.status:type_name -> apiextensions.fn.proto.v1.Status
+	3,  // 38: apiextensions.fn.proto.v1.Condition.target:type_name -> apiextensions.fn.proto.v1.Target
+	10, // 39: apiextensions.fn.proto.v1.RunFunctionRequest.ExtraResourcesEntry.value:type_name -> apiextensions.fn.proto.v1.Resources
+	8,  // 40: apiextensions.fn.proto.v1.RunFunctionRequest.CredentialsEntry.value:type_name -> apiextensions.fn.proto.v1.Credentials
+	10, // 41: apiextensions.fn.proto.v1.RunFunctionRequest.RequiredResourcesEntry.value:type_name -> apiextensions.fn.proto.v1.Resources
+	15, // 42: apiextensions.fn.proto.v1.RunFunctionRequest.RequiredSchemasEntry.value:type_name -> apiextensions.fn.proto.v1.Schema
+	20, // 43: apiextensions.fn.proto.v1.StateDelta.ResourcesEntry.value:type_name -> apiextensions.fn.proto.v1.Resource
+	16, // 44: apiextensions.fn.proto.v1.Requirements.ExtraResourcesEntry.value:type_name -> apiextensions.fn.proto.v1.ResourceSelector
+	16, // 45: apiextensions.fn.proto.v1.Requirements.ResourcesEntry.value:type_name -> apiextensions.fn.proto.v1.ResourceSelector
+	14, // 46: apiextensions.fn.proto.v1.Requirements.SchemasEntry.value:type_name -> apiextensions.fn.proto.v1.SchemaSelector
+	20, // 47: apiextensions.fn.proto.v1.State.ResourcesEntry.value:type_name -> apiextensions.fn.proto.v1.Resource
+	6,  // 48: apiextensions.fn.proto.v1.FunctionRunnerService.RunFunction:input_type -> apiextensions.fn.proto.v1.RunFunctionRequest
+	5,  // 49: apiextensions.fn.proto.v1.FunctionRunnerService.RunFunctionStream:input_type -> apiextensions.fn.proto.v1.RunFunctionChunk
+	11, // 50: apiextensions.fn.proto.v1.FunctionRunnerService.RunFunction:output_type -> apiextensions.fn.proto.v1.RunFunctionResponse
+	5,  // 51: apiextensions.fn.proto.v1.FunctionRunnerService.RunFunctionStream:output_type -> apiextensions.fn.proto.v1.RunFunctionChunk
+	50, // [50:52] is the sub-list for method output_type
+	48, // [48:50] is the sub-list for method input_type
+	48, // [48:48] is the sub-list for extension type_name
+	48, // [48:48] is the sub-list for extension extendee
+	0,  // [0:48] is the sub-list for field type_name
 }
 
 func init() { file_proto_fn_v1_run_function_proto_init() }
@@ -1814,26 +2022,27 @@ func file_proto_fn_v1_run_function_proto_init() {
 	if File_proto_fn_v1_run_function_proto != nil {
 		return
 	}
-	file_proto_fn_v1_run_function_proto_msgTypes[0].OneofWrappers = []any{}
-	file_proto_fn_v1_run_function_proto_msgTypes[1].OneofWrappers = []any{
+	file_proto_fn_v1_run_function_proto_msgTypes[1].OneofWrappers = []any{}
+	file_proto_fn_v1_run_function_proto_msgTypes[2].OneofWrappers = []any{}
+	file_proto_fn_v1_run_function_proto_msgTypes[3].OneofWrappers = []any{
 		(*Credentials_CredentialData)(nil),
 	}
-	file_proto_fn_v1_run_function_proto_msgTypes[4].OneofWrappers = []any{}
-	file_proto_fn_v1_run_function_proto_msgTypes[8].OneofWrappers = []any{}
-	file_proto_fn_v1_run_function_proto_msgTypes[9].OneofWrappers = []any{
+	file_proto_fn_v1_run_function_proto_msgTypes[6].OneofWrappers = []any{}
+	file_proto_fn_v1_run_function_proto_msgTypes[10].OneofWrappers = []any{}
+	file_proto_fn_v1_run_function_proto_msgTypes[11].OneofWrappers = []any{
 		(*ResourceSelector_MatchName)(nil),
 		(*ResourceSelector_MatchLabels)(nil),
 	}
-	file_proto_fn_v1_run_function_proto_msgTypes[11].OneofWrappers = []any{}
-	file_proto_fn_v1_run_function_proto_msgTypes[14].OneofWrappers = []any{}
-	file_proto_fn_v1_run_function_proto_msgTypes[15].OneofWrappers = []any{}
+	file_proto_fn_v1_run_function_proto_msgTypes[13].OneofWrappers = []any{}
+	file_proto_fn_v1_run_function_proto_msgTypes[16].OneofWrappers = []any{}
+	file_proto_fn_v1_run_function_proto_msgTypes[17].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_fn_v1_run_function_proto_rawDesc), len(file_proto_fn_v1_run_function_proto_rawDesc)),
 			NumEnums:      5,
-			NumMessages:   27,
+			NumMessages:   30,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
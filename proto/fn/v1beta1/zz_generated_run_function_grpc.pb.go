@@ -43,7 +43,8 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	FunctionRunnerService_RunFunction_FullMethodName = "/apiextensions.fn.proto.v1beta1.FunctionRunnerService/RunFunction"
+	FunctionRunnerService_RunFunction_FullMethodName       = "/apiextensions.fn.proto.v1beta1.FunctionRunnerService/RunFunction"
+	FunctionRunnerService_RunFunctionStream_FullMethodName = "/apiextensions.fn.proto.v1beta1.FunctionRunnerService/RunFunctionStream"
 )
 
 // FunctionRunnerServiceClient is the client API for FunctionRunnerService service.
@@ -54,6 +55,11 @@ const (
 type FunctionRunnerServiceClient interface {
 	// RunFunction runs the function.
 	RunFunction(ctx context.Context, in *RunFunctionRequest, opts ...grpc.CallOption) (*RunFunctionResponse, error)
+	// RunFunctionStream runs the function, sending its request and response in
+	// chunks. Crossplane only calls it for functions that advertise the
+	// streaming capability in their package metadata, and only when a request
+	// is too large to send in one message.
+	RunFunctionStream(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[RunFunctionChunk, RunFunctionChunk], error)
 }
 
 type functionRunnerServiceClient struct {
@@ -74,6 +80,19 @@ func (c *functionRunnerServiceClient) RunFunction(ctx context.Context, in *RunFu
 	return out, nil
 }
 
+func (c *functionRunnerServiceClient) RunFunctionStream(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[RunFunctionChunk, RunFunctionChunk], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &FunctionRunnerService_ServiceDesc.Streams[0], FunctionRunnerService_RunFunctionStream_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[RunFunctionChunk, RunFunctionChunk]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type FunctionRunnerService_RunFunctionStreamClient = grpc.BidiStreamingClient[RunFunctionChunk, RunFunctionChunk]
+
 // FunctionRunnerServiceServer is the server API for FunctionRunnerService service.
 // All implementations must embed UnimplementedFunctionRunnerServiceServer
 // for forward compatibility.
@@ -82,6 +101,11 @@ func (c *functionRunnerServiceClient) RunFunction(ctx context.Context, in *RunFu
 type FunctionRunnerServiceServer interface {
 	// RunFunction runs the function.
 	RunFunction(context.Context, *RunFunctionRequest) (*RunFunctionResponse, error)
+	// RunFunctionStream runs the function, sending its request and response in
+	// chunks. Crossplane only calls it for functions that advertise the
+	// streaming capability in their package metadata, and only when a request
+	// is too large to send in one message.
+	RunFunctionStream(grpc.BidiStreamingServer[RunFunctionChunk, RunFunctionChunk]) error
 	mustEmbedUnimplementedFunctionRunnerServiceServer()
 }
 
@@ -95,6 +119,9 @@ type UnimplementedFunctionRunnerServiceServer struct{}
 func (UnimplementedFunctionRunnerServiceServer) RunFunction(context.Context, *RunFunctionRequest) (*RunFunctionResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method RunFunction not implemented")
 }
+func (UnimplementedFunctionRunnerServiceServer) RunFunctionStream(grpc.BidiStreamingServer[RunFunctionChunk, RunFunctionChunk]) error {
+	return status.Error(codes.Unimplemented, "method RunFunctionStream not implemented")
+}
 func (UnimplementedFunctionRunnerServiceServer) mustEmbedUnimplementedFunctionRunnerServiceServer() {}
 func (UnimplementedFunctionRunnerServiceServer) testEmbeddedByValue()                               {}
 
@@ -134,6 +161,13 @@ func _FunctionRunnerService_RunFunction_Handler(srv interface{}, ctx context.Con
 	return interceptor(ctx, in, info, handler)
 }
 
+func _FunctionRunnerService_RunFunctionStream_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(FunctionRunnerServiceServer).RunFunctionStream(&grpc.GenericServerStream[RunFunctionChunk, RunFunctionChunk]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type FunctionRunnerService_RunFunctionStreamServer = grpc.BidiStreamingServer[RunFunctionChunk, RunFunctionChunk]
+
 // FunctionRunnerService_ServiceDesc is the grpc.ServiceDesc for FunctionRunnerService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -146,6 +180,13 @@ var FunctionRunnerService_ServiceDesc = grpc.ServiceDesc{
 			Handler:    _FunctionRunnerService_RunFunction_Handler,
 		},
 	},
-	Streams:  []grpc.StreamDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "RunFunctionStream",
+			Handler:       _FunctionRunnerService_RunFunctionStream_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+	},
 	Metadata: "proto/fn/v1beta1/zz_generated_run_function.proto",
 }
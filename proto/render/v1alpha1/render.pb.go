@@ -22,7 +22,7 @@
 // This package defines the render protocol. The render engine runs one real
 // Reconcile() call against a fake in-memory client, backed by the real
 // Crossplane reconciler. It accepts a RenderRequest on stdin and writes a
-// RenderResponse to stdout.
+// RenderResponse to stdout, or serves the RenderService gRPC API.
 
 //buf:lint:ignore PACKAGE_DIRECTORY_MATCH
 
@@ -45,6 +45,116 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+// FixtureMode is how render uses function fixtures.
+type FixtureMode int32
+
+const (
+	FixtureMode_FIXTURE_MODE_UNSPECIFIED FixtureMode = 0
+	// Call the functions, and record each response to a fixture file.
+	FixtureMode_FIXTURE_MODE_RECORD FixtureMode = 1
+	// Don't call the functions. Return each response from its fixture file.
+	// Render fails if a request has no fixture file. Function addresses are
+	// ignored.
+	FixtureMode_FIXTURE_MODE_REPLAY FixtureMode = 2
+)
+
+// Enum value maps for FixtureMode.
+var (
+	FixtureMode_name = map[int32]string{
+		0: "FIXTURE_MODE_UNSPECIFIED",
+		1: "FIXTURE_MODE_RECORD",
+		2: "FIXTURE_MODE_REPLAY",
+	}
+	FixtureMode_value = map[string]int32{
+		"FIXTURE_MODE_UNSPECIFIED": 0,
+		"FIXTURE_MODE_RECORD":      1,
+		"FIXTURE_MODE_REPLAY":      2,
+	}
+)
+
+func (x FixtureMode) Enum() *FixtureMode {
+	p := new(FixtureMode)
+	*p = x
+	return p
+}
+
+func (x FixtureMode) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (FixtureMode) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_render_v1alpha1_render_proto_enumTypes[0].Descriptor()
+}
+
+func (FixtureMode) Type() protoreflect.EnumType {
+	return &file_proto_render_v1alpha1_render_proto_enumTypes[0]
+}
+
+func (x FixtureMode) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use FixtureMode.Descriptor instead.
+func (FixtureMode) EnumDescriptor() ([]byte, []int) {
+	return file_proto_render_v1alpha1_render_proto_rawDescGZIP(), []int{0}
+}
+
+// ChangeType is how a composed resource would change.
+type ChangeType int32
+
+const (
+	ChangeType_CHANGE_TYPE_UNSPECIFIED ChangeType = 0
+	// The reconciler would create the resource.
+	ChangeType_CHANGE_TYPE_ADDED ChangeType = 1
+	// The reconciler would update the resource.
+	ChangeType_CHANGE_TYPE_CHANGED ChangeType = 2
+	// The reconciler would delete the resource.
+	ChangeType_CHANGE_TYPE_REMOVED ChangeType = 3
+)
+
+// Enum value maps for ChangeType.
+var (
+	ChangeType_name = map[int32]string{
+		0: "CHANGE_TYPE_UNSPECIFIED",
+		1: "CHANGE_TYPE_ADDED",
+		2: "CHANGE_TYPE_CHANGED",
+		3: "CHANGE_TYPE_REMOVED",
+	}
+	ChangeType_value = map[string]int32{
+		"CHANGE_TYPE_UNSPECIFIED": 0,
+		"CHANGE_TYPE_ADDED":       1,
+		"CHANGE_TYPE_CHANGED":     2,
+		"CHANGE_TYPE_REMOVED":     3,
+	}
+)
+
+func (x ChangeType) Enum() *ChangeType {
+	p := new(ChangeType)
+	*p = x
+	return p
+}
+
+func (x ChangeType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ChangeType) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_render_v1alpha1_render_proto_enumTypes[1].Descriptor()
+}
+
+func (ChangeType) Type() protoreflect.EnumType {
+	return &file_proto_render_v1alpha1_render_proto_enumTypes[1]
+}
+
+func (x ChangeType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ChangeType.Descriptor instead.
+func (ChangeType) EnumDescriptor() ([]byte, []int) {
+	return file_proto_render_v1alpha1_render_proto_rawDescGZIP(), []int{1}
+}
+
 // A RenderRequest asks the render engine to render a resource.
 type RenderRequest struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
@@ -428,6 +538,65 @@ func (x *FunctionInput) GetAddress() string {
 	return ""
 }
 
+// FunctionFixtures configures render to record function responses to, or
+// replay function responses from, fixture files. Each fixture file contains a
+// request and the function's response to it. Fixture files are stored in a
+// directory per function, named for the xfn.Tag of the request. Credentials,
+// connection details, and Secret data are redacted from recorded requests.
+type FunctionFixtures struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The directory containing fixture files.
+	Directory string `protobuf:"bytes,1,opt,name=directory,proto3" json:"directory,omitempty"`
+	// Whether to record or replay fixtures.
+	Mode          FixtureMode `protobuf:"varint,2,opt,name=mode,proto3,enum=crossplane.render.v1alpha1.FixtureMode" json:"mode,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FunctionFixtures) Reset() {
+	*x = FunctionFixtures{}
+	mi := &file_proto_render_v1alpha1_render_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FunctionFixtures) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FunctionFixtures) ProtoMessage() {}
+
+func (x *FunctionFixtures) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_render_v1alpha1_render_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FunctionFixtures.ProtoReflect.Descriptor instead.
+func (*FunctionFixtures) Descriptor() ([]byte, []int) {
+	return file_proto_render_v1alpha1_render_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *FunctionFixtures) GetDirectory() string {
+	if x != nil {
+		return x.Directory
+	}
+	return ""
+}
+
+func (x *FunctionFixtures) GetMode() FixtureMode {
+	if x != nil {
+		return x.Mode
+	}
+	return FixtureMode_FIXTURE_MODE_UNSPECIFIED
+}
+
 // An Event represents a Kubernetes event the reconciler would emit.
 type Event struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
@@ -443,7 +612,7 @@ type Event struct {
 
 func (x *Event) Reset() {
 	*x = Event{}
-	mi := &file_proto_render_v1alpha1_render_proto_msgTypes[5]
+	mi := &file_proto_render_v1alpha1_render_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -455,7 +624,7 @@ func (x *Event) String() string {
 func (*Event) ProtoMessage() {}
 
 func (x *Event) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_render_v1alpha1_render_proto_msgTypes[5]
+	mi := &file_proto_render_v1alpha1_render_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -468,7 +637,7 @@ func (x *Event) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Event.ProtoReflect.Descriptor instead.
 func (*Event) Descriptor() ([]byte, []int) {
-	return file_proto_render_v1alpha1_render_proto_rawDescGZIP(), []int{5}
+	return file_proto_render_v1alpha1_render_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *Event) GetType() string {
@@ -518,19 +687,88 @@ type CompositeInput struct {
 	// mirroring what the production reconciler does. Its composite GVK must
 	// match the input XR.
 	//
-	// Render does not recurse into composed XRs. Callers that need to render
-	// a chain of nested XRs should invoke render once per XR, supplying the
-	// matching XRD on each call.
-	//
-	// Optional. If absent, the binary falls back to Schema=Modern.
+	// Optional. If absent, the binary looks for a matching XRD in
+	// composite_resource_definitions, and falls back to Schema=Modern if it
+	// finds none.
 	CompositeResourceDefinition *structpb.Struct `protobuf:"bytes,8,opt,name=composite_resource_definition,json=compositeResourceDefinition,proto3" json:"composite_resource_definition,omitempty"`
-	unknownFields               protoimpl.UnknownFields
-	sizeCache                   protoimpl.SizeCache
+	// Recursive tells render to also render any composed resource that is
+	// itself a composite resource. A composed resource is considered an XR if
+	// its Group+Kind matches one of composite_resource_definitions. Each
+	// composed XR is rendered using the same functions, required resources,
+	// credentials and required schemas as the input XR. Optional.
+	Recursive bool `protobuf:"varint,9,opt,name=recursive,proto3" json:"recursive,omitempty"`
+	// CompositeResourceDefinitions that define composed XRs. Only used when
+	// recursive is true. Optional.
+	CompositeResourceDefinitions []*structpb.Struct `protobuf:"bytes,10,rep,name=composite_resource_definitions,json=compositeResourceDefinitions,proto3" json:"composite_resource_definitions,omitempty"`
+	// Compositions that composed XRs may select. Only used when recursive is
+	// true. Render selects a composed XR's Composition like the XR controller
+	// does: the XRD's enforced Composition, then the XR's Composition
+	// reference, then the XRD's default Composition, then the XR's Composition
+	// selector. Where the controller would pick one of several Compositions
+	// matching the selector at random, render requires exactly one to match.
+	// Optional.
+	Compositions []*structpb.Struct `protobuf:"bytes,11,rep,name=compositions,proto3" json:"compositions,omitempty"`
+	// Reconciles is the maximum number of times to run the XR reconciler.
+	// Between reconciles render feeds the XR and the composed resources it
+	// applied back in, as the XR and its observed resources. Render stops early
+	// if a reconcile produces the same XR and composed resources as the one
+	// before it. Optional. Defaults to one reconcile.
+	Reconciles int32 `protobuf:"varint,12,opt,name=reconciles,proto3" json:"reconciles,omitempty"`
+	// Patches to apply to composed resources between reconciles. They
+	// simulate other controllers, typically providers, updating composed
+	// resources - for example to make them ready. Only used when reconciles is
+	// greater than one. Optional.
+	ComposedResourcePatches []*ComposedResourcePatch `protobuf:"bytes,13,rep,name=composed_resource_patches,json=composedResourcePatches,proto3" json:"composed_resource_patches,omitempty"`
+	// ServerSideApply tells render to merge the resources the reconciler applies
+	// into the resources it observed the way an API server would, using
+	// server-side apply field ownership. Render tracks the fields each field
+	// manager owns in metadata.managedFields. A field the reconciler stops
+	// applying is removed only if no other field manager owns it. Render uses
+	// required_schemas and the XRDs to determine how to merge each kind. Kinds
+	// without a schema are merged as if each field were atomic. Optional.
+	ServerSideApply bool `protobuf:"varint,14,opt,name=server_side_apply,json=serverSideApply,proto3" json:"server_side_apply,omitempty"`
+	// Validate tells render to validate the XR and each composed resource
+	// against its schema, the way an API server would validate it. This
+	// includes CEL x-kubernetes-validations rules, and checking for fields the
+	// schema doesn't declare. Render uses required_schemas and the XRDs to find
+	// each resource's schema. Resources without a schema aren't validated.
+	// Validation errors don't cause render to fail. Render returns them in the
+	// output's validation_results. Optional.
+	Validate bool `protobuf:"varint,15,opt,name=validate,proto3" json:"validate,omitempty"`
+	// Diff tells render to compare each composed resource the reconciler would
+	// apply or delete with the corresponding observed resource, and return the
+	// differences in the output's diffs. Diff implies server_side_apply, so
+	// each diff reflects what an API server would store after the reconciler's
+	// server-side apply - not just the fields the reconciler applied. When
+	// reconciles is greater than one, render compares the final reconcile's
+	// composed resources with the input's observed resources. Optional.
+	Diff bool `protobuf:"varint,16,opt,name=diff,proto3" json:"diff,omitempty"`
+	// Trace tells render to record the request and response of each function
+	// pipeline step in the output's traces, like the Pipeline Inspector does.
+	// Credentials, connection details, and Secret data are redacted. Optional.
+	Trace bool `protobuf:"varint,17,opt,name=trace,proto3" json:"trace,omitempty"`
+	// Fixtures to record function responses to, or replay them from. Optional.
+	// By default render calls the functions without recording their responses.
+	Fixtures *FunctionFixtures `protobuf:"bytes,18,opt,name=fixtures,proto3" json:"fixtures,omitempty"`
+	// Usages, ClusterUsages, and legacy apiextensions.crossplane.io Usages that
+	// may block the deletion of composed resources. Composed Usages are also
+	// considered. Only used when simulate_deletions is true. Usages must
+	// reference the resources they protect and the resources using them by
+	// name - render doesn't resolve resource selectors. Optional.
+	Usages []*structpb.Struct `protobuf:"bytes,19,rep,name=usages,proto3" json:"usages,omitempty"`
+	// SimulateDeletions tells render to simulate deleting the composed
+	// resources the reconciler would garbage collect, and return the order in
+	// which their deletions would succeed in the output's deletions. Render
+	// finds the usages of each resource the way Crossplane's Usage admission
+	// webhook does. Optional.
+	SimulateDeletions bool `protobuf:"varint,20,opt,name=simulate_deletions,json=simulateDeletions,proto3" json:"simulate_deletions,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
 }
 
 func (x *CompositeInput) Reset() {
 	*x = CompositeInput{}
-	mi := &file_proto_render_v1alpha1_render_proto_msgTypes[6]
+	mi := &file_proto_render_v1alpha1_render_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -542,7 +780,7 @@ func (x *CompositeInput) String() string {
 func (*CompositeInput) ProtoMessage() {}
 
 func (x *CompositeInput) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_render_v1alpha1_render_proto_msgTypes[6]
+	mi := &file_proto_render_v1alpha1_render_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -555,7 +793,7 @@ func (x *CompositeInput) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CompositeInput.ProtoReflect.Descriptor instead.
 func (*CompositeInput) Descriptor() ([]byte, []int) {
-	return file_proto_render_v1alpha1_render_proto_rawDescGZIP(), []int{6}
+	return file_proto_render_v1alpha1_render_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *CompositeInput) GetCompositeResource() *structpb.Struct {
@@ -614,6 +852,148 @@ func (x *CompositeInput) GetCompositeResourceDefinition() *structpb.Struct {
 	return nil
 }
 
+func (x *CompositeInput) GetRecursive() bool {
+	if x != nil {
+		return x.Recursive
+	}
+	return false
+}
+
+func (x *CompositeInput) GetCompositeResourceDefinitions() []*structpb.Struct {
+	if x != nil {
+		return x.CompositeResourceDefinitions
+	}
+	return nil
+}
+
+func (x *CompositeInput) GetCompositions() []*structpb.Struct {
+	if x != nil {
+		return x.Compositions
+	}
+	return nil
+}
+
+func (x *CompositeInput) GetReconciles() int32 {
+	if x != nil {
+		return x.Reconciles
+	}
+	return 0
+}
+
+func (x *CompositeInput) GetComposedResourcePatches() []*ComposedResourcePatch {
+	if x != nil {
+		return x.ComposedResourcePatches
+	}
+	return nil
+}
+
+func (x *CompositeInput) GetServerSideApply() bool {
+	if x != nil {
+		return x.ServerSideApply
+	}
+	return false
+}
+
+func (x *CompositeInput) GetValidate() bool {
+	if x != nil {
+		return x.Validate
+	}
+	return false
+}
+
+func (x *CompositeInput) GetDiff() bool {
+	if x != nil {
+		return x.Diff
+	}
+	return false
+}
+
+func (x *CompositeInput) GetTrace() bool {
+	if x != nil {
+		return x.Trace
+	}
+	return false
+}
+
+func (x *CompositeInput) GetFixtures() *FunctionFixtures {
+	if x != nil {
+		return x.Fixtures
+	}
+	return nil
+}
+
+func (x *CompositeInput) GetUsages() []*structpb.Struct {
+	if x != nil {
+		return x.Usages
+	}
+	return nil
+}
+
+func (x *CompositeInput) GetSimulateDeletions() bool {
+	if x != nil {
+		return x.SimulateDeletions
+	}
+	return false
+}
+
+// A ComposedResourcePatch is applied to a composed resource between
+// reconciles.
+type ComposedResourcePatch struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The name of the composed resource in the function pipeline, i.e. the
+	// value of its crossplane.io/composition-resource-name annotation.
+	ResourceName string `protobuf:"bytes,1,opt,name=resource_name,json=resourceName,proto3" json:"resource_name,omitempty"`
+	// A JSON merge patch (RFC 7386) to apply to the composed resource. For
+	// example {"status":{"conditions":[{"type":"Ready","status":"True"}]}}.
+	Patch         *structpb.Struct `protobuf:"bytes,2,opt,name=patch,proto3" json:"patch,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ComposedResourcePatch) Reset() {
+	*x = ComposedResourcePatch{}
+	mi := &file_proto_render_v1alpha1_render_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ComposedResourcePatch) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ComposedResourcePatch) ProtoMessage() {}
+
+func (x *ComposedResourcePatch) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_render_v1alpha1_render_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ComposedResourcePatch.ProtoReflect.Descriptor instead.
+func (*ComposedResourcePatch) Descriptor() ([]byte, []int) {
+	return file_proto_render_v1alpha1_render_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *ComposedResourcePatch) GetResourceName() string {
+	if x != nil {
+		return x.ResourceName
+	}
+	return ""
+}
+
+func (x *ComposedResourcePatch) GetPatch() *structpb.Struct {
+	if x != nil {
+		return x.Patch
+	}
+	return nil
+}
+
 // A CompositeOutput contains the results of rendering a composite resource.
 type CompositeOutput struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
@@ -631,13 +1011,37 @@ type CompositeOutput struct {
 	// Required schemas that were requested by the function pipeline. The structs
 	// are fnv1.SchemaSelector messages.
 	RequiredSchemas []*structpb.Struct `protobuf:"bytes,6,rep,name=required_schemas,json=requiredSchemas,proto3" json:"required_schemas,omitempty"`
-	unknownFields   protoimpl.UnknownFields
-	sizeCache       protoimpl.SizeCache
+	// The results of rendering each composed resource that is itself a
+	// composite resource, in the same order as composed_resources. Each may in
+	// turn contain the results of rendering its own composed XRs. Only set
+	// when the input's recursive field is true.
+	NestedComposites []*CompositeOutput `protobuf:"bytes,7,rep,name=nested_composites,json=nestedComposites,proto3" json:"nested_composites,omitempty"`
+	// The number of times render ran the XR reconciler. Every other field
+	// reflects the final reconcile, except deleted_resources and events which
+	// accumulate across all reconciles.
+	Reconciles int32 `protobuf:"varint,8,opt,name=reconciles,proto3" json:"reconciles,omitempty"`
+	// The XR and composed resources that failed validation, if any. Only set
+	// when the input's validate field is true.
+	ValidationResults []*ValidationResult `protobuf:"bytes,9,rep,name=validation_results,json=validationResults,proto3" json:"validation_results,omitempty"`
+	// How each composed resource would change, relative to the input's
+	// observed resources. Composed resources that wouldn't change are omitted.
+	// Only set when the input's diff field is true.
+	Diffs []*ResourceDiff `protobuf:"bytes,10,rep,name=diffs,proto3" json:"diffs,omitempty"`
+	// The request and response of each function pipeline step, in the order
+	// the steps ran. Like events, traces accumulate across all reconciles. Only
+	// set when the input's trace field is true.
+	Traces []*StepTrace `protobuf:"bytes,11,rep,name=traces,proto3" json:"traces,omitempty"`
+	// The order in which the deleted_resources would be deleted, taking
+	// usages into account. Only set when the input's simulate_deletions field
+	// is true.
+	Deletions     []*ResourceDeletion `protobuf:"bytes,12,rep,name=deletions,proto3" json:"deletions,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
 func (x *CompositeOutput) Reset() {
 	*x = CompositeOutput{}
-	mi := &file_proto_render_v1alpha1_render_proto_msgTypes[7]
+	mi := &file_proto_render_v1alpha1_render_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -649,7 +1053,7 @@ func (x *CompositeOutput) String() string {
 func (*CompositeOutput) ProtoMessage() {}
 
 func (x *CompositeOutput) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_render_v1alpha1_render_proto_msgTypes[7]
+	mi := &file_proto_render_v1alpha1_render_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -662,7 +1066,7 @@ func (x *CompositeOutput) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CompositeOutput.ProtoReflect.Descriptor instead.
 func (*CompositeOutput) Descriptor() ([]byte, []int) {
-	return file_proto_render_v1alpha1_render_proto_rawDescGZIP(), []int{7}
+	return file_proto_render_v1alpha1_render_proto_rawDescGZIP(), []int{9}
 }
 
 func (x *CompositeOutput) GetCompositeResource() *structpb.Struct {
@@ -707,96 +1111,917 @@ func (x *CompositeOutput) GetRequiredSchemas() []*structpb.Struct {
 	return nil
 }
 
-// An OperationInput contains all inputs needed to render an Operation using the
-// real Operation reconciler.
-type OperationInput struct {
-	state protoimpl.MessageState `protogen:"open.v1"`
-	// The Operation to reconcile.
-	Operation *structpb.Struct `protobuf:"bytes,1,opt,name=operation,proto3" json:"operation,omitempty"`
-	// Functions to call in the pipeline.
-	Functions []*FunctionInput `protobuf:"bytes,2,rep,name=functions,proto3" json:"functions,omitempty"`
-	// Resources available for functions that request them via the Requirements
-	// protocol. Optional.
-	RequiredResources []*structpb.Struct `protobuf:"bytes,3,rep,name=required_resources,json=requiredResources,proto3" json:"required_resources,omitempty"`
-	// Kubernetes Secrets for function credentials. Optional.
-	Credentials []*structpb.Struct `protobuf:"bytes,4,rep,name=credentials,proto3" json:"credentials,omitempty"`
-	// OpenAPI v3 documents providing schemas for resource kinds. Functions can
-	// request schemas via the Requirements protocol. Each entry is a full OpenAPI
-	// v3 document as JSON. Optional.
-	RequiredSchemas []*structpb.Struct `protobuf:"bytes,5,rep,name=required_schemas,json=requiredSchemas,proto3" json:"required_schemas,omitempty"`
-	unknownFields   protoimpl.UnknownFields
-	sizeCache       protoimpl.SizeCache
-}
-
-func (x *OperationInput) Reset() {
-	*x = OperationInput{}
-	mi := &file_proto_render_v1alpha1_render_proto_msgTypes[8]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
-}
-
-func (x *OperationInput) String() string {
-	return protoimpl.X.MessageStringOf(x)
-}
-
-func (*OperationInput) ProtoMessage() {}
-
-func (x *OperationInput) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_render_v1alpha1_render_proto_msgTypes[8]
+func (x *CompositeOutput) GetNestedComposites() []*CompositeOutput {
 	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+		return x.NestedComposites
 	}
-	return mi.MessageOf(x)
-}
-
-// Deprecated: Use OperationInput.ProtoReflect.Descriptor instead.
-func (*OperationInput) Descriptor() ([]byte, []int) {
-	return file_proto_render_v1alpha1_render_proto_rawDescGZIP(), []int{8}
+	return nil
 }
 
-func (x *OperationInput) GetOperation() *structpb.Struct {
+func (x *CompositeOutput) GetReconciles() int32 {
 	if x != nil {
-		return x.Operation
+		return x.Reconciles
 	}
-	return nil
+	return 0
 }
 
-func (x *OperationInput) GetFunctions() []*FunctionInput {
+func (x *CompositeOutput) GetValidationResults() []*ValidationResult {
 	if x != nil {
-		return x.Functions
+		return x.ValidationResults
 	}
 	return nil
 }
 
-func (x *OperationInput) GetRequiredResources() []*structpb.Struct {
+func (x *CompositeOutput) GetDiffs() []*ResourceDiff {
 	if x != nil {
-		return x.RequiredResources
+		return x.Diffs
 	}
 	return nil
 }
 
-func (x *OperationInput) GetCredentials() []*structpb.Struct {
+func (x *CompositeOutput) GetTraces() []*StepTrace {
 	if x != nil {
-		return x.Credentials
+		return x.Traces
 	}
 	return nil
 }
 
-func (x *OperationInput) GetRequiredSchemas() []*structpb.Struct {
+func (x *CompositeOutput) GetDeletions() []*ResourceDeletion {
 	if x != nil {
-		return x.RequiredSchemas
+		return x.Deletions
 	}
 	return nil
 }
 
-// An OperationOutput contains the results of rendering an Operation.
-type OperationOutput struct {
+// A ResourceDeletion describes when a composed resource would be deleted.
+type ResourceDeletion struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
-	// The Operation with status set by the reconciler.
+	// The resource's API version.
+	ApiVersion string `protobuf:"bytes,1,opt,name=api_version,json=apiVersion,proto3" json:"api_version,omitempty"`
+	// The resource's kind.
+	Kind string `protobuf:"bytes,2,opt,name=kind,proto3" json:"kind,omitempty"`
+	// The resource's namespace, if it's namespaced.
+	Namespace string `protobuf:"bytes,3,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	// The resource's name.
+	Name string `protobuf:"bytes,4,opt,name=name,proto3" json:"name,omitempty"`
+	// The composed resource's name in the function pipeline, i.e. the value of
+	// its crossplane.io/composition-resource-name annotation.
+	CompositionResourceName string `protobuf:"bytes,5,opt,name=composition_resource_name,json=compositionResourceName,proto3" json:"composition_resource_name,omitempty"`
+	// The wave in which the deletion would succeed, starting at 1. Deletions in
+	// the same wave don't block each other. A deletion in a wave is blocked
+	// until the deletions in the previous wave succeed. Zero if the deletion
+	// would be blocked indefinitely.
+	Wave int32 `protobuf:"varint,6,opt,name=wave,proto3" json:"wave,omitempty"`
+	// Why the deletion would be blocked indefinitely. Empty if the deletion
+	// would succeed.
+	BlockedReason string `protobuf:"bytes,7,opt,name=blocked_reason,json=blockedReason,proto3" json:"blocked_reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResourceDeletion) Reset() {
+	*x = ResourceDeletion{}
+	mi := &file_proto_render_v1alpha1_render_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResourceDeletion) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResourceDeletion) ProtoMessage() {}
+
+func (x *ResourceDeletion) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_render_v1alpha1_render_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResourceDeletion.ProtoReflect.Descriptor instead.
+func (*ResourceDeletion) Descriptor() ([]byte, []int) {
+	return file_proto_render_v1alpha1_render_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *ResourceDeletion) GetApiVersion() string {
+	if x != nil {
+		return x.ApiVersion
+	}
+	return ""
+}
+
+func (x *ResourceDeletion) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *ResourceDeletion) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+func (x *ResourceDeletion) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ResourceDeletion) GetCompositionResourceName() string {
+	if x != nil {
+		return x.CompositionResourceName
+	}
+	return ""
+}
+
+func (x *ResourceDeletion) GetWave() int32 {
+	if x != nil {
+		return x.Wave
+	}
+	return 0
+}
+
+func (x *ResourceDeletion) GetBlockedReason() string {
+	if x != nil {
+		return x.BlockedReason
+	}
+	return ""
+}
+
+// A ResourceDiff describes how a composed resource would change.
+type ResourceDiff struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The resource's API version.
+	ApiVersion string `protobuf:"bytes,1,opt,name=api_version,json=apiVersion,proto3" json:"api_version,omitempty"`
+	// The resource's kind.
+	Kind string `protobuf:"bytes,2,opt,name=kind,proto3" json:"kind,omitempty"`
+	// The resource's namespace, if it's namespaced.
+	Namespace string `protobuf:"bytes,3,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	// The resource's name.
+	Name string `protobuf:"bytes,4,opt,name=name,proto3" json:"name,omitempty"`
+	// The composed resource's name in the function pipeline, i.e. the value of
+	// its crossplane.io/composition-resource-name annotation.
+	CompositionResourceName string `protobuf:"bytes,5,opt,name=composition_resource_name,json=compositionResourceName,proto3" json:"composition_resource_name,omitempty"`
+	// How the resource would change.
+	Change ChangeType `protobuf:"varint,6,opt,name=change,proto3,enum=crossplane.render.v1alpha1.ChangeType" json:"change,omitempty"`
+	// A JSON patch (RFC 6902) that transforms the observed resource into the
+	// rendered resource. Objects are compared field by field. Arrays are
+	// replaced as a whole. Empty when the resource would be added or removed.
+	// Ignores metadata.managedFields and metadata.resourceVersion.
+	Patch         []*JSONPatchOperation `protobuf:"bytes,7,rep,name=patch,proto3" json:"patch,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResourceDiff) Reset() {
+	*x = ResourceDiff{}
+	mi := &file_proto_render_v1alpha1_render_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResourceDiff) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResourceDiff) ProtoMessage() {}
+
+func (x *ResourceDiff) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_render_v1alpha1_render_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResourceDiff.ProtoReflect.Descriptor instead.
+func (*ResourceDiff) Descriptor() ([]byte, []int) {
+	return file_proto_render_v1alpha1_render_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *ResourceDiff) GetApiVersion() string {
+	if x != nil {
+		return x.ApiVersion
+	}
+	return ""
+}
+
+func (x *ResourceDiff) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *ResourceDiff) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+func (x *ResourceDiff) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ResourceDiff) GetCompositionResourceName() string {
+	if x != nil {
+		return x.CompositionResourceName
+	}
+	return ""
+}
+
+func (x *ResourceDiff) GetChange() ChangeType {
+	if x != nil {
+		return x.Change
+	}
+	return ChangeType_CHANGE_TYPE_UNSPECIFIED
+}
+
+func (x *ResourceDiff) GetPatch() []*JSONPatchOperation {
+	if x != nil {
+		return x.Patch
+	}
+	return nil
+}
+
+// A JSONPatchOperation is one operation of a JSON patch (RFC 6902).
+type JSONPatchOperation struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The operation - add, remove, or replace.
+	Op string `protobuf:"bytes,1,opt,name=op,proto3" json:"op,omitempty"`
+	// A JSON pointer (RFC 6901) to the field the operation changes, e.g.
+	// /spec/forProvider/region.
+	Path string `protobuf:"bytes,2,opt,name=path,proto3" json:"path,omitempty"`
+	// The field's new value. Unset for remove operations.
+	Value         *structpb.Value `protobuf:"bytes,3,opt,name=value,proto3" json:"value,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *JSONPatchOperation) Reset() {
+	*x = JSONPatchOperation{}
+	mi := &file_proto_render_v1alpha1_render_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *JSONPatchOperation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*JSONPatchOperation) ProtoMessage() {}
+
+func (x *JSONPatchOperation) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_render_v1alpha1_render_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use JSONPatchOperation.ProtoReflect.Descriptor instead.
+func (*JSONPatchOperation) Descriptor() ([]byte, []int) {
+	return file_proto_render_v1alpha1_render_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *JSONPatchOperation) GetOp() string {
+	if x != nil {
+		return x.Op
+	}
+	return ""
+}
+
+func (x *JSONPatchOperation) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *JSONPatchOperation) GetValue() *structpb.Value {
+	if x != nil {
+		return x.Value
+	}
+	return nil
+}
+
+// A ValidationResult describes a resource that failed validation against its
+// schema.
+type ValidationResult struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The resource's API version.
+	ApiVersion string `protobuf:"bytes,1,opt,name=api_version,json=apiVersion,proto3" json:"api_version,omitempty"`
+	// The resource's kind.
+	Kind string `protobuf:"bytes,2,opt,name=kind,proto3" json:"kind,omitempty"`
+	// The resource's namespace, if it's namespaced.
+	Namespace string `protobuf:"bytes,3,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	// The resource's name.
+	Name string `protobuf:"bytes,4,opt,name=name,proto3" json:"name,omitempty"`
+	// The composed resource's name in the function pipeline, i.e. the value of
+	// its crossplane.io/composition-resource-name annotation. Empty for the XR.
+	CompositionResourceName string `protobuf:"bytes,5,opt,name=composition_resource_name,json=compositionResourceName,proto3" json:"composition_resource_name,omitempty"`
+	// Why the resource is invalid.
+	Errors        []*ValidationError `protobuf:"bytes,6,rep,name=errors,proto3" json:"errors,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ValidationResult) Reset() {
+	*x = ValidationResult{}
+	mi := &file_proto_render_v1alpha1_render_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ValidationResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ValidationResult) ProtoMessage() {}
+
+func (x *ValidationResult) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_render_v1alpha1_render_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ValidationResult.ProtoReflect.Descriptor instead.
+func (*ValidationResult) Descriptor() ([]byte, []int) {
+	return file_proto_render_v1alpha1_render_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *ValidationResult) GetApiVersion() string {
+	if x != nil {
+		return x.ApiVersion
+	}
+	return ""
+}
+
+func (x *ValidationResult) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *ValidationResult) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+func (x *ValidationResult) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ValidationResult) GetCompositionResourceName() string {
+	if x != nil {
+		return x.CompositionResourceName
+	}
+	return ""
+}
+
+func (x *ValidationResult) GetErrors() []*ValidationError {
+	if x != nil {
+		return x.Errors
+	}
+	return nil
+}
+
+// A ValidationError describes why a field of a resource is invalid.
+type ValidationError struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The path to the invalid field, e.g. spec.forProvider.region.
+	Field string `protobuf:"bytes,1,opt,name=field,proto3" json:"field,omitempty"`
+	// The type of error, e.g. FieldValueRequired or FieldValueInvalid.
+	Type string `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	// A human readable description of the error.
+	Message       string `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ValidationError) Reset() {
+	*x = ValidationError{}
+	mi := &file_proto_render_v1alpha1_render_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ValidationError) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ValidationError) ProtoMessage() {}
+
+func (x *ValidationError) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_render_v1alpha1_render_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ValidationError.ProtoReflect.Descriptor instead.
+func (*ValidationError) Descriptor() ([]byte, []int) {
+	return file_proto_render_v1alpha1_render_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *ValidationError) GetField() string {
+	if x != nil {
+		return x.Field
+	}
+	return ""
+}
+
+func (x *ValidationError) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *ValidationError) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+// A StepTrace records a call to a function pipeline step.
+type StepTrace struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The reconcile during which the step ran, starting at 1. Always 1 for an
+	// Operation.
+	Reconcile int32 `protobuf:"varint,1,opt,name=reconcile,proto3" json:"reconcile,omitempty"`
+	// The name of the pipeline step.
+	Step string `protobuf:"bytes,2,opt,name=step,proto3" json:"step,omitempty"`
+	// The index of the pipeline step, starting at 0.
+	StepIndex int32 `protobuf:"varint,3,opt,name=step_index,json=stepIndex,proto3" json:"step_index,omitempty"`
+	// The name of the function the step called.
+	FunctionName string `protobuf:"bytes,4,opt,name=function_name,json=functionName,proto3" json:"function_name,omitempty"`
+	// The RunFunctionRequest sent to the function. The struct is a
+	// fnv1.RunFunctionRequest message.
+	Request *structpb.Struct `protobuf:"bytes,5,opt,name=request,proto3" json:"request,omitempty"`
+	// The RunFunctionResponse the function returned, if any. The struct is a
+	// fnv1.RunFunctionResponse message.
+	Response *structpb.Struct `protobuf:"bytes,6,opt,name=response,proto3" json:"response,omitempty"`
+	// The error calling the function, if any.
+	Error         string `protobuf:"bytes,7,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StepTrace) Reset() {
+	*x = StepTrace{}
+	mi := &file_proto_render_v1alpha1_render_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StepTrace) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StepTrace) ProtoMessage() {}
+
+func (x *StepTrace) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_render_v1alpha1_render_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StepTrace.ProtoReflect.Descriptor instead.
+func (*StepTrace) Descriptor() ([]byte, []int) {
+	return file_proto_render_v1alpha1_render_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *StepTrace) GetReconcile() int32 {
+	if x != nil {
+		return x.Reconcile
+	}
+	return 0
+}
+
+func (x *StepTrace) GetStep() string {
+	if x != nil {
+		return x.Step
+	}
+	return ""
+}
+
+func (x *StepTrace) GetStepIndex() int32 {
+	if x != nil {
+		return x.StepIndex
+	}
+	return 0
+}
+
+func (x *StepTrace) GetFunctionName() string {
+	if x != nil {
+		return x.FunctionName
+	}
+	return ""
+}
+
+func (x *StepTrace) GetRequest() *structpb.Struct {
+	if x != nil {
+		return x.Request
+	}
+	return nil
+}
+
+func (x *StepTrace) GetResponse() *structpb.Struct {
+	if x != nil {
+		return x.Response
+	}
+	return nil
+}
+
+func (x *StepTrace) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+// An OperationInput contains all inputs needed to render an Operation using the
+// real Operation reconciler.
+type OperationInput struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The Operation to reconcile.
+	Operation *structpb.Struct `protobuf:"bytes,1,opt,name=operation,proto3" json:"operation,omitempty"`
+	// Functions to call in the pipeline.
+	Functions []*FunctionInput `protobuf:"bytes,2,rep,name=functions,proto3" json:"functions,omitempty"`
+	// Resources available for functions that request them via the Requirements
+	// protocol. Optional.
+	RequiredResources []*structpb.Struct `protobuf:"bytes,3,rep,name=required_resources,json=requiredResources,proto3" json:"required_resources,omitempty"`
+	// Kubernetes Secrets for function credentials. Optional.
+	Credentials []*structpb.Struct `protobuf:"bytes,4,rep,name=credentials,proto3" json:"credentials,omitempty"`
+	// OpenAPI v3 documents providing schemas for resource kinds. Functions can
+	// request schemas via the Requirements protocol. Each entry is a full OpenAPI
+	// v3 document as JSON. Optional.
+	RequiredSchemas []*structpb.Struct `protobuf:"bytes,5,rep,name=required_schemas,json=requiredSchemas,proto3" json:"required_schemas,omitempty"`
+	// ServerSideApply tells render to merge the resources the Operation applies
+	// into the required resources the way an API server would, using
+	// server-side apply field ownership. Render tracks the fields each field
+	// manager owns in metadata.managedFields. Render uses required_schemas to
+	// determine how to merge each kind. Kinds without a schema are merged as if
+	// each field were atomic. Optional.
+	ServerSideApply bool `protobuf:"varint,6,opt,name=server_side_apply,json=serverSideApply,proto3" json:"server_side_apply,omitempty"`
+	// Trace tells render to record the request and response of each function
+	// pipeline step in the output's traces, like the Pipeline Inspector does.
+	// Credentials, connection details, and Secret data are redacted. Optional.
+	Trace bool `protobuf:"varint,7,opt,name=trace,proto3" json:"trace,omitempty"`
+	// Fixtures to record function responses to, or replay them from. Optional.
+	// By default render calls the functions without recording their responses.
+	Fixtures *FunctionFixtures `protobuf:"bytes,8,opt,name=fixtures,proto3" json:"fixtures,omitempty"`
+	// Retries tells render to reconcile the Operation repeatedly, the way the
+	// Operation controller retries an Operation that fails, until it completes
+	// or reaches its retry limit. Optional. By default render reconciles the
+	// Operation once.
+	Retries       *OperationRetries `protobuf:"bytes,9,opt,name=retries,proto3" json:"retries,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OperationInput) Reset() {
+	*x = OperationInput{}
+	mi := &file_proto_render_v1alpha1_render_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OperationInput) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OperationInput) ProtoMessage() {}
+
+func (x *OperationInput) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_render_v1alpha1_render_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OperationInput.ProtoReflect.Descriptor instead.
+func (*OperationInput) Descriptor() ([]byte, []int) {
+	return file_proto_render_v1alpha1_render_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *OperationInput) GetOperation() *structpb.Struct {
+	if x != nil {
+		return x.Operation
+	}
+	return nil
+}
+
+func (x *OperationInput) GetFunctions() []*FunctionInput {
+	if x != nil {
+		return x.Functions
+	}
+	return nil
+}
+
+func (x *OperationInput) GetRequiredResources() []*structpb.Struct {
+	if x != nil {
+		return x.RequiredResources
+	}
+	return nil
+}
+
+func (x *OperationInput) GetCredentials() []*structpb.Struct {
+	if x != nil {
+		return x.Credentials
+	}
+	return nil
+}
+
+func (x *OperationInput) GetRequiredSchemas() []*structpb.Struct {
+	if x != nil {
+		return x.RequiredSchemas
+	}
+	return nil
+}
+
+func (x *OperationInput) GetServerSideApply() bool {
+	if x != nil {
+		return x.ServerSideApply
+	}
+	return false
+}
+
+func (x *OperationInput) GetTrace() bool {
+	if x != nil {
+		return x.Trace
+	}
+	return false
+}
+
+func (x *OperationInput) GetFixtures() *FunctionFixtures {
+	if x != nil {
+		return x.Fixtures
+	}
+	return nil
+}
+
+func (x *OperationInput) GetRetries() *OperationRetries {
+	if x != nil {
+		return x.Retries
+	}
+	return nil
+}
+
+// OperationRetries configures how render retries an Operation.
+type OperationRetries struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The maximum number of times to reconcile the Operation. Optional. Defaults
+	// to one more than the Operation's spec.retryLimit, which is enough for the
+	// Operation to reach its retry limit if every attempt fails.
+	MaxAttempts int32 `protobuf:"varint,1,opt,name=max_attempts,json=maxAttempts,proto3" json:"max_attempts,omitempty"`
+	// Errors to return instead of calling functions, to simulate functions
+	// failing. Optional.
+	FunctionErrors []*ScriptedFunctionError `protobuf:"bytes,2,rep,name=function_errors,json=functionErrors,proto3" json:"function_errors,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *OperationRetries) Reset() {
+	*x = OperationRetries{}
+	mi := &file_proto_render_v1alpha1_render_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OperationRetries) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OperationRetries) ProtoMessage() {}
+
+func (x *OperationRetries) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_render_v1alpha1_render_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OperationRetries.ProtoReflect.Descriptor instead.
+func (*OperationRetries) Descriptor() ([]byte, []int) {
+	return file_proto_render_v1alpha1_render_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *OperationRetries) GetMaxAttempts() int32 {
+	if x != nil {
+		return x.MaxAttempts
+	}
+	return 0
+}
+
+func (x *OperationRetries) GetFunctionErrors() []*ScriptedFunctionError {
+	if x != nil {
+		return x.FunctionErrors
+	}
+	return nil
+}
+
+// A ScriptedFunctionError is returned instead of calling a function during an
+// attempt to reconcile an Operation.
+type ScriptedFunctionError struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The attempt during which to return the error, starting at 1.
+	Attempt int32 `protobuf:"varint,1,opt,name=attempt,proto3" json:"attempt,omitempty"`
+	// The name of the function to return the error instead of calling.
+	// Optional. Matches all functions if empty.
+	FunctionName string `protobuf:"bytes,2,opt,name=function_name,json=functionName,proto3" json:"function_name,omitempty"`
+	// The error message.
+	Message       string `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ScriptedFunctionError) Reset() {
+	*x = ScriptedFunctionError{}
+	mi := &file_proto_render_v1alpha1_render_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ScriptedFunctionError) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ScriptedFunctionError) ProtoMessage() {}
+
+func (x *ScriptedFunctionError) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_render_v1alpha1_render_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ScriptedFunctionError.ProtoReflect.Descriptor instead.
+func (*ScriptedFunctionError) Descriptor() ([]byte, []int) {
+	return file_proto_render_v1alpha1_render_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *ScriptedFunctionError) GetAttempt() int32 {
+	if x != nil {
+		return x.Attempt
+	}
+	return 0
+}
+
+func (x *ScriptedFunctionError) GetFunctionName() string {
+	if x != nil {
+		return x.FunctionName
+	}
+	return ""
+}
+
+func (x *ScriptedFunctionError) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+// An OperationAttempt describes one attempt to reconcile an Operation.
+type OperationAttempt struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The attempt number, starting at 1.
+	Attempt int32 `protobuf:"varint,1,opt,name=attempt,proto3" json:"attempt,omitempty"`
+	// The Operation's status at the end of the attempt.
+	Status *structpb.Struct `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	// Events the reconciler would emit during the attempt.
+	Events []*Event `protobuf:"bytes,3,rep,name=events,proto3" json:"events,omitempty"`
+	// The error the reconciler returned, if any. The Operation controller
+	// retries the Operation when the reconciler returns an error.
+	Error         string `protobuf:"bytes,4,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OperationAttempt) Reset() {
+	*x = OperationAttempt{}
+	mi := &file_proto_render_v1alpha1_render_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OperationAttempt) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OperationAttempt) ProtoMessage() {}
+
+func (x *OperationAttempt) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_render_v1alpha1_render_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OperationAttempt.ProtoReflect.Descriptor instead.
+func (*OperationAttempt) Descriptor() ([]byte, []int) {
+	return file_proto_render_v1alpha1_render_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *OperationAttempt) GetAttempt() int32 {
+	if x != nil {
+		return x.Attempt
+	}
+	return 0
+}
+
+func (x *OperationAttempt) GetStatus() *structpb.Struct {
+	if x != nil {
+		return x.Status
+	}
+	return nil
+}
+
+func (x *OperationAttempt) GetEvents() []*Event {
+	if x != nil {
+		return x.Events
+	}
+	return nil
+}
+
+func (x *OperationAttempt) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+// An OperationOutput contains the results of rendering an Operation.
+type OperationOutput struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The Operation with status set by the reconciler.
 	Operation *structpb.Struct `protobuf:"bytes,1,opt,name=operation,proto3" json:"operation,omitempty"`
 	// Resources the Operation would apply via server-side apply.
 	AppliedResources []*structpb.Struct `protobuf:"bytes,2,rep,name=applied_resources,json=appliedResources,proto3" json:"applied_resources,omitempty"`
@@ -808,13 +2033,20 @@ type OperationOutput struct {
 	// Required schemas that were requested by the function pipeline. The structs
 	// are fnv1.SchemaSelector messages.
 	RequiredSchemas []*structpb.Struct `protobuf:"bytes,5,rep,name=required_schemas,json=requiredSchemas,proto3" json:"required_schemas,omitempty"`
-	unknownFields   protoimpl.UnknownFields
-	sizeCache       protoimpl.SizeCache
+	// The request and response of each function pipeline step, in the order
+	// the steps ran. Only set when the input's trace field is true.
+	Traces []*StepTrace `protobuf:"bytes,6,rep,name=traces,proto3" json:"traces,omitempty"`
+	// Each attempt to reconcile the Operation, in order. Only set when the
+	// input's retries field is set. Events and traces accumulate across all
+	// attempts.
+	Attempts      []*OperationAttempt `protobuf:"bytes,7,rep,name=attempts,proto3" json:"attempts,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
 func (x *OperationOutput) Reset() {
 	*x = OperationOutput{}
-	mi := &file_proto_render_v1alpha1_render_proto_msgTypes[9]
+	mi := &file_proto_render_v1alpha1_render_proto_msgTypes[20]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -826,7 +2058,7 @@ func (x *OperationOutput) String() string {
 func (*OperationOutput) ProtoMessage() {}
 
 func (x *OperationOutput) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_render_v1alpha1_render_proto_msgTypes[9]
+	mi := &file_proto_render_v1alpha1_render_proto_msgTypes[20]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -839,7 +2071,7 @@ func (x *OperationOutput) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use OperationOutput.ProtoReflect.Descriptor instead.
 func (*OperationOutput) Descriptor() ([]byte, []int) {
-	return file_proto_render_v1alpha1_render_proto_rawDescGZIP(), []int{9}
+	return file_proto_render_v1alpha1_render_proto_rawDescGZIP(), []int{20}
 }
 
 func (x *OperationOutput) GetOperation() *structpb.Struct {
@@ -877,6 +2109,20 @@ func (x *OperationOutput) GetRequiredSchemas() []*structpb.Struct {
 	return nil
 }
 
+func (x *OperationOutput) GetTraces() []*StepTrace {
+	if x != nil {
+		return x.Traces
+	}
+	return nil
+}
+
+func (x *OperationOutput) GetAttempts() []*OperationAttempt {
+	if x != nil {
+		return x.Attempts
+	}
+	return nil
+}
+
 // A CronOperationInput contains all inputs needed to produce the Operation a
 // CronOperation would create.
 type CronOperationInput struct {
@@ -891,7 +2137,7 @@ type CronOperationInput struct {
 
 func (x *CronOperationInput) Reset() {
 	*x = CronOperationInput{}
-	mi := &file_proto_render_v1alpha1_render_proto_msgTypes[10]
+	mi := &file_proto_render_v1alpha1_render_proto_msgTypes[21]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -903,7 +2149,7 @@ func (x *CronOperationInput) String() string {
 func (*CronOperationInput) ProtoMessage() {}
 
 func (x *CronOperationInput) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_render_v1alpha1_render_proto_msgTypes[10]
+	mi := &file_proto_render_v1alpha1_render_proto_msgTypes[21]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -916,7 +2162,7 @@ func (x *CronOperationInput) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CronOperationInput.ProtoReflect.Descriptor instead.
 func (*CronOperationInput) Descriptor() ([]byte, []int) {
-	return file_proto_render_v1alpha1_render_proto_rawDescGZIP(), []int{10}
+	return file_proto_render_v1alpha1_render_proto_rawDescGZIP(), []int{21}
 }
 
 func (x *CronOperationInput) GetCronOperation() *structpb.Struct {
@@ -944,7 +2190,7 @@ type CronOperationOutput struct {
 
 func (x *CronOperationOutput) Reset() {
 	*x = CronOperationOutput{}
-	mi := &file_proto_render_v1alpha1_render_proto_msgTypes[11]
+	mi := &file_proto_render_v1alpha1_render_proto_msgTypes[22]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -956,7 +2202,7 @@ func (x *CronOperationOutput) String() string {
 func (*CronOperationOutput) ProtoMessage() {}
 
 func (x *CronOperationOutput) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_render_v1alpha1_render_proto_msgTypes[11]
+	mi := &file_proto_render_v1alpha1_render_proto_msgTypes[22]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -969,7 +2215,7 @@ func (x *CronOperationOutput) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CronOperationOutput.ProtoReflect.Descriptor instead.
 func (*CronOperationOutput) Descriptor() ([]byte, []int) {
-	return file_proto_render_v1alpha1_render_proto_rawDescGZIP(), []int{11}
+	return file_proto_render_v1alpha1_render_proto_rawDescGZIP(), []int{22}
 }
 
 func (x *CronOperationOutput) GetOperation() *structpb.Struct {
@@ -993,7 +2239,7 @@ type WatchOperationInput struct {
 
 func (x *WatchOperationInput) Reset() {
 	*x = WatchOperationInput{}
-	mi := &file_proto_render_v1alpha1_render_proto_msgTypes[12]
+	mi := &file_proto_render_v1alpha1_render_proto_msgTypes[23]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1005,7 +2251,7 @@ func (x *WatchOperationInput) String() string {
 func (*WatchOperationInput) ProtoMessage() {}
 
 func (x *WatchOperationInput) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_render_v1alpha1_render_proto_msgTypes[12]
+	mi := &file_proto_render_v1alpha1_render_proto_msgTypes[23]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1018,7 +2264,7 @@ func (x *WatchOperationInput) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use WatchOperationInput.ProtoReflect.Descriptor instead.
 func (*WatchOperationInput) Descriptor() ([]byte, []int) {
-	return file_proto_render_v1alpha1_render_proto_rawDescGZIP(), []int{12}
+	return file_proto_render_v1alpha1_render_proto_rawDescGZIP(), []int{23}
 }
 
 func (x *WatchOperationInput) GetWatchOperation() *structpb.Struct {
@@ -1046,7 +2292,7 @@ type WatchOperationOutput struct {
 
 func (x *WatchOperationOutput) Reset() {
 	*x = WatchOperationOutput{}
-	mi := &file_proto_render_v1alpha1_render_proto_msgTypes[13]
+	mi := &file_proto_render_v1alpha1_render_proto_msgTypes[24]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1058,7 +2304,7 @@ func (x *WatchOperationOutput) String() string {
 func (*WatchOperationOutput) ProtoMessage() {}
 
 func (x *WatchOperationOutput) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_render_v1alpha1_render_proto_msgTypes[13]
+	mi := &file_proto_render_v1alpha1_render_proto_msgTypes[24]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1071,7 +2317,7 @@ func (x *WatchOperationOutput) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use WatchOperationOutput.ProtoReflect.Descriptor instead.
 func (*WatchOperationOutput) Descriptor() ([]byte, []int) {
-	return file_proto_render_v1alpha1_render_proto_rawDescGZIP(), []int{13}
+	return file_proto_render_v1alpha1_render_proto_rawDescGZIP(), []int{24}
 }
 
 func (x *WatchOperationOutput) GetOperation() *structpb.Struct {
@@ -1104,11 +2350,14 @@ const file_proto_render_v1alpha1_render_proto_rawDesc = "" +
 	"\fResponseMeta\"=\n" +
 	"\rFunctionInput\x12\x12\n" +
 	"\x04name\x18\x01 \x01(\tR\x04name\x12\x18\n" +
-	"\aaddress\x18\x02 \x01(\tR\aaddress\"M\n" +
+	"\aaddress\x18\x02 \x01(\tR\aaddress\"m\n" +
+	"\x10FunctionFixtures\x12\x1c\n" +
+	"\tdirectory\x18\x01 \x01(\tR\tdirectory\x12;\n" +
+	"\x04mode\x18\x02 \x01(\x0e2'.crossplane.render.v1alpha1.FixtureModeR\x04mode\"M\n" +
 	"\x05Event\x12\x12\n" +
 	"\x04type\x18\x01 \x01(\tR\x04type\x12\x16\n" +
 	"\x06reason\x18\x02 \x01(\tR\x06reason\x12\x18\n" +
-	"\amessage\x18\x03 \x01(\tR\amessage\"\xc8\x04\n" +
+	"\amessage\x18\x03 \x01(\tR\amessage\"\xad\t\n" +
 	"\x0eCompositeInput\x12F\n" +
 	"\x12composite_resource\x18\x01 \x01(\v2\x17.google.protobuf.StructR\x11compositeResource\x129\n" +
 	"\vcomposition\x18\x02 \x01(\v2\x17.google.protobuf.StructR\vcomposition\x12G\n" +
@@ -1117,26 +2366,114 @@ const file_proto_render_v1alpha1_render_proto_rawDesc = "" +
 	"\x12required_resources\x18\x05 \x03(\v2\x17.google.protobuf.StructR\x11requiredResources\x129\n" +
 	"\vcredentials\x18\x06 \x03(\v2\x17.google.protobuf.StructR\vcredentials\x12B\n" +
 	"\x10required_schemas\x18\a \x03(\v2\x17.google.protobuf.StructR\x0frequiredSchemas\x12[\n" +
-	"\x1dcomposite_resource_definition\x18\b \x01(\v2\x17.google.protobuf.StructR\x1bcompositeResourceDefinition\"\xae\x03\n" +
+	"\x1dcomposite_resource_definition\x18\b \x01(\v2\x17.google.protobuf.StructR\x1bcompositeResourceDefinition\x12\x1c\n" +
+	"\trecursive\x18\t \x01(\bR\trecursive\x12]\n" +
+	"\x1ecomposite_resource_definitions\x18\n" +
+	" \x03(\v2\x17.google.protobuf.StructR\x1ccompositeResourceDefinitions\x12;\n" +
+	"\fcompositions\x18\v \x03(\v2\x17.google.protobuf.StructR\fcompositions\x12\x1e\n" +
+	"\n" +
+	"reconciles\x18\f \x01(\x05R\n" +
+	"reconciles\x12m\n" +
+	"\x19composed_resource_patches\x18\r \x03(\v21.crossplane.render.v1alpha1.ComposedResourcePatchR\x17composedResourcePatches\x12*\n" +
+	"\x11server_side_apply\x18\x0e \x01(\bR\x0fserverSideApply\x12\x1a\n" +
+	"\bvalidate\x18\x0f \x01(\bR\bvalidate\x12\x12\n" +
+	"\x04diff\x18\x10 \x01(\bR\x04diff\x12\x14\n" +
+	"\x05trace\x18\x11 \x01(\bR\x05trace\x12H\n" +
+	"\bfixtures\x18\x12 \x01(\v2,.crossplane.render.v1alpha1.FunctionFixturesR\bfixtures\x12/\n" +
+	"\x06usages\x18\x13 \x03(\v2\x17.google.protobuf.StructR\x06usages\x12-\n" +
+	"\x12simulate_deletions\x18\x14 \x01(\bR\x11simulateDeletions\"k\n" +
+	"\x15ComposedResourcePatch\x12#\n" +
+	"\rresource_name\x18\x01 \x01(\tR\fresourceName\x12-\n" +
+	"\x05patch\x18\x02 \x01(\v2\x17.google.protobuf.StructR\x05patch\"\xd0\x06\n" +
 	"\x0fCompositeOutput\x12F\n" +
 	"\x12composite_resource\x18\x01 \x01(\v2\x17.google.protobuf.StructR\x11compositeResource\x12F\n" +
 	"\x12composed_resources\x18\x02 \x03(\v2\x17.google.protobuf.StructR\x11composedResources\x12D\n" +
 	"\x11deleted_resources\x18\x03 \x03(\v2\x17.google.protobuf.StructR\x10deletedResources\x129\n" +
 	"\x06events\x18\x04 \x03(\v2!.crossplane.render.v1alpha1.EventR\x06events\x12F\n" +
 	"\x12required_resources\x18\x05 \x03(\v2\x17.google.protobuf.StructR\x11requiredResources\x12B\n" +
-	"\x10required_schemas\x18\x06 \x03(\v2\x17.google.protobuf.StructR\x0frequiredSchemas\"\xd7\x02\n" +
+	"\x10required_schemas\x18\x06 \x03(\v2\x17.google.protobuf.StructR\x0frequiredSchemas\x12X\n" +
+	"\x11nested_composites\x18\a \x03(\v2+.crossplane.render.v1alpha1.CompositeOutputR\x10nestedComposites\x12\x1e\n" +
+	"\n" +
+	"reconciles\x18\b \x01(\x05R\n" +
+	"reconciles\x12[\n" +
+	"\x12validation_results\x18\t \x03(\v2,.crossplane.render.v1alpha1.ValidationResultR\x11validationResults\x12>\n" +
+	"\x05diffs\x18\n" +
+	" \x03(\v2(.crossplane.render.v1alpha1.ResourceDiffR\x05diffs\x12=\n" +
+	"\x06traces\x18\v \x03(\v2%.crossplane.render.v1alpha1.StepTraceR\x06traces\x12J\n" +
+	"\tdeletions\x18\f \x03(\v2,.crossplane.render.v1alpha1.ResourceDeletionR\tdeletions\"\xf0\x01\n" +
+	"\x10ResourceDeletion\x12\x1f\n" +
+	"\vapi_version\x18\x01 \x01(\tR\n" +
+	"apiVersion\x12\x12\n" +
+	"\x04kind\x18\x02 \x01(\tR\x04kind\x12\x1c\n" +
+	"\tnamespace\x18\x03 \x01(\tR\tnamespace\x12\x12\n" +
+	"\x04name\x18\x04 \x01(\tR\x04name\x12:\n" +
+	"\x19composition_resource_name\x18\x05 \x01(\tR\x17compositionResourceName\x12\x12\n" +
+	"\x04wave\x18\x06 \x01(\x05R\x04wave\x12%\n" +
+	"\x0eblocked_reason\x18\a \x01(\tR\rblockedReason\"\xb7\x02\n" +
+	"\fResourceDiff\x12\x1f\n" +
+	"\vapi_version\x18\x01 \x01(\tR\n" +
+	"apiVersion\x12\x12\n" +
+	"\x04kind\x18\x02 \x01(\tR\x04kind\x12\x1c\n" +
+	"\tnamespace\x18\x03 \x01(\tR\tnamespace\x12\x12\n" +
+	"\x04name\x18\x04 \x01(\tR\x04name\x12:\n" +
+	"\x19composition_resource_name\x18\x05 \x01(\tR\x17compositionResourceName\x12>\n" +
+	"\x06change\x18\x06 \x01(\x0e2&.crossplane.render.v1alpha1.ChangeTypeR\x06change\x12D\n" +
+	"\x05patch\x18\a \x03(\v2..crossplane.render.v1alpha1.JSONPatchOperationR\x05patch\"f\n" +
+	"\x12JSONPatchOperation\x12\x0e\n" +
+	"\x02op\x18\x01 \x01(\tR\x02op\x12\x12\n" +
+	"\x04path\x18\x02 \x01(\tR\x04path\x12,\n" +
+	"\x05value\x18\x03 \x01(\v2\x16.google.protobuf.ValueR\x05value\"\xfa\x01\n" +
+	"\x10ValidationResult\x12\x1f\n" +
+	"\vapi_version\x18\x01 \x01(\tR\n" +
+	"apiVersion\x12\x12\n" +
+	"\x04kind\x18\x02 \x01(\tR\x04kind\x12\x1c\n" +
+	"\tnamespace\x18\x03 \x01(\tR\tnamespace\x12\x12\n" +
+	"\x04name\x18\x04 \x01(\tR\x04name\x12:\n" +
+	"\x19composition_resource_name\x18\x05 \x01(\tR\x17compositionResourceName\x12C\n" +
+	"\x06errors\x18\x06 \x03(\v2+.crossplane.render.v1alpha1.ValidationErrorR\x06errors\"U\n" +
+	"\x0fValidationError\x12\x14\n" +
+	"\x05field\x18\x01 \x01(\tR\x05field\x12\x12\n" +
+	"\x04type\x18\x02 \x01(\tR\x04type\x12\x18\n" +
+	"\amessage\x18\x03 \x01(\tR\amessage\"\xff\x01\n" +
+	"\tStepTrace\x12\x1c\n" +
+	"\treconcile\x18\x01 \x01(\x05R\treconcile\x12\x12\n" +
+	"\x04step\x18\x02 \x01(\tR\x04step\x12\x1d\n" +
+	"\n" +
+	"step_index\x18\x03 \x01(\x05R\tstepIndex\x12#\n" +
+	"\rfunction_name\x18\x04 \x01(\tR\ffunctionName\x121\n" +
+	"\arequest\x18\x05 \x01(\v2\x17.google.protobuf.StructR\arequest\x123\n" +
+	"\bresponse\x18\x06 \x01(\v2\x17.google.protobuf.StructR\bresponse\x12\x14\n" +
+	"\x05error\x18\a \x01(\tR\x05error\"\xab\x04\n" +
 	"\x0eOperationInput\x125\n" +
 	"\toperation\x18\x01 \x01(\v2\x17.google.protobuf.StructR\toperation\x12G\n" +
 	"\tfunctions\x18\x02 \x03(\v2).crossplane.render.v1alpha1.FunctionInputR\tfunctions\x12F\n" +
 	"\x12required_resources\x18\x03 \x03(\v2\x17.google.protobuf.StructR\x11requiredResources\x129\n" +
 	"\vcredentials\x18\x04 \x03(\v2\x17.google.protobuf.StructR\vcredentials\x12B\n" +
-	"\x10required_schemas\x18\x05 \x03(\v2\x17.google.protobuf.StructR\x0frequiredSchemas\"\xd5\x02\n" +
+	"\x10required_schemas\x18\x05 \x03(\v2\x17.google.protobuf.StructR\x0frequiredSchemas\x12*\n" +
+	"\x11server_side_apply\x18\x06 \x01(\bR\x0fserverSideApply\x12\x14\n" +
+	"\x05trace\x18\a \x01(\bR\x05trace\x12H\n" +
+	"\bfixtures\x18\b \x01(\v2,.crossplane.render.v1alpha1.FunctionFixturesR\bfixtures\x12F\n" +
+	"\aretries\x18\t \x01(\v2,.crossplane.render.v1alpha1.OperationRetriesR\aretries\"\x91\x01\n" +
+	"\x10OperationRetries\x12!\n" +
+	"\fmax_attempts\x18\x01 \x01(\x05R\vmaxAttempts\x12Z\n" +
+	"\x0ffunction_errors\x18\x02 \x03(\v21.crossplane.render.v1alpha1.ScriptedFunctionErrorR\x0efunctionErrors\"p\n" +
+	"\x15ScriptedFunctionError\x12\x18\n" +
+	"\aattempt\x18\x01 \x01(\x05R\aattempt\x12#\n" +
+	"\rfunction_name\x18\x02 \x01(\tR\ffunctionName\x12\x18\n" +
+	"\amessage\x18\x03 \x01(\tR\amessage\"\xae\x01\n" +
+	"\x10OperationAttempt\x12\x18\n" +
+	"\aattempt\x18\x01 \x01(\x05R\aattempt\x12/\n" +
+	"\x06status\x18\x02 \x01(\v2\x17.google.protobuf.StructR\x06status\x129\n" +
+	"\x06events\x18\x03 \x03(\v2!.crossplane.render.v1alpha1.EventR\x06events\x12\x14\n" +
+	"\x05error\x18\x04 \x01(\tR\x05error\"\xde\x03\n" +
 	"\x0fOperationOutput\x125\n" +
 	"\toperation\x18\x01 \x01(\v2\x17.google.protobuf.StructR\toperation\x12D\n" +
 	"\x11applied_resources\x18\x02 \x03(\v2\x17.google.protobuf.StructR\x10appliedResources\x129\n" +
 	"\x06events\x18\x03 \x03(\v2!.crossplane.render.v1alpha1.EventR\x06events\x12F\n" +
 	"\x12required_resources\x18\x04 \x03(\v2\x17.google.protobuf.StructR\x11requiredResources\x12B\n" +
-	"\x10required_schemas\x18\x05 \x03(\v2\x17.google.protobuf.StructR\x0frequiredSchemas\"\xaf\x01\n" +
+	"\x10required_schemas\x18\x05 \x03(\v2\x17.google.protobuf.StructR\x0frequiredSchemas\x12=\n" +
+	"\x06traces\x18\x06 \x03(\v2%.crossplane.render.v1alpha1.StepTraceR\x06traces\x12H\n" +
+	"\battempts\x18\a \x03(\v2,.crossplane.render.v1alpha1.OperationAttemptR\battempts\"\xaf\x01\n" +
 	"\x12CronOperationInput\x12>\n" +
 	"\x0ecron_operation\x18\x01 \x01(\v2\x17.google.protobuf.StructR\rcronOperation\x12F\n" +
 	"\x0escheduled_time\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampH\x00R\rscheduledTime\x88\x01\x01B\x11\n" +
@@ -1147,7 +2484,19 @@ const file_proto_render_v1alpha1_render_proto_rawDesc = "" +
 	"\x0fwatch_operation\x18\x01 \x01(\v2\x17.google.protobuf.StructR\x0ewatchOperation\x12B\n" +
 	"\x10watched_resource\x18\x02 \x01(\v2\x17.google.protobuf.StructR\x0fwatchedResource\"M\n" +
 	"\x14WatchOperationOutput\x125\n" +
-	"\toperation\x18\x01 \x01(\v2\x17.google.protobuf.StructR\toperationB;Z9github.com/crossplane/crossplane/v2/proto/render/v1alpha1b\x06proto3"
+	"\toperation\x18\x01 \x01(\v2\x17.google.protobuf.StructR\toperation*]\n" +
+	"\vFixtureMode\x12\x1c\n" +
+	"\x18FIXTURE_MODE_UNSPECIFIED\x10\x00\x12\x17\n" +
+	"\x13FIXTURE_MODE_RECORD\x10\x01\x12\x17\n" +
+	"\x13FIXTURE_MODE_REPLAY\x10\x02*r\n" +
+	"\n" +
+	"ChangeType\x12\x1b\n" +
+	"\x17CHANGE_TYPE_UNSPECIFIED\x10\x00\x12\x15\n" +
+	"\x11CHANGE_TYPE_ADDED\x10\x01\x12\x17\n" +
+	"\x13CHANGE_TYPE_CHANGED\x10\x02\x12\x17\n" +
+	"\x13CHANGE_TYPE_REMOVED\x10\x032r\n" +
+	"\rRenderService\x12a\n" +
+	"\x06Render\x12).crossplane.render.v1alpha1.RenderRequest\x1a*.crossplane.render.v1alpha1.RenderResponse\"\x00B;Z9github.com/crossplane/crossplane/v2/proto/render/v1alpha1b\x06proto3"
 
 var (
 	file_proto_render_v1alpha1_render_proto_rawDescOnce sync.Once
@@ -1161,71 +2510,113 @@ func file_proto_render_v1alpha1_render_proto_rawDescGZIP() []byte {
 	return file_proto_render_v1alpha1_render_proto_rawDescData
 }
 
-var file_proto_render_v1alpha1_render_proto_msgTypes = make([]protoimpl.MessageInfo, 14)
+var file_proto_render_v1alpha1_render_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
+var file_proto_render_v1alpha1_render_proto_msgTypes = make([]protoimpl.MessageInfo, 25)
 var file_proto_render_v1alpha1_render_proto_goTypes = []any{
-	(*RenderRequest)(nil),         // 0: crossplane.render.v1alpha1.RenderRequest
-	(*RenderResponse)(nil),        // 1: crossplane.render.v1alpha1.RenderResponse
-	(*RequestMeta)(nil),           // 2: crossplane.render.v1alpha1.RequestMeta
-	(*ResponseMeta)(nil),          // 3: crossplane.render.v1alpha1.ResponseMeta
-	(*FunctionInput)(nil),         // 4: crossplane.render.v1alpha1.FunctionInput
-	(*Event)(nil),                 // 5: crossplane.render.v1alpha1.Event
-	(*CompositeInput)(nil),        // 6: crossplane.render.v1alpha1.CompositeInput
-	(*CompositeOutput)(nil),       // 7: crossplane.render.v1alpha1.CompositeOutput
-	(*OperationInput)(nil),        // 8: crossplane.render.v1alpha1.OperationInput
-	(*OperationOutput)(nil),       // 9: crossplane.render.v1alpha1.OperationOutput
-	(*CronOperationInput)(nil),    // 10: crossplane.render.v1alpha1.CronOperationInput
-	(*CronOperationOutput)(nil),   // 11: crossplane.render.v1alpha1.CronOperationOutput
-	(*WatchOperationInput)(nil),   // 12: crossplane.render.v1alpha1.WatchOperationInput
-	(*WatchOperationOutput)(nil),  // 13: crossplane.render.v1alpha1.WatchOperationOutput
-	(*structpb.Struct)(nil),       // 14: google.protobuf.Struct
-	(*timestamppb.Timestamp)(nil), // 15: google.protobuf.Timestamp
+	(FixtureMode)(0),              // 0: crossplane.render.v1alpha1.FixtureMode
+	(ChangeType)(0),               // 1: crossplane.render.v1alpha1.ChangeType
+	(*RenderRequest)(nil),         // 2: crossplane.render.v1alpha1.RenderRequest
+	(*RenderResponse)(nil),        // 3: crossplane.render.v1alpha1.RenderResponse
+	(*RequestMeta)(nil),           // 4: crossplane.render.v1alpha1.RequestMeta
+	(*ResponseMeta)(nil),          // 5: crossplane.render.v1alpha1.ResponseMeta
+	(*FunctionInput)(nil),         // 6: crossplane.render.v1alpha1.FunctionInput
+	(*FunctionFixtures)(nil),      // 7: crossplane.render.v1alpha1.FunctionFixtures
+	(*Event)(nil),                 // 8: crossplane.render.v1alpha1.Event
+	(*CompositeInput)(nil),        // 9: crossplane.render.v1alpha1.CompositeInput
+	(*ComposedResourcePatch)(nil), // 10: crossplane.render.v1alpha1.ComposedResourcePatch
+	(*CompositeOutput)(nil),       // 11: crossplane.render.v1alpha1.CompositeOutput
+	(*ResourceDeletion)(nil),      // 12: crossplane.render.v1alpha1.ResourceDeletion
+	(*ResourceDiff)(nil),          // 13: crossplane.render.v1alpha1.ResourceDiff
+	(*JSONPatchOperation)(nil),    // 14: crossplane.render.v1alpha1.JSONPatchOperation
+	(*ValidationResult)(nil),      // 15: crossplane.render.v1alpha1.ValidationResult
+	(*ValidationError)(nil),       // 16: crossplane.render.v1alpha1.ValidationError
+	(*StepTrace)(nil),             // 17: crossplane.render.v1alpha1.StepTrace
+	(*OperationInput)(nil),        // 18: crossplane.render.v1alpha1.OperationInput
+	(*OperationRetries)(nil),      // 19: crossplane.render.v1alpha1.OperationRetries
+	(*ScriptedFunctionError)(nil), // 20: crossplane.render.v1alpha1.ScriptedFunctionError
+	(*OperationAttempt)(nil),      // 21: crossplane.render.v1alpha1.OperationAttempt
+	(*OperationOutput)(nil),       // 22: crossplane.render.v1alpha1.OperationOutput
+	(*CronOperationInput)(nil),    // 23: crossplane.render.v1alpha1.CronOperationInput
+	(*CronOperationOutput)(nil),   // 24: crossplane.render.v1alpha1.CronOperationOutput
+	(*WatchOperationInput)(nil),   // 25: crossplane.render.v1alpha1.WatchOperationInput
+	(*WatchOperationOutput)(nil),  // 26: crossplane.render.v1alpha1.WatchOperationOutput
+	(*structpb.Struct)(nil),       // 27: google.protobuf.Struct
+	(*structpb.Value)(nil),        // 28: google.protobuf.Value
+	(*timestamppb.Timestamp)(nil), // 29: google.protobuf.Timestamp
 }
 var file_proto_render_v1alpha1_render_proto_depIdxs = []int32{
-	2,  // 0: crossplane.render.v1alpha1.RenderRequest.meta:type_name -> crossplane.render.v1alpha1.RequestMeta
-	6,  // 1: crossplane.render.v1alpha1.RenderRequest.composite:type_name -> crossplane.render.v1alpha1.CompositeInput
-	8,  // 2: crossplane.render.v1alpha1.RenderRequest.operation:type_name -> crossplane.render.v1alpha1.OperationInput
-	10, // 3: crossplane.render.v1alpha1.RenderRequest.cron_operation:type_name -> crossplane.render.v1alpha1.CronOperationInput
-	12, // 4: crossplane.render.v1alpha1.RenderRequest.watch_operation:type_name -> crossplane.render.v1alpha1.WatchOperationInput
-	3,  // 5: crossplane.render.v1alpha1.RenderResponse.meta:type_name -> crossplane.render.v1alpha1.ResponseMeta
-	7,  // 6: crossplane.render.v1alpha1.RenderResponse.composite:type_name -> crossplane.render.v1alpha1.CompositeOutput
-	9,  // 7: crossplane.render.v1alpha1.RenderResponse.operation:type_name -> crossplane.render.v1alpha1.OperationOutput
-	11, // 8: crossplane.render.v1alpha1.RenderResponse.cron_operation:type_name -> crossplane.render.v1alpha1.CronOperationOutput
-	13, // 9: crossplane.render.v1alpha1.RenderResponse.watch_operation:type_name -> crossplane.render.v1alpha1.WatchOperationOutput
-	14, // 10: crossplane.render.v1alpha1.CompositeInput.composite_resource:type_name -> google.protobuf.Struct
-	14, // 11: crossplane.render.v1alpha1.CompositeInput.composition:type_name -> google.protobuf.Struct
-	4,  // 12: crossplane.render.v1alpha1.CompositeInput.functions:type_name -> crossplane.render.v1alpha1.FunctionInput
-	14, // 13: crossplane.render.v1alpha1.CompositeInput.observed_resources:type_name -> google.protobuf.Struct
-	14, // 14: crossplane.render.v1alpha1.CompositeInput.required_resources:type_name -> google.protobuf.Struct
-	14, // 15: crossplane.render.v1alpha1.CompositeInput.credentials:type_name -> google.protobuf.Struct
-	14, // 16: crossplane.render.v1alpha1.CompositeInput.required_schemas:type_name -> google.protobuf.Struct
-	14, // 17: crossplane.render.v1alpha1.CompositeInput.composite_resource_definition:type_name -> google.protobuf.Struct
-	14, // 18: crossplane.render.v1alpha1.CompositeOutput.composite_resource:type_name -> google.protobuf.Struct
-	14, // 19: crossplane.render.v1alpha1.CompositeOutput.composed_resources:type_name -> google.protobuf.Struct
-	14, // 20: crossplane.render.v1alpha1.CompositeOutput.deleted_resources:type_name -> google.protobuf.Struct
-	5,  // 21: crossplane.render.v1alpha1.CompositeOutput.events:type_name -> crossplane.render.v1alpha1.Event
-	14, // 22: crossplane.render.v1alpha1.CompositeOutput.required_resources:type_name -> google.protobuf.Struct
-	14, // 23: crossplane.render.v1alpha1.CompositeOutput.required_schemas:type_name -> google.protobuf.Struct
-	14, // 24: crossplane.render.v1alpha1.OperationInput.operation:type_name -> google.protobuf.Struct
-	4,  // 25: crossplane.render.v1alpha1.OperationInput.functions:type_name -> crossplane.render.v1alpha1.FunctionInput
-	14, // 26: crossplane.render.v1alpha1.OperationInput.required_resources:type_name -> google.protobuf.Struct
-	14, // 27: crossplane.render.v1alpha1.OperationInput.credentials:type_name -> google.protobuf.Struct
-	14, // 28: crossplane.render.v1alpha1.OperationInput.required_schemas:type_name -> google.protobuf.Struct
-	14, // 29: crossplane.render.v1alpha1.OperationOutput.operation:type_name -> google.protobuf.Struct
-	14, // 30: crossplane.render.v1alpha1.OperationOutput.applied_resources:type_name -> google.protobuf.Struct
-	5,  // 31: crossplane.render.v1alpha1.OperationOutput.events:type_name -> crossplane.render.v1alpha1.Event
-	14, // 32: crossplane.render.v1alpha1.OperationOutput.required_resources:type_name -> google.protobuf.Struct
-	14, // 33: crossplane.render.v1alpha1.OperationOutput.required_schemas:type_name -> google.protobuf.Struct
-	14, // 34: crossplane.render.v1alpha1.CronOperationInput.cron_operation:type_name -> google.protobuf.Struct
-	15, // 35: crossplane.render.v1alpha1.CronOperationInput.scheduled_time:type_name -> google.protobuf.Timestamp
-	14, // 36: crossplane.render.v1alpha1.CronOperationOutput.operation:type_name -> google.protobuf.Struct
-	14, // 37: crossplane.render.v1alpha1.WatchOperationInput.watch_operation:type_name -> google.protobuf.Struct
-	14, // 38: crossplane.render.v1alpha1.WatchOperationInput.watched_resource:type_name -> google.protobuf.Struct
-	14, // 39: crossplane.render.v1alpha1.WatchOperationOutput.operation:type_name -> google.protobuf.Struct
-	40, // [40:40] is the sub-list for method output_type
-	40, // [40:40] is the sub-list for method input_type
-	40, // [40:40] is the sub-list for extension type_name
-	40, // [40:40] is the sub-list for extension extendee
-	0,  // [0:40] is the sub-list for field type_name
+	4,  // 0: crossplane.render.v1alpha1.RenderRequest.meta:type_name -> crossplane.render.v1alpha1.RequestMeta
+	9,  // 1: crossplane.render.v1alpha1.RenderRequest.composite:type_name -> crossplane.render.v1alpha1.CompositeInput
+	18, // 2: crossplane.render.v1alpha1.RenderRequest.operation:type_name -> crossplane.render.v1alpha1.OperationInput
+	23, // 3: crossplane.render.v1alpha1.RenderRequest.cron_operation:type_name -> crossplane.render.v1alpha1.CronOperationInput
+	25, // 4: crossplane.render.v1alpha1.RenderRequest.watch_operation:type_name -> crossplane.render.v1alpha1.WatchOperationInput
+	5,  // 5: crossplane.render.v1alpha1.RenderResponse.meta:type_name -> crossplane.render.v1alpha1.ResponseMeta
+	11, // 6: crossplane.render.v1alpha1.RenderResponse.composite:type_name -> crossplane.render.v1alpha1.CompositeOutput
+	22, // 7: crossplane.render.v1alpha1.RenderResponse.operation:type_name -> crossplane.render.v1alpha1.OperationOutput
+	24, // 8: crossplane.render.v1alpha1.RenderResponse.cron_operation:type_name -> crossplane.render.v1alpha1.CronOperationOutput
+	26, // 9: crossplane.render.v1alpha1.RenderResponse.watch_operation:type_name -> crossplane.render.v1alpha1.WatchOperationOutput
+	0,  // 10: crossplane.render.v1alpha1.FunctionFixtures.mode:type_name -> crossplane.render.v1alpha1.FixtureMode
+	27, // 11: crossplane.render.v1alpha1.CompositeInput.composite_resource:type_name -> google.protobuf.Struct
+	27, // 12: crossplane.render.v1alpha1.CompositeInput.composition:type_name -> google.protobuf.Struct
+	6,  // 13: crossplane.render.v1alpha1.CompositeInput.functions:type_name -> crossplane.render.v1alpha1.FunctionInput
+	27, // 14: crossplane.render.v1alpha1.CompositeInput.observed_resources:type_name -> google.protobuf.Struct
+	27, // 15: crossplane.render.v1alpha1.CompositeInput.required_resources:type_name -> google.protobuf.Struct
+	27, // 16: crossplane.render.v1alpha1.CompositeInput.credentials:type_name -> google.protobuf.Struct
+	27, // 17: crossplane.render.v1alpha1.CompositeInput.required_schemas:type_name -> google.protobuf.Struct
+	27, // 18: crossplane.render.v1alpha1.CompositeInput.composite_resource_definition:type_name -> google.protobuf.Struct
+	27, // 19: crossplane.render.v1alpha1.CompositeInput.composite_resource_definitions:type_name -> google.protobuf.Struct
+	27, // 20: crossplane.render.v1alpha1.CompositeInput.compositions:type_name -> google.protobuf.Struct
+	10, // 21: crossplane.render.v1alpha1.CompositeInput.composed_resource_patches:type_name -> crossplane.render.v1alpha1.ComposedResourcePatch
+	7,  // 22: crossplane.render.v1alpha1.CompositeInput.fixtures:type_name -> crossplane.render.v1alpha1.FunctionFixtures
+	27, // 23: crossplane.render.v1alpha1.CompositeInput.usages:type_name -> google.protobuf.Struct
+	27, // 24: crossplane.render.v1alpha1.ComposedResourcePatch.patch:type_name -> google.protobuf.Struct
+	27, // 25: crossplane.render.v1alpha1.CompositeOutput.composite_resource:type_name -> google.protobuf.Struct
+	27, // 26: crossplane.render.v1alpha1.CompositeOutput.composed_resources:type_name -> google.protobuf.Struct
+	27, // 27: crossplane.render.v1alpha1.CompositeOutput.deleted_resources:type_name -> google.protobuf.Struct
+	8,  // 28: crossplane.render.v1alpha1.CompositeOutput.events:type_name -> crossplane.render.v1alpha1.Event
+	27, // 29: crossplane.render.v1alpha1.CompositeOutput.required_resources:type_name -> google.protobuf.Struct
+	27, // 30: crossplane.render.v1alpha1.CompositeOutput.required_schemas:type_name -> google.protobuf.Struct
+	11, // 31: crossplane.render.v1alpha1.CompositeOutput.nested_composites:type_name -> crossplane.render.v1alpha1.CompositeOutput
+	15, // 32: crossplane.render.v1alpha1.CompositeOutput.validation_results:type_name -> crossplane.render.v1alpha1.ValidationResult
+	13, // 33: crossplane.render.v1alpha1.CompositeOutput.diffs:type_name -> crossplane.render.v1alpha1.ResourceDiff
+	17, // 34: crossplane.render.v1alpha1.CompositeOutput.traces:type_name -> crossplane.render.v1alpha1.StepTrace
+	12, // 35: crossplane.render.v1alpha1.CompositeOutput.deletions:type_name -> crossplane.render.v1alpha1.ResourceDeletion
+	1,  // 36: crossplane.render.v1alpha1.ResourceDiff.change:type_name -> crossplane.render.v1alpha1.ChangeType
+	14, // 37: crossplane.render.v1alpha1.ResourceDiff.patch:type_name -> crossplane.render.v1alpha1.JSONPatchOperation
+	28, // 38: crossplane.render.v1alpha1.JSONPatchOperation.value:type_name -> google.protobuf.Value
+	16, // 39: crossplane.render.v1alpha1.ValidationResult.errors:type_name -> crossplane.render.v1alpha1.ValidationError
+	27, // 40: crossplane.render.v1alpha1.StepTrace.request:type_name -> google.protobuf.Struct
+	27, // 41: crossplane.render.v1alpha1.StepTrace.response:type_name -> google.protobuf.Struct
+	27, // 42: crossplane.render.v1alpha1.OperationInput.operation:type_name -> google.protobuf.Struct
+	6,  // 43: crossplane.render.v1alpha1.OperationInput.functions:type_name -> crossplane.render.v1alpha1.FunctionInput
+	27, // 44: crossplane.render.v1alpha1.OperationInput.required_resources:type_name -> google.protobuf.Struct
+	27, // 45: crossplane.render.v1alpha1.OperationInput.credentials:type_name -> google.protobuf.Struct
+	27, // 46: crossplane.render.v1alpha1.OperationInput.required_schemas:type_name -> google.protobuf.Struct
+	7,  // 47: crossplane.render.v1alpha1.OperationInput.fixtures:type_name -> crossplane.render.v1alpha1.FunctionFixtures
+	19, // 48: crossplane.render.v1alpha1.OperationInput.retries:type_name -> crossplane.render.v1alpha1.OperationRetries
+	20, // 49: crossplane.render.v1alpha1.OperationRetries.function_errors:type_name -> crossplane.render.v1alpha1.ScriptedFunctionError
+	27, // 50: crossplane.render.v1alpha1.OperationAttempt.status:type_name -> google.protobuf.Struct
+	8,  // 51: crossplane.render.v1alpha1.OperationAttempt.events:type_name -> crossplane.render.v1alpha1.Event
+	27, // 52: crossplane.render.v1alpha1.OperationOutput.operation:type_name -> google.protobuf.Struct
+	27, // 53: crossplane.render.v1alpha1.OperationOutput.applied_resources:type_name -> google.protobuf.Struct
+	8,  // 54: crossplane.render.v1alpha1.OperationOutput.events:type_name -> crossplane.render.v1alpha1.Event
+	27, // 55: crossplane.render.v1alpha1.OperationOutput.required_resources:type_name -> google.protobuf.Struct
+	27, // 56: crossplane.render.v1alpha1.OperationOutput.required_schemas:type_name -> google.protobuf.Struct
+	17, // 57: crossplane.render.v1alpha1.OperationOutput.traces:type_name -> crossplane.render.v1alpha1.StepTrace
+	21, // 58: crossplane.render.v1alpha1.OperationOutput.attempts:type_name -> crossplane.render.v1alpha1.OperationAttempt
+	27, // 59: crossplane.render.v1alpha1.CronOperationInput.cron_operation:type_name -> google.protobuf.Struct
+	29, // 60: crossplane.render.v1alpha1.CronOperationInput.scheduled_time:type_name -> google.protobuf.Timestamp
+	27, // 61: crossplane.render.v1alpha1.CronOperationOutput.operation:type_name -> google.protobuf.Struct
+	27, // 62: crossplane.render.v1alpha1.WatchOperationInput.watch_operation:type_name -> google.protobuf.Struct
+	27, // 63: crossplane.render.v1alpha1.WatchOperationInput.watched_resource:type_name -> google.protobuf.Struct
+	27, // 64: crossplane.render.v1alpha1.WatchOperationOutput.operation:type_name -> google.protobuf.Struct
+	2,  // 65: crossplane.render.v1alpha1.RenderService.Render:input_type -> crossplane.render.v1alpha1.RenderRequest
+	3,  // 66: crossplane.render.v1alpha1.RenderService.Render:output_type -> crossplane.render.v1alpha1.RenderResponse
+	66, // [66:67] is the sub-list for method output_type
+	65, // [65:66] is the sub-list for method input_type
+	65, // [65:65] is the sub-list for extension type_name
+	65, // [65:65] is the sub-list for extension extendee
+	0,  // [0:65] is the sub-list for field type_name
 }
 
 func init() { file_proto_render_v1alpha1_render_proto_init() }
@@ -1245,19 +2636,20 @@ func file_proto_render_v1alpha1_render_proto_init() {
 		(*RenderResponse_CronOperation)(nil),
 		(*RenderResponse_WatchOperation)(nil),
 	}
-	file_proto_render_v1alpha1_render_proto_msgTypes[10].OneofWrappers = []any{}
+	file_proto_render_v1alpha1_render_proto_msgTypes[21].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_render_v1alpha1_render_proto_rawDesc), len(file_proto_render_v1alpha1_render_proto_rawDesc)),
-			NumEnums:      0,
-			NumMessages:   14,
+			NumEnums:      2,
+			NumMessages:   25,
 			NumExtensions: 0,
-			NumServices:   0,
+			NumServices:   1,
 		},
 		GoTypes:           file_proto_render_v1alpha1_render_proto_goTypes,
 		DependencyIndexes: file_proto_render_v1alpha1_render_proto_depIdxs,
+		EnumInfos:         file_proto_render_v1alpha1_render_proto_enumTypes,
 		MessageInfos:      file_proto_render_v1alpha1_render_proto_msgTypes,
 	}.Build()
 	File_proto_render_v1alpha1_render_proto = out.File
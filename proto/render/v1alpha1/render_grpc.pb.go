@@ -0,0 +1,159 @@
+//
+//Copyright 2026 The Crossplane Authors.
+//
+//Licensed under the Apache License, Version 2.0 (the "License");
+//you may not use this file except in compliance with the License.
+//You may obtain a copy of the License at
+//
+//http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS,
+//WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//See the License for the specific language governing permissions and
+//limitations under the License.
+
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.6.2
+// - protoc             (unknown)
+// source: proto/render/v1alpha1/render.proto
+
+// This package defines the render protocol. The render engine runs one real
+// Reconcile() call against a fake in-memory client, backed by the real
+// Crossplane reconciler. It accepts a RenderRequest on stdin and writes a
+// RenderResponse to stdout, or serves the RenderService gRPC API.
+
+//buf:lint:ignore PACKAGE_DIRECTORY_MATCH
+
+package v1alpha1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	RenderService_Render_FullMethodName = "/crossplane.render.v1alpha1.RenderService/Render"
+)
+
+// RenderServiceClient is the client API for RenderService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// A RenderService renders resources using the render engine. It's an
+// alternative to invoking the render engine once per RenderRequest for callers
+// that render many resources.
+type RenderServiceClient interface {
+	// Render a resource. When a function pipeline step returns a fatal result
+	// the RPC fails with code FAILED_PRECONDITION. The partial RenderResponse
+	// is attached to the status details, so callers can recover the requirements
+	// the pipeline recorded before the fatal result.
+	Render(ctx context.Context, in *RenderRequest, opts ...grpc.CallOption) (*RenderResponse, error)
+}
+
+type renderServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewRenderServiceClient(cc grpc.ClientConnInterface) RenderServiceClient {
+	return &renderServiceClient{cc}
+}
+
+func (c *renderServiceClient) Render(ctx context.Context, in *RenderRequest, opts ...grpc.CallOption) (*RenderResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RenderResponse)
+	err := c.cc.Invoke(ctx, RenderService_Render_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// RenderServiceServer is the server API for RenderService service.
+// All implementations must embed UnimplementedRenderServiceServer
+// for forward compatibility.
+//
+// A RenderService renders resources using the render engine. It's an
+// alternative to invoking the render engine once per RenderRequest for callers
+// that render many resources.
+type RenderServiceServer interface {
+	// Render a resource. When a function pipeline step returns a fatal result
+	// the RPC fails with code FAILED_PRECONDITION. The partial RenderResponse
+	// is attached to the status details, so callers can recover the requirements
+	// the pipeline recorded before the fatal result.
+	Render(context.Context, *RenderRequest) (*RenderResponse, error)
+	mustEmbedUnimplementedRenderServiceServer()
+}
+
+// UnimplementedRenderServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedRenderServiceServer struct{}
+
+func (UnimplementedRenderServiceServer) Render(context.Context, *RenderRequest) (*RenderResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method Render not implemented")
+}
+func (UnimplementedRenderServiceServer) mustEmbedUnimplementedRenderServiceServer() {}
+func (UnimplementedRenderServiceServer) testEmbeddedByValue()                       {}
+
+// UnsafeRenderServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to RenderServiceServer will
+// result in compilation errors.
+type UnsafeRenderServiceServer interface {
+	mustEmbedUnimplementedRenderServiceServer()
+}
+
+func RegisterRenderServiceServer(s grpc.ServiceRegistrar, srv RenderServiceServer) {
+	// If the following call panics, it indicates UnimplementedRenderServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&RenderService_ServiceDesc, srv)
+}
+
+func _RenderService_Render_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RenderRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RenderServiceServer).Render(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RenderService_Render_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RenderServiceServer).Render(ctx, req.(*RenderRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// RenderService_ServiceDesc is the grpc.ServiceDesc for RenderService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var RenderService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "crossplane.render.v1alpha1.RenderService",
+	HandlerType: (*RenderServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Render",
+			Handler:    _RenderService_Render_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "proto/render/v1alpha1/render.proto",
+}